@@ -54,6 +54,13 @@ type SlurmClusterSpec struct {
 	// +kubebuilder:validation:Required
 	Secrets Secrets `json:"secrets"`
 
+	// KeyRotation defines the scheduled rotation of the munge key and the JWT signing key.
+	// A rotation is also started on demand by setting a new value of the slurm.nebius.ai/rotate-munge-key or
+	// slurm.nebius.ai/rotate-jwt-signing-key annotation
+	//
+	// +kubebuilder:validation:Optional
+	KeyRotation KeyRotation `json:"keyRotation,omitempty"`
+
 	// SlurmNodes define the desired state of Slurm nodes
 	//
 	// +kubebuilder:validation:Required
@@ -647,7 +654,24 @@ type Secrets struct {
 	SshdKeysName string `json:"sshdKeysName,omitempty"`
 }
 
+// KeyRotation defines how often the cluster keys are rotated
+type KeyRotation struct {
+	// MungeKeyInterval defines how often the munge key is rotated.
+	// The key is only rotated on demand if not set
+	//
+	// +kubebuilder:validation:Optional
+	MungeKeyInterval *metav1.Duration `json:"mungeKeyInterval,omitempty"`
+
+	// JWTSigningKeyInterval defines how often the key signing the JWTs issued by the operator is rotated.
+	// The key is only rotated on demand if not set
+	//
+	// +kubebuilder:validation:Optional
+	JWTSigningKeyInterval *metav1.Duration `json:"jwtSigningKeyInterval,omitempty"`
+}
+
 // SlurmNodes define the desired state of the Slurm nodes
+//
+// +kubebuilder:validation:XValidation:rule="!has(self.rest.externalJWT) || (has(self.rest.enabled) && self.rest.enabled && has(self.accounting.enabled) && self.accounting.enabled)",message="rest.externalJWT requires both rest and accounting to be enabled"
 type SlurmNodes struct {
 	// Accounting represents the Slurm accounting node and database configuration
 	//
@@ -702,12 +726,57 @@ type SlurmRest struct {
 	// +kubebuilder:default=10
 	MaxConnections *int32 `json:"maxConnections,omitempty"`
 
+	// ExternalJWT defines the verification of the JWTs issued by an external identity provider, e.g. an SSO, so that
+	// they can call slurmrestd directly
+	//
+	// +kubebuilder:validation:Optional
+	ExternalJWT *ExternalJWT `json:"externalJWT,omitempty"`
+
 	// SlurmRestNode represents the Slurm REST API daemon configuration
 	//
 	// +kubebuilder:validation:Optional
 	SlurmRestNode NodeContainer `json:"rest,omitempty"`
 }
 
+// ExternalJWT defines where the public keys of an external identity provider come from, and which claim of its JWTs
+// holds the Slurm username
+//
+// +kubebuilder:validation:XValidation:rule="has(self.jwksSecretRef) != has(self.jwksURL)",message="Exactly one of jwksSecretRef and jwksURL must be set"
+type ExternalJWT struct {
+	// JWKSSecretRef refers to the key of a Secret holding the JWKS of the identity provider
+	//
+	// +kubebuilder:validation:Optional
+	JWKSSecretRef *SecretKeyRef `json:"jwksSecretRef,omitempty"`
+
+	// JWKSURL is the URL the JWKS of the identity provider is published at.
+	// The operator mirrors it into the <cluster>-external-jwks Secret every hour
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^https://`
+	JWKSURL string `json:"jwksURL,omitempty"`
+
+	// UsernameClaim is the claim of the JWTs holding the Slurm username.
+	// Slurm takes the username from this claim in all JWTs, not only in the external ones.
+	// The JWTs issued by the operator carry it as well, but the ones issued by `scontrol token` don't,
+	// so they're rejected unless it's "sun"
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="sun"
+	UsernameClaim string `json:"usernameClaim,omitempty"`
+}
+
+// SecretKeyRef refers to a key of a Secret in the namespace of the SlurmCluster
+type SecretKeyRef struct {
+	// Name defines the name of the Secret
+	//
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// Key defines the key in the Secret
+	//
+	// +kubebuilder:validation:Required
+	Key string `json:"key"`
+}
+
 // SlurmNodeAccounting represents the Slurm accounting configuration
 type SlurmNodeAccounting struct {
 	SlurmNode `json:",inline"`
@@ -924,6 +993,7 @@ type AccountingSlurmConf struct {
 }
 
 // SlurmNodeController defines the configuration for the Slurm controller node
+// +kubebuilder:validation:XValidation:rule="!has(self.highAvailability) || !has(self.highAvailability.enabled) || !self.highAvailability.enabled || has(self.volumes.spool.volumeSourceName)",message="highAvailability requires the spool volume to be shared between controllers, i.e. set by volumeSourceName"
 type SlurmNodeController struct {
 	// CustomInitContainers represent additional init containers that should be added to created Pods
 	//
@@ -980,6 +1050,21 @@ type SlurmNodeController struct {
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default={enabled: true, serviceMonitor: {interval: "30s", scrapeTimeout: "28s"}}
 	OpenMetrics OpenMetrics `json:"openMetrics,omitempty"`
+
+	// HighAvailability configures a backup slurmctld taking control when the primary one is unavailable
+	//
+	// +kubebuilder:validation:Optional
+	HighAvailability SlurmctldHighAvailability `json:"highAvailability,omitempty"`
+}
+
+// SlurmctldHighAvailability defines the configuration of the backup Slurm controller
+type SlurmctldHighAvailability struct {
+	// Enabled runs a primary and a backup slurmctld listed in that order as SlurmctldHost in slurm.conf.
+	// Both controllers keep their state in the spool volume, so it must be shared between them
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled,omitempty"`
 }
 
 // SlurmNodeControllerVolumes define the volumes for the Slurm controller node
@@ -1520,6 +1605,62 @@ type SlurmClusterStatus struct {
 	// ReadySConfigController represents the number of ready SConfigController pods
 	// +kubebuilder:validation:Optional
 	ReadySConfigController *int32 `json:"readySConfigController,omitempty"`
+
+	// KeyRotation represents the progress of the munge key and JWT signing key rotations
+	// +kubebuilder:validation:Optional
+	KeyRotation *KeyRotationStatus `json:"keyRotation,omitempty"`
+}
+
+// KeyRotationStatus defines the progress of the cluster key rotations
+type KeyRotationStatus struct {
+	// MungeKey represents the progress of the munge key rotation
+	// +kubebuilder:validation:Optional
+	MungeKey KeyRotationProgress `json:"mungeKey,omitempty"`
+
+	// JWTSigningKey represents the progress of the JWT signing key rotation
+	// +kubebuilder:validation:Optional
+	JWTSigningKey KeyRotationProgress `json:"jwtSigningKey,omitempty"`
+}
+
+// KeyRotationPhase defines the phase of a key rotation
+// +kubebuilder:validation:Enum=Publishing;Retiring;Completed
+type KeyRotationPhase string
+
+const (
+	// KeyRotationPhasePublishing means the new key is being published to the pods, while the previous one is still
+	// in use
+	KeyRotationPhasePublishing KeyRotationPhase = "Publishing"
+	// KeyRotationPhaseRetiring means the JWTs are signed by the new key, while the previous one is still accepted
+	KeyRotationPhaseRetiring KeyRotationPhase = "Retiring"
+	// KeyRotationPhaseCompleted means the new key is the only one in use
+	KeyRotationPhaseCompleted KeyRotationPhase = "Completed"
+)
+
+// KeyRotationProgress defines the progress of a key rotation
+type KeyRotationProgress struct {
+	// Phase is the current phase of the rotation
+	// +kubebuilder:validation:Optional
+	Phase KeyRotationPhase `json:"phase,omitempty"`
+
+	// PhaseTransitionTime is the time the rotation entered the current phase
+	// +kubebuilder:validation:Optional
+	PhaseTransitionTime *metav1.Time `json:"phaseTransitionTime,omitempty"`
+
+	// LastRotationTime is the time the current key was generated
+	// +kubebuilder:validation:Optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+
+	// KeyID is the ID of the newest key, for the keys having one
+	// +kubebuilder:validation:Optional
+	KeyID string `json:"keyID,omitempty"`
+
+	// ObservedRequest is the value of the rotation request annotation the operator acted on last
+	// +kubebuilder:validation:Optional
+	ObservedRequest string `json:"observedRequest,omitempty"`
+
+	// Message describes the current phase
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
 }
 
 // SetCondition sets the given condition in the SlurmClusterStatus conditions slice.
@@ -1,7 +1,12 @@
 package v1_test
 
 import (
+	"path/filepath"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionstest "k8s.io/apiextensions-apiserver/pkg/test"
 )
 
 // TestCELExpressionPseudoValidation tests the logical structure we expect
@@ -36,3 +41,172 @@ func TestCELExpressionPseudoValidation(t *testing.T) {
 		t.Logf("")
 	}
 }
+
+func TestControllerHighAvailabilityCELValidation(t *testing.T) {
+	validator, err := apiextensionstest.VersionValidatorFromFile(
+		t,
+		filepath.Join("..", "..", "config", "crd", "bases", "slurm.nebius.ai_slurmclusters.yaml"),
+		"v1",
+	)
+	require.NoError(t, err)
+
+	const message = "highAvailability requires the spool volume to be shared between controllers"
+
+	tests := []struct {
+		name             string
+		highAvailability map[string]any
+		spool            map[string]any
+		wantValid        bool
+	}{
+		{name: "no high availability", spool: map[string]any{"volumeClaimTemplateSpec": map[string]any{}}, wantValid: true},
+		{name: "high availability disabled", highAvailability: map[string]any{"enabled": false}, spool: map[string]any{"volumeClaimTemplateSpec": map[string]any{}}, wantValid: true},
+		{name: "high availability with shared spool", highAvailability: map[string]any{"enabled": true}, spool: map[string]any{"volumeSourceName": "controller-spool"}, wantValid: true},
+		{name: "high availability with spool per controller", highAvailability: map[string]any{"enabled": true}, spool: map[string]any{"volumeClaimTemplateSpec": map[string]any{}}, wantValid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := map[string]any{
+				"volumes": map[string]any{
+					"spool": tt.spool,
+					"jail":  map[string]any{"volumeSourceName": "jail"},
+				},
+			}
+			if tt.highAvailability != nil {
+				controller["highAvailability"] = tt.highAvailability
+			}
+
+			// Other required fields are omitted, so only the presence of the error is checked.
+			errs := validator(map[string]any{
+				"spec": map[string]any{
+					"slurmNodes": map[string]any{"controller": controller},
+				},
+			}, nil)
+
+			if tt.wantValid {
+				for _, err := range errs {
+					assert.NotContains(t, err.Error(), message)
+				}
+				return
+			}
+			if assert.NotEmpty(t, errs) {
+				assert.Contains(t, errs.ToAggregate().Error(), message)
+			}
+		})
+	}
+}
+
+func TestRESTExternalJWTCELValidation(t *testing.T) {
+	validator, err := apiextensionstest.VersionValidatorFromFile(
+		t,
+		filepath.Join("..", "..", "config", "crd", "bases", "slurm.nebius.ai_slurmclusters.yaml"),
+		"v1",
+	)
+	require.NoError(t, err)
+
+	const message = "Exactly one of jwksSecretRef and jwksURL must be set"
+
+	jwksSecretRef := map[string]any{"name": "sso-jwks", "key": "jwks.json"}
+	tests := []struct {
+		name        string
+		externalJWT map[string]any
+		wantValid   bool
+	}{
+		{name: "JWKS from Secret", externalJWT: map[string]any{"jwksSecretRef": jwksSecretRef}, wantValid: true},
+		{name: "JWKS from URL", externalJWT: map[string]any{"jwksURL": "https://sso.example.com/jwks.json"}, wantValid: true},
+		{name: "no JWKS", externalJWT: map[string]any{"usernameClaim": "email"}, wantValid: false},
+		{name: "JWKS from both", externalJWT: map[string]any{"jwksSecretRef": jwksSecretRef, "jwksURL": "https://sso.example.com/jwks.json"}, wantValid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Other required fields are omitted, so only the presence of the error is checked.
+			errs := validator(map[string]any{
+				"spec": map[string]any{
+					"slurmNodes": map[string]any{"rest": map[string]any{"externalJWT": tt.externalJWT}},
+				},
+			}, nil)
+
+			if tt.wantValid {
+				for _, err := range errs {
+					assert.NotContains(t, err.Error(), message)
+				}
+				return
+			}
+			if assert.NotEmpty(t, errs) {
+				assert.Contains(t, errs.ToAggregate().Error(), message)
+			}
+		})
+	}
+}
+
+func TestSlurmNodesExternalJWTCELValidation(t *testing.T) {
+	validator, err := apiextensionstest.VersionValidatorFromFile(
+		t,
+		filepath.Join("..", "..", "config", "crd", "bases", "slurm.nebius.ai_slurmclusters.yaml"),
+		"v1",
+	)
+	require.NoError(t, err)
+
+	const message = "rest.externalJWT requires both rest and accounting to be enabled"
+
+	externalJWT := map[string]any{"jwksURL": "https://sso.example.com/jwks.json"}
+	tests := []struct {
+		name       string
+		rest       map[string]any
+		accounting map[string]any
+		wantValid  bool
+	}{
+		{
+			name:       "REST and accounting enabled",
+			rest:       map[string]any{"enabled": true, "externalJWT": externalJWT},
+			accounting: map[string]any{"enabled": true},
+			wantValid:  true,
+		},
+		{
+			name:       "no external JWT",
+			rest:       map[string]any{"enabled": false},
+			accounting: map[string]any{"enabled": false},
+			wantValid:  true,
+		},
+		{
+			name:       "REST disabled",
+			rest:       map[string]any{"enabled": false, "externalJWT": externalJWT},
+			accounting: map[string]any{"enabled": true},
+			wantValid:  false,
+		},
+		{
+			name:       "accounting disabled",
+			rest:       map[string]any{"enabled": true, "externalJWT": externalJWT},
+			accounting: map[string]any{"enabled": false},
+			wantValid:  false,
+		},
+		{
+			name:       "accounting unset",
+			rest:       map[string]any{"enabled": true, "externalJWT": externalJWT},
+			accounting: map[string]any{},
+			wantValid:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Other required fields are omitted, so only the presence of the error is checked.
+			errs := validator(map[string]any{
+				"spec": map[string]any{
+					"slurmNodes": map[string]any{"rest": tt.rest, "accounting": tt.accounting},
+				},
+			}, nil)
+
+			if tt.wantValid {
+				for _, err := range errs {
+					assert.NotContains(t, err.Error(), message)
+				}
+				return
+			}
+			if assert.NotEmpty(t, errs) {
+				assert.Contains(t, errs.ToAggregate().Error(), message)
+			}
+		})
+	}
+}
@@ -148,6 +148,26 @@ func (in *ExternalDBTLSConfig) DeepCopy() *ExternalDBTLSConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalJWT) DeepCopyInto(out *ExternalJWT) {
+	*out = *in
+	if in.JWKSSecretRef != nil {
+		in, out := &in.JWKSSecretRef, &out.JWKSSecretRef
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalJWT.
+func (in *ExternalJWT) DeepCopy() *ExternalJWT {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalJWT)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HealthCheckConfig) DeepCopyInto(out *HealthCheckConfig) {
 	*out = *in
@@ -217,6 +237,71 @@ func (in *K8sNodeFilter) DeepCopy() *K8sNodeFilter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyRotation) DeepCopyInto(out *KeyRotation) {
+	*out = *in
+	if in.MungeKeyInterval != nil {
+		in, out := &in.MungeKeyInterval, &out.MungeKeyInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.JWTSigningKeyInterval != nil {
+		in, out := &in.JWTSigningKeyInterval, &out.JWTSigningKeyInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyRotation.
+func (in *KeyRotation) DeepCopy() *KeyRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyRotationProgress) DeepCopyInto(out *KeyRotationProgress) {
+	*out = *in
+	if in.PhaseTransitionTime != nil {
+		in, out := &in.PhaseTransitionTime, &out.PhaseTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyRotationProgress.
+func (in *KeyRotationProgress) DeepCopy() *KeyRotationProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyRotationProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyRotationStatus) DeepCopyInto(out *KeyRotationStatus) {
+	*out = *in
+	in.MungeKey.DeepCopyInto(&out.MungeKey)
+	in.JWTSigningKey.DeepCopyInto(&out.JWTSigningKey)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyRotationStatus.
+func (in *KeyRotationStatus) DeepCopy() *KeyRotationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyRotationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoginUserIsolation) DeepCopyInto(out *LoginUserIsolation) {
 	*out = *in
@@ -740,6 +825,21 @@ func (in *SConfigController) DeepCopy() *SConfigController {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretKeyRef) DeepCopyInto(out *SecretKeyRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyRef.
+func (in *SecretKeyRef) DeepCopy() *SecretKeyRef {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretKeyRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Secrets) DeepCopyInto(out *Secrets) {
 	*out = *in
@@ -858,6 +958,7 @@ func (in *SlurmClusterSpec) DeepCopyInto(out *SlurmClusterSpec) {
 		}
 	}
 	out.Secrets = in.Secrets
+	in.KeyRotation.DeepCopyInto(&out.KeyRotation)
 	in.SlurmNodes.DeepCopyInto(&out.SlurmNodes)
 	in.PartitionConfiguration.DeepCopyInto(&out.PartitionConfiguration)
 	in.SlurmConfig.DeepCopyInto(&out.SlurmConfig)
@@ -921,6 +1022,11 @@ func (in *SlurmClusterStatus) DeepCopyInto(out *SlurmClusterStatus) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.KeyRotation != nil {
+		in, out := &in.KeyRotation, &out.KeyRotation
+		*out = new(KeyRotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmClusterStatus.
@@ -1119,6 +1225,7 @@ func (in *SlurmNodeController) DeepCopyInto(out *SlurmNodeController) {
 	in.SidecarSSSD.DeepCopyInto(&out.SidecarSSSD)
 	in.Volumes.DeepCopyInto(&out.Volumes)
 	in.OpenMetrics.DeepCopyInto(&out.OpenMetrics)
+	out.HighAvailability = in.HighAvailability
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmNodeController.
@@ -1261,6 +1368,11 @@ func (in *SlurmRest) DeepCopyInto(out *SlurmRest) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ExternalJWT != nil {
+		in, out := &in.ExternalJWT, &out.ExternalJWT
+		*out = new(ExternalJWT)
+		(*in).DeepCopyInto(*out)
+	}
 	in.SlurmRestNode.DeepCopyInto(&out.SlurmRestNode)
 }
 
@@ -1274,6 +1386,21 @@ func (in *SlurmRest) DeepCopy() *SlurmRest {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmctldHighAvailability) DeepCopyInto(out *SlurmctldHighAvailability) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmctldHighAvailability.
+func (in *SlurmctldHighAvailability) DeepCopy() *SlurmctldHighAvailability {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmctldHighAvailability)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SlurmdbdConfig) DeepCopyInto(out *SlurmdbdConfig) {
 	*out = *in
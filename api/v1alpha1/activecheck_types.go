@@ -2,6 +2,7 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 
@@ -9,6 +10,8 @@ import (
 )
 
 // ActiveCheckSpec defines the desired state of ActiveCheck.
+// +kubebuilder:validation:XValidation:rule="!has(self.successReactions) || !has(self.successReactions.setHardwareIssuesSuspected)",message="setHardwareIssuesSuspected is only supported in failureReactions"
+// +kubebuilder:validation:XValidation:rule="!has(self.targets) || (has(self.checkType) && self.checkType == 'slurmJob')",message="targets are only supported for checks of the slurmJob type"
 type ActiveCheckSpec struct {
 	// Name defines the name of k8s cronJob
 	// +kubebuilder:validation:Required
@@ -89,6 +92,14 @@ type ActiveCheckSpec struct {
 	// +kubebuilder:validation:Optional
 	SlurmJobSpec SlurmJobSpec `json:"slurmJobSpec,omitempty"`
 
+	// Targets limits the check to a subset of Slurm nodes, e.g. to the NodeSets with GPUs.
+	// The controller resolves the targets into a node list, which the jobs are submitted against.
+	// MaxNumberOfJobs then limits the number of targeted nodes the jobs run on.
+	// If omitted, the check runs on all nodes.
+	// Only supported for checks of the slurmJob type.
+	// +kubebuilder:validation:Optional
+	Targets *ActiveCheckTargets `json:"targets,omitempty"`
+
 	// CheckType defines the type of the check
 	// +kubebuilder:validation:Enum=k8sJob;slurmJob
 	// +kubebuilder:validation:Optional
@@ -110,6 +121,41 @@ type ActiveCheckSpec struct {
 	// FailureReactions defines reaction on specific check when it fails
 	// +kubebuilder:validation:Optional
 	FailureReactions *Reactions `json:"failureReactions,omitempty"`
+
+	// FailureReactionsThreshold defines the number of consecutive failures of the check on a Slurm node
+	// required before failure reactions are applied to the node.
+	// Consecutive failures are counted in status.nodeResults.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=20
+	// +kubebuilder:default=1
+	FailureReactionsThreshold *int32 `json:"failureReactionsThreshold,omitempty"`
+}
+
+// ActiveCheckTargets defines the Slurm nodes a check runs on.
+// A node is targeted if it's selected by any of the fields.
+// +kubebuilder:validation:XValidation:rule="has(self.nodeSetRefs) || has(self.partitions) || has(self.features) || has(self.nodeList)",message="at least one of nodeSetRefs, partitions, features and nodeList must be set"
+type ActiveCheckTargets struct {
+	// NodeSetRefs selects the nodes of the given NodeSets.
+	// The NodeSets must be in the same namespace.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	NodeSetRefs []string `json:"nodeSetRefs,omitempty"`
+
+	// Partitions selects the nodes of the NodeSets referenced by the given partitions.
+	// Requires the structured partition configuration of the Slurm cluster.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	Partitions []string `json:"partitions,omitempty"`
+
+	// Features selects the nodes of the NodeSets having any of the given Slurm node features.
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	Features []string `json:"features,omitempty"`
+
+	// NodeList is a Slurm hostlist expression of additional nodes, e.g. "worker-[0-3],worker-7".
+	// +kubebuilder:validation:Optional
+	NodeList string `json:"nodeList,omitempty"`
 }
 
 type Reactions struct {
@@ -119,6 +165,16 @@ type Reactions struct {
 	// CommentSlurmNode enabling slurm node commenting
 	// +kubebuilder:validation:Optional
 	CommentSlurmNode *CommentSlurmNodeSpec `json:"commentSlurmNode,omitempty"`
+	// RebootSlurmNode enabling slurm node rebooting
+	// +kubebuilder:validation:Optional
+	RebootSlurmNode *RebootSlurmNodeSpec `json:"rebootSlurmNode,omitempty"`
+	// SetHardwareIssuesSuspected enabling setting the HardwareIssuesSuspected condition on the K8s node of the slurm node,
+	// which leads to the node replacement
+	// +kubebuilder:validation:Optional
+	SetHardwareIssuesSuspected *SetHardwareIssuesSuspectedSpec `json:"setHardwareIssuesSuspected,omitempty"`
+	// Notify enabling posting a notification to an HTTP webhook
+	// +kubebuilder:validation:Optional
+	Notify *NotifySpec `json:"notify,omitempty"`
 }
 
 type DrainSlurmNodeSpec struct {
@@ -129,6 +185,31 @@ type CommentSlurmNodeSpec struct {
 	CommentPrefix string `json:"commentPrefix,omitempty"`
 }
 
+type RebootSlurmNodeSpec struct {
+	ReasonPrefix string `json:"reasonPrefix,omitempty"`
+
+	// NextState defines the state of the slurm node after the reboot
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=DOWN;RESUME
+	// +kubebuilder:default="RESUME"
+	NextState string `json:"nextState,omitempty"`
+}
+
+type SetHardwareIssuesSuspectedSpec struct {
+	MessagePrefix string `json:"messagePrefix,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="has(self.url) != has(self.urlSecretRef)",message="exactly one of url and urlSecretRef must be set"
+type NotifySpec struct {
+	// URL defines the webhook the notification is posted to
+	// +kubebuilder:validation:Optional
+	URL string `json:"url,omitempty"`
+
+	// URLSecretRef defines the secret key containing the webhook URL
+	// +kubebuilder:validation:Optional
+	URLSecretRef *corev1.SecretKeySelector `json:"urlSecretRef,omitempty"`
+}
+
 type ContainerSpec struct {
 	Image        string               `json:"image,omitempty"`
 	Command      []string             `json:"command,omitempty"`
@@ -185,6 +266,41 @@ type SlurmJobSpec struct {
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default=0
 	MaxNumberOfJobs *int64 `json:"maxNumberOfJobs,omitempty"`
+	// Results defines the machine-readable results the Slurm jobs publish in their comment, and the thresholds they're
+	// checked against. A Slurm job which completes with values out of the thresholds is considered failed.
+	// +kubebuilder:validation:Optional
+	Results *SlurmJobResultsSpec `json:"results,omitempty"`
+}
+
+// SlurmJobResultsSpec defines the results published by the Slurm jobs of a check.
+type SlurmJobResultsSpec struct {
+	// Format defines the format of the Slurm job comment holding the results.
+	// JSON expects an object of numeric values, nested objects are flattened with dot-separated keys.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=JSON
+	// +kubebuilder:default="JSON"
+	Format string `json:"format,omitempty"`
+
+	// Thresholds defines the bounds of the result metrics. Metrics with thresholds are required in the results.
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=metric
+	Thresholds []SlurmJobResultThreshold `json:"thresholds,omitempty"`
+}
+
+// SlurmJobResultThreshold defines the bounds of a result metric.
+// +kubebuilder:validation:XValidation:rule="has(self.min) || has(self.max)",message="at least one of min and max must be set"
+type SlurmJobResultThreshold struct {
+	// Metric is the name of the result metric.
+	Metric string `json:"metric"`
+
+	// Min is the lowest passing value of the metric.
+	// +kubebuilder:validation:Optional
+	Min *resource.Quantity `json:"min,omitempty"`
+
+	// Max is the highest passing value of the metric.
+	// +kubebuilder:validation:Optional
+	Max *resource.Quantity `json:"max,omitempty"`
 }
 
 // ActiveCheckK8sJobsStatus defines the observed state of ActiveCheck k8s jobs.
@@ -222,11 +338,91 @@ type ActiveCheckSlurmJobsStatus struct {
 	LastRunSubmitTime *metav1.Time `json:"lastRunSubmitTime"`
 }
 
+// ActiveCheckNodeResult defines the outcomes of the check on a Slurm node.
+type ActiveCheckNodeResult struct {
+	// Node is the name of the Slurm node.
+	Node string `json:"node"`
+
+	// LastPassTime is the end time of the last Slurm job which passed on the node.
+	// +kubebuilder:validation:Optional
+	LastPassTime *metav1.Time `json:"lastPassTime,omitempty"`
+
+	// LastFailTime is the end time of the last Slurm job which failed on the node.
+	// +kubebuilder:validation:Optional
+	LastFailTime *metav1.Time `json:"lastFailTime,omitempty"`
+
+	// LastResult is the outcome of the last Slurm job of the check on the node.
+	// +kubebuilder:validation:Optional
+	LastResult *ActiveCheckNodeOutcome `json:"lastResult,omitempty"`
+
+	// ConsecutiveFailures is the number of failures of the check on the node since its last pass.
+	// Cancelled and errored outcomes neither count as failures nor reset the counter.
+	// +kubebuilder:validation:Optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+
+	// ResultFlips is the number of changes between passed and failed of the check on the node.
+	// +kubebuilder:validation:Optional
+	ResultFlips int32 `json:"resultFlips,omitempty"`
+
+	// LastMetrics holds the results of the last Slurm job which published them on the node.
+	// +kubebuilder:validation:Optional
+	LastMetrics *ActiveCheckNodeMetrics `json:"lastMetrics,omitempty"`
+}
+
+// ActiveCheckNodeMetrics defines the results published by a Slurm job of the check.
+type ActiveCheckNodeMetrics struct {
+	// JobID is the ID of the Slurm job.
+	JobID string `json:"jobID"`
+
+	// Time is the end time of the Slurm job.
+	Time metav1.Time `json:"time"`
+
+	// Values holds the result metrics, ordered by name.
+	// +listType=map
+	// +listMapKey=name
+	Values []ActiveCheckMetricValue `json:"values"`
+}
+
+// ActiveCheckMetricValue defines the value of a result metric.
+type ActiveCheckMetricValue struct {
+	// Name is the name of the metric.
+	Name string `json:"name"`
+
+	// Value is the decimal value of the metric.
+	Value string `json:"value"`
+}
+
+// ActiveCheckNodeOutcome defines the outcome of a single Slurm job of the check on a node.
+type ActiveCheckNodeOutcome struct {
+	// Result is the outcome of the Slurm job.
+	Result consts.ActiveCheckNodeResult `json:"result"`
+
+	// JobID is the ID of the Slurm job.
+	JobID string `json:"jobID"`
+
+	// Time is the end time of the Slurm job.
+	Time metav1.Time `json:"time"`
+
+	// Reason is the state reason of the Slurm job, if it didn't pass.
+	// +kubebuilder:validation:Optional
+	Reason string `json:"reason,omitempty"`
+}
+
 // ActiveCheckStatus defines the observed state of ActiveCheck.
 type ActiveCheckStatus struct {
 	StatusMetadata  `json:",inline"`
 	K8sJobsStatus   ActiveCheckK8sJobsStatus   `json:"k8sJobsStatus,omitempty"`
 	SlurmJobsStatus ActiveCheckSlurmJobsStatus `json:"slurmJobsStatus,omitempty"`
+
+	// TargetNodeList is the hostlist expression of the Slurm nodes spec.targets resolve into.
+	// +kubebuilder:validation:Optional
+	TargetNodeList string `json:"targetNodeList,omitempty"`
+
+	// NodeResults holds the last outcome of the check per targeted Slurm node, ordered by node name.
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=node
+	NodeResults []ActiveCheckNodeResult `json:"nodeResults,omitempty"`
 }
 
 type JobAndReason struct {
@@ -264,6 +460,10 @@ type ActiveCheckList struct {
 	Items           []ActiveCheck `json:"items"`
 }
 
+const (
+	KindActiveCheck = "ActiveCheck"
+)
+
 func init() {
 	SchemeBuilder.Register(&ActiveCheck{}, &ActiveCheckList{})
 }
@@ -276,4 +476,7 @@ func (s *ActiveCheckSpec) SetDefaults() {
 	if s.RunAfterCreation == nil {
 		s.RunAfterCreation = ptr.To(true)
 	}
+	if s.FailureReactionsThreshold == nil {
+		s.FailureReactionsThreshold = ptr.To(int32(1))
+	}
 }
@@ -0,0 +1,127 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"nebius.ai/slurm-operator/internal/consts"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=acrun
+// +kubebuilder:printcolumn:name="Check",type="string",JSONPath=".spec.activeCheckName",description="The ActiveCheck being run"
+// +kubebuilder:printcolumn:name="Nodes",type="string",JSONPath=".spec.nodes",description="The nodes the run is limited to"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Phase of the run"
+// +kubebuilder:printcolumn:name="Job",type="string",JSONPath=".status.k8sJobName",description="The K8s Job of the run"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ActiveCheckRun is the Schema for the activecheckruns API.
+// It triggers a single on-demand run of an ActiveCheck, optionally limited to a subset of nodes, and tracks it to
+// completion.
+type ActiveCheckRun struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ActiveCheckRunSpec   `json:"spec,omitempty"`
+	Status ActiveCheckRunStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ActiveCheckRunList contains a list of ActiveCheckRun
+type ActiveCheckRunList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ActiveCheckRun `json:"items"`
+}
+
+// ActiveCheckRunSpec defines the desired state of ActiveCheckRun
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="ActiveCheckRun spec is immutable"
+type ActiveCheckRunSpec struct {
+	// ActiveCheckName is the name of the ActiveCheck to run. It must be in the same namespace.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	ActiveCheckName string `json:"activeCheckName"`
+
+	// Nodes limits the run to the given Slurm nodes, e.g. ["worker-3"].
+	// Only supported for checks of the slurmJob type.
+	// With eachWorkerJobs, one job is submitted per node. Otherwise, the nodes are passed to sbatch as --nodelist.
+	// If empty, the check runs on the same nodes as its scheduled runs.
+	//
+	// +kubebuilder:validation:Optional
+	Nodes []string `json:"nodes,omitempty"`
+
+	// Reservation is the Slurm reservation the jobs of the run are submitted to, so that the run can check
+	// nodes which are reserved, e.g. for their verification after an update.
+	// Only supported for checks of the slurmJob type.
+	//
+	// +kubebuilder:validation:Optional
+	Reservation string `json:"reservation,omitempty"`
+}
+
+// ActiveCheckRunStatus defines the observed state of ActiveCheckRun
+type ActiveCheckRunStatus struct {
+	// Phase is the phase of the run.
+	//
+	// +kubebuilder:validation:Optional
+	Phase consts.ActiveCheckRunPhase `json:"phase,omitempty"`
+
+	// Message explains the phase, e.g. why the run failed.
+	//
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+
+	// K8sJobName is the name of the K8s Job created for the run.
+	//
+	// +kubebuilder:validation:Optional
+	K8sJobName string `json:"k8sJobName,omitempty"`
+
+	// SlurmJobIDs are the IDs of the Slurm jobs submitted by the run.
+	//
+	// +kubebuilder:validation:Optional
+	SlurmJobIDs []string `json:"slurmJobIDs,omitempty"`
+
+	// FailedNodes are the Slurm nodes whose jobs failed.
+	//
+	// +kubebuilder:validation:Optional
+	FailedNodes []string `json:"failedNodes,omitempty"`
+
+	// FailJobsAndReasons are the Slurm jobs which failed and their reasons.
+	//
+	// +kubebuilder:validation:Optional
+	FailJobsAndReasons []JobAndReason `json:"failJobsAndReasons,omitempty"`
+
+	// StartTime is the time the K8s Job of the run was created.
+	//
+	// +kubebuilder:validation:Optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time the run reached a final phase.
+	//
+	// +kubebuilder:validation:Optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// IsFinished reports whether the run reached a final phase.
+func (s *ActiveCheckRunStatus) IsFinished() bool {
+	switch s.Phase {
+	case consts.ActiveCheckRunPhaseComplete,
+		consts.ActiveCheckRunPhaseFailed,
+		consts.ActiveCheckRunPhaseCancelled,
+		consts.ActiveCheckRunPhaseError,
+		consts.ActiveCheckRunPhaseSkipped:
+		return true
+	default:
+		return false
+	}
+}
+
+const (
+	KindActiveCheckRun = "ActiveCheckRun"
+)
+
+func init() {
+	SchemeBuilder.Register(&ActiveCheckRun{}, &ActiveCheckRunList{})
+}
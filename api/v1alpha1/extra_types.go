@@ -134,3 +134,25 @@ type ContainerSecuritySpec struct {
 	// +kubebuilder:validation:Enum=Default;Unmasked
 	ProcMount corev1.ProcMountType `json:"procMount,omitempty"`
 }
+
+// DeletionPolicy defines what happens to the object in Slurm when the resource declaring it is deleted
+// +kubebuilder:validation:Enum=Delete;Retain
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete deletes the object in Slurm together with the resource.
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyRetain keeps the object in Slurm when the resource is deleted.
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+)
+
+const (
+	// ConditionSlurmAccountingSynced is set on SlurmAccount and SlurmQOS when the slurmdbd objects match the spec.
+	ConditionSlurmAccountingSynced = "Synced"
+
+	ReasonSlurmAccountingCreated             = "Created"
+	ReasonSlurmAccountingUpdated             = "Updated"
+	ReasonSlurmAccountingInSync              = "InSync"
+	ReasonSlurmAccountingSlurmAPIUnavailable = "SlurmAPIUnavailable"
+	ReasonSlurmAccountingSlurmAPIError       = "SlurmAPIError"
+)
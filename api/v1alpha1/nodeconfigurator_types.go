@@ -153,6 +153,91 @@ type Rebooter struct {
 	//
 	// +kubebuilder:validation:Optional
 	LogFormat string `json:"logFormat,omitempty"`
+
+	// MaxConcurrentReboots defines the maximum number of nodes rebooted at the same time across the cluster.
+	// Unlimited if 0
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	MaxConcurrentReboots int32 `json:"maxConcurrentReboots,omitempty"`
+
+	// MaxConcurrentRebootsPerNodeSet defines the maximum number of nodes of the same NodeSet rebooted at the same time.
+	// Unlimited if 0
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	MaxConcurrentRebootsPerNodeSet int32 `json:"maxConcurrentRebootsPerNodeSet,omitempty"`
+
+	// MaintenanceWindows defines the recurring time windows nodes may start rebooting in.
+	// Nodes may reboot at any time if empty
+	//
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// PreRebootHooks defines the commands run on the host before the node is rebooted, e.g. to collect dmesg.
+	// A failed hook is reported and doesn't block the reboot
+	//
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	PreRebootHooks []RebooterHook `json:"preRebootHooks,omitempty"`
+
+	// PostRebootChecks defines the commands run on the host once the node is back from a reboot, e.g. to check GPUs.
+	// The node is kept drained until all of them succeed
+	//
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	PostRebootChecks []RebooterHook `json:"postRebootChecks,omitempty"`
+}
+
+// RebooterHook is a command the rebooter runs in the host namespaces.
+type RebooterHook struct {
+	// Name defines the name of the hook reported in logs and events
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Command defines the command and its arguments
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Command []string `json:"command"`
+
+	// Timeout defines how long the command may run before it's considered failed
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="1m"
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+}
+
+// MaintenanceWindow is a recurring time window nodes may start rebooting in.
+// +kubebuilder:validation:XValidation:rule="self.start != self.end",message="start and end must differ"
+type MaintenanceWindow struct {
+	// Days are the days of the week the window starts on. All days if empty.
+	//
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	Days []Weekday `json:"days,omitempty"`
+
+	// Start is the time of day the window starts at, in the "HH:MM" format.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+
+	// End is the time of day the window ends at, in the "HH:MM" format.
+	// An end before the start ends the window on the next day.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	End string `json:"end"`
+
+	// TimeZone is the IANA time zone Start and End are in, e.g. "Europe/Amsterdam".
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="UTC"
+	TimeZone string `json:"timeZone,omitempty"`
 }
 
 type CustomContainer struct {
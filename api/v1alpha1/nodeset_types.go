@@ -18,6 +18,7 @@ import (
 // +kubebuilder:printcolumn:name="Desired",type="integer",JSONPath=".spec.replicas",description="The desired number of workers."
 // +kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.replicas",description="The current number of workers being ready for some time."
 // +kubebuilder:printcolumn:name="Ephemeral",type="boolean",JSONPath=".spec.ephemeralNodes",description="Whether the NodeSet uses ephemeral nodes."
+// +kubebuilder:printcolumn:name="Rollout",type="string",JSONPath=".status.rollout.state",description="The state of the slurm-aware rolling update.",priority=1
 
 // NodeSet is the Schema for the nodesets API
 type NodeSet struct {
@@ -67,6 +68,41 @@ type NodeSetStatus struct {
 	//
 	// +kubebuilder:validation:Optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Rollout is the progress of the slurmAwareRollingUpdate strategy.
+	//
+	// +kubebuilder:validation:Optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+}
+
+// RolloutStatus defines the observed progress of the slurmAwareRollingUpdate strategy.
+type RolloutStatus struct {
+	// OperationID is the ID of the current rollout, which is the update revision of the worker StatefulSet.
+	// Worker pods being updated are labeled with it.
+	//
+	// +kubebuilder:validation:Optional
+	OperationID string `json:"operationID,omitempty"`
+
+	// State is the state of the rollout.
+	// Known values are: RolloutStateProgressing, RolloutStatePaused, RolloutStateHalted, and RolloutStateComplete.
+	//
+	// +kubebuilder:validation:Optional
+	State string `json:"state,omitempty"`
+
+	// UpdatedWorkers is the number of workers running the update revision.
+	UpdatedWorkers int32 `json:"updatedWorkers"`
+
+	// PendingWorkers is the number of outdated workers which aren't being updated yet,
+	// including the workers held back by the partition.
+	PendingWorkers int32 `json:"pendingWorkers"`
+
+	// InFlightWorkers is the number of workers being updated or verified.
+	InFlightWorkers int32 `json:"inFlightWorkers"`
+
+	// FailedVerificationWorkers are the updated workers which failed the update verification.
+	//
+	// +kubebuilder:validation:Optional
+	FailedVerificationWorkers []string `json:"failedVerificationWorkers,omitempty"`
 }
 
 // SetCondition sets the given condition in the NodeSetStatus conditions slice.
@@ -112,9 +148,20 @@ const (
 	ConditionNodeSetPodsReady = "PodsReady"
 	// ConditionNodeSetStatefulSetTerminated is set when StatefulSet for NodeSet is terminated.
 	ConditionNodeSetStatefulSetTerminated = "StatefulSetTerminated"
+
+	// RolloutStateProgressing is set when outdated workers are being updated.
+	RolloutStateProgressing = "Progressing"
+	// RolloutStatePaused is set when the rollout is paused by the NodeSet.
+	RolloutStatePaused = "Paused"
+	// RolloutStateHalted is set when more updated workers failed the update verification than allowed.
+	RolloutStateHalted = "Halted"
+	// RolloutStateComplete is set when all workers allowed by the partition run the update revision.
+	RolloutStateComplete = "Complete"
 )
 
 // NodeSetSpec defines the desired state of NodeSet
+// +kubebuilder:validation:XValidation:rule="!has(self.updateVerification) || self.updateStrategy == 'slurmAwareRollingUpdate'",message="updateVerification is only supported with the slurmAwareRollingUpdate update strategy"
+// +kubebuilder:validation:XValidation:rule="!has(self.rollout) || self.updateStrategy == 'slurmAwareRollingUpdate'",message="rollout is only supported with the slurmAwareRollingUpdate update strategy"
 // +kubebuilder:validation:XValidation:rule="!has(self.maxUnavailable) || (type(self.maxUnavailable) == int ? (self.maxUnavailable >= 1 && self.maxUnavailable <= 500) : (self.maxUnavailable.matches('^[1-9][0-9]*%$') && int(self.maxUnavailable.find('^[0-9]+')) * self.replicas / 100 <= 500))",message="maxUnavailable must resolve to no more than 500 workers"
 type NodeSetSpec struct {
 	// ClusterName is the name of the SlurmCluster this NodeSet belongs to.
@@ -311,6 +358,90 @@ type NodeSetSpec struct {
 	// +kubebuilder:validation:Enum=rollingUpdate;slurmAwareRollingUpdate
 	// +kubebuilder:default="rollingUpdate"
 	UpdateStrategy consts.UpdateStrategy `json:"updateStrategy"`
+
+	// UpdateVerification runs an ActiveCheck on each worker updated by the slurmAwareRollingUpdate strategy
+	// before the worker is returned to service, and halts the rollout if too many updated workers fail it.
+	//
+	// +kubebuilder:validation:Optional
+	UpdateVerification *UpdateVerificationSpec `json:"updateVerification,omitempty"`
+
+	// Rollout controls the progress of the slurmAwareRollingUpdate strategy,
+	// e.g. to pause it or to canary an update on a few workers first.
+	//
+	// +kubebuilder:validation:Optional
+	Rollout *RolloutSpec `json:"rollout,omitempty"`
+}
+
+// RolloutSpec defines the controls of the slurmAwareRollingUpdate strategy.
+// +kubebuilder:validation:XValidation:rule="!has(self.drainAndWait) || (has(self.mode) && self.mode == 'drainAndWait')",message="drainAndWait is only supported with the drainAndWait rollout mode"
+type RolloutSpec struct {
+	// Paused stops rebooting outdated workers.
+	// Workers which are already being updated or verified finish their update.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	Paused bool `json:"paused,omitempty"`
+
+	// Partition limits the update to the workers with an ordinal greater than or equal to it.
+	// Setting it to replicas - N canaries an update on the last N workers, lowering it then rolls the update out
+	// to the remaining workers.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=0
+	Partition int32 `json:"partition,omitempty"`
+
+	// Mode defines how outdated workers are taken out of service for their update.
+	// The reboot mode schedules a Slurm reboot of the workers in ordinal order, which drains them until their jobs
+	// finish. The drainAndWait mode picks the workers whose jobs finish soonest and reserves each of them from the
+	// time its jobs finish, so that only jobs ending before that can still be scheduled on it.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=reboot;drainAndWait
+	// +kubebuilder:default=reboot
+	Mode consts.RolloutMode `json:"mode,omitempty"`
+
+	// DrainAndWait configures the drainAndWait mode.
+	//
+	// +kubebuilder:validation:Optional
+	DrainAndWait *DrainAndWaitSpec `json:"drainAndWait,omitempty"`
+}
+
+// DrainAndWaitSpec defines the drainAndWait rollout mode.
+type DrainAndWaitSpec struct {
+	// ReservationDuration is how long a worker is reserved for its update once its jobs finish.
+	// It's kept short, so that a worker isn't held out of service for long if its update is interrupted.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="1h"
+	ReservationDuration metav1.Duration `json:"reservationDuration,omitempty"`
+
+	// MaxDrainedPerPartition caps the nodes out of service in each Slurm partition of the workers.
+	// Value can be an absolute number (ex: 2) or a percentage of the nodes of the partition (ex: 10%).
+	// Absolute number is calculated from percentage by rounding down, but is at least 1.
+	// Nodes drained for any reason, rebooting or reserved for an update count against it.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="10%"
+	MaxDrainedPerPartition *intstr.IntOrString `json:"maxDrainedPerPartition,omitempty"`
+}
+
+// UpdateVerificationSpec defines the check of the workers updated by the slurmAwareRollingUpdate strategy.
+type UpdateVerificationSpec struct {
+	// ActiveCheckName is the name of the ActiveCheck run on each updated worker.
+	// It must be a check of the slurmJob type in the same namespace.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	ActiveCheckName string `json:"activeCheckName"`
+
+	// MaxFailedNodes is the number of updated workers allowed to fail the check.
+	// Once more workers fail it, no more workers are updated until the NodeSet is updated again.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=0
+	MaxFailedNodes int32 `json:"maxFailedNodes,omitempty"`
 }
 
 // ContainerSlurmdSpec defines the Slurm worker daemon configuration
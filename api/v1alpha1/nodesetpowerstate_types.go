@@ -9,6 +9,7 @@ import (
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="NodeSet",type="string",JSONPath=".spec.nodeSetRef",description="Reference to the NodeSet"
 // +kubebuilder:printcolumn:name="Active",type="integer",JSONPath=".status.activeCount",description="Number of active nodes"
+// +kubebuilder:printcolumn:name="Warm",type="integer",JSONPath=".status.desiredWarmNodes",description="Number of nodes kept warm by the policy",priority=1
 // +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status",description="Ready status"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
@@ -49,8 +50,102 @@ type NodeSetPowerStateSpec struct {
 	// +kubebuilder:validation:Optional
 	// +listType=set
 	ActiveNodes []int32 `json:"activeNodes,omitempty"`
+
+	// Policy keeps nodes powered on in addition to the ones Slurm requested, e.g. to avoid cold starts on a morning burst.
+	// The NodeSet controller merges the nodes kept by the policy with ActiveNodes.
+	//
+	// +kubebuilder:validation:Optional
+	Policy *NodeSetPowerPolicy `json:"policy,omitempty"`
+}
+
+// NodeSetPowerPolicy defines how many nodes of an ephemeral NodeSet are kept powered on regardless of Slurm's
+// ResumeProgram/SuspendProgram calls.
+// +kubebuilder:validation:XValidation:rule="!has(self.maxNodes) || !has(self.minWarmNodes) || self.minWarmNodes <= self.maxNodes",message="minWarmNodes must not exceed maxNodes"
+type NodeSetPowerPolicy struct {
+	// MinWarmNodes is the number of nodes kept powered on at any time.
+	// Nodes requested by Slurm count towards it.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:default=0
+	MinWarmNodes int32 `json:"minWarmNodes,omitempty"`
+
+	// MaxNodes is the maximum number of nodes powered on, including the ones requested by Slurm.
+	// Nodes requested by Slurm above the limit aren't started, and the power manager marks them down in Slurm.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	MaxNodes *int32 `json:"maxNodes,omitempty"`
+
+	// Schedules raise the number of warm nodes during recurring time windows.
+	// When several schedules are active, the largest number of warm nodes applies.
+	//
+	// +kubebuilder:validation:Optional
+	// +listType=atomic
+	Schedules []NodeSetPowerSchedule `json:"schedules,omitempty"`
+
+	// ScaleDownCooldown is how long the warm nodes are kept after the number of warm nodes was last raised,
+	// e.g. to ride out short gaps between schedules.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="10m"
+	ScaleDownCooldown metav1.Duration `json:"scaleDownCooldown,omitempty"`
+}
+
+// NodeSetPowerSchedule keeps a number of nodes warm during a recurring time window.
+// +kubebuilder:validation:XValidation:rule="self.start != self.end",message="start and end must differ"
+type NodeSetPowerSchedule struct {
+	// Name is an optional name of the schedule, reported in the status.
+	//
+	// +kubebuilder:validation:Optional
+	Name string `json:"name,omitempty"`
+
+	// Days are the days of the week the window starts on. All days if empty.
+	//
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	Days []Weekday `json:"days,omitempty"`
+
+	// Start is the time of day the window starts at, in the "HH:MM" format.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+
+	// End is the time of day the window ends at, in the "HH:MM" format.
+	// An end before the start ends the window on the next day.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	End string `json:"end"`
+
+	// TimeZone is the IANA time zone Start and End are in, e.g. "Europe/Amsterdam".
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="UTC"
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// MinWarmNodes is the number of nodes kept powered on during the window.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=0
+	MinWarmNodes int32 `json:"minWarmNodes"`
 }
 
+// Weekday is a day of the week.
+// +kubebuilder:validation:Enum=Mon;Tue;Wed;Thu;Fri;Sat;Sun
+type Weekday string
+
+const (
+	WeekdayMonday    Weekday = "Mon"
+	WeekdayTuesday   Weekday = "Tue"
+	WeekdayWednesday Weekday = "Wed"
+	WeekdayThursday  Weekday = "Thu"
+	WeekdayFriday    Weekday = "Fri"
+	WeekdaySaturday  Weekday = "Sat"
+	WeekdaySunday    Weekday = "Sun"
+)
+
 // NodeSetPowerStateStatus defines the observed state of NodeSetPowerState
 type NodeSetPowerStateStatus struct {
 	// ObservedGeneration is the most recent generation observed for this NodeSetPowerState.
@@ -64,13 +159,35 @@ type NodeSetPowerStateStatus struct {
 	// +kubebuilder:validation:Optional
 	ActiveCount int32 `json:"activeCount,omitempty"`
 
-	// NodeStates contains the detailed state of each active node, keyed by ordinal.
+	// NodeStates contains the detailed state of each node requested by Slurm or kept warm, keyed by ordinal.
+	// The power manager reads it to report nodes that fail to resume back to Slurm.
 	//
 	// +kubebuilder:validation:Optional
 	NodeStates map[string]NodePowerStateInfo `json:"nodeStates,omitempty"`
 
+	// WarmNodes is the list of node ordinals kept powered on by the policy in addition to spec.activeNodes.
+	//
+	// +kubebuilder:validation:Optional
+	WarmNodes []int32 `json:"warmNodes,omitempty"`
+
+	// DesiredWarmNodes is the number of warm nodes the policy currently asks for.
+	//
+	// +kubebuilder:validation:Optional
+	DesiredWarmNodes int32 `json:"desiredWarmNodes,omitempty"`
+
+	// ActiveSchedules is the list of names of the policy schedules which are currently active.
+	//
+	// +kubebuilder:validation:Optional
+	ActiveSchedules []string `json:"activeSchedules,omitempty"`
+
+	// LastScaleUpTime is the last time the number of warm nodes was raised.
+	// The scale-down cooldown is counted from it.
+	//
+	// +kubebuilder:validation:Optional
+	LastScaleUpTime *metav1.Time `json:"lastScaleUpTime,omitempty"`
+
 	// Conditions represent the observations of a NodeSetPowerState's current state.
-	// Known types are: Ready, ScalingInProgress.
+	// Known types are: Ready, ScalingInProgress, ScalingLimited.
 	//
 	// +patchMergeKey=type
 	// +patchStrategy=merge
@@ -98,11 +215,23 @@ type NodePowerStateInfo struct {
 	// +kubebuilder:validation:Optional
 	SlurmState string `json:"slurmState,omitempty"`
 
-	// LastTransitionTime is the last time the node transitioned to this phase.
+	// ActivationTime is the time the node was powered on.
+	// Resume latencies are measured from it.
+	//
+	// +kubebuilder:validation:Optional
+	ActivationTime *metav1.Time `json:"activationTime,omitempty"`
+
+	// LastTransitionTime is the last time the node transitioned to this phase, reason or Slurm state.
 	//
 	// +kubebuilder:validation:Optional
 	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
 
+	// Reason is a CamelCase reason of why the node's pod isn't running yet,
+	// e.g. Unschedulable, ImagePullBackOff or MaxNodesReached. Empty while the pod runs fine.
+	//
+	// +kubebuilder:validation:Optional
+	Reason string `json:"reason,omitempty"`
+
 	// Message provides additional details about the node's state.
 	// This is typically used to provide error messages.
 	//
@@ -146,6 +275,17 @@ const (
 
 	// ConditionNodeSetPowerStateScalingInProgress indicates that scaling operations are in progress.
 	ConditionNodeSetPowerStateScalingInProgress = "ScalingInProgress"
+
+	// ConditionNodeSetPowerStateScalingLimited indicates that nodes requested by Slurm are not started because of the
+	// policy's maxNodes.
+	ConditionNodeSetPowerStateScalingLimited = "ScalingLimited"
+)
+
+const (
+	// NodePowerReasonPodNotCreated is set when the worker pod of an active node doesn't exist yet.
+	NodePowerReasonPodNotCreated = "PodNotCreated"
+	// NodePowerReasonMaxNodesReached is set when the node isn't started because of the policy's maxNodes.
+	NodePowerReasonMaxNodesReached = "MaxNodesReached"
 )
 
 func init() {
@@ -0,0 +1,194 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.slurmClusterRefName",description="The SlurmCluster the account is created in"
+// +kubebuilder:printcolumn:name="Account",type="string",JSONPath=".status.accountName",description="The name of the account in slurmdbd"
+// +kubebuilder:printcolumn:name="Parent",type="string",JSONPath=".spec.parentAccount",description="The parent account"
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status",description="Whether the account in slurmdbd matches the spec"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SlurmAccount is the Schema for the slurmaccounts API.
+// It declares a Slurm account with its association limits and users, which are created and kept in sync in slurmdbd
+// through the Slurm REST API.
+type SlurmAccount struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SlurmAccountSpec   `json:"spec,omitempty"`
+	Status SlurmAccountStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SlurmAccountList contains a list of SlurmAccount
+type SlurmAccountList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []SlurmAccount `json:"items"`
+}
+
+// SlurmAccountSpec defines the desired state of SlurmAccount
+// +kubebuilder:validation:XValidation:rule="!has(self.defaultQOS) || !has(self.qos) || self.defaultQOS in self.qos",message="defaultQOS must be in qos"
+type SlurmAccountSpec struct {
+	// SlurmClusterRefName is the name of the SlurmCluster the account is created in.
+	// The cluster must be in the same namespace and have accounting enabled.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="slurmClusterRefName is immutable"
+	SlurmClusterRefName string `json:"slurmClusterRefName"`
+
+	// AccountName is the name of the account in slurmdbd.
+	// Defaults to the name of the SlurmAccount.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="accountName is immutable"
+	AccountName string `json:"accountName,omitempty"`
+
+	// Description is an arbitrary description of the account.
+	// Defaults to the account name.
+	//
+	// +kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
+
+	// Organization is the organization the account belongs to.
+	// Defaults to the account name.
+	//
+	// +kubebuilder:validation:Optional
+	Organization string `json:"organization,omitempty"`
+
+	// ParentAccount is the account this account is nested under in the fairshare tree.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="root"
+	ParentAccount string `json:"parentAccount,omitempty"`
+
+	// Fairshare is the raw number of shares of the account.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	Fairshare *int32 `json:"fairshare,omitempty"`
+
+	// QOS is the list of QOS jobs of the account may use.
+	//
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	QOS []string `json:"qos,omitempty"`
+
+	// DefaultQOS is the QOS jobs of the account run under when they don't request one.
+	//
+	// +kubebuilder:validation:Optional
+	DefaultQOS string `json:"defaultQOS,omitempty"`
+
+	// Limits are the limits of the account association.
+	//
+	// +kubebuilder:validation:Optional
+	Limits SlurmAccountLimits `json:"limits,omitempty"`
+
+	// Users is the list of users of the account.
+	// Users are created in slurmdbd if they don't exist yet. User associations of the account which aren't listed
+	// here are deleted.
+	//
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	Users []string `json:"users,omitempty"`
+
+	// DeletionPolicy defines whether the account and its associations are deleted from slurmdbd when the SlurmAccount
+	// is deleted.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="Retain"
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// SlurmAccountLimits defines the limits of an account association.
+// TRES limits are keyed by TRES name, e.g. "cpu", "mem" or "gres/gpu". Memory is set in MB, as in Slurm.
+// Limits removed from the spec are left unchanged in slurmdbd; set them to -1 to remove them.
+type SlurmAccountLimits struct {
+	// GrpTRES is the total amount of TRES running jobs of the account may use.
+	//
+	// +kubebuilder:validation:Optional
+	GrpTRES map[string]int64 `json:"grpTRES,omitempty"`
+
+	// GrpTRESMinutes is the total amount of TRES-minutes jobs of the account may consume.
+	//
+	// +kubebuilder:validation:Optional
+	GrpTRESMinutes map[string]int64 `json:"grpTRESMinutes,omitempty"`
+
+	// GrpJobs is the maximum number of running jobs of the account.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=-1
+	GrpJobs *int32 `json:"grpJobs,omitempty"`
+
+	// MaxJobs is the maximum number of running jobs of each user of the account.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=-1
+	MaxJobs *int32 `json:"maxJobs,omitempty"`
+
+	// MaxTRESPerJob is the amount of TRES a single job of the account may use.
+	//
+	// +kubebuilder:validation:Optional
+	MaxTRESPerJob map[string]int64 `json:"maxTRESPerJob,omitempty"`
+
+	// MaxWallPerJobMinutes is the maximum wall clock time a job of the account may run for.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=-1
+	MaxWallPerJobMinutes *int32 `json:"maxWallPerJobMinutes,omitempty"`
+}
+
+// GetAccountName returns the name of the account in slurmdbd.
+func (a *SlurmAccount) GetAccountName() string {
+	if a.Spec.AccountName != "" {
+		return a.Spec.AccountName
+	}
+	return a.Name
+}
+
+// SlurmAccountStatus defines the observed state of SlurmAccount
+type SlurmAccountStatus struct {
+	// ObservedGeneration is the most recent generation observed for this SlurmAccount.
+	//
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// AccountName is the name of the account in slurmdbd.
+	//
+	// +kubebuilder:validation:Optional
+	AccountName string `json:"accountName,omitempty"`
+
+	// Users is the list of users associated with the account in slurmdbd.
+	//
+	// +kubebuilder:validation:Optional
+	Users []string `json:"users,omitempty"`
+
+	// LastDriftCorrectionTime is the last time the account was updated in slurmdbd because it didn't match the spec.
+	//
+	// +kubebuilder:validation:Optional
+	LastDriftCorrectionTime *metav1.Time `json:"lastDriftCorrectionTime,omitempty"`
+
+	// Conditions represent the observations of a SlurmAccount's current state.
+	// Known types are: ConditionSlurmAccountingSynced.
+	//
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchMergeKey:"type" patchStrategy:"merge"`
+}
+
+const (
+	KindSlurmAccount = "SlurmAccount"
+)
+
+func init() {
+	SchemeBuilder.Register(&SlurmAccount{}, &SlurmAccountList{})
+}
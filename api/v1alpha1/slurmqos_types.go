@@ -0,0 +1,154 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=sqos
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.slurmClusterRefName",description="The SlurmCluster the QOS is created in"
+// +kubebuilder:printcolumn:name="QOS",type="string",JSONPath=".status.qosName",description="The name of the QOS in slurmdbd"
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status",description="Whether the QOS in slurmdbd matches the spec"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SlurmQOS is the Schema for the slurmqos API.
+// It declares a Slurm quality of service, which is created and kept in sync in slurmdbd through the Slurm REST API.
+type SlurmQOS struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SlurmQOSSpec   `json:"spec,omitempty"`
+	Status SlurmQOSStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SlurmQOSList contains a list of SlurmQOS
+type SlurmQOSList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []SlurmQOS `json:"items"`
+}
+
+// SlurmQOSSpec defines the desired state of SlurmQOS
+type SlurmQOSSpec struct {
+	// SlurmClusterRefName is the name of the SlurmCluster the QOS is created in.
+	// The cluster must be in the same namespace and have accounting enabled.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="slurmClusterRefName is immutable"
+	SlurmClusterRefName string `json:"slurmClusterRefName"`
+
+	// QOSName is the name of the QOS in slurmdbd.
+	// Defaults to the name of the SlurmQOS.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="qosName is immutable"
+	QOSName string `json:"qosName,omitempty"`
+
+	// Description is an arbitrary description of the QOS.
+	//
+	// +kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
+
+	// Priority is the priority of jobs running under the QOS.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	Priority *int32 `json:"priority,omitempty"`
+
+	// UsageFactor is the factor applied to the usage of jobs running under the QOS, e.g. "0.5".
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	UsageFactor *string `json:"usageFactor,omitempty"`
+
+	// Limits are the resource limits of the QOS.
+	//
+	// +kubebuilder:validation:Optional
+	Limits SlurmQOSLimits `json:"limits,omitempty"`
+
+	// DeletionPolicy defines whether the QOS is deleted from slurmdbd when the SlurmQOS is deleted.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="Retain"
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// SlurmQOSLimits defines the limits of a QOS.
+// TRES limits are keyed by TRES name, e.g. "cpu", "mem" or "gres/gpu". Memory is set in MB, as in Slurm.
+// Limits removed from the spec are left unchanged in slurmdbd; set them to -1 to remove them.
+type SlurmQOSLimits struct {
+	// GrpTRES is the total amount of TRES jobs running under the QOS may use.
+	//
+	// +kubebuilder:validation:Optional
+	GrpTRES map[string]int64 `json:"grpTRES,omitempty"`
+
+	// MaxTRESPerUser is the amount of TRES a single user may use under the QOS.
+	//
+	// +kubebuilder:validation:Optional
+	MaxTRESPerUser map[string]int64 `json:"maxTRESPerUser,omitempty"`
+
+	// MaxJobsPerUser is the maximum number of running jobs per user.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=-1
+	MaxJobsPerUser *int32 `json:"maxJobsPerUser,omitempty"`
+
+	// MaxWallPerJobMinutes is the maximum wall clock time a job may run for.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=-1
+	MaxWallPerJobMinutes *int32 `json:"maxWallPerJobMinutes,omitempty"`
+}
+
+// GetQOSName returns the name of the QOS in slurmdbd.
+func (q *SlurmQOS) GetQOSName() string {
+	if q.Spec.QOSName != "" {
+		return q.Spec.QOSName
+	}
+	return q.Name
+}
+
+// SlurmQOSStatus defines the observed state of SlurmQOS
+type SlurmQOSStatus struct {
+	// ObservedGeneration is the most recent generation observed for this SlurmQOS.
+	//
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// QOSName is the name of the QOS in slurmdbd.
+	//
+	// +kubebuilder:validation:Optional
+	QOSName string `json:"qosName,omitempty"`
+
+	// ID is the ID of the QOS in slurmdbd.
+	//
+	// +kubebuilder:validation:Optional
+	ID *int32 `json:"id,omitempty"`
+
+	// LastDriftCorrectionTime is the last time the QOS was updated in slurmdbd because it didn't match the spec.
+	//
+	// +kubebuilder:validation:Optional
+	LastDriftCorrectionTime *metav1.Time `json:"lastDriftCorrectionTime,omitempty"`
+
+	// Conditions represent the observations of a SlurmQOS's current state.
+	// Known types are: ConditionSlurmAccountingSynced.
+	//
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchMergeKey:"type" patchStrategy:"merge"`
+}
+
+const (
+	KindSlurmQOS = "SlurmQOS"
+)
+
+func init() {
+	SchemeBuilder.Register(&SlurmQOS{}, &SlurmQOSList{})
+}
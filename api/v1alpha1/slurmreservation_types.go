@@ -0,0 +1,253 @@
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.slurmClusterRefName",description="The SlurmCluster the reservation is created in"
+// +kubebuilder:printcolumn:name="Nodes",type="string",JSONPath=".status.nodeList",description="Nodes reserved in Slurm"
+// +kubebuilder:printcolumn:name="Start",type="string",JSONPath=".spec.startTime",description="Reservation start time"
+// +kubebuilder:printcolumn:name="End",type="string",JSONPath=".spec.endTime",description="Reservation end time"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="The phase of the reservation"
+// +kubebuilder:printcolumn:name="Synced",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status",description="Whether the Slurm reservation matches the spec"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// SlurmReservation is the Schema for the slurmreservations API.
+// It declares a Slurm reservation, e.g. a maintenance window, which is created and kept in sync
+// through the Slurm REST API.
+type SlurmReservation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SlurmReservationSpec   `json:"spec,omitempty"`
+	Status SlurmReservationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SlurmReservationList contains a list of SlurmReservation
+type SlurmReservationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []SlurmReservation `json:"items"`
+}
+
+// SlurmReservationSpec defines the desired state of SlurmReservation
+// +kubebuilder:validation:XValidation:rule="has(self.nodeSetRefs) || has(self.nodeList) || has(self.nodeCount)",message="one of nodeSetRefs, nodeList or nodeCount must be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.nodeCount) || (!has(self.nodeSetRefs) && !has(self.nodeList))",message="nodeCount can't be combined with nodeSetRefs or nodeList"
+// +kubebuilder:validation:XValidation:rule="has(self.users) || has(self.accounts)",message="one of users or accounts must be set"
+// +kubebuilder:validation:XValidation:rule="timestamp(self.endTime) > timestamp(self.startTime)",message="endTime must be after startTime"
+type SlurmReservationSpec struct {
+	// SlurmClusterRefName is the name of the SlurmCluster the reservation is created in.
+	// The cluster must be in the same namespace.
+	//
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="slurmClusterRefName is immutable"
+	SlurmClusterRefName string `json:"slurmClusterRefName"`
+
+	// ReservationName is the name of the reservation in Slurm.
+	// Defaults to the name of the SlurmReservation.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="reservationName is immutable"
+	ReservationName string `json:"reservationName,omitempty"`
+
+	// NodeSetRefs is the list of NodeSets whose nodes are reserved.
+	// The NodeSets must be in the same namespace.
+	//
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	NodeSetRefs []string `json:"nodeSetRefs,omitempty"`
+
+	// NodeList is a Slurm hostlist expression of additional nodes to reserve, e.g. "worker-[0-3],worker-7".
+	//
+	// +kubebuilder:validation:Optional
+	NodeList string `json:"nodeList,omitempty"`
+
+	// NodeCount is the number of nodes Slurm picks for the reservation.
+	// Can't be combined with NodeSetRefs and NodeList.
+	//
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	NodeCount *int32 `json:"nodeCount,omitempty"`
+
+	// Partition is the partition the nodes are picked from when NodeCount is used.
+	//
+	// +kubebuilder:validation:Optional
+	Partition string `json:"partition,omitempty"`
+
+	// StartTime is the time the reservation starts at.
+	// A start time in the past makes the reservation start immediately.
+	//
+	// +kubebuilder:validation:Required
+	StartTime metav1.Time `json:"startTime"`
+
+	// EndTime is the time the reservation ends at.
+	// Slurm removes the reservation once it ends, unless it is recurring.
+	//
+	// +kubebuilder:validation:Required
+	EndTime metav1.Time `json:"endTime"`
+
+	// Flags is the list of Slurm reservation flags, e.g. MAINT or IGNORE_JOBS.
+	// Flags are only added to an existing reservation: removing a flag from the list doesn't remove it in Slurm.
+	//
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	Flags []SlurmReservationFlag `json:"flags,omitempty"`
+
+	// Users is the list of users allowed to use the reservation.
+	//
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	Users []string `json:"users,omitempty"`
+
+	// Accounts is the list of accounts allowed to use the reservation.
+	//
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	Accounts []string `json:"accounts,omitempty"`
+}
+
+// SlurmReservationFlag is a Slurm reservation flag.
+// +kubebuilder:validation:Enum=MAINT;IGNORE_JOBS;FLEX;OVERLAP;ANY_NODES;MAGNETIC;PURGE_COMP;REPLACE;REPLACE_DOWN;STATIC;NO_HOLD_JOBS_AFTER_END;USER_DELETE;HOURLY;DAILY;WEEKDAY;WEEKEND;WEEKLY
+type SlurmReservationFlag string
+
+const (
+	SlurmReservationFlagMaint      SlurmReservationFlag = "MAINT"
+	SlurmReservationFlagIgnoreJobs SlurmReservationFlag = "IGNORE_JOBS"
+	SlurmReservationFlagHourly     SlurmReservationFlag = "HOURLY"
+	SlurmReservationFlagDaily      SlurmReservationFlag = "DAILY"
+	SlurmReservationFlagWeekday    SlurmReservationFlag = "WEEKDAY"
+	SlurmReservationFlagWeekend    SlurmReservationFlag = "WEEKEND"
+	SlurmReservationFlagWeekly     SlurmReservationFlag = "WEEKLY"
+)
+
+// IsRecurring reports whether the reservation is repeated by Slurm, which moves its start and end time on every repetition.
+func (s *SlurmReservationSpec) IsRecurring() bool {
+	for _, flag := range s.Flags {
+		switch flag {
+		case SlurmReservationFlagHourly,
+			SlurmReservationFlagDaily,
+			SlurmReservationFlagWeekday,
+			SlurmReservationFlagWeekend,
+			SlurmReservationFlagWeekly:
+			return true
+		}
+	}
+	return false
+}
+
+// GetReservationName returns the name of the reservation in Slurm.
+func (r *SlurmReservation) GetReservationName() string {
+	if r.Spec.ReservationName != "" {
+		return r.Spec.ReservationName
+	}
+	return r.Name
+}
+
+// SlurmReservationStatus defines the observed state of SlurmReservation
+type SlurmReservationStatus struct {
+	// ObservedGeneration is the most recent generation observed for this SlurmReservation.
+	//
+	// +kubebuilder:validation:Optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Phase indicates the current phase of the reservation.
+	// Known values are: PhaseSlurmReservationPending, PhaseSlurmReservationScheduled, PhaseSlurmReservationActive, and PhaseSlurmReservationExpired.
+	//
+	// +kubebuilder:validation:Optional
+	Phase string `json:"phase,omitempty"`
+
+	// ReservationName is the name of the reservation in Slurm.
+	//
+	// +kubebuilder:validation:Optional
+	ReservationName string `json:"reservationName,omitempty"`
+
+	// NodeList is the hostlist expression of the nodes reserved in Slurm.
+	//
+	// +kubebuilder:validation:Optional
+	NodeList string `json:"nodeList,omitempty"`
+
+	// NodeCount is the number of nodes reserved in Slurm.
+	//
+	// +kubebuilder:validation:Optional
+	NodeCount int32 `json:"nodeCount,omitempty"`
+
+	// StartTime is the start time of the reservation in Slurm.
+	//
+	// +kubebuilder:validation:Optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// EndTime is the end time of the reservation in Slurm.
+	//
+	// +kubebuilder:validation:Optional
+	EndTime *metav1.Time `json:"endTime,omitempty"`
+
+	// Flags is the list of flags of the reservation in Slurm, including the ones Slurm sets on its own.
+	//
+	// +kubebuilder:validation:Optional
+	Flags []string `json:"flags,omitempty"`
+
+	// Users is the list of users allowed to use the reservation in Slurm.
+	//
+	// +kubebuilder:validation:Optional
+	Users []string `json:"users,omitempty"`
+
+	// Accounts is the list of accounts allowed to use the reservation in Slurm.
+	//
+	// +kubebuilder:validation:Optional
+	Accounts []string `json:"accounts,omitempty"`
+
+	// LastDriftCorrectionTime is the last time the reservation was updated in Slurm because it didn't match the spec.
+	//
+	// +kubebuilder:validation:Optional
+	LastDriftCorrectionTime *metav1.Time `json:"lastDriftCorrectionTime,omitempty"`
+
+	// Conditions represent the observations of a SlurmReservation's current state.
+	// Known types are: ConditionSlurmReservationSynced.
+	//
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchMergeKey:"type" patchStrategy:"merge"`
+}
+
+// SetCondition sets the given condition in the SlurmReservationStatus conditions slice.
+// Returns true if the condition was added or updated, false otherwise.
+func (s *SlurmReservationStatus) SetCondition(condition metav1.Condition) bool {
+	return meta.SetStatusCondition(&s.Conditions, condition)
+}
+
+const (
+	KindSlurmReservation = "SlurmReservation"
+
+	// PhaseSlurmReservationPending is set when the reservation doesn't exist in Slurm yet.
+	PhaseSlurmReservationPending = "Pending"
+	// PhaseSlurmReservationScheduled is set when the reservation exists in Slurm and starts in the future.
+	PhaseSlurmReservationScheduled = "Scheduled"
+	// PhaseSlurmReservationActive is set when the reservation exists in Slurm and has started.
+	PhaseSlurmReservationActive = "Active"
+	// PhaseSlurmReservationExpired is set when the reservation has ended and is no longer reconciled.
+	PhaseSlurmReservationExpired = "Expired"
+
+	// ConditionSlurmReservationSynced is set when the reservation in Slurm matches the spec.
+	ConditionSlurmReservationSynced = "Synced"
+
+	ReasonSlurmReservationCreated             = "Created"
+	ReasonSlurmReservationUpdated             = "Updated"
+	ReasonSlurmReservationInSync              = "InSync"
+	ReasonSlurmReservationExpired             = "Expired"
+	ReasonSlurmReservationInvalidNodes        = "InvalidNodes"
+	ReasonSlurmReservationSlurmAPIUnavailable = "SlurmAPIUnavailable"
+	ReasonSlurmReservationSlurmAPIError       = "SlurmAPIError"
+)
+
+func init() {
+	SchemeBuilder.Register(&SlurmReservation{}, &SlurmReservationList{})
+}
@@ -53,3 +53,463 @@ func TestNodeSetMaxUnavailableCELValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestNodeSetUpdateVerificationCELValidation(t *testing.T) {
+	validator, err := apiextensionstest.VersionValidatorFromFile(
+		t,
+		filepath.Join("..", "..", "config", "crd", "bases", "slurm.nebius.ai_nodesets.yaml"),
+		"v1alpha1",
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		updateStrategy string
+		wantErr        string
+	}{
+		{name: "slurm-aware rolling update", updateStrategy: "slurmAwareRollingUpdate"},
+		{
+			name:           "rolling update",
+			updateStrategy: "rollingUpdate",
+			wantErr:        "updateVerification is only supported with the slurmAwareRollingUpdate update strategy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validator(map[string]any{
+				"spec": map[string]any{
+					"replicas":       int64(4),
+					"updateStrategy": tt.updateStrategy,
+					"updateVerification": map[string]any{
+						"activeCheckName": "gpu-checks",
+						"maxFailedNodes":  int64(1),
+					},
+				},
+			}, nil)
+
+			if tt.wantErr == "" {
+				assert.Empty(t, errs)
+				return
+			}
+
+			if assert.NotEmpty(t, errs) {
+				assert.Contains(t, errs.ToAggregate().Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNodeSetRolloutCELValidation(t *testing.T) {
+	validator, err := apiextensionstest.VersionValidatorFromFile(
+		t,
+		filepath.Join("..", "..", "config", "crd", "bases", "slurm.nebius.ai_nodesets.yaml"),
+		"v1alpha1",
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		updateStrategy string
+		mode           string
+		drainAndWait   map[string]any
+		wantErr        string
+	}{
+		{name: "slurm-aware rolling update", updateStrategy: "slurmAwareRollingUpdate"},
+		{
+			name:           "rolling update",
+			updateStrategy: "rollingUpdate",
+			wantErr:        "rollout is only supported with the slurmAwareRollingUpdate update strategy",
+		},
+		{
+			name:           "drain and wait",
+			updateStrategy: "slurmAwareRollingUpdate",
+			mode:           "drainAndWait",
+			drainAndWait:   map[string]any{"reservationDuration": "30m", "maxDrainedPerPartition": "5%"},
+		},
+		{
+			name:           "drain and wait settings in the reboot mode",
+			updateStrategy: "slurmAwareRollingUpdate",
+			mode:           "reboot",
+			drainAndWait:   map[string]any{"reservationDuration": "30m"},
+			wantErr:        "drainAndWait is only supported with the drainAndWait rollout mode",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rollout := map[string]any{
+				"paused":    true,
+				"partition": int64(2),
+			}
+			if tt.mode != "" {
+				rollout["mode"] = tt.mode
+			}
+			if tt.drainAndWait != nil {
+				rollout["drainAndWait"] = tt.drainAndWait
+			}
+			errs := validator(map[string]any{
+				"spec": map[string]any{
+					"replicas":       int64(4),
+					"updateStrategy": tt.updateStrategy,
+					"rollout":        rollout,
+				},
+			}, nil)
+
+			if tt.wantErr == "" {
+				assert.Empty(t, errs)
+				return
+			}
+
+			if assert.NotEmpty(t, errs) {
+				assert.Contains(t, errs.ToAggregate().Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSlurmReservationCELValidation(t *testing.T) {
+	validator, err := apiextensionstest.VersionValidatorFromFile(
+		t,
+		filepath.Join("..", "..", "config", "crd", "bases", "slurm.nebius.ai_slurmreservations.yaml"),
+		"v1alpha1",
+	)
+	require.NoError(t, err)
+
+	validSpec := func() map[string]any {
+		return map[string]any{
+			"slurmClusterRefName": "soperator",
+			"nodeSetRefs":         []any{"worker"},
+			"startTime":           "2026-11-02T08:00:00Z",
+			"endTime":             "2026-11-02T12:00:00Z",
+			"users":               []any{"root"},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(spec map[string]any)
+		wantErr string
+	}{
+		{name: "valid", mutate: func(map[string]any) {}},
+		{
+			name:    "no nodes",
+			mutate:  func(spec map[string]any) { delete(spec, "nodeSetRefs") },
+			wantErr: "one of nodeSetRefs, nodeList or nodeCount must be set",
+		},
+		{
+			name:    "node count with node sets",
+			mutate:  func(spec map[string]any) { spec["nodeCount"] = int64(2) },
+			wantErr: "nodeCount can't be combined with nodeSetRefs or nodeList",
+		},
+		{
+			name: "node count only",
+			mutate: func(spec map[string]any) {
+				delete(spec, "nodeSetRefs")
+				spec["nodeCount"] = int64(2)
+			},
+		},
+		{
+			name:    "no users or accounts",
+			mutate:  func(spec map[string]any) { delete(spec, "users") },
+			wantErr: "one of users or accounts must be set",
+		},
+		{
+			name:    "end before start",
+			mutate:  func(spec map[string]any) { spec["endTime"] = "2026-11-02T07:00:00Z" },
+			wantErr: "endTime must be after startTime",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := validSpec()
+			tt.mutate(spec)
+
+			errs := validator(map[string]any{"spec": spec}, nil)
+
+			if tt.wantErr == "" {
+				assert.Empty(t, errs)
+				return
+			}
+
+			if assert.NotEmpty(t, errs) {
+				assert.Contains(t, errs.ToAggregate().Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSlurmAccountCELValidation(t *testing.T) {
+	validator, err := apiextensionstest.VersionValidatorFromFile(
+		t,
+		filepath.Join("..", "..", "config", "crd", "bases", "slurm.nebius.ai_slurmaccounts.yaml"),
+		"v1alpha1",
+	)
+	require.NoError(t, err)
+
+	validSpec := func() map[string]any {
+		return map[string]any{
+			"slurmClusterRefName": "soperator",
+			"qos":                 []any{"normal", "high"},
+			"defaultQOS":          "normal",
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(spec map[string]any)
+		wantErr string
+	}{
+		{name: "valid", mutate: func(map[string]any) {}},
+		{
+			name:   "default qos without qos list",
+			mutate: func(spec map[string]any) { delete(spec, "qos") },
+		},
+		{
+			name:    "default qos not in qos list",
+			mutate:  func(spec map[string]any) { spec["defaultQOS"] = "low" },
+			wantErr: "defaultQOS must be in qos",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := validSpec()
+			tt.mutate(spec)
+
+			errs := validator(map[string]any{"spec": spec}, nil)
+
+			if tt.wantErr == "" {
+				assert.Empty(t, errs)
+				return
+			}
+
+			if assert.NotEmpty(t, errs) {
+				assert.Contains(t, errs.ToAggregate().Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNodeSetPowerStateCELValidation(t *testing.T) {
+	validator, err := apiextensionstest.VersionValidatorFromFile(
+		t,
+		filepath.Join("..", "..", "config", "crd", "bases", "slurm.nebius.ai_nodesetpowerstates.yaml"),
+		"v1alpha1",
+	)
+	require.NoError(t, err)
+
+	validSpec := func() map[string]any {
+		return map[string]any{
+			"nodeSetRef": "worker",
+			"policy": map[string]any{
+				"minWarmNodes": int64(2),
+				"maxNodes":     int64(16),
+				"schedules": []any{
+					map[string]any{"start": "08:00", "end": "20:00", "minWarmNodes": int64(16)},
+				},
+			},
+		}
+	}
+	policy := func(spec map[string]any) map[string]any {
+		return spec["policy"].(map[string]any)
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(spec map[string]any)
+		wantErr string
+	}{
+		{name: "valid", mutate: func(map[string]any) {}},
+		{
+			name:    "min warm nodes above max nodes",
+			mutate:  func(spec map[string]any) { policy(spec)["minWarmNodes"] = int64(32) },
+			wantErr: "minWarmNodes must not exceed maxNodes",
+		},
+		{
+			name: "empty schedule window",
+			mutate: func(spec map[string]any) {
+				policy(spec)["schedules"] = []any{
+					map[string]any{"start": "08:00", "end": "08:00", "minWarmNodes": int64(1)},
+				}
+			},
+			wantErr: "start and end must differ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := validSpec()
+			tt.mutate(spec)
+
+			errs := validator(map[string]any{"spec": spec}, nil)
+
+			if tt.wantErr == "" {
+				assert.Empty(t, errs)
+				return
+			}
+
+			if assert.NotEmpty(t, errs) {
+				assert.Contains(t, errs.ToAggregate().Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestActiveCheckRunCELValidation(t *testing.T) {
+	validator, err := apiextensionstest.VersionValidatorFromFile(
+		t,
+		filepath.Join("..", "..", "config", "crd", "bases", "slurm.nebius.ai_activecheckruns.yaml"),
+		"v1alpha1",
+	)
+	require.NoError(t, err)
+
+	oldRun := map[string]any{
+		"spec": map[string]any{
+			"activeCheckName": "gpu-checks",
+			"nodes":           []any{"worker-3"},
+		},
+	}
+
+	assert.Empty(t, validator(oldRun, nil))
+	assert.Empty(t, validator(oldRun, oldRun))
+
+	errs := validator(map[string]any{
+		"spec": map[string]any{
+			"activeCheckName": "gpu-checks",
+			"nodes":           []any{"worker-5"},
+		},
+	}, oldRun)
+	if assert.NotEmpty(t, errs) {
+		assert.Contains(t, errs.ToAggregate().Error(), "ActiveCheckRun spec is immutable")
+	}
+}
+
+func TestActiveCheckReactionsCELValidation(t *testing.T) {
+	validator, err := apiextensionstest.VersionValidatorFromFile(
+		t,
+		filepath.Join("..", "..", "config", "crd", "bases", "slurm.nebius.ai_activechecks.yaml"),
+		"v1alpha1",
+	)
+	require.NoError(t, err)
+
+	newCheck := func(spec map[string]any) map[string]any {
+		spec["name"] = "gpu-checks"
+		spec["slurmClusterRefName"] = "soperator"
+		return map[string]any{"spec": spec}
+	}
+
+	tests := []struct {
+		name    string
+		spec    map[string]any
+		wantErr string
+	}{
+		{
+			name: "valid",
+			spec: map[string]any{
+				"failureReactionsThreshold": int64(3),
+				"failureReactions": map[string]any{
+					"setHardwareIssuesSuspected": map[string]any{},
+					"notify":                     map[string]any{"url": "https://example.com/hook"},
+				},
+			},
+		},
+		{
+			name: "hardware issues on success",
+			spec: map[string]any{
+				"successReactions": map[string]any{"setHardwareIssuesSuspected": map[string]any{}},
+			},
+			wantErr: "setHardwareIssuesSuspected is only supported in failureReactions",
+		},
+		{
+			name: "notify without URL",
+			spec: map[string]any{
+				"failureReactions": map[string]any{"notify": map[string]any{}},
+			},
+			wantErr: "exactly one of url and urlSecretRef must be set",
+		},
+		{
+			name: "notify with both URLs",
+			spec: map[string]any{
+				"failureReactions": map[string]any{"notify": map[string]any{
+					"url":          "https://example.com/hook",
+					"urlSecretRef": map[string]any{"name": "hook", "key": "url"},
+				}},
+			},
+			wantErr: "exactly one of url and urlSecretRef must be set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validator(newCheck(tt.spec), nil)
+			if tt.wantErr == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			if assert.NotEmpty(t, errs) {
+				assert.Contains(t, errs.ToAggregate().Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestActiveCheckTargetsCELValidation(t *testing.T) {
+	validator, err := apiextensionstest.VersionValidatorFromFile(
+		t,
+		filepath.Join("..", "..", "config", "crd", "bases", "slurm.nebius.ai_activechecks.yaml"),
+		"v1alpha1",
+	)
+	require.NoError(t, err)
+
+	newCheck := func(checkType string, targets map[string]any) map[string]any {
+		return map[string]any{"spec": map[string]any{
+			"name":                "gpu-checks",
+			"slurmClusterRefName": "soperator",
+			"checkType":           checkType,
+			"targets":             targets,
+		}}
+	}
+
+	tests := []struct {
+		name      string
+		checkType string
+		targets   map[string]any
+		wantErr   string
+	}{
+		{
+			name:      "valid",
+			checkType: "slurmJob",
+			targets: map[string]any{
+				"nodeSetRefs": []any{"h100"},
+				"features":    []any{"platform-h100"},
+			},
+		},
+		{
+			name:      "empty targets",
+			checkType: "slurmJob",
+			targets:   map[string]any{},
+			wantErr:   "at least one of nodeSetRefs, partitions, features and nodeList must be set",
+		},
+		{
+			name:      "k8s job",
+			checkType: "k8sJob",
+			targets:   map[string]any{"nodeList": "worker-[0-3]"},
+			wantErr:   "targets are only supported for checks of the slurmJob type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validator(newCheck(tt.checkType, tt.targets), nil)
+			if tt.wantErr == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			if assert.NotEmpty(t, errs) {
+				assert.Contains(t, errs.ToAggregate().Error(), tt.wantErr)
+			}
+		})
+	}
+}
@@ -94,6 +94,208 @@ func (in *ActiveCheckList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActiveCheckMetricValue) DeepCopyInto(out *ActiveCheckMetricValue) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveCheckMetricValue.
+func (in *ActiveCheckMetricValue) DeepCopy() *ActiveCheckMetricValue {
+	if in == nil {
+		return nil
+	}
+	out := new(ActiveCheckMetricValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActiveCheckNodeMetrics) DeepCopyInto(out *ActiveCheckNodeMetrics) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]ActiveCheckMetricValue, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveCheckNodeMetrics.
+func (in *ActiveCheckNodeMetrics) DeepCopy() *ActiveCheckNodeMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(ActiveCheckNodeMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActiveCheckNodeOutcome) DeepCopyInto(out *ActiveCheckNodeOutcome) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveCheckNodeOutcome.
+func (in *ActiveCheckNodeOutcome) DeepCopy() *ActiveCheckNodeOutcome {
+	if in == nil {
+		return nil
+	}
+	out := new(ActiveCheckNodeOutcome)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActiveCheckNodeResult) DeepCopyInto(out *ActiveCheckNodeResult) {
+	*out = *in
+	if in.LastPassTime != nil {
+		in, out := &in.LastPassTime, &out.LastPassTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastFailTime != nil {
+		in, out := &in.LastFailTime, &out.LastFailTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastResult != nil {
+		in, out := &in.LastResult, &out.LastResult
+		*out = new(ActiveCheckNodeOutcome)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastMetrics != nil {
+		in, out := &in.LastMetrics, &out.LastMetrics
+		*out = new(ActiveCheckNodeMetrics)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveCheckNodeResult.
+func (in *ActiveCheckNodeResult) DeepCopy() *ActiveCheckNodeResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ActiveCheckNodeResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActiveCheckRun) DeepCopyInto(out *ActiveCheckRun) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveCheckRun.
+func (in *ActiveCheckRun) DeepCopy() *ActiveCheckRun {
+	if in == nil {
+		return nil
+	}
+	out := new(ActiveCheckRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ActiveCheckRun) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActiveCheckRunList) DeepCopyInto(out *ActiveCheckRunList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ActiveCheckRun, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveCheckRunList.
+func (in *ActiveCheckRunList) DeepCopy() *ActiveCheckRunList {
+	if in == nil {
+		return nil
+	}
+	out := new(ActiveCheckRunList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ActiveCheckRunList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActiveCheckRunSpec) DeepCopyInto(out *ActiveCheckRunSpec) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveCheckRunSpec.
+func (in *ActiveCheckRunSpec) DeepCopy() *ActiveCheckRunSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ActiveCheckRunSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActiveCheckRunStatus) DeepCopyInto(out *ActiveCheckRunStatus) {
+	*out = *in
+	if in.SlurmJobIDs != nil {
+		in, out := &in.SlurmJobIDs, &out.SlurmJobIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailedNodes != nil {
+		in, out := &in.FailedNodes, &out.FailedNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailJobsAndReasons != nil {
+		in, out := &in.FailJobsAndReasons, &out.FailJobsAndReasons
+		*out = make([]JobAndReason, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveCheckRunStatus.
+func (in *ActiveCheckRunStatus) DeepCopy() *ActiveCheckRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ActiveCheckRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ActiveCheckSlurmJobsStatus) DeepCopyInto(out *ActiveCheckSlurmJobsStatus) {
 	*out = *in
@@ -173,6 +375,11 @@ func (in *ActiveCheckSpec) DeepCopyInto(out *ActiveCheckSpec) {
 	}
 	in.K8sJobSpec.DeepCopyInto(&out.K8sJobSpec)
 	in.SlurmJobSpec.DeepCopyInto(&out.SlurmJobSpec)
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = new(ActiveCheckTargets)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.HostUsers != nil {
 		in, out := &in.HostUsers, &out.HostUsers
 		*out = new(bool)
@@ -188,6 +395,11 @@ func (in *ActiveCheckSpec) DeepCopyInto(out *ActiveCheckSpec) {
 		*out = new(Reactions)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.FailureReactionsThreshold != nil {
+		in, out := &in.FailureReactionsThreshold, &out.FailureReactionsThreshold
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveCheckSpec.
@@ -206,6 +418,13 @@ func (in *ActiveCheckStatus) DeepCopyInto(out *ActiveCheckStatus) {
 	in.StatusMetadata.DeepCopyInto(&out.StatusMetadata)
 	in.K8sJobsStatus.DeepCopyInto(&out.K8sJobsStatus)
 	in.SlurmJobsStatus.DeepCopyInto(&out.SlurmJobsStatus)
+	if in.NodeResults != nil {
+		in, out := &in.NodeResults, &out.NodeResults
+		*out = make([]ActiveCheckNodeResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveCheckStatus.
@@ -218,6 +437,36 @@ func (in *ActiveCheckStatus) DeepCopy() *ActiveCheckStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActiveCheckTargets) DeepCopyInto(out *ActiveCheckTargets) {
+	*out = *in
+	if in.NodeSetRefs != nil {
+		in, out := &in.NodeSetRefs, &out.NodeSetRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Partitions != nil {
+		in, out := &in.Partitions, &out.Partitions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Features != nil {
+		in, out := &in.Features, &out.Features
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveCheckTargets.
+func (in *ActiveCheckTargets) DeepCopy() *ActiveCheckTargets {
+	if in == nil {
+		return nil
+	}
+	out := new(ActiveCheckTargets)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CommentSlurmNodeSpec) DeepCopyInto(out *CommentSlurmNodeSpec) {
 	*out = *in
@@ -536,6 +785,27 @@ func (in *DockerSpec) DeepCopy() *DockerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DrainAndWaitSpec) DeepCopyInto(out *DrainAndWaitSpec) {
+	*out = *in
+	out.ReservationDuration = in.ReservationDuration
+	if in.MaxDrainedPerPartition != nil {
+		in, out := &in.MaxDrainedPerPartition, &out.MaxDrainedPerPartition
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DrainAndWaitSpec.
+func (in *DrainAndWaitSpec) DeepCopy() *DrainAndWaitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DrainAndWaitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DrainSlurmNodeSpec) DeepCopyInto(out *DrainSlurmNodeSpec) {
 	*out = *in
@@ -752,6 +1022,26 @@ func (in *K8sJobSpec) DeepCopy() *K8sJobSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]Weekday, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeConfig) DeepCopyInto(out *NodeConfig) {
 	*out = *in
@@ -876,6 +1166,10 @@ func (in *NodeConfiguratorStatus) DeepCopy() *NodeConfiguratorStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodePowerStateInfo) DeepCopyInto(out *NodePowerStateInfo) {
 	*out = *in
+	if in.ActivationTime != nil {
+		in, out := &in.ActivationTime, &out.ActivationTime
+		*out = (*in).DeepCopy()
+	}
 	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
 }
 
@@ -948,6 +1242,54 @@ func (in *NodeSetList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetPowerPolicy) DeepCopyInto(out *NodeSetPowerPolicy) {
+	*out = *in
+	if in.MaxNodes != nil {
+		in, out := &in.MaxNodes, &out.MaxNodes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]NodeSetPowerSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.ScaleDownCooldown = in.ScaleDownCooldown
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetPowerPolicy.
+func (in *NodeSetPowerPolicy) DeepCopy() *NodeSetPowerPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetPowerPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeSetPowerSchedule) DeepCopyInto(out *NodeSetPowerSchedule) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]Weekday, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetPowerSchedule.
+func (in *NodeSetPowerSchedule) DeepCopy() *NodeSetPowerSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeSetPowerSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeSetPowerState) DeepCopyInto(out *NodeSetPowerState) {
 	*out = *in
@@ -1015,6 +1357,11 @@ func (in *NodeSetPowerStateSpec) DeepCopyInto(out *NodeSetPowerStateSpec) {
 		*out = make([]int32, len(*in))
 		copy(*out, *in)
 	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(NodeSetPowerPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetPowerStateSpec.
@@ -1037,6 +1384,20 @@ func (in *NodeSetPowerStateStatus) DeepCopyInto(out *NodeSetPowerStateStatus) {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
+	if in.WarmNodes != nil {
+		in, out := &in.WarmNodes, &out.WarmNodes
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.ActiveSchedules != nil {
+		in, out := &in.ActiveSchedules, &out.ActiveSchedules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastScaleUpTime != nil {
+		in, out := &in.LastScaleUpTime, &out.LastScaleUpTime
+		*out = (*in).DeepCopy()
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -1118,6 +1479,16 @@ func (in *NodeSetSpec) DeepCopyInto(out *NodeSetSpec) {
 		*out = make([]v1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.UpdateVerification != nil {
+		in, out := &in.UpdateVerification, &out.UpdateVerification
+		*out = new(UpdateVerificationSpec)
+		**out = **in
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetSpec.
@@ -1140,6 +1511,11 @@ func (in *NodeSetStatus) DeepCopyInto(out *NodeSetStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeSetStatus.
@@ -1193,24 +1569,44 @@ func (in *NodeVolumeMount) DeepCopy() *NodeVolumeMount {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PersistentVolumeClaimRetentionPolicy) DeepCopyInto(out *PersistentVolumeClaimRetentionPolicy) {
+func (in *NotifySpec) DeepCopyInto(out *NotifySpec) {
 	*out = *in
+	if in.URLSecretRef != nil {
+		in, out := &in.URLSecretRef, &out.URLSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistentVolumeClaimRetentionPolicy.
-func (in *PersistentVolumeClaimRetentionPolicy) DeepCopy() *PersistentVolumeClaimRetentionPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotifySpec.
+func (in *NotifySpec) DeepCopy() *NotifySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PersistentVolumeClaimRetentionPolicy)
+	out := new(NotifySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodConfig) DeepCopyInto(out *PodConfig) {
+func (in *PersistentVolumeClaimRetentionPolicy) DeepCopyInto(out *PersistentVolumeClaimRetentionPolicy) {
 	*out = *in
-	if in.HostUsers != nil {
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersistentVolumeClaimRetentionPolicy.
+func (in *PersistentVolumeClaimRetentionPolicy) DeepCopy() *PersistentVolumeClaimRetentionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PersistentVolumeClaimRetentionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodConfig) DeepCopyInto(out *PodConfig) {
+	*out = *in
+	if in.HostUsers != nil {
 		in, out := &in.HostUsers, &out.HostUsers
 		*out = new(bool)
 		**out = **in
@@ -1240,6 +1636,21 @@ func (in *Reactions) DeepCopyInto(out *Reactions) {
 		*out = new(CommentSlurmNodeSpec)
 		**out = **in
 	}
+	if in.RebootSlurmNode != nil {
+		in, out := &in.RebootSlurmNode, &out.RebootSlurmNode
+		*out = new(RebootSlurmNodeSpec)
+		**out = **in
+	}
+	if in.SetHardwareIssuesSuspected != nil {
+		in, out := &in.SetHardwareIssuesSuspected, &out.SetHardwareIssuesSuspected
+		*out = new(SetHardwareIssuesSuspectedSpec)
+		**out = **in
+	}
+	if in.Notify != nil {
+		in, out := &in.Notify, &out.Notify
+		*out = new(NotifySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Reactions.
@@ -1252,11 +1663,47 @@ func (in *Reactions) DeepCopy() *Reactions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RebootSlurmNodeSpec) DeepCopyInto(out *RebootSlurmNodeSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RebootSlurmNodeSpec.
+func (in *RebootSlurmNodeSpec) DeepCopy() *RebootSlurmNodeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RebootSlurmNodeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Rebooter) DeepCopyInto(out *Rebooter) {
 	*out = *in
 	in.ContainerConfig.DeepCopyInto(&out.ContainerConfig)
 	in.PodConfig.DeepCopyInto(&out.PodConfig)
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PreRebootHooks != nil {
+		in, out := &in.PreRebootHooks, &out.PreRebootHooks
+		*out = make([]RebooterHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PostRebootChecks != nil {
+		in, out := &in.PostRebootChecks, &out.PostRebootChecks
+		*out = make([]RebooterHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Rebooter.
@@ -1269,6 +1716,301 @@ func (in *Rebooter) DeepCopy() *Rebooter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RebooterHook) DeepCopyInto(out *RebooterHook) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.Timeout = in.Timeout
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RebooterHook.
+func (in *RebooterHook) DeepCopy() *RebooterHook {
+	if in == nil {
+		return nil
+	}
+	out := new(RebooterHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutSpec) DeepCopyInto(out *RolloutSpec) {
+	*out = *in
+	if in.DrainAndWait != nil {
+		in, out := &in.DrainAndWait, &out.DrainAndWait
+		*out = new(DrainAndWaitSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutSpec.
+func (in *RolloutSpec) DeepCopy() *RolloutSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStatus) DeepCopyInto(out *RolloutStatus) {
+	*out = *in
+	if in.FailedVerificationWorkers != nil {
+		in, out := &in.FailedVerificationWorkers, &out.FailedVerificationWorkers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStatus.
+func (in *RolloutStatus) DeepCopy() *RolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SetHardwareIssuesSuspectedSpec) DeepCopyInto(out *SetHardwareIssuesSuspectedSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SetHardwareIssuesSuspectedSpec.
+func (in *SetHardwareIssuesSuspectedSpec) DeepCopy() *SetHardwareIssuesSuspectedSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SetHardwareIssuesSuspectedSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmAccount) DeepCopyInto(out *SlurmAccount) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmAccount.
+func (in *SlurmAccount) DeepCopy() *SlurmAccount {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmAccount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SlurmAccount) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmAccountLimits) DeepCopyInto(out *SlurmAccountLimits) {
+	*out = *in
+	if in.GrpTRES != nil {
+		in, out := &in.GrpTRES, &out.GrpTRES
+		*out = make(map[string]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.GrpTRESMinutes != nil {
+		in, out := &in.GrpTRESMinutes, &out.GrpTRESMinutes
+		*out = make(map[string]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.GrpJobs != nil {
+		in, out := &in.GrpJobs, &out.GrpJobs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxJobs != nil {
+		in, out := &in.MaxJobs, &out.MaxJobs
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxTRESPerJob != nil {
+		in, out := &in.MaxTRESPerJob, &out.MaxTRESPerJob
+		*out = make(map[string]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MaxWallPerJobMinutes != nil {
+		in, out := &in.MaxWallPerJobMinutes, &out.MaxWallPerJobMinutes
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmAccountLimits.
+func (in *SlurmAccountLimits) DeepCopy() *SlurmAccountLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmAccountLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmAccountList) DeepCopyInto(out *SlurmAccountList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SlurmAccount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmAccountList.
+func (in *SlurmAccountList) DeepCopy() *SlurmAccountList {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmAccountList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SlurmAccountList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmAccountSpec) DeepCopyInto(out *SlurmAccountSpec) {
+	*out = *in
+	if in.Fairshare != nil {
+		in, out := &in.Fairshare, &out.Fairshare
+		*out = new(int32)
+		**out = **in
+	}
+	if in.QOS != nil {
+		in, out := &in.QOS, &out.QOS
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Limits.DeepCopyInto(&out.Limits)
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmAccountSpec.
+func (in *SlurmAccountSpec) DeepCopy() *SlurmAccountSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmAccountSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmAccountStatus) DeepCopyInto(out *SlurmAccountStatus) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastDriftCorrectionTime != nil {
+		in, out := &in.LastDriftCorrectionTime, &out.LastDriftCorrectionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmAccountStatus.
+func (in *SlurmAccountStatus) DeepCopy() *SlurmAccountStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmAccountStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmJobResultThreshold) DeepCopyInto(out *SlurmJobResultThreshold) {
+	*out = *in
+	if in.Min != nil {
+		in, out := &in.Min, &out.Min
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmJobResultThreshold.
+func (in *SlurmJobResultThreshold) DeepCopy() *SlurmJobResultThreshold {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmJobResultThreshold)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmJobResultsSpec) DeepCopyInto(out *SlurmJobResultsSpec) {
+	*out = *in
+	if in.Thresholds != nil {
+		in, out := &in.Thresholds, &out.Thresholds
+		*out = make([]SlurmJobResultThreshold, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmJobResultsSpec.
+func (in *SlurmJobResultsSpec) DeepCopy() *SlurmJobResultsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmJobResultsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SlurmJobSpec) DeepCopyInto(out *SlurmJobSpec) {
 	*out = *in
@@ -1289,6 +2031,11 @@ func (in *SlurmJobSpec) DeepCopyInto(out *SlurmJobSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.Results != nil {
+		in, out := &in.Results, &out.Results
+		*out = new(SlurmJobResultsSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmJobSpec.
@@ -1301,6 +2048,311 @@ func (in *SlurmJobSpec) DeepCopy() *SlurmJobSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmQOS) DeepCopyInto(out *SlurmQOS) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmQOS.
+func (in *SlurmQOS) DeepCopy() *SlurmQOS {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmQOS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SlurmQOS) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmQOSLimits) DeepCopyInto(out *SlurmQOSLimits) {
+	*out = *in
+	if in.GrpTRES != nil {
+		in, out := &in.GrpTRES, &out.GrpTRES
+		*out = make(map[string]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MaxTRESPerUser != nil {
+		in, out := &in.MaxTRESPerUser, &out.MaxTRESPerUser
+		*out = make(map[string]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.MaxJobsPerUser != nil {
+		in, out := &in.MaxJobsPerUser, &out.MaxJobsPerUser
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxWallPerJobMinutes != nil {
+		in, out := &in.MaxWallPerJobMinutes, &out.MaxWallPerJobMinutes
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmQOSLimits.
+func (in *SlurmQOSLimits) DeepCopy() *SlurmQOSLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmQOSLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmQOSList) DeepCopyInto(out *SlurmQOSList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SlurmQOS, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmQOSList.
+func (in *SlurmQOSList) DeepCopy() *SlurmQOSList {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmQOSList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SlurmQOSList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmQOSSpec) DeepCopyInto(out *SlurmQOSSpec) {
+	*out = *in
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int32)
+		**out = **in
+	}
+	if in.UsageFactor != nil {
+		in, out := &in.UsageFactor, &out.UsageFactor
+		*out = new(string)
+		**out = **in
+	}
+	in.Limits.DeepCopyInto(&out.Limits)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmQOSSpec.
+func (in *SlurmQOSSpec) DeepCopy() *SlurmQOSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmQOSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmQOSStatus) DeepCopyInto(out *SlurmQOSStatus) {
+	*out = *in
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LastDriftCorrectionTime != nil {
+		in, out := &in.LastDriftCorrectionTime, &out.LastDriftCorrectionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmQOSStatus.
+func (in *SlurmQOSStatus) DeepCopy() *SlurmQOSStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmQOSStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmReservation) DeepCopyInto(out *SlurmReservation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmReservation.
+func (in *SlurmReservation) DeepCopy() *SlurmReservation {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmReservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SlurmReservation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmReservationList) DeepCopyInto(out *SlurmReservationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]SlurmReservation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmReservationList.
+func (in *SlurmReservationList) DeepCopy() *SlurmReservationList {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmReservationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SlurmReservationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmReservationSpec) DeepCopyInto(out *SlurmReservationSpec) {
+	*out = *in
+	if in.NodeSetRefs != nil {
+		in, out := &in.NodeSetRefs, &out.NodeSetRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeCount != nil {
+		in, out := &in.NodeCount, &out.NodeCount
+		*out = new(int32)
+		**out = **in
+	}
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.EndTime.DeepCopyInto(&out.EndTime)
+	if in.Flags != nil {
+		in, out := &in.Flags, &out.Flags
+		*out = make([]SlurmReservationFlag, len(*in))
+		copy(*out, *in)
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Accounts != nil {
+		in, out := &in.Accounts, &out.Accounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmReservationSpec.
+func (in *SlurmReservationSpec) DeepCopy() *SlurmReservationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmReservationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SlurmReservationStatus) DeepCopyInto(out *SlurmReservationStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Flags != nil {
+		in, out := &in.Flags, &out.Flags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Accounts != nil {
+		in, out := &in.Accounts, &out.Accounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastDriftCorrectionTime != nil {
+		in, out := &in.LastDriftCorrectionTime, &out.LastDriftCorrectionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlurmReservationStatus.
+func (in *SlurmReservationStatus) DeepCopy() *SlurmReservationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SlurmReservationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StatusMetadata) DeepCopyInto(out *StatusMetadata) {
 	*out = *in
@@ -1323,6 +2375,21 @@ func (in *StatusMetadata) DeepCopy() *StatusMetadata {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpdateVerificationSpec) DeepCopyInto(out *UpdateVerificationSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpdateVerificationSpec.
+func (in *UpdateVerificationSpec) DeepCopy() *UpdateVerificationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UpdateVerificationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkerVolumesSpec) DeepCopyInto(out *WorkerVolumesSpec) {
 	*out = *in
@@ -57,6 +57,7 @@ type Flags struct {
 	maxCollectorInflight   string
 	jobSource              string
 	accountingJobsLookback string
+	accountingCollectors   string
 
 	// modes
 	kubeconfigPath string
@@ -120,6 +121,7 @@ func parseFlags() Flags {
 		{"max-collector-inflight", "SLURM_EXPORTER_MAX_COLLECTOR_INFLIGHT", "1", "Maximum in-flight runs per exporter sub-collector", &flags.maxCollectorInflight},
 		{"job-source", "SLURM_EXPORTER_JOB_SOURCE", "controller", "SLURM job source: controller (Slurm controller API) or accounting (Slurm accounting API)", &flags.jobSource},
 		{"accounting-jobs-lookback", "SLURM_EXPORTER_ACCOUNTING_JOBS_LOOKBACK", "1h", "when --job-source=accounting, the size of the time window queried from the accounting API ([now - lookback, now + 5m]).", &flags.accountingJobsLookback},
+		{"accounting-collectors", "SLURM_EXPORTER_ACCOUNTING_COLLECTORS", "false", "Enable collectors reading from the Slurm accounting API (slurmdbd), such as QOS limits and fairshare", &flags.accountingCollectors},
 		{"scontrol-path", "SLURM_EXPORTER_SCONTROL_PATH", "scontrol", "Path to scontrol command for standalone mode", &flags.scontrolPath},
 		{"key-rotation-interval", "SLURM_EXPORTER_KEY_ROTATION_INTERVAL", "30m", "Key rotation interval for standalone mode (e.g., 30m, 1h)", &flags.keyRotationInterval},
 	}
@@ -327,6 +329,11 @@ func main() {
 		cli.Fail(log, err, "Failed to parse job collection configuration")
 	}
 
+	accountingCollectors, err := strconv.ParseBool(strings.TrimSpace(flags.accountingCollectors))
+	if err != nil {
+		cli.Fail(log, err, "Failed to parse accounting collectors flag")
+	}
+
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 	var nodeTopologySource exporter.NodeTopologySource
@@ -355,6 +362,7 @@ func main() {
 			MaxCollectorInflight: maxCollectorInflight,
 			JobListParams:        jobListParams,
 			NodeTopologySource:   nodeTopologySource,
+			AccountingCollectors: accountingCollectors,
 		},
 	)
 
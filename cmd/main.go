@@ -19,6 +19,7 @@ package main
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"flag"
 	"os"
 	"reflect"
@@ -59,6 +60,7 @@ import (
 	"nebius.ai/slurm-operator/internal/controllersenabled"
 	metricsopts "nebius.ai/slurm-operator/internal/metrics"
 	"nebius.ai/slurm-operator/internal/slurmapi"
+	"nebius.ai/slurm-operator/internal/tokenservice"
 	webhookv1 "nebius.ai/slurm-operator/internal/webhook/v1"
 	webhookv1alpha1 "nebius.ai/slurm-operator/internal/webhook/v1alpha1"
 	//+kubebuilder:scaffold:imports
@@ -138,6 +140,13 @@ func main() {
 
 		cacheSyncTimeout time.Duration
 		maxConcurrency   int
+
+		tokenServiceAddr          string
+		tokenServicePolicyFile    string
+		tokenServiceCertDir       string
+		tokenServiceTokenLifetime time.Duration
+		tokenServiceAudience      string
+		tokenServiceInsecure      bool
 	)
 
 	var watchNsCacheByName map[string]cache.Config
@@ -167,6 +176,12 @@ func main() {
 	flag.DurationVar(&cacheSyncTimeout, "cache-sync-timeout", 2*time.Minute, "The maximum duration allowed for caching sync")
 	flag.IntVar(&maxConcurrency, "max-concurrent-reconciles", 1, "Configures number of concurrent reconciles. It should improve performance for clusters with many objects.")
 	flag.StringVar(&controllersFlag, "controllers", "", "A comma-separated list of controllers to enable or disable. Use '*' for all, and '-name' to disable. Overrides SLURM_OPERATOR_CONTROLLERS if set.")
+	flag.StringVar(&tokenServiceAddr, "token-service-bind-address", "", "The address the token service issuing Slurm JWTs to ServiceAccounts binds to. The token service is disabled if it's empty.")
+	flag.StringVar(&tokenServicePolicyFile, "token-service-policy-file", "", "The file with the policy mapping ServiceAccounts to the Slurm usernames they can get JWTs for.")
+	flag.StringVar(&tokenServiceCertDir, "token-service-cert-dir", "", "The directory with tls.crt and tls.key the token service is served with. It's required unless --token-service-insecure is set.")
+	flag.BoolVar(&tokenServiceInsecure, "token-service-insecure", false, "If set, the token service is served over plain HTTP when --token-service-cert-dir is empty, exposing the ServiceAccount tokens of the callers.")
+	flag.DurationVar(&tokenServiceTokenLifetime, "token-service-token-lifetime", tokenservice.DefaultTokenLifetime, "The lifetime of the JWTs issued by the token service.")
+	flag.StringVar(&tokenServiceAudience, "token-service-audience", tokenservice.DefaultAudience, "The audience the ServiceAccount tokens of the token service callers must be issued for.")
 	flag.Parse()
 	opts := getZapOpts(logFormat, logLevel)
 	zapLogger := zap.New(opts...)
@@ -287,6 +302,8 @@ func main() {
 	}
 	// endregion Reconciler/NodeConfigurator
 
+	slurmAPIClients := slurmapi.NewClientSet(context.Background())
+
 	// region Reconciler/NodeSet
 	if controllersSet.Enabled("nodeset") {
 		nodeSetName := reflect.TypeOf(slurmv1alpha1.NodeSet{}).Name()
@@ -296,6 +313,7 @@ func main() {
 			mgr.GetClient(),
 			mgr.GetScheme(),
 			mgr.GetEventRecorderFor(nodeSetNameLower+"-controller"),
+			slurmAPIClients,
 		).
 			SetupWithManager(mgr, nodeSetNameLower, maxConcurrency, cacheSyncTimeout); err != nil {
 			cli.Fail(setupLog, err, "unable to create controller", "controller", nodeSetName)
@@ -311,8 +329,6 @@ func main() {
 	}
 	// endregion Reconciler/NodeSet
 
-	slurmAPIClients := slurmapi.NewClientSet(context.Background())
-
 	if controllersSet.Enabled("rollingupdate") {
 		if err = soperatorchecks.NewSlurmAPIClientsController(
 			mgr.GetClient(),
@@ -362,6 +378,35 @@ func main() {
 	}
 	// endregion Reconciler/Topology
 
+	// region TokenService
+	if tokenServiceAddr != "" {
+		policy, err := tokenservice.LoadPolicy(tokenServicePolicyFile)
+		if err != nil {
+			cli.Fail(setupLog, err, "unable to load token service policy")
+		}
+
+		if tokenServiceCertDir == "" && !tokenServiceInsecure {
+			cli.Fail(setupLog, errors.New("--token-service-cert-dir is required unless --token-service-insecure is set"),
+				"unable to set up token service")
+		}
+
+		var audiences []string
+		if tokenServiceAudience != "" {
+			audiences = []string{tokenServiceAudience}
+		}
+		if err = mgr.Add(tokenservice.NewServer(mgr.GetClient(), policy, tokenservice.Options{
+			BindAddress:   tokenServiceAddr,
+			CertDir:       tokenServiceCertDir,
+			Insecure:      tokenServiceInsecure,
+			TLSOpts:       tlsOpts,
+			TokenLifetime: tokenServiceTokenLifetime,
+			Audiences:     audiences,
+		})); err != nil {
+			cli.Fail(setupLog, err, "unable to set up token service")
+		}
+	}
+	// endregion TokenService
+
 	//+kubebuilder:scaffold:builder
 
 	if err = mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -18,15 +18,18 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"net"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -78,19 +81,26 @@ func main() {
 	namespace := flag.String("namespace", "", "Kubernetes namespace (auto-detected from ServiceAccount if not specified)")
 	nodes := flag.String("nodes", "", "Node list from Slurm (e.g., 'worker-[0-5],gpu-[2-4]') (required)")
 	timeout := flag.Duration("timeout", 30*time.Second, "Timeout for operations")
+	socketPath := flag.String("socket", defaultSocketPath, "Unix socket of the power-manager server")
+	batchInterval := flag.Duration("batch-interval", defaultBatchInterval, "How long the server collects requests for a NodeSet before applying them (serve only)")
+	slurmAPIServer := flag.String("slurm-api-server", "", "Slurm REST API server URL used to mark failed nodes down (defaults to the REST service of the NodeSet's cluster)")
 
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "Usage: %s <command> [options]\n\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "Commands:\n")
+		fmt.Fprintf(os.Stderr, "  serve         Run the resident server which batches resume and suspend requests per NodeSet\n")
 		fmt.Fprintf(os.Stderr, "  resume        Resume (power on) nodes - called by Slurm's ResumeProgram\n")
 		fmt.Fprintf(os.Stderr, "  suspend       Suspend (power off) nodes - called by Slurm's SuspendProgram\n")
-		fmt.Fprintf(os.Stderr, "  wait-added    Wait for nodes to appear in activeNodes - verify resume completed\n")
+		fmt.Fprintf(os.Stderr, "  resume-failed Report nodes which failed to resume and power them down - called by Slurm's ResumeFailProgram\n")
+		fmt.Fprintf(os.Stderr, "  wait-added    Wait for worker pods of resumed nodes to run, reporting failed nodes back to Slurm\n")
 		fmt.Fprintf(os.Stderr, "  wait-removed  Wait for nodes to be removed from activeNodes - verify suspend completed\n")
 		fmt.Fprintf(os.Stderr, "\nOptions:\n")
 		flag.PrintDefaults()
 		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  %s serve --socket=/run/soperator/power-manager.sock\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s resume --nodes='worker-[0-5],gpu-[2-4]'\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s suspend --nodes='worker-3' --namespace=slurm\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s resume-failed --nodes='worker-[0-5]'\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s wait-added --nodes='worker-[0-5]' --timeout=60s\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s wait-removed --nodes='worker-[0-5]' --timeout=60s\n", os.Args[0])
 	}
@@ -102,7 +112,7 @@ func main() {
 
 	command := os.Args[1]
 	switch command {
-	case "resume", "suspend", "wait-added", "wait-removed":
+	case "serve", "resume", "resume-failed", "suspend", "wait-added", "wait-removed":
 		// ok
 	default:
 		log.Error(fmt.Errorf("unknown command: %s", command), "Invalid command")
@@ -115,7 +125,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	if *nodes == "" {
+	if *nodes == "" && command != "serve" {
 		log.Error(fmt.Errorf("--nodes is required"), "Missing required flag")
 		os.Exit(1)
 	}
@@ -133,18 +143,30 @@ func main() {
 
 	// Run the power action
 	switch command {
+	case "serve":
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+		defer stop()
+		if err := runServer(ctx, ns, *socketPath, *batchInterval); err != nil {
+			log.Error(err, "Server failed")
+			os.Exit(1)
+		}
 	case "resume":
-		if err := runPowerAction(context.Background(), ns, *nodes, *timeout, true); err != nil {
+		if err := requestPowerAction(context.Background(), ns, *nodes, *socketPath, *timeout, true); err != nil {
 			log.Error(err, "Power action failed")
 			os.Exit(1)
 		}
+	case "resume-failed":
+		if err := runResumeFailed(context.Background(), ns, *nodes, *slurmAPIServer, *timeout); err != nil {
+			log.Error(err, "Reporting failed nodes failed")
+			os.Exit(1)
+		}
 	case "suspend":
-		if err := runPowerAction(context.Background(), ns, *nodes, *timeout, false); err != nil {
+		if err := requestPowerAction(context.Background(), ns, *nodes, *socketPath, *timeout, false); err != nil {
 			log.Error(err, "Power action failed")
 			os.Exit(1)
 		}
 	case "wait-added":
-		if err := waitForNodes(context.Background(), ns, *nodes, *timeout, true); err != nil {
+		if err := waitForResume(context.Background(), ns, *nodes, *slurmAPIServer, *timeout); err != nil {
 			log.Error(err, "Wait for nodes failed")
 			os.Exit(1)
 		}
@@ -165,6 +187,24 @@ func getNamespaceFromServiceAccount() (string, error) {
 	return strings.TrimSpace(string(data)), nil
 }
 
+// requestPowerAction forwards the power action to the power-manager server.
+// If the server isn't running, the action is applied directly.
+func requestPowerAction(ctx context.Context, namespace, nodes, socketPath string, timeout time.Duration, resume bool) error {
+	forwardCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := forwardPowerAction(forwardCtx, socketPath, nodes, resume)
+	if !errors.Is(err, errServerUnavailable) {
+		if err == nil {
+			log.Info("Power action applied by server", "nodes", nodes, "resume", resume)
+		}
+		return err
+	}
+
+	log.Info("Power-manager server is unavailable, applying power action directly", "socket", socketPath, "reason", err.Error())
+	return runPowerAction(ctx, namespace, nodes, timeout, resume)
+}
+
 func runPowerAction(ctx context.Context, namespace, nodes string, timeout time.Duration, resume bool) error {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
@@ -181,6 +221,30 @@ func runPowerAction(ctx context.Context, namespace, nodes string, timeout time.D
 		return fmt.Errorf("failed to create kubernetes client: %w", err)
 	}
 
+	if err := applyPowerAction(ctx, client, namespace, nodes, resume, func(ctx context.Context, nodeSetName string, ordinals []int32) error {
+		return updateNodeSetPowerState(ctx, client, namespace, nodeSetName, ordinals, resume)
+	}); err != nil {
+		return err
+	}
+
+	log.Info("Power action completed successfully", "action", action)
+	return nil
+}
+
+// applyPowerAction resolves the nodes of the Slurm hostlist to ordinals of ephemeral NodeSets
+// and passes them to update, one call per NodeSet.
+func applyPowerAction(
+	ctx context.Context,
+	client ctrlclient.Reader,
+	namespace, nodes string,
+	resume bool,
+	update func(ctx context.Context, nodeSetName string, ordinals []int32) error,
+) error {
+	action := "suspend"
+	if resume {
+		action = "resume"
+	}
+
 	nodeRefs, err := parseNodeList(nodes)
 	if err != nil {
 		return fmt.Errorf("failed to parse node list: %w", err)
@@ -213,7 +277,7 @@ func runPowerAction(ctx context.Context, namespace, nodes string, timeout time.D
 			continue
 		}
 
-		if err := updateNodeSetPowerState(ctx, client, namespace, nodeSetName, ordinals, resume); err != nil {
+		if err := update(ctx, nodeSetName, ordinals); err != nil {
 			log.Error(err, "Failed to update NodeSetPowerState", "nodeSet", nodeSetName)
 			return err
 		}
@@ -221,7 +285,6 @@ func runPowerAction(ctx context.Context, namespace, nodes string, timeout time.D
 		log.Info("Updated NodeSetPowerState", "nodeSet", nodeSetName, "action", action, "ordinals", ordinals)
 	}
 
-	log.Info("Power action completed successfully", "action", action)
 	return nil
 }
 
@@ -537,6 +600,17 @@ func groupNodesByNodeSet(refs []NodeRef) map[string][]int32 {
 // updateNodeSetPowerState updates the NodeSetPowerState CR for the given NodeSet.
 // It uses retry.RetryOnConflict to safely handle concurrent resume/suspend calls.
 func updateNodeSetPowerState(ctx context.Context, client ctrlclient.Client, namespace, nodeSetName string, ordinals []int32, resume bool) error {
+	changes := make(map[int32]bool, len(ordinals))
+	for _, ord := range ordinals {
+		changes[ord] = resume
+	}
+	return applyNodeSetPowerChanges(ctx, client, namespace, nodeSetName, changes)
+}
+
+// applyNodeSetPowerChanges powers the ordinals of the given NodeSet on (true) or off (false) in a single update
+// of its NodeSetPowerState CR.
+// It uses retry.RetryOnConflict to safely handle concurrent resume/suspend calls.
+func applyNodeSetPowerChanges(ctx context.Context, client ctrlclient.Client, namespace, nodeSetName string, changes map[int32]bool) error {
 	powerStateKey := ctrlclient.ObjectKey{
 		Namespace: namespace,
 		Name:      nodeSetName,
@@ -566,12 +640,10 @@ func updateNodeSetPowerState(ctx context.Context, client ctrlclient.Client, name
 			currentActiveSet[ord] = true
 		}
 
-		if resume {
-			for _, ord := range ordinals {
+		for ord, active := range changes {
+			if active {
 				currentActiveSet[ord] = true
-			}
-		} else {
-			for _, ord := range ordinals {
+			} else {
 				delete(currentActiveSet, ord)
 			}
 		}
@@ -0,0 +1,387 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/naming"
+	"nebius.ai/slurm-operator/internal/slurmapi"
+	tokenstandalone "nebius.ai/slurm-operator/internal/token-standalone"
+)
+
+const (
+	// eventSourceComponent is the component the power manager reports Kubernetes events as.
+	eventSourceComponent = "power-manager"
+
+	eventReasonNodeResumeFailed  = "NodeResumeFailed"
+	eventReasonNodeResumePending = "NodeResumePending"
+
+	// slurmReasonMaxLength keeps node reasons readable in sinfo and scontrol output.
+	slurmReasonMaxLength = 256
+	slurmReasonPrefix    = "soperator: "
+
+	// slurmTokenLifetime is the lifetime of the token used for Slurm REST API calls.
+	// The power manager exits long before it expires.
+	slurmTokenLifetime = 10 * time.Minute
+)
+
+// terminalNodeReasons are the node state reasons after which the worker pod doesn't start without intervention.
+var terminalNodeReasons = map[string]bool{
+	slurmv1alpha1.NodePowerReasonMaxNodesReached: true,
+	"ImagePullBackOff":                           true,
+	"InvalidImageName":                           true,
+	"ErrImageNeverPull":                          true,
+	"CreateContainerConfigError":                 true,
+	"CrashLoopBackOff":                           true,
+}
+
+// resumeOutcome is the outcome of resuming a single node.
+type resumeOutcome string
+
+const (
+	resumeInProgress resumeOutcome = "InProgress"
+	resumeSucceeded  resumeOutcome = "Succeeded"
+	resumeFailed     resumeOutcome = "Failed"
+)
+
+// nodeResume tracks the resume of a single node.
+type nodeResume struct {
+	Ref NodeRef
+	// State is the last state of the node recorded in NodeSetPowerState, nil if not recorded yet.
+	State   *slurmv1alpha1.NodePowerStateInfo
+	Outcome resumeOutcome
+}
+
+// NodeName returns the name of the node in Slurm.
+func (n nodeResume) NodeName() string {
+	return fmt.Sprintf("%s-%d", n.Ref.NodeSetName, n.Ref.Ordinal)
+}
+
+// evaluateResume derives the resume outcome of the node with the given ordinal from the NodeSetPowerState status.
+// The node succeeds once its pod runs without problems, and fails once the pod fails or is stuck for a reason
+// which doesn't go away on its own.
+func evaluateResume(powerState *slurmv1alpha1.NodeSetPowerState, ordinal int32) (resumeOutcome, *slurmv1alpha1.NodePowerStateInfo) {
+	state, found := powerState.Status.NodeStates[strconv.Itoa(int(ordinal))]
+	if !found {
+		return resumeInProgress, nil
+	}
+
+	switch {
+	case state.Phase == slurmv1alpha1.NodePowerPhaseFailed, state.Phase == slurmv1alpha1.NodePowerPhaseSucceeded:
+		return resumeFailed, &state
+	case terminalNodeReasons[state.Reason]:
+		return resumeFailed, &state
+	case state.Phase == slurmv1alpha1.NodePowerPhaseRunning && state.Reason == "":
+		return resumeSucceeded, &state
+	default:
+		return resumeInProgress, &state
+	}
+}
+
+// describeNodeState returns a single-line description of the node's pod, e.g.
+// "pod worker-3 is Pending (Unschedulable): 0/4 nodes are available".
+func describeNodeState(state *slurmv1alpha1.NodePowerStateInfo) string {
+	res := fmt.Sprintf("pod %s is %s", state.PodName, state.Phase)
+	if state.Reason != "" {
+		res += fmt.Sprintf(" (%s)", state.Reason)
+	}
+	if state.Message != "" {
+		res += ": " + state.Message
+	}
+	return strings.Join(strings.Fields(res), " ")
+}
+
+// slurmReason builds the reason the node is marked down with in Slurm.
+func slurmReason(description string) string {
+	res := slurmReasonPrefix + description
+	if len(res) > slurmReasonMaxLength {
+		res = res[:slurmReasonMaxLength-3] + "..."
+	}
+	return res
+}
+
+// waitForResume waits for the worker pods of the resumed nodes to run.
+// Nodes whose pods fail are reported back to Slurm right away. Nodes still starting when the timeout expires are
+// left to Slurm's ResumeTimeout, with a Kubernetes event pointing to the pending pod.
+func waitForResume(ctx context.Context, namespace, nodes, slurmAPIServer string, timeout time.Duration) error {
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	log.Info("Waiting for nodes to resume", "nodes", nodes, "namespace", namespace, "timeout", timeout)
+
+	client, err := createClient()
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	nodeRefs, err := parseNodeList(nodes)
+	if err != nil {
+		return fmt.Errorf("failed to parse node list: %w", err)
+	}
+
+	resumes := make([]*nodeResume, 0, len(nodeRefs))
+	for _, ref := range nodeRefs {
+		resumes = append(resumes, &nodeResume{Ref: ref, Outcome: resumeInProgress})
+	}
+
+	slurmClientFor := func(ctx context.Context, nodeSetName string) (slurmapi.Client, error) {
+		return newSlurmClient(ctx, client, namespace, slurmAPIServer, nodeSetName)
+	}
+
+	var (
+		failedCount int
+		errs        []error
+	)
+
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-waitCtx.Done():
+			var pending []*nodeResume
+			for _, resume := range resumes {
+				if resume.Outcome == resumeInProgress {
+					pending = append(pending, resume)
+				}
+			}
+			reportCtx, reportCancel := context.WithTimeout(ctx, 30*time.Second)
+			reportResumePending(reportCtx, client, namespace, pending, timeout)
+			reportCancel()
+
+			log.Info("Nodes are still starting, leaving them to Slurm's ResumeTimeout", "count", len(pending))
+			return resumeError(failedCount, errs)
+		case <-ticker.C:
+			failed := refreshResumes(waitCtx, client, namespace, resumes)
+			if len(failed) > 0 {
+				failedCount += len(failed)
+				if err := reportResumeFailures(waitCtx, client, namespace, slurmClientFor, failed, true); err != nil {
+					errs = append(errs, err)
+				}
+			}
+
+			if !slices.ContainsFunc(resumes, func(resume *nodeResume) bool {
+				return resume.Outcome == resumeInProgress
+			}) {
+				log.Info("All nodes finished resuming", "failed", failedCount)
+				return resumeError(failedCount, errs)
+			}
+		}
+	}
+}
+
+// refreshResumes updates the in-progress resumes from their NodeSetPowerStates and returns the nodes which failed.
+func refreshResumes(ctx context.Context, client ctrlclient.Client, namespace string, resumes []*nodeResume) []*nodeResume {
+	powerStates := map[string]*slurmv1alpha1.NodeSetPowerState{}
+	var failed []*nodeResume
+
+	for _, resume := range resumes {
+		if resume.Outcome != resumeInProgress {
+			continue
+		}
+
+		powerState, found := powerStates[resume.Ref.NodeSetName]
+		if !found {
+			powerState = &slurmv1alpha1.NodeSetPowerState{}
+			if err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: resume.Ref.NodeSetName}, powerState); err != nil {
+				log.V(1).Info("Error getting NodeSetPowerState, will retry", "nodeSet", resume.Ref.NodeSetName, "error", err)
+				powerState = nil
+			}
+			powerStates[resume.Ref.NodeSetName] = powerState
+		}
+		if powerState == nil {
+			continue
+		}
+
+		resume.Outcome, resume.State = evaluateResume(powerState, resume.Ref.Ordinal)
+		if resume.Outcome == resumeFailed {
+			failed = append(failed, resume)
+		}
+	}
+
+	return failed
+}
+
+// runResumeFailed reports nodes which Slurm gave up on after ResumeTimeout. It's called by Slurm's ResumeFailProgram.
+// Nodes with a recorded state get a reason pointing to their pod, and all of them are powered down.
+func runResumeFailed(ctx context.Context, namespace, nodes, slurmAPIServer string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	log.Info("Reporting nodes which failed to resume", "nodes", nodes, "namespace", namespace)
+
+	client, err := createClient()
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	nodeRefs, err := parseNodeList(nodes)
+	if err != nil {
+		return fmt.Errorf("failed to parse node list: %w", err)
+	}
+
+	resumes := make([]*nodeResume, 0, len(nodeRefs))
+	for _, ref := range nodeRefs {
+		resumes = append(resumes, &nodeResume{Ref: ref, Outcome: resumeInProgress})
+	}
+	refreshResumes(ctx, client, namespace, resumes)
+	for _, resume := range resumes {
+		resume.Outcome = resumeFailed
+	}
+
+	slurmClientFor := func(ctx context.Context, nodeSetName string) (slurmapi.Client, error) {
+		return newSlurmClient(ctx, client, namespace, slurmAPIServer, nodeSetName)
+	}
+	return reportResumeFailures(ctx, client, namespace, slurmClientFor, resumes, false)
+}
+
+// reportResumeFailures marks the failed nodes down in Slurm, records Kubernetes events and powers the nodes down,
+// so that their pods don't keep waiting for resources.
+// Nodes without a recorded state keep the reason Slurm has set unless markUnknown is true.
+func reportResumeFailures(
+	ctx context.Context,
+	client ctrlclient.Client,
+	namespace string,
+	slurmClientFor func(ctx context.Context, nodeSetName string) (slurmapi.Client, error),
+	failed []*nodeResume,
+	markUnknown bool,
+) error {
+	var (
+		slurmClient    slurmapi.Client
+		slurmClientErr error
+		errs           []error
+	)
+
+	ordinalsByNodeSet := map[string][]int32{}
+	for _, resume := range failed {
+		ordinalsByNodeSet[resume.Ref.NodeSetName] = append(ordinalsByNodeSet[resume.Ref.NodeSetName], resume.Ref.Ordinal)
+
+		description := "no worker pod state is recorded"
+		if resume.State != nil {
+			description = describeNodeState(resume.State)
+		}
+		log.Info("Node failed to resume", "node", resume.NodeName(), "description", description)
+
+		if err := recordPowerStateEvent(ctx, client, namespace, resume.Ref.NodeSetName, corev1.EventTypeWarning, eventReasonNodeResumeFailed,
+			fmt.Sprintf("Node %s failed to resume: %s", resume.NodeName(), description),
+		); err != nil {
+			log.Error(err, "Failed to record event", "node", resume.NodeName())
+		}
+
+		if resume.State == nil && !markUnknown {
+			continue
+		}
+		if slurmClient == nil && slurmClientErr == nil {
+			slurmClient, slurmClientErr = slurmClientFor(ctx, resume.Ref.NodeSetName)
+			if slurmClientErr != nil {
+				log.Error(slurmClientErr, "Failed to create Slurm API client, nodes are not marked down")
+				errs = append(errs, slurmClientErr)
+			}
+		}
+		if slurmClient == nil {
+			continue
+		}
+		if err := slurmClient.DownNode(ctx, resume.NodeName(), slurmReason(description)); err != nil {
+			log.Error(err, "Failed to mark node down in Slurm", "node", resume.NodeName())
+			errs = append(errs, err)
+		}
+	}
+
+	for nodeSetName, ordinals := range ordinalsByNodeSet {
+		if err := updateNodeSetPowerState(ctx, client, namespace, nodeSetName, ordinals, false); err != nil {
+			errs = append(errs, fmt.Errorf("powering down failed nodes of NodeSet %s: %w", nodeSetName, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// reportResumePending records Kubernetes events for nodes which are still starting.
+func reportResumePending(ctx context.Context, client ctrlclient.Client, namespace string, pending []*nodeResume, waited time.Duration) {
+	for _, resume := range pending {
+		description := "no worker pod state is recorded yet"
+		if resume.State != nil {
+			description = describeNodeState(resume.State)
+		}
+		if err := recordPowerStateEvent(ctx, client, namespace, resume.Ref.NodeSetName, corev1.EventTypeWarning, eventReasonNodeResumePending,
+			fmt.Sprintf("Node %s is not running after %s: %s", resume.NodeName(), waited, description),
+		); err != nil {
+			log.Error(err, "Failed to record event", "node", resume.NodeName())
+		}
+	}
+}
+
+// resumeError returns the error the resume wait ends with.
+func resumeError(failedCount int, errs []error) error {
+	if failedCount == 0 && len(errs) == 0 {
+		return nil
+	}
+	return errors.Join(append([]error{fmt.Errorf("%d node(s) failed to resume", failedCount)}, errs...)...)
+}
+
+// recordPowerStateEvent records a Kubernetes event on the NodeSetPowerState of the given NodeSet.
+func recordPowerStateEvent(ctx context.Context, client ctrlclient.Client, namespace, nodeSetName, eventType, reason, message string) error {
+	powerState := &slurmv1alpha1.NodeSetPowerState{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: nodeSetName}, powerState); err != nil {
+		return fmt.Errorf("failed to get NodeSetPowerState: %w", err)
+	}
+
+	now := metav1.Now()
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: nodeSetName + ".",
+			Namespace:    namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion:      slurmv1alpha1.GroupVersion.String(),
+			Kind:            slurmv1alpha1.KindNodeSetPowerState,
+			Namespace:       namespace,
+			Name:            powerState.Name,
+			UID:             powerState.UID,
+			ResourceVersion: powerState.ResourceVersion,
+		},
+		Type:           eventType,
+		Reason:         reason,
+		Message:        message,
+		Source:         corev1.EventSource{Component: eventSourceComponent},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+	if err := client.Create(ctx, event); err != nil {
+		return fmt.Errorf("failed to create event: %w", err)
+	}
+	return nil
+}
+
+// newSlurmClient creates a Slurm REST API client authenticated with a token from scontrol.
+// Unless the server is given, the REST service of the cluster the NodeSet belongs to is used.
+func newSlurmClient(ctx context.Context, client ctrlclient.Client, namespace, server, nodeSetName string) (slurmapi.Client, error) {
+	nodeSet := &slurmv1alpha1.NodeSet{}
+	if err := client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: nodeSetName}, nodeSet); err != nil {
+		return nil, fmt.Errorf("failed to get NodeSet %s: %w", nodeSetName, err)
+	}
+	cluster := types.NamespacedName{Namespace: namespace, Name: nodeSet.Spec.ClusterName}
+
+	if server == "" {
+		if cluster.Name == "" {
+			return nil, fmt.Errorf("NodeSet %s doesn't reference a cluster, --slurm-api-server is required", nodeSetName)
+		}
+		server = fmt.Sprintf("http://%s.%s:6820", naming.BuildServiceName(consts.ComponentTypeREST, cluster.Name), namespace)
+	}
+
+	issuer := tokenstandalone.NewStandaloneTokenIssuer(cluster, consts.SlurmUser).WithRotationInterval(slurmTokenLifetime)
+	return slurmapi.NewClient(server, issuer, slurmapi.DefaultHTTPClient())
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/slurmapi"
+	slurmapifake "nebius.ai/slurm-operator/internal/slurmapi/fake"
+)
+
+func TestEvaluateResume(t *testing.T) {
+	tests := []struct {
+		name  string
+		state *slurmv1alpha1.NodePowerStateInfo
+		want  resumeOutcome
+	}{
+		{
+			name: "state not recorded yet",
+			want: resumeInProgress,
+		},
+		{
+			name:  "pod is running",
+			state: &slurmv1alpha1.NodePowerStateInfo{Phase: slurmv1alpha1.NodePowerPhaseRunning},
+			want:  resumeSucceeded,
+		},
+		{
+			name:  "pod is unschedulable",
+			state: &slurmv1alpha1.NodePowerStateInfo{Phase: slurmv1alpha1.NodePowerPhasePending, Reason: "Unschedulable"},
+			want:  resumeInProgress,
+		},
+		{
+			name:  "image can't be pulled",
+			state: &slurmv1alpha1.NodePowerStateInfo{Phase: slurmv1alpha1.NodePowerPhasePending, Reason: "ImagePullBackOff"},
+			want:  resumeFailed,
+		},
+		{
+			name:  "slurmd crashes",
+			state: &slurmv1alpha1.NodePowerStateInfo{Phase: slurmv1alpha1.NodePowerPhaseRunning, Reason: "CrashLoopBackOff"},
+			want:  resumeFailed,
+		},
+		{
+			name:  "pod failed",
+			state: &slurmv1alpha1.NodePowerStateInfo{Phase: slurmv1alpha1.NodePowerPhaseFailed, Reason: "Evicted"},
+			want:  resumeFailed,
+		},
+		{
+			name: "node dropped by maxNodes",
+			state: &slurmv1alpha1.NodePowerStateInfo{
+				Phase:  slurmv1alpha1.NodePowerPhasePending,
+				Reason: slurmv1alpha1.NodePowerReasonMaxNodesReached,
+			},
+			want: resumeFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			powerState := &slurmv1alpha1.NodeSetPowerState{}
+			if tt.state != nil {
+				powerState.Status.NodeStates = map[string]slurmv1alpha1.NodePowerStateInfo{"3": *tt.state}
+			}
+
+			got, state := evaluateResume(powerState, 3)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.state, state)
+		})
+	}
+}
+
+func TestSlurmReason(t *testing.T) {
+	description := describeNodeState(&slurmv1alpha1.NodePowerStateInfo{
+		Phase:   slurmv1alpha1.NodePowerPhasePending,
+		PodName: "worker-3",
+		Reason:  "Unschedulable",
+		Message: "0/4 nodes are available:\n4 Insufficient nvidia.com/gpu.",
+	})
+	assert.Equal(t, "pod worker-3 is Pending (Unschedulable): 0/4 nodes are available: 4 Insufficient nvidia.com/gpu.", description)
+	assert.Equal(t, "soperator: "+description, slurmReason(description))
+
+	long := slurmReason(strings.Repeat("x", 2*slurmReasonMaxLength))
+	assert.Len(t, long, slurmReasonMaxLength)
+	assert.True(t, strings.HasSuffix(long, "..."))
+}
+
+func TestReportResumeFailures(t *testing.T) {
+	powerState := &slurmv1alpha1.NodeSetPowerState{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "soperator"},
+		Spec:       slurmv1alpha1.NodeSetPowerStateSpec{NodeSetRef: "worker", ActiveNodes: []int32{0, 1, 2}},
+	}
+	client := clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(powerState).Build()
+
+	slurmClient := slurmapifake.NewMockClient(t)
+	slurmClient.EXPECT().DownNode(
+		mock.Anything, "worker-1", "soperator: pod worker-1 is Pending (ImagePullBackOff): Container slurmd: Back-off pulling image",
+	).Return(nil).Once()
+
+	failed := []*nodeResume{
+		{
+			Ref: NodeRef{NodeSetName: "worker", Ordinal: 1},
+			State: &slurmv1alpha1.NodePowerStateInfo{
+				Phase:   slurmv1alpha1.NodePowerPhasePending,
+				PodName: "worker-1",
+				Reason:  "ImagePullBackOff",
+				Message: "Container slurmd: Back-off pulling image",
+			},
+			Outcome: resumeFailed,
+		},
+		{
+			// Without a recorded state the reason set by Slurm is kept.
+			Ref:     NodeRef{NodeSetName: "worker", Ordinal: 2},
+			Outcome: resumeFailed,
+		},
+	}
+
+	slurmClientFor := func(context.Context, string) (slurmapi.Client, error) {
+		return slurmClient, nil
+	}
+	require.NoError(t, reportResumeFailures(context.Background(), client, "soperator", slurmClientFor, failed, false))
+
+	updated := &slurmv1alpha1.NodeSetPowerState{}
+	require.NoError(t, client.Get(context.Background(), types.NamespacedName{Namespace: "soperator", Name: "worker"}, updated))
+	assert.Equal(t, []int32{0}, updated.Spec.ActiveNodes)
+
+	events := &corev1.EventList{}
+	require.NoError(t, client.List(context.Background(), events, ctrlclient.InNamespace("soperator")))
+	require.Len(t, events.Items, 2)
+	for _, event := range events.Items {
+		assert.Equal(t, corev1.EventTypeWarning, event.Type)
+		assert.Equal(t, eventReasonNodeResumeFailed, event.Reason)
+		assert.Equal(t, slurmv1alpha1.KindNodeSetPowerState, event.InvolvedObject.Kind)
+		assert.Equal(t, "worker", event.InvolvedObject.Name)
+	}
+}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/rest"
+	ctrlcache "sigs.k8s.io/controller-runtime/pkg/cache"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+)
+
+const (
+	// defaultSocketPath is the unix socket the power-manager server listens on in the controller pod.
+	defaultSocketPath = "/run/soperator/power-manager.sock"
+
+	// defaultBatchInterval is how long the server collects requests for a NodeSet before updating its
+	// NodeSetPowerState.
+	defaultBatchInterval = 500 * time.Millisecond
+
+	powerActionPath = "/v1/power"
+
+	powerActionResume  = "resume"
+	powerActionSuspend = "suspend"
+)
+
+// errServerUnavailable is returned by the client when no power-manager server listens on the socket.
+var errServerUnavailable = errors.New("power-manager server is unavailable")
+
+// powerActionRequest is the request the CLI forwards to the power-manager server.
+type powerActionRequest struct {
+	Action string `json:"action"`
+	Nodes  string `json:"nodes"`
+}
+
+// powerActionResponse is the response of the power-manager server.
+type powerActionResponse struct {
+	Error string `json:"error,omitempty"`
+}
+
+// powerBatch collects the changes requested for a NodeSet until they are applied together.
+type powerBatch struct {
+	// changes maps ordinals to whether they are powered on. Later requests for an ordinal override earlier ones.
+	changes map[int32]bool
+	waiters []chan error
+}
+
+// powerServer is the resident power-manager. It batches and coalesces resume and suspend requests per NodeSet,
+// so that concurrent Slurm calls result in a single NodeSetPowerState update instead of conflicting ones.
+type powerServer struct {
+	// reader serves NodeSet lookups. It's backed by an informer cache in the controller pod.
+	reader ctrlclient.Reader
+	// client updates NodeSetPowerStates. Reads go to the API server to avoid conflicts on stale objects.
+	client ctrlclient.Client
+
+	namespace     string
+	batchInterval time.Duration
+
+	mu      sync.Mutex
+	batches map[string]*powerBatch
+}
+
+func newPowerServer(reader ctrlclient.Reader, client ctrlclient.Client, namespace string, batchInterval time.Duration) *powerServer {
+	return &powerServer{
+		reader:        reader,
+		client:        client,
+		namespace:     namespace,
+		batchInterval: batchInterval,
+		batches:       map[string]*powerBatch{},
+	}
+}
+
+// submit adds the change to the pending batch of the NodeSet and waits until the batch is applied.
+func (s *powerServer) submit(ctx context.Context, nodeSetName string, ordinals []int32, resume bool) error {
+	done := make(chan error, 1)
+
+	s.mu.Lock()
+	batch, found := s.batches[nodeSetName]
+	if !found {
+		batch = &powerBatch{changes: map[int32]bool{}}
+		s.batches[nodeSetName] = batch
+		time.AfterFunc(s.batchInterval, func() { s.flush(nodeSetName) })
+	}
+	for _, ordinal := range ordinals {
+		batch.changes[ordinal] = resume
+	}
+	batch.waiters = append(batch.waiters, done)
+	s.mu.Unlock()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// flush applies the pending batch of the NodeSet and notifies everyone waiting for it.
+func (s *powerServer) flush(nodeSetName string) {
+	s.mu.Lock()
+	batch := s.batches[nodeSetName]
+	delete(s.batches, nodeSetName)
+	s.mu.Unlock()
+
+	if batch == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	err := applyNodeSetPowerChanges(ctx, s.client, s.namespace, nodeSetName, batch.changes)
+	if err != nil {
+		log.Error(err, "Failed to apply batch", "nodeSet", nodeSetName, "requests", len(batch.waiters))
+	} else {
+		log.Info("Applied batch", "nodeSet", nodeSetName, "requests", len(batch.waiters), "ordinals", len(batch.changes))
+	}
+
+	for _, waiter := range batch.waiters {
+		waiter <- err
+	}
+}
+
+// ServeHTTP handles power actions forwarded by the CLI.
+func (s *powerServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != powerActionPath {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	request := powerActionRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writePowerActionResponse(w, http.StatusBadRequest, fmt.Errorf("failed to decode request: %w", err))
+		return
+	}
+
+	var resume bool
+	switch request.Action {
+	case powerActionResume:
+		resume = true
+	case powerActionSuspend:
+		resume = false
+	default:
+		writePowerActionResponse(w, http.StatusBadRequest, fmt.Errorf("unknown action: %q", request.Action))
+		return
+	}
+
+	log.Info("Received power action", "action", request.Action, "nodes", request.Nodes)
+
+	err := applyPowerAction(r.Context(), s.reader, s.namespace, request.Nodes, resume, func(ctx context.Context, nodeSetName string, ordinals []int32) error {
+		return s.submit(ctx, nodeSetName, ordinals, resume)
+	})
+	if err != nil {
+		writePowerActionResponse(w, http.StatusInternalServerError, err)
+		return
+	}
+	writePowerActionResponse(w, http.StatusOK, nil)
+}
+
+func writePowerActionResponse(w http.ResponseWriter, status int, err error) {
+	response := powerActionResponse{}
+	if err != nil {
+		response.Error = err.Error()
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// listenUnixSocket listens on the unix socket, replacing a socket left over by a previous server.
+func listenUnixSocket(socketPath string) (net.Listener, error) {
+	if err := os.MkdirAll(filepath.Dir(socketPath), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create socket directory: %w", err)
+	}
+	if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+	}
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", socketPath, err)
+	}
+	if err := os.Chmod(socketPath, 0o600); err != nil {
+		_ = listener.Close()
+		return nil, fmt.Errorf("failed to restrict socket permissions: %w", err)
+	}
+	return listener, nil
+}
+
+// runServer runs the resident power-manager until the context is cancelled.
+// NodeSets are read from an informer cache, so that requests don't hit the API server for lookups.
+func runServer(ctx context.Context, namespace, socketPath string, batchInterval time.Duration) error {
+	config, err := getKubeConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get kubernetes config: %w", err)
+	}
+
+	cache, err := newNodeSetCache(config, namespace)
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := cache.Start(ctx); err != nil {
+			log.Error(err, "NodeSet cache stopped")
+		}
+	}()
+	if !cache.WaitForCacheSync(ctx) {
+		return errors.New("failed to sync NodeSet cache")
+	}
+
+	client, err := ctrlclient.New(config, ctrlclient.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	listener, err := listenUnixSocket(socketPath)
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{
+		Handler:           newPowerServer(cache, client, namespace, batchInterval),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	log.Info("Serving power actions", "socket", socketPath, "namespace", namespace, "batchInterval", batchInterval)
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve: %w", err)
+	}
+	return nil
+}
+
+func newNodeSetCache(config *rest.Config, namespace string) (ctrlcache.Cache, error) {
+	cache, err := ctrlcache.New(config, ctrlcache.Options{
+		Scheme: scheme,
+		DefaultNamespaces: map[string]ctrlcache.Config{
+			namespace: {},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cache: %w", err)
+	}
+	// Start the informer before the first request, so that it's synced once the server listens.
+	if _, err := cache.GetInformer(context.Background(), &slurmv1alpha1.NodeSet{}); err != nil {
+		return nil, fmt.Errorf("failed to create NodeSet informer: %w", err)
+	}
+	return cache, nil
+}
+
+// forwardPowerAction sends the power action to the power-manager server and waits until it's applied.
+// Returns errServerUnavailable if the server doesn't listen on the socket.
+func forwardPowerAction(ctx context.Context, socketPath, nodes string, resume bool) error {
+	action := powerActionSuspend
+	if resume {
+		action = powerActionResume
+	}
+
+	body, err := json.Marshal(powerActionRequest{Action: action, Nodes: nodes})
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	httpClient := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", socketPath)
+		},
+	}}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://power-manager"+powerActionPath, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return fmt.Errorf("%w: %w", errServerUnavailable, err)
+		}
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer response.Body.Close()
+
+	result := powerActionResponse{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode response: status=%d: %w", response.StatusCode, err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("power-manager server failed: status=%d: %s", response.StatusCode, result.Error)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+)
+
+func newTestPowerServer(t *testing.T, activeNodes []int32) (*powerServer, ctrlclient.Client, *atomic.Int32) {
+	t.Helper()
+
+	updates := &atomic.Int32{}
+	client := clientfake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&slurmv1alpha1.NodeSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "soperator"},
+				Spec:       slurmv1alpha1.NodeSetSpec{EphemeralNodes: ptr.To(true)},
+			},
+			&slurmv1alpha1.NodeSetPowerState{
+				ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "soperator"},
+				Spec:       slurmv1alpha1.NodeSetPowerStateSpec{NodeSetRef: "worker", ActiveNodes: activeNodes},
+			},
+		).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Update: func(ctx context.Context, client ctrlclient.WithWatch, obj ctrlclient.Object, opts ...ctrlclient.UpdateOption) error {
+				updates.Add(1)
+				return client.Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	return newPowerServer(client, client, "soperator", 50*time.Millisecond), client, updates
+}
+
+func getTestActiveNodes(t *testing.T, client ctrlclient.Client) []int32 {
+	t.Helper()
+
+	powerState := &slurmv1alpha1.NodeSetPowerState{}
+	require.NoError(t, client.Get(context.Background(), types.NamespacedName{Namespace: "soperator", Name: "worker"}, powerState))
+	return powerState.Spec.ActiveNodes
+}
+
+func TestPowerServerBatchesRequests(t *testing.T) {
+	server, client, updates := newTestPowerServer(t, []int32{0})
+
+	requests := []struct {
+		ordinals []int32
+		resume   bool
+	}{
+		{ordinals: []int32{1, 2}, resume: true},
+		{ordinals: []int32{3}, resume: true},
+		{ordinals: []int32{0}, resume: false},
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(requests))
+	for i, request := range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = server.submit(context.Background(), "worker", request.ordinals, request.resume)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []int32{1, 2, 3}, getTestActiveNodes(t, client))
+	assert.Equal(t, int32(1), updates.Load(), "concurrent requests must result in a single update")
+}
+
+func TestPowerServerLaterRequestWins(t *testing.T) {
+	server, client, _ := newTestPowerServer(t, nil)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- server.submit(context.Background(), "worker", []int32{1, 2}, true)
+	}()
+	require.Eventually(t, func() bool {
+		server.mu.Lock()
+		defer server.mu.Unlock()
+		return server.batches["worker"] != nil
+	}, time.Second, time.Millisecond)
+
+	require.NoError(t, server.submit(context.Background(), "worker", []int32{2}, false))
+	require.NoError(t, <-done)
+	assert.Equal(t, []int32{1}, getTestActiveNodes(t, client))
+}
+
+func TestForwardPowerAction(t *testing.T) {
+	server, client, _ := newTestPowerServer(t, nil)
+
+	socketPath := filepath.Join(t.TempDir(), "power-manager.sock")
+	listener, err := listenUnixSocket(socketPath)
+	require.NoError(t, err)
+
+	httpServer := &http.Server{Handler: server, ReadHeaderTimeout: time.Second}
+	go func() { _ = httpServer.Serve(listener) }()
+	t.Cleanup(func() { _ = httpServer.Close() })
+
+	require.NoError(t, forwardPowerAction(context.Background(), socketPath, "worker-[1-2],other-0", true))
+	assert.Equal(t, []int32{1, 2}, getTestActiveNodes(t, client))
+
+	require.NoError(t, forwardPowerAction(context.Background(), socketPath, "worker-1", false))
+	assert.Equal(t, []int32{2}, getTestActiveNodes(t, client))
+
+	err = forwardPowerAction(context.Background(), socketPath, "worker-[", true)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, errServerUnavailable)
+}
+
+func TestForwardPowerActionServerUnavailable(t *testing.T) {
+	err := forwardPowerAction(context.Background(), filepath.Join(t.TempDir(), "missing.sock"), "worker-1", true)
+	assert.ErrorIs(t, err, errServerUnavailable)
+}
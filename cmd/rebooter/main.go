@@ -21,6 +21,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strconv"
 	"time"
 
 	"go.uber.org/zap/zapcore"
@@ -93,6 +94,19 @@ func getZapOpts(logFormat, logLevel string) []zap.Opts {
 	return zapOpts
 }
 
+// getInt32Env returns the integer value of the environment variable, or 0 if it's not set.
+func getInt32Env(name string) (int32, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0, nil
+	}
+	res, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("parsing %s: %w", name, err)
+	}
+	return int32(res), nil
+}
+
 // maxConcurrency is the maximum number of concurrent reconciles for a controller.
 // For reconsiling the node it has to be 1. Otherwise, it wold  be possible to get race conditions.
 const maxConcurrency = 1
@@ -207,6 +221,29 @@ func main() {
 	default:
 		rebooterParams.EvictionMethod = consts.RebooterEvict
 	}
+	rebooterParams.Namespace = os.Getenv(consts.RebooterNamespaceEnv)
+	if rebooterParams.MaxConcurrentReboots, err = getInt32Env(consts.RebooterMaxConcurrentRebootsEnv); err != nil {
+		cli.Fail(setupLog, err, "unable to parse max concurrent reboots")
+	}
+	if rebooterParams.MaxConcurrentRebootsPerNodeSet, err = getInt32Env(consts.RebooterMaxConcurrentRebootsPerNodeSetEnv); err != nil {
+		cli.Fail(setupLog, err, "unable to parse max concurrent reboots per NodeSet")
+	}
+	if rebooterParams.MaintenanceWindows, err = rebooter.ParseMaintenanceWindows(os.Getenv(consts.RebooterMaintenanceWindowsEnv)); err != nil {
+		cli.Fail(setupLog, err, "unable to parse maintenance windows")
+	}
+	if rebooterParams.PreRebootHooks, err = rebooter.ParseHooks(os.Getenv(consts.RebooterPreRebootHooksEnv)); err != nil {
+		cli.Fail(setupLog, err, "unable to parse pre-reboot hooks")
+	}
+	if rebooterParams.PostRebootChecks, err = rebooter.ParseHooks(os.Getenv(consts.RebooterPostRebootChecksEnv)); err != nil {
+		cli.Fail(setupLog, err, "unable to parse post-reboot checks")
+	}
+	coordinated := rebooterParams.MaxConcurrentReboots > 0 ||
+		rebooterParams.MaxConcurrentRebootsPerNodeSet > 0 ||
+		len(rebooterParams.MaintenanceWindows) > 0
+	if coordinated && rebooterParams.Namespace == "" {
+		cli.Fail(setupLog, fmt.Errorf("%s environment variable is not set", consts.RebooterNamespaceEnv), "unable to coordinate reboots")
+	}
+
 	nodePodsFetcher, err := rebooter.NewAPIServerNodePodsFetcher(mgr.GetConfig())
 	if err != nil {
 		cli.Fail(setupLog, err, "unable to create node pods fetcher")
@@ -0,0 +1,210 @@
+/*
+Copyright 2024 Nebius B.V.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+
+	kruisev1b1 "github.com/openkruise/kruise-api/apps/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	slurmv1 "nebius.ai/slurm-operator/api/v1"
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/configpreview"
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/naming"
+	"nebius.ai/slurm-operator/internal/utils/resourcegetter"
+	"nebius.ai/slurm-operator/internal/values"
+)
+
+const (
+	// exitCodeDiff is returned if the rendered Slurm configs differ from the deployed ones, as kubectl diff does
+	exitCodeDiff = 1
+	// exitCodeError is returned on failures, so they aren't confused with differences
+	exitCodeError = 2
+)
+
+var (
+	scheme = runtime.NewScheme()
+	log    = ctrl.Log.WithName("slurm-configs")
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(slurmv1.AddToScheme(scheme))
+	utilruntime.Must(slurmv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(kruisev1b1.AddToScheme(scheme))
+}
+
+func main() {
+	var (
+		files       []string
+		crdDir      string
+		diff        bool
+		namespace   string
+		legacyNames bool
+	)
+
+	flag.Func("f", "SlurmCluster and NodeSet YAML file (repeatable). Other objects in the files are skipped", func(path string) error {
+		files = append(files, path)
+		return nil
+	})
+	flag.StringVar(&crdDir, "crd-dir", "config/crd/bases", "Directory with the CRDs whose defaults are applied to the objects")
+	flag.BoolVar(&diff, "diff", false, "Print the unified diff against the Slurm configs deployed to the current kubeconfig context")
+	flag.StringVar(&namespace, "namespace", "", "Namespace of the SlurmCluster (defaults to its namespace in the files)")
+	flag.BoolVar(&legacyNames, "legacy-names", false, "Render the workload names of a cluster created before they got prefixed with the cluster name (ignored with --diff, where it's detected)")
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s -f <file> [-f <file>...] [options]\n\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "Renders the Slurm configs of a SlurmCluster and its NodeSets the same way the operator does.\n")
+		fmt.Fprintf(os.Stderr, "With --diff, the NodeSets deployed to the cluster are used unless overridden in the files, and the exit code\n")
+		fmt.Fprintf(os.Stderr, "is %d if there are differences and %d on errors.\n", exitCodeDiff, exitCodeError)
+		fmt.Fprintf(os.Stderr, "\nOptions:\n")
+		flag.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\nExamples:\n")
+		fmt.Fprintf(os.Stderr, "  %s -f cluster.yaml -f nodesets.yaml\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -f cluster.yaml --diff\n", os.Args[0])
+	}
+	flag.Parse()
+
+	ctrl.SetLogger(zap.New(zap.UseDevMode(false)))
+
+	if len(files) == 0 {
+		flag.Usage()
+		os.Exit(exitCodeError)
+	}
+
+	defaulter, err := configpreview.NewDefaulter(crdDir)
+	if err != nil {
+		fail(err, "Failed to read CRDs", "crdDir", crdDir)
+	}
+	objectsYAML, err := configpreview.ReadFiles(files)
+	if err != nil {
+		fail(err, "Failed to read files")
+	}
+	objects, err := configpreview.DecodeObjects(objectsYAML, defaulter)
+	if err != nil {
+		fail(err, "Failed to decode objects")
+	}
+	if namespace != "" {
+		objects.Cluster.Namespace = namespace
+	}
+
+	ctx := context.Background()
+
+	if !diff {
+		opts := configpreview.RenderOptions{NamePrefix: objects.Cluster.Name}
+		if legacyNames {
+			opts.NamePrefix = ""
+		}
+		configs, err := configpreview.Render(ctx, objects, opts)
+		if err != nil {
+			fail(err, "Failed to render Slurm configs")
+		}
+		fmt.Print(configpreview.FormatConfigs(configs))
+		return
+	}
+
+	config, err := ctrl.GetConfig()
+	if err != nil {
+		fail(err, "Failed to get kubeconfig")
+	}
+	c, err := ctrlclient.New(config, ctrlclient.Options{Scheme: scheme})
+	if err != nil {
+		fail(err, "Failed to create Kubernetes client")
+	}
+	deployed, rendered, err := renderForDiff(ctx, c, objects)
+	if err != nil {
+		fail(err, "Failed to render Slurm configs")
+	}
+	res, err := configpreview.Diff(deployed, rendered)
+	if err != nil {
+		fail(err, "Failed to diff Slurm configs")
+	}
+	if res != "" {
+		fmt.Print(res)
+		os.Exit(exitCodeDiff)
+	}
+}
+
+// renderForDiff returns the deployed Slurm configs and the ones rendered from the objects for the state of the
+// deployed cluster.
+func renderForDiff(
+	ctx context.Context,
+	c ctrlclient.Client,
+	objects configpreview.Objects,
+) (deployed, rendered map[string]string, err error) {
+	cluster := objects.Cluster
+	clusterRef := types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}
+
+	configMap := &corev1.ConfigMap{}
+	err = c.Get(ctx, types.NamespacedName{
+		Namespace: cluster.Namespace,
+		Name:      naming.BuildConfigMapSlurmConfigsName(cluster.Name),
+	}, configMap)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, nil, fmt.Errorf("getting deployed Slurm configs: %w", err)
+	}
+	deployed = maps.Clone(configMap.Data)
+	if deployed == nil {
+		deployed = map[string]string{}
+	}
+	jwks := deployed[consts.ConfigMapKeyJWKS]
+	delete(deployed, consts.ConfigMapKeyJWKS)
+
+	namePrefix, err := resourcegetter.ResolveWorkloadNamePrefix(ctx, c, cluster.Namespace, cluster.Name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("resolving workload name prefix: %w", err)
+	}
+	deployedNodeSets, err := resourcegetter.ListNodeSetsByClusterRef(ctx, c, clusterRef)
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing deployed NodeSets: %w", err)
+	}
+	deployedActiveChecks, err := resourcegetter.ListActiveChecksByClusterRef(ctx, c, clusterRef)
+	if err != nil {
+		return nil, nil, fmt.Errorf("listing deployed ActiveChecks: %w", err)
+	}
+
+	rendered, err = configpreview.Render(ctx, objects.WithDeployedNodeSets(deployedNodeSets), configpreview.RenderOptions{
+		NamePrefix:       namePrefix,
+		JWKS:             jwks,
+		StoreJobComments: values.BuildStoreJobCommentsFromActiveChecks(deployedActiveChecks),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return deployed, rendered, nil
+}
+
+func fail(err error, message string, keysAndValues ...any) {
+	log.Error(err, message, keysAndValues...)
+	os.Exit(exitCodeError)
+}
@@ -122,6 +122,8 @@ func main() {
 		requeueAfterActiveCheck                time.Duration
 		requeueAfterActiveCheckJob             time.Duration
 		requeueAfterPodEphemeralStorageCheck   time.Duration
+		requeueAfterSlurmReservation           time.Duration
+		requeueAfterSlurmAccounting            time.Duration
 		maxConcurrency                         int
 		maxConcurrencyPodEphemeralStorageCheck int
 		cacheSyncTimeout                       time.Duration
@@ -159,6 +161,8 @@ func main() {
 	flag.DurationVar(&requeueAfterActiveCheck, "requeue-after-activecheck", 10*time.Second, "The duration after which ActiveCheck will be requeued for reconciliation.")
 	flag.DurationVar(&requeueAfterActiveCheckJob, "requeue-after-activecheckjob", time.Minute, "The duration after which ActiveCheckJob will be requeued for reconciliation.")
 	flag.DurationVar(&requeueAfterPodEphemeralStorageCheck, "requeue-after-pod-ephemeral-storage-check", time.Minute, "The duration after which Pod Ephemeral Storage Check will be requeued for reconciliation.")
+	flag.DurationVar(&requeueAfterSlurmReservation, "requeue-after-slurmreservation", time.Minute, "The duration after which SlurmReservation will be requeued to correct the drift of the Slurm reservation.")
+	flag.DurationVar(&requeueAfterSlurmAccounting, "requeue-after-slurmaccounting", 5*time.Minute, "The duration after which SlurmAccount and SlurmQOS will be requeued to correct the drift of slurmdbd accounts and QOS.")
 	flag.IntVar(&maxConcurrency, "max-concurrent-reconciles", 1, "Configures number of concurrent reconciles. It should improve performance for clusters with many objects.")
 	flag.IntVar(&maxConcurrencyPodEphemeralStorageCheck, "pod-ephemeral-max-concurrent-reconciles", 50, "Configures number of concurrent reconciles for Pod Ephemeral Storage Check. It should improve performance for clusters with many pods.")
 	flag.DurationVar(&cacheSyncTimeout, "cache-sync-timeout", 2*time.Minute, "The maximum duration allowed for caching sync")
@@ -199,8 +203,12 @@ func main() {
 		"k8snodes",
 		"activecheck",
 		"activecheckjob",
+		"activecheckrun",
 		"serviceaccount",
 		"podephemeralstoragecheck",
+		"slurmreservation",
+		"slurmaccount",
+		"slurmqos",
 	}
 	controllersSet, err := controllersenabled.New(
 		controllersSpec,
@@ -344,6 +352,17 @@ func main() {
 			cli.Fail(setupLog, err, "unable to create activecheckjob controller", "controller", "ActiveCheckJob")
 		}
 	}
+	if controllersSet.Enabled("activecheckrun") {
+		if err = soperatorchecks.NewActiveCheckRunController(
+			mgr.GetClient(),
+			mgr.GetScheme(),
+			mgr.GetEventRecorderFor(soperatorchecks.SlurmActiveCheckRunControllerName),
+			slurmAPIClients,
+			requeueAfterActiveCheckJob,
+		).SetupWithManager(mgr, maxConcurrency, cacheSyncTimeout); err != nil {
+			cli.Fail(setupLog, err, "unable to create activecheckrun controller", "controller", "ActiveCheckRun")
+		}
+	}
 	if controllersSet.Enabled("serviceaccount") {
 		if err = soperatorchecks.NewServiceAccountController(
 			mgr.GetClient(),
@@ -380,6 +399,42 @@ func main() {
 		}
 	}
 
+	if controllersSet.Enabled("slurmreservation") {
+		if err = soperatorchecks.NewSlurmReservationController(
+			mgr.GetClient(),
+			mgr.GetScheme(),
+			mgr.GetEventRecorderFor(soperatorchecks.SlurmReservationControllerName),
+			slurmAPIClients,
+			requeueAfterSlurmReservation,
+		).SetupWithManager(mgr, maxConcurrency, cacheSyncTimeout); err != nil {
+			cli.Fail(setupLog, err, "unable to create slurmreservation controller", "controller", "SlurmReservation")
+		}
+	}
+
+	if controllersSet.Enabled("slurmqos") {
+		if err = soperatorchecks.NewSlurmQOSController(
+			mgr.GetClient(),
+			mgr.GetScheme(),
+			mgr.GetEventRecorderFor(soperatorchecks.SlurmQOSControllerName),
+			slurmAPIClients,
+			requeueAfterSlurmAccounting,
+		).SetupWithManager(mgr, maxConcurrency, cacheSyncTimeout); err != nil {
+			cli.Fail(setupLog, err, "unable to create slurmqos controller", "controller", "SlurmQOS")
+		}
+	}
+
+	if controllersSet.Enabled("slurmaccount") {
+		if err = soperatorchecks.NewSlurmAccountController(
+			mgr.GetClient(),
+			mgr.GetScheme(),
+			mgr.GetEventRecorderFor(soperatorchecks.SlurmAccountControllerName),
+			slurmAPIClients,
+			requeueAfterSlurmAccounting,
+		).SetupWithManager(mgr, maxConcurrency, cacheSyncTimeout); err != nil {
+			cli.Fail(setupLog, err, "unable to create slurmaccount controller", "controller", "SlurmAccount")
+		}
+	}
+
 	//+kubebuilder:scaffold:builder
 
 	if err = mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -22,7 +22,8 @@ if [ $exit_code -ne 0 ]; then
     exit $exit_code
 fi
 
-# Wait for nodes to appear in activeNodes (verify the update was applied)
+# Wait for the worker pods to run. Nodes whose pods fail are marked DOWN in Slurm with a reason
+# pointing to the pod, and nodes still starting after the timeout are left to ResumeTimeout
 /opt/soperator/bin/power-manager wait-added -nodes "$1" -timeout 180s
 exit_code=$?
 
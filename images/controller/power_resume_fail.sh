@@ -1,8 +1,9 @@
 #!/bin/bash
 # Slurm ResumeFailProgram for ephemeral nodes
 # This script is called by slurmctld when nodes fail to resume within ResumeTimeout
-# It calls power-manager which removes the ordinals from NodeSetPowerState CRs,
-# so the worker pods that did not become ready in time are torn down
+# It calls power-manager which marks the nodes DOWN with a reason pointing to their worker pods
+# and removes the ordinals from NodeSetPowerState CRs, so the pods that did not become ready
+# in time are torn down
 
 log_json() {
     local level="$1"
@@ -13,13 +14,13 @@ log_json() {
 
 log_json "info" "ResumeFailProgram invoked" ",\"script\":\"$0\",\"nodes\":\"$1\""
 
-# Call power-manager to power the nodes back down
+# Call power-manager to report the nodes and power them back down
 # $1 contains the node list in Slurm format (e.g., "worker-[0-5,7]")
-/opt/soperator/bin/power-manager suspend -nodes "$1"
+/opt/soperator/bin/power-manager resume-failed -nodes "$1"
 exit_code=$?
 
 if [ $exit_code -ne 0 ]; then
-    log_json "error" "ResumeFailProgram suspend failed" ",\"exit_code\":${exit_code}"
+    log_json "error" "ResumeFailProgram resume-failed failed" ",\"exit_code\":${exit_code}"
     exit $exit_code
 fi
 
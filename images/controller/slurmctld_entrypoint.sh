@@ -29,5 +29,8 @@ while [ ! -S "/run/munge/munge.socket.2" ]; do sleep 2; done
 # Hack with logs: multilog will write log in stdout and in log file, and rotate log file
 # # s100000000 (bytes) - 100MB, n5 - 5 files
 
+echo "Start power-manager server in background"
+/opt/soperator/bin/power-manager serve &
+
 echo "Start slurmctld daemon"
 exec /usr/sbin/slurmctld -D 2>&1 | tee >(multilog s100000000 n5 /var/log/slurm/multilog)
@@ -2,11 +2,67 @@
 
 set -e # Exit immediately if any command returns a non-zero error code
 
-echo "Bind-mount munge key from K8S secret"
-mount --bind /mnt/munge-key/munge.key /etc/munge/munge.key
+MUNGE_KEY_DIR=/mnt/munge-key
+MUNGE_KEY_CHECK_INTERVAL="${MUNGE_KEY_CHECK_INTERVAL:-10}"
+
+# During a key rotation, the operator stages the next key along with its activation time (Unix timestamp), so that
+# the munge daemons of all pods switch to it at the same moment.
+# The moment is taken from the node clock, and munged accepts a single key, so the daemons of nodes whose clocks are
+# skewed run different keys for as long as the skew, and the credentials encoded by one of them fail on the other.
+desired_key_file() {
+    local activate_at_file="$MUNGE_KEY_DIR/munge.key.activate-at"
+    if [ -f "$MUNGE_KEY_DIR/munge.key.next" ] && [ -f "$activate_at_file" ] \
+        && [ "$(date +%s)" -ge "$(cat "$activate_at_file")" ]; then
+        echo "$MUNGE_KEY_DIR/munge.key.next"
+    else
+        echo "$MUNGE_KEY_DIR/munge.key"
+    fi
+}
+
+key_checksum() {
+    sha256sum "$1" | cut -d' ' -f1
+}
+
+start_munged() {
+    local key_file
+    key_file="$(desired_key_file)"
+
+    echo "Bind-mount munge key $key_file from K8S secret"
+    umount "$MUNGE_KEY_FILE" 2>/dev/null || true
+    mount --bind "$key_file" "$MUNGE_KEY_FILE"
+    running_key_checksum="$(key_checksum "$MUNGE_KEY_FILE")"
+
+    echo "Start munge daemon"
+    munged -F --num-threads="$MUNGE_NUM_THREADS" --key-file="$MUNGE_KEY_FILE" --pid-file="$MUNGE_PID_FILE" -S "$MUNGE_SOCKET_FILE" &
+    munged_pid=$!
+}
+
+stop_munged() {
+    kill -TERM "$munged_pid" 2>/dev/null || true
+    wait "$munged_pid" || true
+}
+
+trap 'stop_munged; exit 0' TERM INT
 
 echo "Set permissions for shared /run/munge"
 chmod 755 /run/munge # It changes permissions of this shared directory in other containers as well
 
-echo "Start munge daemon"
-exec munged -F --num-threads="$MUNGE_NUM_THREADS" --key-file="$MUNGE_KEY_FILE" --pid-file="$MUNGE_PID_FILE" -S "$MUNGE_SOCKET_FILE"
+start_munged
+
+echo "Watch munge key for rotations"
+while true; do
+    sleep "$MUNGE_KEY_CHECK_INTERVAL" &
+    wait $!
+
+    if ! kill -0 "$munged_pid" 2>/dev/null; then
+        echo "Munge daemon exited"
+        wait "$munged_pid"
+        exit 1
+    fi
+
+    if [ "$(key_checksum "$(desired_key_file)")" != "$running_key_checksum" ]; then
+        echo "Munge key is rotated, restart munge daemon"
+        stop_munged
+        start_munged
+    fi
+done
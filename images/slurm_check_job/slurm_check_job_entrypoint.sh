@@ -65,6 +65,8 @@ if [[ "${ACTIVE_CHECK_REQUIRES_GPU:-}" == "true" ]]; then
     fi
 fi
 
+# ACTIVE_CHECK_NODES is set by the targets of the check, or by on-demand ActiveCheckRuns limited to a subset of nodes.
+# It's the pool of candidate nodes for the Slurm jobs, here as well as in slurm_submit_jobs.sh.
 if [[ "${EACH_WORKER_JOBS:-}" == "true" ]]; then
     echo "Submitting job using slurm_submit_jobs.sh..."
     SUBMIT_OUTPUT=$(/opt/bin/slurm/slurm_submit_jobs.sh)
@@ -79,6 +81,23 @@ if [[ "${EACH_WORKER_JOBS:-}" == "true" ]]; then
 else
     echo "Submitting regular Slurm job..."
     OUT_PATTERN='/opt/soperator-outputs/local/slurm_jobs/%N.%x.%j.out'
+    SBATCH_NODE_ARGS=()
+    if [[ -n "${ACTIVE_CHECK_NODES:-}" ]]; then
+        echo "Restricting to candidate nodes: $ACTIVE_CHECK_NODES"
+        # --nodelist would make the job span all the candidate nodes, so the other nodes of the partition are excluded
+        # instead, letting Slurm pick as many candidates as the job requests.
+        readarray -t EXCLUDED_NODES < <(comm -23 \
+            <(sinfo -h -N --partition="$PARTITION" -o '%N' | sort -u) \
+            <(scontrol show hostnames "$ACTIVE_CHECK_NODES" | sort -u))
+        if [[ ${#EXCLUDED_NODES[@]} -ne 0 ]]; then
+            SBATCH_NODE_ARGS=(--exclude="$(scontrol show hostlistsorted "$(IFS=,; echo "${EXCLUDED_NODES[*]}")")")
+        fi
+    fi
+    # ACTIVE_CHECK_RESERVATION is set by on-demand ActiveCheckRuns checking reserved nodes.
+    if [[ -n "${ACTIVE_CHECK_RESERVATION:-}" ]]; then
+        echo "Submitting to reservation $ACTIVE_CHECK_RESERVATION"
+        SBATCH_NODE_ARGS+=(--reservation="$ACTIVE_CHECK_RESERVATION")
+    fi
     # Here we use env variables instead of --output and --error because they do not support %N (node name) parameter.
     SLURM_OUTPUT=$(
       SBATCH_OUTPUT="$OUT_PATTERN" \
@@ -88,6 +107,7 @@ else
         --chdir=/opt/soperator-home/soperatorchecks \
         --uid=soperatorchecks \
         --partition="$PARTITION" \
+        "${SBATCH_NODE_ARGS[@]}" \
         /opt/bin/sbatch.sh
     )
     if [[ -z "$SLURM_OUTPUT" ]]; then
@@ -4,8 +4,14 @@ set -euxo pipefail # Exit immediately if any command returns a non-zero error co
 
 PARTITION="hidden"
 
-echo "Cancelling currently active jobs with the same name..."
-scancel --partition="$PARTITION" --name="$ACTIVE_CHECK_NAME"
+# Only the jobs on the candidate nodes (ACTIVE_CHECK_NODES) are cancelled, so that scheduled runs on other nodes go on.
+if [[ -n "${ACTIVE_CHECK_NODES:-}" ]]; then
+    echo "Cancelling currently active jobs with the same name on nodes $ACTIVE_CHECK_NODES..."
+    scancel --partition="$PARTITION" --name="$ACTIVE_CHECK_NAME" --nodelist="$ACTIVE_CHECK_NODES"
+else
+    echo "Cancelling currently active jobs with the same name..."
+    scancel --partition="$PARTITION" --name="$ACTIVE_CHECK_NAME"
+fi
 
 echo "Finding nodes to exclude..."
 # When the check requires GPU (auto-detected from the sbatch #SBATCH directives
@@ -15,12 +21,15 @@ echo "Finding nodes to exclude..."
 # avoids the wasted sbatch round-trips, the per-node error noise, and the risk of
 # that noise masking a real GPU-node failure. The ".gres.total" path is defined
 # by the SINFO_DATA parser in Slurm's data_parser plugin.
+# Reserved nodes are only candidates when the jobs are submitted to a reservation (ACTIVE_CHECK_RESERVATION), e.g. to
+# verify workers reserved during a rolling update. sbatch rejects the nodes which are not in the reservation.
 JQ_SELECT_NODES='
 .sinfo[]
 | select(
     (
       .node.state
-      | map(test("^(DOWN|ERROR|DRAIN|RESERVED|INVALID_REG|NOT_RESPONDING|POWER_DOWN|POWERING_DOWN|POWERED_DOWN|REBOOT_ISSUED|REBOOT_REQUESTED)$"))
+      | map(test("^(DOWN|ERROR|DRAIN|RESERVED|INVALID_REG|NOT_RESPONDING|POWER_DOWN|POWERING_DOWN|POWERED_DOWN|REBOOT_ISSUED|REBOOT_REQUESTED)$")
+            and (. != "RESERVED" or $reservation == ""))
       | any
     )
     | not
@@ -38,8 +47,31 @@ fi
 
 readarray -t NODES < <(
     sinfo -N --partition="$PARTITION" --responding --json \
-    | jq -r --arg requires_gpu "${ACTIVE_CHECK_REQUIRES_GPU:-}" "$JQ_SELECT_NODES"
+    | jq -r --arg requires_gpu "${ACTIVE_CHECK_REQUIRES_GPU:-}" --arg reservation "${ACTIVE_CHECK_RESERVATION:-}" \
+        "$JQ_SELECT_NODES"
 )
+
+SBATCH_RESERVATION_ARGS=()
+if [[ -n "${ACTIVE_CHECK_RESERVATION:-}" ]]; then
+    echo "Submitting to reservation $ACTIVE_CHECK_RESERVATION"
+    SBATCH_RESERVATION_ARGS=(--reservation="$ACTIVE_CHECK_RESERVATION")
+fi
+
+if [[ -n "${ACTIVE_CHECK_NODES:-}" ]]; then
+    echo "Restricting to requested nodes: $ACTIVE_CHECK_NODES"
+    # Targets may select the same node more than once, e.g. by a NodeSet and by a feature.
+    readarray -t REQUESTED_NODES < <(scontrol show hostnames "$ACTIVE_CHECK_NODES" | sort -u)
+    AVAILABLE_NODES=("${NODES[@]}")
+    NODES=()
+    for node in "${REQUESTED_NODES[@]}"; do
+        if printf "%s\n" "${AVAILABLE_NODES[@]}" | grep -qx "$node"; then
+            NODES+=("$node")
+        else
+            echo "Requested node $node is not available (drained, down or without required GPUs), skipping..."
+        fi
+    done
+fi
+
 NUM_NODES=${#NODES[@]}
 # "scontrol show hostlistsorted" compacts the list (e.g. "worker-[0-7]") without contacting slurmctld
 echo "Candidate nodes ($NUM_NODES): $(scontrol show hostlistsorted "$(IFS=,; echo "${NODES[*]}")")"
@@ -83,6 +115,7 @@ for node in "${SELECTED_NODES[@]}"; do
             --no-requeue \
             --nodelist="$node" \
             --nodes=1 \
+            "${SBATCH_RESERVATION_ARGS[@]}" \
             --chdir=/opt/soperator-home/soperatorchecks \
             --uid=soperatorchecks \
             /opt/bin/sbatch.sh
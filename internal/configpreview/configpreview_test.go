@@ -0,0 +1,207 @@
+package configpreview
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/consts"
+)
+
+const testObjects = `
+apiVersion: slurm.nebius.ai/v1
+kind: SlurmCluster
+metadata:
+  name: slurm
+  namespace: soperator
+spec:
+  k8sNodeFilters:
+    - name: cpu
+  populateJail:
+    image: populate-jail
+    k8sNodeFilterName: cpu
+  secrets: {}
+  volumeSources:
+    - name: jail
+      persistentVolumeClaim:
+        claimName: jail
+    - name: controller-spool
+      persistentVolumeClaim:
+        claimName: controller-spool
+  slurmNodes:
+    accounting:
+      k8sNodeFilterName: cpu
+    controller:
+      k8sNodeFilterName: cpu
+      munge:
+        image: munge
+      slurmctld:
+        image: controller_slurmctld
+      volumes:
+        jail:
+          volumeSourceName: jail
+        spool:
+          volumeSourceName: controller-spool
+    exporter:
+      k8sNodeFilterName: cpu
+    login:
+      k8sNodeFilterName: cpu
+      munge:
+        image: munge
+      sshd:
+        image: login_sshd
+      sshRootPublicKeys: []
+      sshdServiceType: LoadBalancer
+      volumes:
+        jail:
+          volumeSourceName: jail
+    rest:
+      k8sNodeFilterName: cpu
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: skipped
+---
+apiVersion: slurm.nebius.ai/v1alpha1
+kind: NodeSet
+metadata:
+  name: gpu
+  namespace: soperator
+spec:
+  clusterName: slurm
+  replicas: 2
+  gpu:
+    enabled: true
+    nvidia:
+      gdrCopyEnabled: false
+  slurmd:
+    image:
+      repository: worker_slurmd
+      tag: test
+  munge:
+    image:
+      repository: munge
+      tag: test
+---
+apiVersion: slurm.nebius.ai/v1alpha1
+kind: NodeSet
+metadata:
+  name: other-cluster
+  namespace: soperator
+spec:
+  clusterName: other
+`
+
+func newTestDefaulter(t *testing.T) *Defaulter {
+	t.Helper()
+	defaulter, err := NewDefaulter(filepath.Join("..", "..", "config", "crd", "bases"))
+	require.NoError(t, err)
+	return defaulter
+}
+
+func TestDecodeObjects(t *testing.T) {
+	defaulter := newTestDefaulter(t)
+
+	objects, err := DecodeObjects(strings.NewReader(testObjects), defaulter)
+	require.NoError(t, err)
+	assert.Equal(t, "slurm", objects.Cluster.Name)
+	assert.NotEmpty(t, objects.Cluster.Spec.SlurmConfig.MaxJobCount, "CRD defaults are applied")
+	require.Len(t, objects.NodeSets, 1, "NodeSets of other clusters are skipped")
+	assert.Equal(t, "gpu", objects.NodeSets[0].Name)
+
+	for name, content := range map[string]string{
+		"no SlurmCluster":       `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "skipped"}}`,
+		"several SlurmClusters": testObjects + "---\n" + testObjects,
+		"unknown field":         `{"apiVersion": "slurm.nebius.ai/v1", "kind": "SlurmCluster", "spec": {"unknown": 1}}`,
+		"malformed YAML":        "kind: [",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := DecodeObjects(strings.NewReader(content), defaulter)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	objects, err := DecodeObjects(strings.NewReader(testObjects), newTestDefaulter(t))
+	require.NoError(t, err)
+
+	configs, err := Render(context.Background(), objects, RenderOptions{NamePrefix: "slurm"})
+	require.NoError(t, err)
+	assert.Contains(t, configs, "slurm.conf")
+	assert.Contains(t, configs, "gres.conf")
+	assert.Contains(t, configs["slurm_base.conf.noedit"], "GresTypes=gpu", "NodeSets are taken into account")
+	assert.NotContains(t, configs, "jwks.json")
+
+	formatted := FormatConfigs(configs)
+	assert.Contains(t, formatted, "# ==> slurm.conf <==\n")
+}
+
+func TestRender_JWKS(t *testing.T) {
+	objects, err := DecodeObjects(strings.NewReader(testObjects), newTestDefaulter(t))
+	require.NoError(t, err)
+	objects.Cluster.Spec.SlurmNodes.Accounting.Enabled = true
+	objects.Cluster.Spec.SlurmNodes.Rest.Enabled = true
+
+	configs, err := Render(context.Background(), objects, RenderOptions{NamePrefix: "slurm"})
+	require.NoError(t, err)
+	assert.NotContains(t, configs["slurm_base.conf.noedit"], "jwks=", "JWTs are signed by the REST JWT key by default")
+
+	objects.Cluster.Annotations = map[string]string{consts.AnnotationRotateJWTSigningKey: "1"}
+	configs, err = Render(context.Background(), objects, RenderOptions{NamePrefix: "slurm"})
+	require.NoError(t, err)
+	assert.Contains(t, configs["slurm_base.conf.noedit"], "jwks=", "JWKS is published once the JWT signing key rotation is requested")
+}
+
+func TestObjects_WithDeployedNodeSets(t *testing.T) {
+	objects, err := DecodeObjects(strings.NewReader(testObjects), newTestDefaulter(t))
+	require.NoError(t, err)
+
+	deployedGPU := objects.NodeSets[0]
+	deployedGPU.Spec.Replicas = 8
+	deployedCPU := objects.NodeSets[0]
+	deployedCPU.Name = "cpu"
+
+	merged := objects.WithDeployedNodeSets([]slurmv1alpha1.NodeSet{deployedGPU, deployedCPU})
+	require.Len(t, merged.NodeSets, 2)
+	assert.Equal(t, objects.NodeSets[0], merged.NodeSets[0], "NodeSet from the files wins")
+	assert.Equal(t, "cpu", merged.NodeSets[1].Name)
+}
+
+func TestDiff(t *testing.T) {
+	deployed := map[string]string{
+		"slurm.conf":  "ClusterName=slurm\nMaxJobCount=10000\n",
+		"cgroup.conf": "CgroupPlugin=autodetect\n",
+	}
+
+	diff, err := Diff(deployed, deployed)
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+
+	diff, err = Diff(deployed, map[string]string{
+		"slurm.conf": "ClusterName=slurm\nMaxJobCount=20000\n",
+		"mpi.conf":   "PMIxEnv=OMPI_MCA_btl_tcp_if_include=eth0\n",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `--- deployed/cgroup.conf
++++ /dev/null
+@@ -1 +0,0 @@
+-CgroupPlugin=autodetect
+--- /dev/null
++++ rendered/mpi.conf
+@@ -0,0 +1 @@
++PMIxEnv=OMPI_MCA_btl_tcp_if_include=eth0
+--- deployed/slurm.conf
++++ rendered/slurm.conf
+@@ -1,2 +1,2 @@
+ ClusterName=slurm
+-MaxJobCount=10000
++MaxJobCount=20000
+`, diff)
+}
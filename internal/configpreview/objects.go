@@ -0,0 +1,191 @@
+package configpreview
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	structuraldefaulting "k8s.io/apiextensions-apiserver/pkg/apiserver/schema/defaulting"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	slurmv1 "nebius.ai/slurm-operator/api/v1"
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/consts"
+)
+
+// Objects are the SlurmCluster and its NodeSets the Slurm configs are rendered from.
+type Objects struct {
+	Cluster  *slurmv1.SlurmCluster
+	NodeSets []slurmv1alpha1.NodeSet
+}
+
+// Defaulter applies the defaults of the CRD schemas to the objects, as the Kubernetes API server does on their
+// creation.
+type Defaulter struct {
+	schemas map[schema.GroupVersionKind]*structuralschema.Structural
+}
+
+// NewDefaulter reads the CRD schemas from the YAML files in the directory.
+func NewDefaulter(crdDir string) (*Defaulter, error) {
+	paths, err := filepath.Glob(filepath.Join(crdDir, "*.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("listing CRD files: %w", err)
+	}
+
+	res := &Defaulter{schemas: map[schema.GroupVersionKind]*structuralschema.Structural{}}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading CRD file %s: %w", path, err)
+		}
+
+		crd := apiextensionsv1.CustomResourceDefinition{}
+		if err = yaml.Unmarshal(data, &crd); err != nil {
+			return nil, fmt.Errorf("parsing CRD file %s: %w", path, err)
+		}
+		if crd.Kind != "CustomResourceDefinition" {
+			continue
+		}
+
+		for _, version := range crd.Spec.Versions {
+			if version.Schema == nil || version.Schema.OpenAPIV3Schema == nil {
+				continue
+			}
+			internalSchema := apiextensions.JSONSchemaProps{}
+			if err = apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(
+				version.Schema.OpenAPIV3Schema, &internalSchema, nil,
+			); err != nil {
+				return nil, fmt.Errorf("converting schema of %s/%s: %w", crd.Name, version.Name, err)
+			}
+			structural, err := structuralschema.NewStructural(&internalSchema)
+			if err != nil {
+				return nil, fmt.Errorf("building structural schema of %s/%s: %w", crd.Name, version.Name, err)
+			}
+			res.schemas[schema.GroupVersionKind{
+				Group:   crd.Spec.Group,
+				Version: version.Name,
+				Kind:    crd.Spec.Names.Kind,
+			}] = structural
+		}
+	}
+
+	if len(res.schemas) == 0 {
+		return nil, fmt.Errorf("no CRDs found in %s", crdDir)
+	}
+	return res, nil
+}
+
+// Default applies the defaults of the CRD schema of the object kind.
+func (d *Defaulter) Default(obj map[string]any) error {
+	gvk := schema.FromAPIVersionAndKind(fmt.Sprint(obj["apiVersion"]), fmt.Sprint(obj["kind"]))
+	structural, found := d.schemas[gvk]
+	if !found {
+		return fmt.Errorf("no CRD schema found for %s", gvk)
+	}
+	structuraldefaulting.Default(obj, structural)
+	return nil
+}
+
+// DecodeObjects decodes the SlurmCluster and the NodeSets from the multi-document YAML, applying the CRD defaults.
+// Other objects are skipped. Exactly one SlurmCluster is expected.
+func DecodeObjects(r io.Reader, defaulter *Defaulter) (Objects, error) {
+	res := Objects{}
+
+	decoder := utilyaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		obj := map[string]any{}
+		if err := decoder.Decode(&obj); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return Objects{}, fmt.Errorf("decoding YAML: %w", err)
+		}
+		if len(obj) == 0 {
+			continue
+		}
+
+		gvk := schema.FromAPIVersionAndKind(fmt.Sprint(obj["apiVersion"]), fmt.Sprint(obj["kind"]))
+		switch gvk {
+		case slurmv1.GroupVersion.WithKind(slurmv1.KindSlurmCluster):
+			if res.Cluster != nil {
+				return Objects{}, errors.New("more than one SlurmCluster found")
+			}
+			cluster := &slurmv1.SlurmCluster{}
+			if err := decodeObject(obj, defaulter, cluster); err != nil {
+				return Objects{}, err
+			}
+			res.Cluster = cluster
+		case slurmv1alpha1.GroupVersion.WithKind(slurmv1alpha1.KindNodeSet):
+			nodeSet := slurmv1alpha1.NodeSet{}
+			if err := decodeObject(obj, defaulter, &nodeSet); err != nil {
+				return Objects{}, err
+			}
+			res.NodeSets = append(res.NodeSets, nodeSet)
+		}
+	}
+
+	if res.Cluster == nil {
+		return Objects{}, errors.New("no SlurmCluster found")
+	}
+	res.NodeSets = slices.DeleteFunc(res.NodeSets, func(nodeSet slurmv1alpha1.NodeSet) bool {
+		return !belongsToCluster(nodeSet, res.Cluster.Name)
+	})
+	return res, nil
+}
+
+// belongsToCluster checks whether the NodeSet refers to the cluster, the same way resourcegetter.ListNodeSetsByClusterRef
+// does.
+func belongsToCluster(nodeSet slurmv1alpha1.NodeSet, clusterName string) bool {
+	if nodeSet.Spec.ClusterName != "" {
+		return nodeSet.Spec.ClusterName == clusterName
+	}
+	return nodeSet.GetAnnotations()[consts.AnnotationParentalClusterRefName] == clusterName
+}
+
+// WithDeployedNodeSets adds the deployed NodeSets which aren't overridden by the NodeSets with the same name.
+func (o Objects) WithDeployedNodeSets(deployed []slurmv1alpha1.NodeSet) Objects {
+	res := Objects{Cluster: o.Cluster, NodeSets: slices.Clone(o.NodeSets)}
+	for _, nodeSet := range deployed {
+		overridden := slices.ContainsFunc(o.NodeSets, func(ns slurmv1alpha1.NodeSet) bool {
+			return ns.Name == nodeSet.Name
+		})
+		if !overridden {
+			res.NodeSets = append(res.NodeSets, nodeSet)
+		}
+	}
+	return res
+}
+
+func decodeObject(obj map[string]any, defaulter *Defaulter, into runtime.Object) error {
+	if err := defaulter.Default(obj); err != nil {
+		return err
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructuredWithValidation(obj, into, true); err != nil {
+		return fmt.Errorf("converting %s %v: %w", obj["kind"], obj["metadata"], err)
+	}
+	return nil
+}
+
+// ReadFiles concatenates the YAML files into a single multi-document YAML.
+func ReadFiles(paths []string) (io.Reader, error) {
+	buf := bytes.Buffer{}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+		buf.WriteString("\n---\n")
+		buf.Write(data)
+	}
+	return &buf, nil
+}
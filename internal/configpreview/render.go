@@ -0,0 +1,118 @@
+package configpreview
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/render/common"
+	"nebius.ai/slurm-operator/internal/values"
+)
+
+// RenderOptions are the parts of the cluster state the Slurm configs depend on, which can't be derived from the objects.
+type RenderOptions struct {
+	// NamePrefix is the prefix of the workload names, see resourcegetter.ResolveWorkloadNamePrefix.
+	NamePrefix string
+	// JWKS is the JWKS published by the operator. If it's empty, a JWKS with no keys is assumed once it's to be
+	// published.
+	JWKS string
+	// StoreJobComments is set if an ActiveCheck of the cluster reads the results of its Slurm jobs from their comments,
+	// see values.BuildStoreJobCommentsFromActiveChecks.
+	StoreJobComments bool
+}
+
+// Render renders the Slurm configs of the cluster the same way the operator does, keyed by their file names.
+// The JWKS isn't included, as it's published by the key rotation rather than derived from the spec.
+func Render(ctx context.Context, objects Objects, opts RenderOptions) (map[string]string, error) {
+	clusterValues, err := values.BuildSlurmClusterFrom(ctx, objects.Cluster, opts.NamePrefix)
+	if err != nil {
+		return nil, fmt.Errorf("building cluster values: %w", err)
+	}
+
+	nodeSets := slices.Clone(objects.NodeSets)
+	slices.SortFunc(nodeSets, func(a, b slurmv1alpha1.NodeSet) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	clusterValues.NodeSets = nodeSets
+	clusterValues.ClusterWithGPU = values.BuildClusterWithGPUFromNodeSets(nodeSets)
+	clusterValues.StoreJobComments = opts.StoreJobComments
+
+	if clusterValues.NodeAccounting.Enabled && clusterValues.NodeRest.Enabled {
+		clusterValues.JWTAuth.JWKS = opts.JWKS
+		// The JWKS is published once the JWT signing keys are generated, or if the external JWTs are accepted
+		if clusterValues.JWTAuth.JWKS == "" &&
+			(values.JWTSigningKeysRequired(objects.Cluster, clusterValues.JWTAuth.UsernameClaim) || clusterValues.NodeRest.ExternalJWT != nil) {
+			clusterValues.JWTAuth.JWKS = `{"keys":[]}`
+		}
+	}
+
+	res := common.RenderConfigMapSlurmConfigs(clusterValues).Data
+	delete(res, consts.ConfigMapKeyJWKS)
+	return res, nil
+}
+
+// FormatConfigs formats the Slurm configs one after another, sorted by their file names.
+func FormatConfigs(configs map[string]string) string {
+	sb := strings.Builder{}
+	for _, name := range slices.Sorted(maps.Keys(configs)) {
+		fmt.Fprintf(&sb, "# ==> %s <==\n", name)
+		sb.WriteString(configs[name])
+		if !strings.HasSuffix(configs[name], "\n") {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// Diff returns the unified diff of the deployed Slurm configs to the rendered ones, sorted by their file names.
+// It's empty if they're the same.
+func Diff(deployed, rendered map[string]string) (string, error) {
+	names := slices.Collect(maps.Keys(rendered))
+	for name := range deployed {
+		if _, found := rendered[name]; !found {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	sb := strings.Builder{}
+	for _, name := range names {
+		fromFile, toFile := "deployed/"+name, "rendered/"+name
+		if _, found := deployed[name]; !found {
+			fromFile = "/dev/null"
+		}
+		if _, found := rendered[name]; !found {
+			toFile = "/dev/null"
+		}
+
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        splitLines(deployed[name]),
+			B:        splitLines(rendered[name]),
+			FromFile: fromFile,
+			ToFile:   toFile,
+			Context:  3,
+		})
+		if err != nil {
+			return "", fmt.Errorf("diffing %s: %w", name, err)
+		}
+		sb.WriteString(diff)
+	}
+	return sb.String(), nil
+}
+
+// splitLines splits the text into lines keeping their line breaks.
+func splitLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	lines[len(lines)-1] += "\n"
+	return lines
+}
@@ -6,6 +6,14 @@ const (
 	ActiveCheckEachWorkerJobsEnv  = "EACH_WORKER_JOBS"
 	ActiveCheckNameEnv            = "ACTIVE_CHECK_NAME"
 	ActiveCheckMaxNumberOfJobsEnv = "ACTIVE_CHECK_MAX_NUMBER_OF_JOBS"
+	// ActiveCheckNodesEnv holds the Slurm nodes the check is limited to, either by its targets or by an on-demand
+	// ActiveCheckRun, as a hostlist expression.
+	ActiveCheckNodesEnv = "ACTIVE_CHECK_NODES"
+	// ActiveCheckReservationEnv holds the Slurm reservation the jobs of an on-demand ActiveCheckRun are submitted to.
+	ActiveCheckReservationEnv = "ACTIVE_CHECK_RESERVATION"
 
 	ActiveCheckSkippedReasonAnnotation = "slurm-skipped-reason"
+
+	ActiveCheckEventNotificationFailed = "NotificationFailed"
+	ActiveCheckEventInvalidTargets     = "InvalidTargets"
 )
@@ -8,4 +8,35 @@ const (
 	AnnotationActiveCheckName      = K8sGroupNameSoperator + "/activecheck"
 
 	AnnotationParentalClusterRefName = K8sGroupNameSoperator + "/parental-cluster-ref"
+
+	// AnnotationUpdateVerificationCheck is the name of the ActiveCheck verifying the workers of a StatefulSet updated
+	// by the slurm-aware rolling update. It's empty if the verification is disabled.
+	AnnotationUpdateVerificationCheck = K8sGroupNameSoperator + "/update-verification-check"
+	// AnnotationUpdateVerificationMaxFailedNodes is the number of updated workers allowed to fail the verification.
+	AnnotationUpdateVerificationMaxFailedNodes = K8sGroupNameSoperator + "/update-verification-max-failed-nodes"
+	// AnnotationRolloutPaused and AnnotationRolloutPartition control the progress of the slurm-aware rolling update
+	// of a StatefulSet.
+	AnnotationRolloutPaused    = K8sGroupNameSoperator + "/rollout-paused"
+	AnnotationRolloutPartition = K8sGroupNameSoperator + "/rollout-partition"
+	// AnnotationRolloutMode is the RolloutMode of the slurm-aware rolling update of a StatefulSet.
+	AnnotationRolloutMode = K8sGroupNameSoperator + "/rollout-mode"
+	// AnnotationRolloutDrainReservationDuration and AnnotationRolloutMaxDrainedPerPartition configure the drainAndWait
+	// rollout mode. They're empty if the defaults are used.
+	AnnotationRolloutDrainReservationDuration = K8sGroupNameSoperator + "/rollout-drain-reservation-duration"
+	AnnotationRolloutMaxDrainedPerPartition   = K8sGroupNameSoperator + "/rollout-max-drained-per-partition"
+
+	// AnnotationRotateMungeKey and AnnotationRotateJWTSigningKey start a rotation of the cluster key whenever their
+	// value on the SlurmCluster changes.
+	AnnotationRotateMungeKey      = K8sGroupNameSoperator + "/rotate-munge-key"
+	AnnotationRotateJWTSigningKey = K8sGroupNameSoperator + "/rotate-jwt-signing-key"
+	// AnnotationActiveSigningKeyID is the ID of the key in the JWT signing keys Secret the issued JWTs are signed by.
+	// JWTs are signed by the REST JWT key while it's empty.
+	AnnotationActiveSigningKeyID = K8sGroupNameSoperator + "/active-signing-key-id"
+	// AnnotationJWTUsernameClaim is the claim of the JWTs issued by the operator the Slurm username is put into.
+	// It's set on the JWT signing keys Secret.
+	AnnotationJWTUsernameClaim = K8sGroupNameSoperator + "/jwt-username-claim"
+	// AnnotationExternalJWKSURL and AnnotationExternalJWKSFetchTime are the URL and the time the external JWKS
+	// mirrored into the Secret was fetched from and at.
+	AnnotationExternalJWKSURL       = K8sGroupNameSoperator + "/external-jwks-url"
+	AnnotationExternalJWKSFetchTime = K8sGroupNameSoperator + "/external-jwks-fetch-time"
 )
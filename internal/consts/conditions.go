@@ -28,6 +28,7 @@ const (
 	ReasonNodeRebooted   ReasonConditionType = "NodeRebooted"
 
 	ReasonGPUHealthCheckFailed ReasonConditionType = "GPUHealthCheckFailedSoperator"
+	ReasonActiveCheckFailed    ReasonConditionType = "ActiveCheckFailedSoperator"
 )
 
 const (
@@ -66,3 +67,26 @@ const (
 	ActiveCheckSlurmRunStatusError      ActiveCheckSlurmRunStatus = "Error"
 	ActiveCheckSlurmRunStatusSkipped    ActiveCheckSlurmRunStatus = "Skipped"
 )
+
+// ActiveCheckRunPhase defines the phase of an on-demand ActiveCheckRun.
+type ActiveCheckRunPhase string
+
+const (
+	ActiveCheckRunPhasePending   ActiveCheckRunPhase = "Pending"
+	ActiveCheckRunPhaseRunning   ActiveCheckRunPhase = "Running"
+	ActiveCheckRunPhaseComplete  ActiveCheckRunPhase = "Complete"
+	ActiveCheckRunPhaseFailed    ActiveCheckRunPhase = "Failed"
+	ActiveCheckRunPhaseCancelled ActiveCheckRunPhase = "Cancelled"
+	ActiveCheckRunPhaseError     ActiveCheckRunPhase = "Error"
+	ActiveCheckRunPhaseSkipped   ActiveCheckRunPhase = "Skipped"
+)
+
+// ActiveCheckNodeResult defines the outcome of an ActiveCheck Slurm job on a node.
+type ActiveCheckNodeResult string
+
+const (
+	ActiveCheckNodeResultPassed    ActiveCheckNodeResult = "Passed"
+	ActiveCheckNodeResultFailed    ActiveCheckNodeResult = "Failed"
+	ActiveCheckNodeResultError     ActiveCheckNodeResult = "Error"
+	ActiveCheckNodeResultCancelled ActiveCheckNodeResult = "Cancelled"
+)
@@ -24,6 +24,7 @@ const (
 	ConfigMapKeyMPIConfig           = "mpi.conf"
 	ConfigMapKeySlurmdbdConfig      = "slurmdbd.conf"
 	ConfigMapKeyTopologyConfig      = "topology.conf"
+	ConfigMapKeyJWKS                = "jwks.json"
 
 	ConfigMapKeySshdConfig              = SshdName + "_config"
 	ConfigMapKeySshRootPublicKeysConfig = authorizedKeys
@@ -21,6 +21,13 @@ const (
 	ContainerSecurityContextCapabilitySysAdmin = "SYS_ADMIN"
 	ContainerSecurityContextCapabilitySetFcap  = "SETFCAP"
 
+	// ContainerEnvMungeKeyReload tells whether the munge container switches to the rotated munge key on its own, e.g.
+	// for custom munge images. It takes precedence over MungeKeyReloadMinImageVersion.
+	ContainerEnvMungeKeyReload = "MUNGE_KEY_RELOAD"
+	// MungeKeyReloadMinImageVersion is the first version of the munge image whose entrypoint switches to the rotated
+	// munge key.
+	MungeKeyReloadMinImageVersion = "5.0.0"
+
 	ContainerPortNameExporter = "metrics"
 	ContainerPortExporter     = 8080
 	ContainerPathExporter     = "/metrics"
@@ -2,4 +2,17 @@ package consts
 
 const (
 	HighEphemeralStorageUsage = "HighEphemeralStorageUsage"
+
+	NodePowerEventResumed      = "NodeResumed"
+	NodePowerEventResumeFailed = "NodeResumeFailed"
+	NodePowerEventSuspended    = "NodeSuspended"
+
+	RollingUpdateEventVerificationFailed = "UpdateVerificationFailed"
+	RollingUpdateEventHalted             = "RollingUpdateHalted"
+
+	RebooterEventPreRebootHookFailed    = "PreRebootHookFailed"
+	RebooterEventPostRebootCheckFailed  = "PostRebootCheckFailed"
+	RebooterEventPostRebootChecksPassed = "PostRebootChecksPassed"
+
+	ExternalJWKSEventFetchFailed = "ExternalJWKSFetchFailed"
 )
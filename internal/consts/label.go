@@ -23,9 +23,11 @@ const (
 	LabelNodeConfiguratorKey   = K8sGroupNameSoperator + "/node-configurator"
 	LabelNodeConfiguratorValue = "true"
 
-	LabelNodeSetKey  = K8sGroupNameSoperator + "/nodeset"
-	LabelWorkerKey   = K8sGroupNameSoperator + "/worker"
-	LabelWorkerValue = "true"
+	LabelNodeSetKey = K8sGroupNameSoperator + "/nodeset"
+	// LabelActiveCheckRunKey value is the name of the ActiveCheckRun a K8s Job is created for
+	LabelActiveCheckRunKey = K8sGroupNameSoperator + "/activecheckrun"
+	LabelWorkerKey         = K8sGroupNameSoperator + "/worker"
+	LabelWorkerValue       = "true"
 
 	LabelSConfigControllerSourceKey   = "sconfigcontroller." + K8sGroupNameSoperator
 	LabelSConfigControllerSourceValue = "true"
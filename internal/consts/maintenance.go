@@ -13,4 +13,6 @@ const (
 const (
 	ZeroReplicas   = int32(0)
 	SingleReplicas = int32(1)
+	// ControllerHighAvailabilityReplicas is the number of controllers with high availability: the primary and the backup.
+	ControllerHighAvailabilityReplicas = int32(2)
 )
@@ -1,8 +1,14 @@
 package consts
 
 const (
-	RebooterMethodEnv   = "REBOOTER_EVICTION_METHOD"
-	RebooterNodeNameEnv = "REBOOTER_NODE_NAME"
+	RebooterMethodEnv                         = "REBOOTER_EVICTION_METHOD"
+	RebooterNodeNameEnv                       = "REBOOTER_NODE_NAME"
+	RebooterNamespaceEnv                      = "REBOOTER_NAMESPACE"
+	RebooterMaxConcurrentRebootsEnv           = "REBOOTER_MAX_CONCURRENT_REBOOTS"
+	RebooterMaxConcurrentRebootsPerNodeSetEnv = "REBOOTER_MAX_CONCURRENT_REBOOTS_PER_NODESET"
+	RebooterMaintenanceWindowsEnv             = "REBOOTER_MAINTENANCE_WINDOWS"
+	RebooterPreRebootHooksEnv                 = "REBOOTER_PRE_REBOOT_HOOKS"
+	RebooterPostRebootChecksEnv               = "REBOOTER_POST_REBOOT_CHECKS"
 )
 
 type RebooterMethod string
@@ -14,4 +20,7 @@ const (
 
 const (
 	NodeConfiguratorName = "node-configurator"
+
+	// RebooterLockConfigMapName is the name of the ConfigMap rebooters coordinate concurrent reboots through
+	RebooterLockConfigMapName = "rebooter-lock"
 )
@@ -5,4 +5,17 @@ const (
 	DefaultRESTPort       = 6820
 	RESTJWTKeyPath        = "/var/spool/slurmctld/jwt_hs256.key"
 	HostnameREST          = "rest"
+
+	// SecretJWTSigningKeys is the Secret with the RSA keys signing the JWTs issued by the operator, one per data key
+	// named by the key ID.
+	SecretJWTSigningKeys = "jwt-signing-keys"
+	// JWKSPath is the path of the JWKS with the public keys of the JWT signing keys, for both slurmctld and slurmdbd.
+	JWKSPath = "/etc/slurm/" + ConfigMapKeyJWKS
+
+	// SecretExternalJWKS is the Secret the external JWKS fetched from its URL is mirrored into.
+	SecretExternalJWKS = "external-jwks"
+	// SecretExternalJWKSKey is the key of the external JWKS in its mirror Secret.
+	SecretExternalJWKSKey = "jwks.json"
+	// JWTDefaultUsernameClaim is the claim Slurm takes the username of a JWT from, unless configured otherwise.
+	JWTDefaultUsernameClaim = "sun"
 )
@@ -4,6 +4,10 @@ const (
 	SecretMungeKeyName     = Munge
 	SecretMungeKeyFileName = Munge + ".key"
 	SecretMungeKeyFileMode = int32(0400)
+	// SecretMungeKeyNextFileName and SecretMungeKeyActivateAtFileName stage the next munge key during a rotation.
+	// The munge containers switch to the next key at the activation time, as a Unix timestamp.
+	SecretMungeKeyNextFileName       = SecretMungeKeyFileName + ".next"
+	SecretMungeKeyActivateAtFileName = SecretMungeKeyFileName + ".activate-at"
 
 	SecretRESTJWTKeyFileName = "rest_jwt.key"
 	SecretRESTJWTKeyFileMode = int32(0400)
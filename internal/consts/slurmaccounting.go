@@ -0,0 +1,11 @@
+package consts
+
+const (
+	SlurmAccountFinalizer = K8sGroupNameSoperator + "/slurmaccount-finalizer"
+	SlurmQOSFinalizer     = K8sGroupNameSoperator + "/slurmqos-finalizer"
+
+	SlurmAccountingEventCreated        = "AccountingCreated"
+	SlurmAccountingEventDriftCorrected = "AccountingDriftCorrected"
+	SlurmAccountingEventDeleted        = "AccountingDeleted"
+	SlurmAccountingEventSyncFailed     = "AccountingSyncFailed"
+)
@@ -0,0 +1,10 @@
+package consts
+
+const (
+	SlurmReservationFinalizer = K8sGroupNameSoperator + "/slurmreservation-finalizer"
+
+	SlurmReservationEventCreated        = "ReservationCreated"
+	SlurmReservationEventDriftCorrected = "ReservationDriftCorrected"
+	SlurmReservationEventDeleted        = "ReservationDeleted"
+	SlurmReservationEventSyncFailed     = "ReservationSyncFailed"
+)
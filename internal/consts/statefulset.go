@@ -10,3 +10,10 @@ const (
 	UpdateStrategyRollingUpdate           UpdateStrategy = "rollingUpdate"
 	UpdateStrategySlurmAwareRollingUpdate UpdateStrategy = "slurmAwareRollingUpdate"
 )
+
+type RolloutMode string
+
+const (
+	RolloutModeReboot       RolloutMode = "reboot"
+	RolloutModeDrainAndWait RolloutMode = "drainAndWait"
+)
@@ -22,7 +22,9 @@ import (
 	"nebius.ai/slurm-operator/internal/logfield"
 	"nebius.ai/slurm-operator/internal/naming"
 	"nebius.ai/slurm-operator/internal/render/accounting"
+	"nebius.ai/slurm-operator/internal/render/common"
 	"nebius.ai/slurm-operator/internal/utils"
+	"nebius.ai/slurm-operator/internal/utils/resourcegetter"
 	"nebius.ai/slurm-operator/internal/values"
 )
 
@@ -92,6 +94,7 @@ func (r SlurmClusterReconciler) ReconcileAccounting(
 						&clusterValues.NodeAccounting,
 						secret,
 						clusterValues.NodeRest.Enabled,
+						clusterValues.JWTAuth,
 					)
 					if err != nil {
 						stepLogger.Error(err, "Failed to render")
@@ -599,10 +602,6 @@ func (r SlurmClusterReconciler) getAccountingDeploymentDependencies(
 			name: naming.BuildSecretSlurmdbdConfigsName(clusterValues.Name),
 			obj:  &corev1.Secret{},
 		},
-		{
-			name: naming.BuildSecretMungeKeyName(clusterValues.Name),
-			obj:  &corev1.Secret{},
-		},
 	}
 
 	if clusterValues.NodeAccounting.ExternalDB.Enabled {
@@ -636,5 +635,13 @@ func (r SlurmClusterReconciler) getAccountingDeploymentDependencies(
 		res = append(res, object.obj)
 	}
 
+	if !common.MungeContainerReloadsKey(&clusterValues.NodeAccounting.ContainerMunge) {
+		mungeKey, err := resourcegetter.GetMungeKeyDependency(ctx, r.Client, clusterValues.NamespacedName)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, mungeKey)
+	}
+
 	return res, nil
 }
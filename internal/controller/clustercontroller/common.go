@@ -87,6 +87,16 @@ func (r SlurmClusterReconciler) ReconcileCommon(
 					}
 					clusterValues.NodeSets = nodeSets
 
+					activeChecks, err := resourcegetter.ListActiveChecksByClusterRef(
+						stepCtx,
+						r.Client,
+						types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name},
+					)
+					if err != nil {
+						return err
+					}
+					clusterValues.StoreJobComments = values.BuildStoreJobCommentsFromActiveChecks(activeChecks)
+
 					desired := common.RenderConfigMapSlurmConfigs(clusterValues)
 					stepLogger = stepLogger.WithValues(logfield.ResourceKV(&desired)...)
 					stepLogger.V(1).Info("Rendered")
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	kruisev1b1 "github.com/openkruise/kruise-api/apps/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -21,6 +22,7 @@ import (
 	"nebius.ai/slurm-operator/internal/render/common"
 	"nebius.ai/slurm-operator/internal/render/controller"
 	"nebius.ai/slurm-operator/internal/utils"
+	"nebius.ai/slurm-operator/internal/utils/resourcegetter"
 	"nebius.ai/slurm-operator/internal/values"
 )
 
@@ -110,6 +112,45 @@ func (r SlurmClusterReconciler) ReconcileControllers(
 					return nil
 				},
 			},
+			utils.MultiStepExecutionStep{
+				Name: "Slurm Controller Replica Services",
+				Func: func(stepCtx context.Context) error {
+					stepLogger := log.FromContext(stepCtx)
+					stepLogger.V(1).Info("Reconciling")
+
+					if !clusterValues.NodeController.HighAvailability {
+						for ordinal := range consts.ControllerHighAvailabilityReplicas {
+							name := naming.BuildControllerReplicaServiceName(clusterValues.Name, ordinal)
+							if err := r.Service.Reconcile(stepCtx, cluster, nil, &name); err != nil {
+								return fmt.Errorf("deleting controller replica Service %s: %w", name, err)
+							}
+						}
+						stepLogger.V(1).Info("Reconciled")
+						return nil
+					}
+
+					for ordinal, service := range clusterValues.NodeController.ReplicaServices {
+						desired := controller.RenderService(
+							clusterValues.Namespace,
+							clusterValues.Name,
+							service.Name,
+							&clusterValues.NodeController,
+							map[string]string{
+								appsv1.StatefulSetPodNameLabel: fmt.Sprintf("%s-%d", clusterValues.NodeController.StatefulSet.Name, ordinal),
+							},
+						)
+						serviceLogger := stepLogger.WithValues(logfield.ResourceKV(&desired)...)
+						serviceLogger.V(1).Info("Rendered")
+
+						if err := r.Service.Reconcile(stepCtx, cluster, &desired, nil); err != nil {
+							return fmt.Errorf("reconciling controller replica Service %s: %w", service.Name, err)
+						}
+					}
+					stepLogger.V(1).Info("Reconciled")
+
+					return nil
+				},
+			},
 			utils.MultiStepExecutionStep{
 				Name: "Slurm Controller OpenMetrics ServiceMonitor",
 				Func: func(stepCtx context.Context) error {
@@ -252,18 +293,13 @@ func (r SlurmClusterReconciler) getControllersStatefulSetDependencies(
 ) ([]metav1.Object, error) {
 	var res []metav1.Object
 
-	mungeKeySecret := &corev1.Secret{}
-	if err := r.Get(
-		ctx,
-		types.NamespacedName{
-			Namespace: clusterValues.Namespace,
-			Name:      naming.BuildSecretMungeKeyName(clusterValues.Name),
-		},
-		mungeKeySecret,
-	); err != nil {
-		return []metav1.Object{}, err
+	if !common.MungeContainerReloadsKey(&clusterValues.NodeController.ContainerMunge) {
+		mungeKey, err := resourcegetter.GetMungeKeyDependency(ctx, r.Client, clusterValues.NamespacedName)
+		if err != nil {
+			return []metav1.Object{}, err
+		}
+		res = append(res, mungeKey)
 	}
-	res = append(res, mungeKeySecret)
 
 	if clusterValues.NodeAccounting.Enabled {
 		slurmdbdSecret := &corev1.Secret{}
@@ -0,0 +1,234 @@
+package clustercontroller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slurmv1 "nebius.ai/slurm-operator/api/v1"
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/jwt"
+	"nebius.ai/slurm-operator/internal/naming"
+	"nebius.ai/slurm-operator/internal/render/common"
+	"nebius.ai/slurm-operator/internal/values"
+)
+
+const (
+	// externalJWKSRefreshInterval is how often the external JWKS is fetched from its URL again.
+	externalJWKSRefreshInterval = time.Hour
+	// externalJWKSRetryInterval is how soon a failed fetch of the external JWKS is retried while the previously
+	// mirrored one is in use.
+	externalJWKSRetryInterval = time.Minute
+	// externalJWKSMaxSize limits the size of the fetched external JWKS.
+	externalJWKSMaxSize = 1 << 20
+)
+
+// externalJWKSHTTPClient fetches the external JWKS from its URL.
+var externalJWKSHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// ReconcileExternalJWT merges the JWKS of the external identity provider into the one published to slurmctld and
+// slurmdbd, so that they accept the JWTs issued by it. The JWKS fetched from a URL is mirrored into a Secret first.
+// It must follow ReconcileKeyRotation, which sets the JWKS of the operator's signing keys to the cluster values.
+func (r SlurmClusterReconciler) ReconcileExternalJWT(
+	ctx context.Context,
+	cluster *slurmv1.SlurmCluster,
+	clusterValues *values.SlurmCluster,
+) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	externalJWT := clusterValues.NodeRest.ExternalJWT
+	// The JWKS isn't published unless auth/jwt is enabled
+	if externalJWT == nil || !clusterValues.NodeAccounting.Enabled || !clusterValues.NodeRest.Enabled {
+		return ctrl.Result{}, nil
+	}
+
+	var (
+		externalJWKS []byte
+		requeueAfter time.Duration
+		err          error
+	)
+	if externalJWT.JWKSSecretRef != nil {
+		externalJWKS, err = r.getExternalJWKS(ctx, cluster.Namespace, *externalJWT.JWKSSecretRef)
+	} else {
+		externalJWKS, requeueAfter, err = r.mirrorExternalJWKS(ctx, cluster, externalJWT.JWKSURL, time.Now())
+	}
+	if err != nil {
+		logger.Error(err, "Failed to get external JWKS")
+		return ctrl.Result{}, fmt.Errorf("getting external JWKS: %w", err)
+	}
+
+	var jwkSets [][]byte
+	// The JWKS of the operator's signing keys is empty until their rotation is requested or scheduled
+	if clusterValues.JWTAuth.JWKS != "" {
+		jwkSets = append(jwkSets, []byte(clusterValues.JWTAuth.JWKS))
+	}
+	jwks, err := jwt.MergeJWKS(append(jwkSets, externalJWKS)...)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("merging external JWKS: %w", err)
+	}
+	clusterValues.JWTAuth.JWKS = string(jwks)
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// getExternalJWKS gets the external JWKS from the key of the Secret it refers to.
+func (r SlurmClusterReconciler) getExternalJWKS(
+	ctx context.Context,
+	namespace string,
+	ref slurmv1.SecretKeyRef,
+) ([]byte, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: ref.Name}, secret); err != nil {
+		return nil, fmt.Errorf("getting Secret %s: %w", ref.Name, err)
+	}
+
+	data, found := secret.Data[ref.Key]
+	if !found {
+		return nil, fmt.Errorf("key %s not found in Secret %s", ref.Key, ref.Name)
+	}
+	if err := validateJWKS(data); err != nil {
+		return nil, fmt.Errorf("validating JWKS from Secret %s: %w", ref.Name, err)
+	}
+	return data, nil
+}
+
+// mirrorExternalJWKS fetches the external JWKS from the URL into the mirror Secret, unless it's mirrored recently.
+// It returns the mirrored JWKS along with when it has to be fetched again.
+// If the JWKS can't be fetched, the previously mirrored one is used while the fetch is retried.
+func (r SlurmClusterReconciler) mirrorExternalJWKS(
+	ctx context.Context,
+	cluster *slurmv1.SlurmCluster,
+	url string,
+	now time.Time,
+) ([]byte, time.Duration, error) {
+	logger := log.FromContext(ctx)
+
+	secret := &corev1.Secret{}
+	exists := true
+	if err := r.Get(
+		ctx,
+		types.NamespacedName{
+			Namespace: cluster.Namespace,
+			Name:      naming.BuildSecretExternalJWKSName(cluster.Name),
+		},
+		secret,
+	); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, 0, fmt.Errorf("getting external JWKS Secret: %w", err)
+		}
+		exists = false
+	}
+
+	mirrored := exists && secret.Annotations[consts.AnnotationExternalJWKSURL] == url
+	if mirrored {
+		if refreshIn, fresh := externalJWKSFresh(secret, now); fresh {
+			return secret.Data[consts.SecretExternalJWKSKey], refreshIn, nil
+		}
+	}
+
+	jwks, err := fetchExternalJWKS(ctx, externalJWKSHTTPClient, url)
+	if err != nil {
+		if !mirrored {
+			return nil, 0, fmt.Errorf("fetching JWKS from %s: %w", url, err)
+		}
+		logger.Error(err, "Failed to fetch external JWKS, the previously fetched one is used", "URL", url)
+		r.Recorder.Event(
+			cluster,
+			corev1.EventTypeWarning,
+			consts.ExternalJWKSEventFetchFailed,
+			fmt.Sprintf("Failed to fetch JWKS from %s, the one fetched at %s is used: %v",
+				url, secret.Annotations[consts.AnnotationExternalJWKSFetchTime], err),
+		)
+		return secret.Data[consts.SecretExternalJWKSKey], externalJWKSRetryInterval, nil
+	}
+
+	secret.Namespace = cluster.Namespace
+	secret.Name = naming.BuildSecretExternalJWKSName(cluster.Name)
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[consts.AnnotationExternalJWKSURL] = url
+	secret.Annotations[consts.AnnotationExternalJWKSFetchTime] = now.UTC().Format(time.RFC3339)
+	secret.Data = map[string][]byte{consts.SecretExternalJWKSKey: jwks}
+
+	if exists {
+		if err = r.Update(ctx, secret); err != nil {
+			return nil, 0, fmt.Errorf("updating external JWKS Secret: %w", err)
+		}
+	} else {
+		secret.Labels = common.RenderLabels(consts.ComponentTypeREST, cluster.Name)
+		if err = ctrl.SetControllerReference(cluster, secret, r.Scheme); err != nil {
+			return nil, 0, fmt.Errorf("setting controller reference: %w", err)
+		}
+		if err = r.Create(ctx, secret); err != nil {
+			return nil, 0, fmt.Errorf("creating external JWKS Secret: %w", err)
+		}
+	}
+
+	return jwks, externalJWKSRefreshInterval, nil
+}
+
+// externalJWKSFresh returns whether the JWKS mirrored into the Secret doesn't have to be fetched again yet, and when
+// it has to be fetched otherwise.
+func externalJWKSFresh(secret *corev1.Secret, now time.Time) (time.Duration, bool) {
+	fetchTime, err := time.Parse(time.RFC3339, secret.Annotations[consts.AnnotationExternalJWKSFetchTime])
+	if err != nil {
+		return 0, false
+	}
+	refreshIn := fetchTime.Add(externalJWKSRefreshInterval).Sub(now)
+	return refreshIn, refreshIn > 0
+}
+
+// fetchExternalJWKS fetches the JWKS from the URL.
+func fetchExternalJWKS(ctx context.Context, httpClient *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("sending request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, externalJWKSMaxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	if len(data) > externalJWKSMaxSize {
+		return nil, fmt.Errorf("JWKS exceeds %d bytes", externalJWKSMaxSize)
+	}
+	if err = validateJWKS(data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// validateJWKS checks that the data is a JWKS with at least one key.
+func validateJWKS(data []byte) error {
+	jwks := struct {
+		Keys []json.RawMessage `json:"keys"`
+	}{}
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return fmt.Errorf("parsing JWKS: %w", err)
+	}
+	if len(jwks.Keys) == 0 {
+		return errors.New("JWKS has no keys")
+	}
+	return nil
+}
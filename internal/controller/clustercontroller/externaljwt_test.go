@@ -0,0 +1,102 @@
+package clustercontroller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	slurmv1 "nebius.ai/slurm-operator/api/v1"
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/naming"
+)
+
+const testExternalJWKS = `{"keys":[{"kty":"EC","crv":"P-256","kid":"sso","x":"x","y":"y"}]}`
+
+func TestFetchExternalJWKS(t *testing.T) {
+	status, body := http.StatusOK, testExternalJWKS
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	data, err := fetchExternalJWKS(context.Background(), server.Client(), server.URL)
+	require.NoError(t, err)
+	assert.JSONEq(t, testExternalJWKS, string(data))
+
+	status = http.StatusInternalServerError
+	_, err = fetchExternalJWKS(context.Background(), server.Client(), server.URL)
+	assert.Error(t, err)
+
+	status, body = http.StatusOK, `{"keys":[]}`
+	_, err = fetchExternalJWKS(context.Background(), server.Client(), server.URL)
+	assert.Error(t, err, "JWKS without keys is rejected")
+}
+
+func TestMirrorExternalJWKS(t *testing.T) {
+	fetches := 0
+	available := true
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fetches++
+		if !available {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(testExternalJWKS))
+	}))
+	defer server.Close()
+
+	httpClient := externalJWKSHTTPClient
+	externalJWKSHTTPClient = server.Client()
+	defer func() { externalJWKSHTTPClient = httpClient }()
+
+	cluster := &slurmv1.SlurmCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns", Name: "test-cluster"}}
+	r := newTestReconciler(t)
+	recorder := record.NewFakeRecorder(10)
+	r.Recorder = recorder
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	jwks, requeueAfter, err := r.mirrorExternalJWKS(ctx, cluster, server.URL, now)
+	require.NoError(t, err)
+	assert.JSONEq(t, testExternalJWKS, string(jwks))
+	assert.Equal(t, externalJWKSRefreshInterval, requeueAfter)
+	assert.Equal(t, 1, fetches)
+
+	secret := &corev1.Secret{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{
+		Namespace: cluster.Namespace,
+		Name:      naming.BuildSecretExternalJWKSName(cluster.Name),
+	}, secret))
+	assert.Equal(t, server.URL, secret.Annotations[consts.AnnotationExternalJWKSURL])
+	assert.Equal(t, testExternalJWKS, string(secret.Data[consts.SecretExternalJWKSKey]))
+
+	// The mirrored JWKS is used until it's due to be fetched again
+	_, requeueAfter, err = r.mirrorExternalJWKS(ctx, cluster, server.URL, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, externalJWKSRefreshInterval-time.Minute, requeueAfter)
+	assert.Equal(t, 1, fetches)
+
+	// The mirrored JWKS is used while the URL is unavailable
+	available = false
+	jwks, requeueAfter, err = r.mirrorExternalJWKS(ctx, cluster, server.URL, now.Add(externalJWKSRefreshInterval))
+	require.NoError(t, err)
+	assert.JSONEq(t, testExternalJWKS, string(jwks))
+	assert.Equal(t, externalJWKSRetryInterval, requeueAfter)
+	assert.Equal(t, 2, fetches)
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, consts.ExternalJWKSEventFetchFailed)
+
+	// The JWKS mirrored from another URL isn't used
+	_, _, err = r.mirrorExternalJWKS(ctx, cluster, server.URL+"/other", now.Add(externalJWKSRefreshInterval))
+	assert.Error(t, err)
+}
@@ -0,0 +1,395 @@
+package clustercontroller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slurmv1 "nebius.ai/slurm-operator/api/v1"
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/jwt"
+	"nebius.ai/slurm-operator/internal/naming"
+	"nebius.ai/slurm-operator/internal/render/common"
+	"nebius.ai/slurm-operator/internal/render/rest"
+	"nebius.ai/slurm-operator/internal/values"
+)
+
+const (
+	// mungeKeyActivationDelay is how long the next munge key is staged before the munge containers switch to it.
+	// It must be longer than it takes the kubelets to update the mounted Secrets.
+	mungeKeyActivationDelay = 5 * time.Minute
+	// jwtSigningKeyPublishDuration is how long a new JWT signing key is published before it signs the JWTs,
+	// so that slurmctld and slurmdbd load it beforehand.
+	jwtSigningKeyPublishDuration = 5 * time.Minute
+	// jwtSigningKeyRetirementDuration is how long the previous JWT signing key is accepted after the new one signs
+	// the JWTs.
+	jwtSigningKeyRetirementDuration = time.Hour
+)
+
+// ReconcileKeyRotation advances the munge key and JWT signing key rotations and reports their progress in the status.
+// It also sets the JWKS of the published JWT signing keys to the cluster values, so it must precede the rendering of
+// Slurm configs. No JWKS is published until the JWT signing key rotation is requested or scheduled, so the JWTs are
+// signed by the HS256 REST JWT key until then.
+func (r SlurmClusterReconciler) ReconcileKeyRotation(
+	ctx context.Context,
+	cluster *slurmv1.SlurmCluster,
+	clusterValues *values.SlurmCluster,
+) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	now := time.Now()
+
+	status := ptr.Deref(cluster.Status.KeyRotation.DeepCopy(), slurmv1.KeyRotationStatus{})
+
+	mungeKeyRequeueAfter, err := r.rotateMungeKey(ctx, cluster, &status.MungeKey, now)
+	if err != nil {
+		logger.Error(err, "Failed to rotate munge key")
+		return ctrl.Result{}, fmt.Errorf("rotating munge key: %w", err)
+	}
+
+	var jwtSigningKeyRequeueAfter time.Duration
+	if clusterValues.NodeAccounting.Enabled && clusterValues.NodeRest.Enabled {
+		jwtSigningKeyRequeueAfter, clusterValues.JWTAuth.JWKS, err = r.rotateJWTSigningKey(
+			ctx, cluster, &status.JWTSigningKey, clusterValues.JWTAuth.UsernameClaim, now,
+		)
+		if err != nil {
+			logger.Error(err, "Failed to rotate JWT signing key")
+			return ctrl.Result{}, fmt.Errorf("rotating JWT signing key: %w", err)
+		}
+	}
+
+	if err = r.patchStatus(ctx, cluster, func(s *slurmv1.SlurmClusterStatus) bool {
+		if s.KeyRotation == nil && equality.Semantic.DeepEqual(status, slurmv1.KeyRotationStatus{}) {
+			return false
+		}
+		if s.KeyRotation != nil && equality.Semantic.DeepEqual(*s.KeyRotation, status) {
+			return false
+		}
+		s.KeyRotation = &status
+		return true
+	}); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: minRequeueAfter(mungeKeyRequeueAfter, jwtSigningKeyRequeueAfter)}, nil
+}
+
+// rotateMungeKey advances the munge key rotation and returns when it has to be advanced next.
+func (r SlurmClusterReconciler) rotateMungeKey(
+	ctx context.Context,
+	cluster *slurmv1.SlurmCluster,
+	progress *slurmv1.KeyRotationProgress,
+	now time.Time,
+) (time.Duration, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(
+		ctx,
+		types.NamespacedName{
+			Namespace: cluster.Namespace,
+			Name:      naming.BuildSecretMungeKeyName(cluster.Name),
+		},
+		secret,
+	); err != nil {
+		if apierrors.IsNotFound(err) {
+			// The Secret is yet to be created by the common resources reconciliation
+			return 0, nil
+		}
+		return 0, fmt.Errorf("getting munge key Secret: %w", err)
+	}
+
+	changed, requeueAfter, err := advanceMungeKeyRotation(
+		secret,
+		progress,
+		cluster.Annotations[consts.AnnotationRotateMungeKey],
+		cluster.Spec.KeyRotation.MungeKeyInterval,
+		now,
+	)
+	if err != nil {
+		return 0, err
+	}
+	if changed {
+		if err = r.Update(ctx, secret); err != nil {
+			return 0, fmt.Errorf("updating munge key Secret: %w", err)
+		}
+	}
+
+	return requeueAfter, nil
+}
+
+// advanceMungeKeyRotation advances the munge key rotation in the Secret.
+// Munge supports only one key, so the next key is staged in the Secret along with its activation time, and all munge
+// containers switch to it at once. Then the next key is promoted to the current one.
+// The state of the rotation is kept in the Secret, the progress only reflects it.
+func advanceMungeKeyRotation(
+	secret *corev1.Secret,
+	progress *slurmv1.KeyRotationProgress,
+	request string,
+	interval *metav1.Duration,
+	now time.Time,
+) (changed bool, requeueAfter time.Duration, err error) {
+	if nextKey, staged := secret.Data[consts.SecretMungeKeyNextFileName]; staged {
+		activateAt, err := strconv.ParseInt(string(secret.Data[consts.SecretMungeKeyActivateAtFileName]), 10, 64)
+		if err != nil {
+			return false, 0, fmt.Errorf("parsing activation time of the next munge key: %w", err)
+		}
+
+		activationTime := time.Unix(activateAt, 0)
+		if now.Before(activationTime) {
+			setKeyRotationPhase(progress, slurmv1.KeyRotationPhasePublishing, now,
+				fmt.Sprintf("The munge containers switch to the new key at %s", activationTime.UTC().Format(time.RFC3339)))
+			return false, activationTime.Sub(now), nil
+		}
+
+		secret.Data[consts.SecretMungeKeyFileName] = nextKey
+		delete(secret.Data, consts.SecretMungeKeyNextFileName)
+		delete(secret.Data, consts.SecretMungeKeyActivateAtFileName)
+		setKeyRotationPhase(progress, slurmv1.KeyRotationPhaseCompleted, now, "The munge key is rotated")
+		dueIn, _ := keyRotationDue(progress, secret, request, interval, now)
+		return true, dueIn, nil
+	}
+
+	if progress.Phase == slurmv1.KeyRotationPhasePublishing {
+		setKeyRotationPhase(progress, slurmv1.KeyRotationPhaseCompleted, now, "The munge key is rotated")
+	}
+
+	dueIn, due := keyRotationDue(progress, secret, request, interval, now)
+	if !due {
+		return false, dueIn, nil
+	}
+
+	nextKey, err := common.GenerateMungeKey()
+	if err != nil {
+		return false, 0, err
+	}
+	activationTime := time.Unix(now.Add(mungeKeyActivationDelay).Unix(), 0)
+	secret.Data[consts.SecretMungeKeyNextFileName] = nextKey
+	secret.Data[consts.SecretMungeKeyActivateAtFileName] = []byte(strconv.FormatInt(activationTime.Unix(), 10))
+
+	startKeyRotation(progress, request, now)
+	setKeyRotationPhase(progress, slurmv1.KeyRotationPhasePublishing, now,
+		fmt.Sprintf("The munge containers switch to the new key at %s", activationTime.UTC().Format(time.RFC3339)))
+	return true, activationTime.Sub(now), nil
+}
+
+// rotateJWTSigningKey advances the JWT signing key rotation and returns when it has to be advanced next, along with
+// the JWKS of the published keys.
+// It also sets the username claim of the JWTs issued by the operator to the Secret.
+func (r SlurmClusterReconciler) rotateJWTSigningKey(
+	ctx context.Context,
+	cluster *slurmv1.SlurmCluster,
+	progress *slurmv1.KeyRotationProgress,
+	usernameClaim string,
+	now time.Time,
+) (time.Duration, string, error) {
+	secret := &corev1.Secret{}
+	exists := true
+	if err := r.Get(
+		ctx,
+		types.NamespacedName{
+			Namespace: cluster.Namespace,
+			Name:      naming.BuildSecretJWTSigningKeysName(cluster.Name),
+		},
+		secret,
+	); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return 0, "", fmt.Errorf("getting JWT signing keys Secret: %w", err)
+		}
+		if !values.JWTSigningKeysRequired(cluster, usernameClaim) {
+			// The JWTs stay signed by the REST JWT key
+			return 0, "", nil
+		}
+
+		desired := rest.RenderJWTSigningKeysSecret(cluster.Namespace, cluster.Name)
+		if err = ctrl.SetControllerReference(cluster, &desired, r.Scheme); err != nil {
+			return 0, "", fmt.Errorf("setting controller reference: %w", err)
+		}
+		secret = &desired
+		exists = false
+	}
+
+	changed, requeueAfter, err := advanceJWTSigningKeyRotation(
+		secret,
+		progress,
+		cluster.Annotations[consts.AnnotationRotateJWTSigningKey],
+		cluster.Spec.KeyRotation.JWTSigningKeyInterval,
+		now,
+	)
+	if err != nil {
+		return 0, "", err
+	}
+	if secret.Annotations[consts.AnnotationJWTUsernameClaim] != usernameClaim {
+		if usernameClaim == "" {
+			delete(secret.Annotations, consts.AnnotationJWTUsernameClaim)
+		} else {
+			if secret.Annotations == nil {
+				secret.Annotations = map[string]string{}
+			}
+			secret.Annotations[consts.AnnotationJWTUsernameClaim] = usernameClaim
+		}
+		changed = true
+	}
+	switch {
+	case !exists:
+		if err = r.Create(ctx, secret); err != nil {
+			return 0, "", fmt.Errorf("creating JWT signing keys Secret: %w", err)
+		}
+	case changed:
+		if err = r.Update(ctx, secret); err != nil {
+			return 0, "", fmt.Errorf("updating JWT signing keys Secret: %w", err)
+		}
+	}
+
+	if len(secret.Data) == 0 {
+		return requeueAfter, "", nil
+	}
+	jwks, err := jwt.BuildJWKS(secret.Data)
+	if err != nil {
+		return 0, "", fmt.Errorf("building JWKS: %w", err)
+	}
+	return requeueAfter, string(jwks), nil
+}
+
+// advanceJWTSigningKeyRotation advances the JWT signing key rotation in the Secret.
+// Slurm accepts the JWTs signed by any key in the JWKS, so the new key is published first, then it signs the JWTs,
+// and at last the previous key is retired.
+func advanceJWTSigningKeyRotation(
+	secret *corev1.Secret,
+	progress *slurmv1.KeyRotationProgress,
+	request string,
+	interval *metav1.Duration,
+	now time.Time,
+) (changed bool, requeueAfter time.Duration, err error) {
+	activeKeyID := secret.Annotations[consts.AnnotationActiveSigningKeyID]
+	phaseTransitionTime := ptr.Deref(progress.PhaseTransitionTime, metav1.Time{}).Time
+
+	switch progress.Phase {
+	case slurmv1.KeyRotationPhasePublishing:
+		if _, found := secret.Data[progress.KeyID]; !found {
+			setKeyRotationPhase(progress, slurmv1.KeyRotationPhaseCompleted, now,
+				fmt.Sprintf("The new key %s is missing", progress.KeyID))
+			break
+		}
+
+		activationTime := phaseTransitionTime.Add(jwtSigningKeyPublishDuration)
+		if now.Before(activationTime) {
+			return false, activationTime.Sub(now), nil
+		}
+
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+		secret.Annotations[consts.AnnotationActiveSigningKeyID] = progress.KeyID
+		if activeKeyID == "" {
+			setKeyRotationPhase(progress, slurmv1.KeyRotationPhaseCompleted, now, "The JWTs are signed by the new key")
+			dueIn, _ := keyRotationDue(progress, secret, request, interval, now)
+			return true, dueIn, nil
+		}
+
+		retirementTime := now.Add(jwtSigningKeyRetirementDuration)
+		setKeyRotationPhase(progress, slurmv1.KeyRotationPhaseRetiring, now,
+			fmt.Sprintf("The JWTs are signed by the new key, the previous one is accepted until %s",
+				retirementTime.UTC().Format(time.RFC3339)))
+		return true, jwtSigningKeyRetirementDuration, nil
+
+	case slurmv1.KeyRotationPhaseRetiring:
+		retirementTime := phaseTransitionTime.Add(jwtSigningKeyRetirementDuration)
+		if now.Before(retirementTime) {
+			return false, retirementTime.Sub(now), nil
+		}
+		setKeyRotationPhase(progress, slurmv1.KeyRotationPhaseCompleted, now, "The JWT signing key is rotated")
+	}
+
+	// Only the active key is kept while no rotation is in progress
+	for keyID := range secret.Data {
+		if keyID != activeKeyID {
+			delete(secret.Data, keyID)
+			changed = true
+		}
+	}
+
+	dueIn, due := keyRotationDue(progress, secret, request, interval, now)
+	// The first key is generated as soon as the rotation is scheduled, rather than an interval after the Secret creation
+	scheduled := interval != nil && interval.Duration > 0
+	if !due && !(scheduled && len(secret.Data) == 0) {
+		return changed, dueIn, nil
+	}
+
+	keyData, err := jwt.GenerateRSASigningKey()
+	if err != nil {
+		return false, 0, fmt.Errorf("generating JWT signing key: %w", err)
+	}
+	key, err := jwt.ParseRSASigningKey(keyData)
+	if err != nil {
+		return false, 0, fmt.Errorf("parsing JWT signing key: %w", err)
+	}
+	keyID := jwt.RSAKeyID(&key.PublicKey)
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[keyID] = keyData
+
+	startKeyRotation(progress, request, now)
+	progress.KeyID = keyID
+	setKeyRotationPhase(progress, slurmv1.KeyRotationPhasePublishing, now,
+		fmt.Sprintf("The new key is published, the JWTs are signed by it from %s",
+			now.Add(jwtSigningKeyPublishDuration).UTC().Format(time.RFC3339)))
+	return true, jwtSigningKeyPublishDuration, nil
+}
+
+// keyRotationDue returns whether a new rotation of the key in the Secret is due, and when it's due otherwise.
+// A rotation is due if it's requested with a new value of the annotation, or if the interval has passed since the last
+// one. It's never due by schedule if the interval isn't set.
+func keyRotationDue(
+	progress *slurmv1.KeyRotationProgress,
+	secret *corev1.Secret,
+	request string,
+	interval *metav1.Duration,
+	now time.Time,
+) (time.Duration, bool) {
+	if request != "" && request != progress.ObservedRequest {
+		return 0, true
+	}
+	if interval == nil || interval.Duration <= 0 {
+		return 0, false
+	}
+
+	lastRotationTime := secret.CreationTimestamp.Time
+	if progress.LastRotationTime != nil {
+		lastRotationTime = progress.LastRotationTime.Time
+	}
+	dueIn := lastRotationTime.Add(interval.Duration).Sub(now)
+	return dueIn, dueIn <= 0
+}
+
+func startKeyRotation(progress *slurmv1.KeyRotationProgress, request string, now time.Time) {
+	progress.ObservedRequest = request
+	progress.LastRotationTime = ptr.To(metav1.NewTime(now))
+}
+
+func setKeyRotationPhase(progress *slurmv1.KeyRotationProgress, phase slurmv1.KeyRotationPhase, now time.Time, message string) {
+	if progress.Phase != phase {
+		progress.Phase = phase
+		progress.PhaseTransitionTime = ptr.To(metav1.NewTime(now))
+	}
+	progress.Message = message
+}
+
+// minRequeueAfter returns the shortest of the positive durations, or zero if there are none.
+func minRequeueAfter(durations ...time.Duration) time.Duration {
+	var res time.Duration
+	for _, d := range durations {
+		if d > 0 && (res == 0 || d < res) {
+			res = d
+		}
+	}
+	return res
+}
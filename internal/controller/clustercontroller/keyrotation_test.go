@@ -0,0 +1,184 @@
+package clustercontroller
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	slurmv1 "nebius.ai/slurm-operator/api/v1"
+	"nebius.ai/slurm-operator/internal/consts"
+)
+
+func TestAdvanceMungeKeyRotation(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newMungeKeySecret := func() *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-time.Hour))},
+			Data:       map[string][]byte{consts.SecretMungeKeyFileName: []byte("current")},
+		}
+	}
+
+	t.Run("not due", func(t *testing.T) {
+		secret := newMungeKeySecret()
+		progress := &slurmv1.KeyRotationProgress{}
+
+		changed, requeueAfter, err := advanceMungeKeyRotation(secret, progress, "", &metav1.Duration{Duration: 2 * time.Hour}, now)
+		require.NoError(t, err)
+		assert.False(t, changed)
+		assert.Equal(t, time.Hour, requeueAfter)
+		assert.Empty(t, progress.Phase)
+	})
+
+	t.Run("requested rotation", func(t *testing.T) {
+		secret := newMungeKeySecret()
+		progress := &slurmv1.KeyRotationProgress{}
+
+		changed, requeueAfter, err := advanceMungeKeyRotation(secret, progress, "1", nil, now)
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, mungeKeyActivationDelay, requeueAfter)
+		assert.Equal(t, slurmv1.KeyRotationPhasePublishing, progress.Phase)
+		assert.Equal(t, "1", progress.ObservedRequest)
+		assert.Equal(t, []byte("current"), secret.Data[consts.SecretMungeKeyFileName], "current key is kept until activation")
+		require.Contains(t, secret.Data, consts.SecretMungeKeyNextFileName)
+		assert.Equal(t, strconv.FormatInt(now.Add(mungeKeyActivationDelay).Unix(), 10),
+			string(secret.Data[consts.SecretMungeKeyActivateAtFileName]))
+		nextKey := secret.Data[consts.SecretMungeKeyNextFileName]
+
+		changed, requeueAfter, err = advanceMungeKeyRotation(secret, progress, "1", nil, now.Add(time.Minute))
+		require.NoError(t, err)
+		assert.False(t, changed)
+		assert.Equal(t, mungeKeyActivationDelay-time.Minute, requeueAfter)
+
+		changed, _, err = advanceMungeKeyRotation(secret, progress, "1", nil, now.Add(mungeKeyActivationDelay))
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, slurmv1.KeyRotationPhaseCompleted, progress.Phase)
+		assert.Equal(t, map[string][]byte{consts.SecretMungeKeyFileName: nextKey}, secret.Data)
+
+		changed, _, err = advanceMungeKeyRotation(secret, progress, "1", nil, now.Add(time.Hour))
+		require.NoError(t, err)
+		assert.False(t, changed, "observed request doesn't start a new rotation")
+
+		changed, _, err = advanceMungeKeyRotation(secret, progress, "", nil, now.Add(time.Hour))
+		require.NoError(t, err)
+		assert.False(t, changed, "removed request doesn't start a new rotation")
+	})
+
+	t.Run("scheduled rotation", func(t *testing.T) {
+		secret := newMungeKeySecret()
+		progress := &slurmv1.KeyRotationProgress{LastRotationTime: &metav1.Time{Time: now.Add(-25 * time.Hour)}}
+
+		changed, _, err := advanceMungeKeyRotation(secret, progress, "", &metav1.Duration{Duration: 24 * time.Hour}, now)
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, slurmv1.KeyRotationPhasePublishing, progress.Phase)
+		assert.Equal(t, now, progress.LastRotationTime.Time)
+	})
+
+	t.Run("invalid activation time", func(t *testing.T) {
+		secret := newMungeKeySecret()
+		secret.Data[consts.SecretMungeKeyNextFileName] = []byte("next")
+		secret.Data[consts.SecretMungeKeyActivateAtFileName] = []byte("soon")
+
+		_, _, err := advanceMungeKeyRotation(secret, &slurmv1.KeyRotationProgress{}, "", nil, now)
+		assert.Error(t, err)
+	})
+}
+
+func TestAdvanceJWTSigningKeyRotation(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	secret := &corev1.Secret{Data: map[string][]byte{}}
+	progress := &slurmv1.KeyRotationProgress{}
+
+	// No key is generated until the rotation is requested or scheduled
+	changed, _, err := advanceJWTSigningKeyRotation(secret, progress, "", nil, now)
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Empty(t, secret.Data)
+	assert.Empty(t, progress.Phase)
+
+	// The first key is generated and activated right after it's published
+	changed, requeueAfter, err := advanceJWTSigningKeyRotation(secret, progress, "1", nil, now)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, jwtSigningKeyPublishDuration, requeueAfter)
+	assert.Equal(t, slurmv1.KeyRotationPhasePublishing, progress.Phase)
+	require.Contains(t, secret.Data, progress.KeyID)
+	assert.Empty(t, secret.Annotations[consts.AnnotationActiveSigningKeyID])
+	firstKeyID := progress.KeyID
+
+	now = now.Add(jwtSigningKeyPublishDuration)
+	changed, _, err = advanceJWTSigningKeyRotation(secret, progress, "1", nil, now)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, slurmv1.KeyRotationPhaseCompleted, progress.Phase)
+	assert.Equal(t, firstKeyID, secret.Annotations[consts.AnnotationActiveSigningKeyID])
+
+	// The rotation publishes the new key along with the active one
+	now = now.Add(time.Hour)
+	changed, _, err = advanceJWTSigningKeyRotation(secret, progress, "2", nil, now)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, slurmv1.KeyRotationPhasePublishing, progress.Phase)
+	assert.NotEqual(t, firstKeyID, progress.KeyID)
+	assert.Len(t, secret.Data, 2)
+	assert.Equal(t, firstKeyID, secret.Annotations[consts.AnnotationActiveSigningKeyID])
+	secondKeyID := progress.KeyID
+
+	changed, requeueAfter, err = advanceJWTSigningKeyRotation(secret, progress, "2", nil, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, jwtSigningKeyPublishDuration-time.Minute, requeueAfter)
+
+	// The new key signs the JWTs, while the previous one is still accepted
+	now = now.Add(jwtSigningKeyPublishDuration)
+	changed, requeueAfter, err = advanceJWTSigningKeyRotation(secret, progress, "2", nil, now)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, jwtSigningKeyRetirementDuration, requeueAfter)
+	assert.Equal(t, slurmv1.KeyRotationPhaseRetiring, progress.Phase)
+	assert.Equal(t, secondKeyID, secret.Annotations[consts.AnnotationActiveSigningKeyID])
+	assert.Len(t, secret.Data, 2)
+
+	// The previous key is retired
+	now = now.Add(jwtSigningKeyRetirementDuration)
+	changed, _, err = advanceJWTSigningKeyRotation(secret, progress, "2", nil, now)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, slurmv1.KeyRotationPhaseCompleted, progress.Phase)
+	assert.Equal(t, []string{secondKeyID}, slices.Collect(maps.Keys(secret.Data)))
+
+	changed, _, err = advanceJWTSigningKeyRotation(secret, progress, "2", nil, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.False(t, changed)
+}
+
+func TestAdvanceJWTSigningKeyRotation_Scheduled(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(now.Add(-time.Hour))},
+		Data:       map[string][]byte{},
+	}
+	progress := &slurmv1.KeyRotationProgress{}
+
+	// The first key is generated as soon as the rotation is scheduled
+	changed, _, err := advanceJWTSigningKeyRotation(secret, progress, "", &metav1.Duration{Duration: 24 * time.Hour}, now)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, slurmv1.KeyRotationPhasePublishing, progress.Phase)
+	assert.Contains(t, secret.Data, progress.KeyID)
+}
+
+func TestMinRequeueAfter(t *testing.T) {
+	assert.Equal(t, time.Duration(0), minRequeueAfter())
+	assert.Equal(t, time.Duration(0), minRequeueAfter(0, 0))
+	assert.Equal(t, time.Minute, minRequeueAfter(0, time.Hour, time.Minute))
+}
@@ -21,6 +21,7 @@ import (
 	"nebius.ai/slurm-operator/internal/render/common"
 	"nebius.ai/slurm-operator/internal/render/login"
 	"nebius.ai/slurm-operator/internal/utils"
+	"nebius.ai/slurm-operator/internal/utils/resourcegetter"
 	"nebius.ai/slurm-operator/internal/values"
 )
 
@@ -379,18 +380,13 @@ func (r SlurmClusterReconciler) getLoginStatefulSetDependencies(
 	}
 	res = append(res, rootPublicKeys)
 
-	mungeKeySecret := &corev1.Secret{}
-	if err := r.Get(
-		ctx,
-		types.NamespacedName{
-			Namespace: clusterValues.Namespace,
-			Name:      naming.BuildSecretMungeKeyName(clusterValues.Name),
-		},
-		mungeKeySecret,
-	); err != nil {
-		return []metav1.Object{}, err
+	if !common.MungeContainerReloadsKey(&clusterValues.NodeLogin.ContainerMunge) {
+		mungeKey, err := resourcegetter.GetMungeKeyDependency(ctx, r.Client, clusterValues.NamespacedName)
+		if err != nil {
+			return []metav1.Object{}, err
+		}
+		res = append(res, mungeKey)
 	}
-	res = append(res, mungeKeySecret)
 
 	sshConfigsConfigMap := &corev1.ConfigMap{}
 	if err := r.Get(
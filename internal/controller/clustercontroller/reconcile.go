@@ -50,6 +50,7 @@ import (
 //+kubebuilder:rbac:groups=slurm.nebius.ai,resources=slurmclusters/finalizers,verbs=update
 //+kubebuilder:rbac:groups=slurm.nebius.ai,resources=nodesets,verbs=get;list;watch
 //+kubebuilder:rbac:groups=slurm.nebius.ai,resources=nodesets/status,verbs=get
+//+kubebuilder:rbac:groups=slurm.nebius.ai,resources=activechecks,verbs=get;list;watch
 //+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
@@ -264,6 +265,16 @@ func (r *SlurmClusterReconciler) reconcile(ctx context.Context, cluster *slurmv1
 		}
 	}
 
+	keyRotationRes, err := r.ReconcileKeyRotation(ctx, cluster, clusterValues)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	externalJWTRes, err := r.ReconcileExternalJWT(ctx, cluster, clusterValues)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if err := r.ReconcileCommon(ctx, cluster, clusterValues); err != nil {
 		return ctrl.Result{}, err
 	}
@@ -493,6 +504,7 @@ func (r *SlurmClusterReconciler) reconcile(ctx context.Context, cluster *slurmv1
 	if populateJailRes.RequeueAfter > 0 && res.RequeueAfter == 0 {
 		res.RequeueAfter = populateJailRes.RequeueAfter
 	}
+	res.RequeueAfter = minRequeueAfter(res.RequeueAfter, keyRotationRes.RequeueAfter, externalJWTRes.RequeueAfter)
 
 	return res, err
 }
@@ -577,6 +589,7 @@ const (
 	accountingExternalDBPasswordSecretKeyField   = ".spec.slurmNodes.accounting.externalDB.passwordSecretKeyRef.Name"
 	accountingExternalDBTLSServerCASecretField   = ".spec.slurmNodes.accounting.externalDB.tls.serverCASecretRef"
 	accountingExternalDBTLSClientCertSecretField = ".spec.slurmNodes.accounting.externalDB.tls.clientCertSecretRef"
+	restExternalJWTJWKSSecretField               = ".spec.slurmNodes.rest.externalJWT.jwksSecretRef.name"
 )
 
 func (r *SlurmClusterReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurrency int, cacheSyncTimeout time.Duration) error {
@@ -593,7 +606,8 @@ func (r *SlurmClusterReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurren
 		Named("cluster").
 		For(
 			&slurmv1.SlurmCluster{},
-			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+			// Annotations request the key rotations
+			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{})),
 		)
 
 	controllerBuilder.Watches(
@@ -620,6 +634,13 @@ func (r *SlurmClusterReconciler) SetupWithManager(mgr ctrl.Manager, maxConcurren
 		builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
 	)
 
+	// ActiveChecks reading their results from the Slurm job comments require them to be stored in accounting
+	controllerBuilder.Watches(
+		&slurmv1alpha1.ActiveCheck{},
+		handler.EnqueueRequestsFromMapFunc(r.findObjectsForActiveCheck),
+		builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+	)
+
 	resourceChecks := r.createResourceChecks(saPredicate)
 
 	for _, resourceCheck := range resourceChecks {
@@ -669,6 +690,12 @@ func (r *SlurmClusterReconciler) setupSecretIndexer(mgr ctrl.Manager) error {
 		accountingExternalDBTLSClientCertSecretField: func(sc *slurmv1.SlurmCluster) string {
 			return sc.Spec.SlurmNodes.Accounting.ExternalDB.TLS.ClientCertSecretRef
 		},
+		restExternalJWTJWKSSecretField: func(sc *slurmv1.SlurmCluster) string {
+			if sc.Spec.SlurmNodes.Rest.ExternalJWT == nil || sc.Spec.SlurmNodes.Rest.ExternalJWT.JWKSSecretRef == nil {
+				return ""
+			}
+			return sc.Spec.SlurmNodes.Rest.ExternalJWT.JWKSSecretRef.Name
+		},
 	}
 
 	for field, extractFunc := range indexers {
@@ -768,6 +795,7 @@ func (r *SlurmClusterReconciler) findObjectsForSecret(
 		accountingExternalDBPasswordSecretKeyField,
 		accountingExternalDBTLSServerCASecretField,
 		accountingExternalDBTLSClientCertSecretField,
+		restExternalJWTJWKSSecretField,
 	}
 
 	var requests []reconcile.Request
@@ -823,6 +851,23 @@ func (r *SlurmClusterReconciler) findObjectsForNodeSet(
 	return requests
 }
 
+func (r *SlurmClusterReconciler) findObjectsForActiveCheck(
+	_ context.Context,
+	activecheck client.Object,
+) []reconcile.Request {
+	activeCheck, ok := activecheck.(*slurmv1alpha1.ActiveCheck)
+	if !ok {
+		return nil
+	}
+
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{
+			Namespace: activeCheck.Namespace,
+			Name:      activeCheck.Spec.SlurmClusterRefName,
+		},
+	}}
+}
+
 func (r *SlurmClusterReconciler) createResourceChecks(saPredicate predicate.Funcs) []controllercommon.ResourceCheck {
 	return []controllercommon.ResourceCheck{
 		{
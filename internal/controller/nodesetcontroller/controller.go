@@ -43,6 +43,7 @@ import (
 	"nebius.ai/slurm-operator/internal/controller/reconciler"
 	"nebius.ai/slurm-operator/internal/controllerconfig"
 	"nebius.ai/slurm-operator/internal/logfield"
+	"nebius.ai/slurm-operator/internal/slurmapi"
 )
 
 // +kubebuilder:rbac:groups=slurm.nebius.ai,resources=nodesets,verbs=get;list;watch;create;update;patch;delete
@@ -74,9 +75,18 @@ type NodeSetReconciler struct {
 	NodeSetPowerState   *reconciler.NodeSetPowerStateReconciler
 	Role                *reconciler.RoleReconciler
 	RoleBinding         *reconciler.RoleBindingReconciler
+
+	// SlurmAPIClients provides the Slurm states of ephemeral nodes for the power-state metrics.
+	// Slurm states are left empty if it's nil or has no client for the cluster.
+	SlurmAPIClients *slurmapi.ClientSet
 }
 
-func NewNodeSetReconciler(client client.Client, scheme *runtime.Scheme, recorder record.EventRecorder) *NodeSetReconciler {
+func NewNodeSetReconciler(
+	client client.Client,
+	scheme *runtime.Scheme,
+	recorder record.EventRecorder,
+	slurmAPIClients *slurmapi.ClientSet,
+) *NodeSetReconciler {
 	r := reconciler.NewReconciler(client, scheme, recorder)
 	return &NodeSetReconciler{
 		Reconciler:          r,
@@ -88,6 +98,7 @@ func NewNodeSetReconciler(client client.Client, scheme *runtime.Scheme, recorder
 		NodeSetPowerState:   reconciler.NewNodeSetPowerStateReconciler(r),
 		Role:                reconciler.NewRoleReconciler(r),
 		RoleBinding:         reconciler.NewRoleBindingReconciler(r),
+		SlurmAPIClients:     slurmAPIClients,
 	}
 }
 
@@ -125,6 +136,12 @@ func (r *NodeSetReconciler) SetupWithManager(mgr ctrl.Manager, name string, maxC
 		builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
 	)
 
+	controllerBuilder.Watches(
+		&corev1.Pod{},
+		handler.EnqueueRequestsFromMapFunc(r.findEphemeralNodeSetForPod),
+		builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+	)
+
 	resourceChecks := r.createResourceChecks(controllercommon.CreateServiceAccountPredicate())
 	for _, resourceCheck := range resourceChecks {
 		if resourceCheck.Check {
@@ -175,6 +192,7 @@ func (r *NodeSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.V(1).Info("Resource not found. Ignoring since object must be deleted")
+			deleteNodePowerMetrics(req.Namespace, req.Name)
 			return ctrl.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
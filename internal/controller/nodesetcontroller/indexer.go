@@ -11,6 +11,7 @@ import (
 
 	slurmv1 "nebius.ai/slurm-operator/api/v1"
 	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/consts"
 )
 
 const (
@@ -143,3 +144,29 @@ func (r *NodeSetReconciler) findNodeSetForPowerState(
 		},
 	}
 }
+
+// findEphemeralNodeSetForPod maps a worker pod change to its NodeSet, so that the node states of NodeSetPowerState
+// follow the pods. Pods of NodeSets without ephemeral nodes are ignored.
+func (r *NodeSetReconciler) findEphemeralNodeSetForPod(
+	ctx context.Context,
+	pod client.Object,
+) []reconcile.Request {
+	nodeSetName := pod.GetLabels()[consts.LabelNodeSetKey]
+	if nodeSetName == "" {
+		return nil
+	}
+
+	key := types.NamespacedName{
+		Namespace: pod.GetNamespace(),
+		Name:      nodeSetName,
+	}
+	nodeSet := &slurmv1alpha1.NodeSet{}
+	if err := r.Get(ctx, key, nodeSet); err != nil {
+		return nil
+	}
+	if nodeSet.Spec.EphemeralNodes == nil || !*nodeSet.Spec.EphemeralNodes {
+		return nil
+	}
+
+	return []reconcile.Request{{NamespacedName: key}}
+}
@@ -0,0 +1,203 @@
+package nodesetcontroller
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/slurmapi"
+)
+
+const (
+	// resumeStagePodRunning is the stage at which the worker pod of a resumed node runs.
+	resumeStagePodRunning = "pod_running"
+	// resumeStageSlurmReady is the stage at which Slurm reports a resumed node as IDLE, ALLOCATED or MIXED.
+	resumeStageSlurmReady = "slurm_idle"
+
+	// slurmNodeCacheRefreshInterval is how often the Slurm states of ephemeral nodes are refreshed.
+	slurmNodeCacheRefreshInterval = 15 * time.Second
+)
+
+// Node ordinal is deliberately not a label: the series are meant to tune SuspendTime and ResumeTimeout per NodeSet.
+var (
+	powerActiveNodes = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "soperator_nodeset_power_active_nodes",
+		Help: "Number of powered on nodes of an ephemeral NodeSet, including warm nodes",
+	}, []string{"namespace", "nodeset"})
+
+	powerResumeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "soperator_nodeset_power_resume_duration_seconds",
+		Help:    "Time from powering a node on until its pod runs (pod_running) or Slurm can use it (slurm_idle)",
+		Buckets: prometheus.ExponentialBuckets(5, 2, 10),
+	}, []string{"namespace", "nodeset", "stage"})
+
+	powerSuspendsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "soperator_nodeset_power_suspends_total",
+		Help: "Nodes powered off after they had been running",
+	}, []string{"namespace", "nodeset"})
+
+	powerFailedResumesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "soperator_nodeset_power_failed_resumes_total",
+		Help: "Nodes whose pod failed, or which were powered off before their pod ran",
+	}, []string{"namespace", "nodeset"})
+
+	powerIdleBeforeSuspendDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "soperator_nodeset_power_idle_before_suspend_seconds",
+		Help:    "Time a node sat idle in Slurm before it was powered off",
+		Buckets: prometheus.ExponentialBuckets(30, 2, 12),
+	}, []string{"namespace", "nodeset"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		powerActiveNodes,
+		powerResumeDuration,
+		powerSuspendsTotal,
+		powerFailedResumesTotal,
+		powerIdleBeforeSuspendDuration,
+	)
+}
+
+// nodePowerTransitionType is the kind of change of a node between two reconciliations.
+type nodePowerTransitionType int
+
+const (
+	nodePowerTransitionPodRunning nodePowerTransitionType = iota
+	nodePowerTransitionSlurmReady
+	nodePowerTransitionResumeFailed
+	nodePowerTransitionSuspended
+)
+
+// nodePowerTransition is a change of a node between two reconciliations.
+type nodePowerTransition struct {
+	Type    nodePowerTransitionType
+	Ordinal int32
+	// Duration is the time since the node was powered on for resume stages,
+	// and the time the node sat idle in Slurm for suspends. Zero if unknown.
+	Duration time.Duration
+	// Message explains why a resume failed.
+	Message string
+}
+
+// diffNodeStates returns the transitions of the nodes between the previous and the current node states, ordered by
+// ordinal. slurmNodes holds the Slurm nodes by ordinal and is used to find out how long suspended nodes were idle.
+func diffNodeStates(
+	previous, current map[string]slurmv1alpha1.NodePowerStateInfo,
+	slurmNodes map[int32]slurmapi.Node,
+	now time.Time,
+) []nodePowerTransition {
+	var res []nodePowerTransition
+
+	for key, state := range current {
+		if !isPoweredOn(state) {
+			continue
+		}
+		ordinal, err := strconv.ParseInt(key, 10, 32)
+		if err != nil {
+			continue
+		}
+		prev, found := previous[key]
+		if found && !isPoweredOn(prev) {
+			found = false
+		}
+		sinceActivation := now.Sub(state.ActivationTime.Time)
+
+		if state.Phase == slurmv1alpha1.NodePowerPhaseRunning && (!found || prev.Phase != slurmv1alpha1.NodePowerPhaseRunning) {
+			res = append(res, nodePowerTransition{
+				Type:     nodePowerTransitionPodRunning,
+				Ordinal:  int32(ordinal),
+				Duration: sinceActivation,
+			})
+		}
+		if isSlurmReady(state.SlurmState) && (!found || isSlurmPoweringUp(prev.SlurmState)) {
+			res = append(res, nodePowerTransition{
+				Type:     nodePowerTransitionSlurmReady,
+				Ordinal:  int32(ordinal),
+				Duration: sinceActivation,
+			})
+		}
+		if state.Phase == slurmv1alpha1.NodePowerPhaseFailed && (!found || prev.Phase != slurmv1alpha1.NodePowerPhaseFailed) {
+			res = append(res, nodePowerTransition{
+				Type:    nodePowerTransitionResumeFailed,
+				Ordinal: int32(ordinal),
+				Message: state.Message,
+			})
+		}
+	}
+
+	for key, prev := range previous {
+		if !isPoweredOn(prev) {
+			continue
+		}
+		if state, found := current[key]; found && isPoweredOn(state) {
+			continue
+		}
+		ordinal, err := strconv.ParseInt(key, 10, 32)
+		if err != nil {
+			continue
+		}
+
+		switch prev.Phase {
+		case slurmv1alpha1.NodePowerPhaseRunning:
+			transition := nodePowerTransition{
+				Type:    nodePowerTransitionSuspended,
+				Ordinal: int32(ordinal),
+			}
+			if node, found := slurmNodes[int32(ordinal)]; found && !node.LastBusy.IsZero() && node.LastBusy.Before(now) {
+				transition.Duration = now.Sub(node.LastBusy)
+			}
+			res = append(res, transition)
+		case slurmv1alpha1.NodePowerPhaseFailed:
+			// Already reported when the pod failed.
+		default:
+			res = append(res, nodePowerTransition{
+				Type:    nodePowerTransitionResumeFailed,
+				Ordinal: int32(ordinal),
+				Message: "Node was powered off before its pod ran: " + prev.Message,
+			})
+		}
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Ordinal != res[j].Ordinal {
+			return res[i].Ordinal < res[j].Ordinal
+		}
+		return res[i].Type < res[j].Type
+	})
+	return res
+}
+
+// observeNodePowerTransitions records the transitions of the NodeSet's nodes in the metrics.
+func observeNodePowerTransitions(namespace, nodeSetName string, activeCount int32, transitions []nodePowerTransition) {
+	powerActiveNodes.WithLabelValues(namespace, nodeSetName).Set(float64(activeCount))
+
+	for _, transition := range transitions {
+		switch transition.Type {
+		case nodePowerTransitionPodRunning:
+			powerResumeDuration.WithLabelValues(namespace, nodeSetName, resumeStagePodRunning).Observe(transition.Duration.Seconds())
+		case nodePowerTransitionSlurmReady:
+			powerResumeDuration.WithLabelValues(namespace, nodeSetName, resumeStageSlurmReady).Observe(transition.Duration.Seconds())
+		case nodePowerTransitionResumeFailed:
+			powerFailedResumesTotal.WithLabelValues(namespace, nodeSetName).Inc()
+		case nodePowerTransitionSuspended:
+			powerSuspendsTotal.WithLabelValues(namespace, nodeSetName).Inc()
+			if transition.Duration > 0 {
+				powerIdleBeforeSuspendDuration.WithLabelValues(namespace, nodeSetName).Observe(transition.Duration.Seconds())
+			}
+		}
+	}
+}
+
+// deleteNodePowerMetrics removes the series of a deleted NodeSet.
+func deleteNodePowerMetrics(namespace, nodeSetName string) {
+	labels := prometheus.Labels{"namespace": namespace, "nodeset": nodeSetName}
+	powerActiveNodes.DeletePartialMatch(labels)
+	powerResumeDuration.DeletePartialMatch(labels)
+	powerSuspendsTotal.DeletePartialMatch(labels)
+	powerFailedResumesTotal.DeletePartialMatch(labels)
+	powerIdleBeforeSuspendDuration.DeletePartialMatch(labels)
+}
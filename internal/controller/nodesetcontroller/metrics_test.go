@@ -0,0 +1,63 @@
+package nodesetcontroller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/slurmapi"
+)
+
+func TestDiffNodeStates(t *testing.T) {
+	now := time.Date(2026, time.October, 16, 12, 0, 0, 0, time.UTC)
+	activated := metav1.NewTime(now.Add(-3 * time.Minute))
+
+	previous := map[string]slurmv1alpha1.NodePowerStateInfo{
+		// Pod starts running and Slurm reports the node as idle.
+		"0": {Phase: slurmv1alpha1.NodePowerPhasePending, SlurmState: "POWER_UP", ActivationTime: &activated},
+		// Pod fails.
+		"1": {Phase: slurmv1alpha1.NodePowerPhasePending, ActivationTime: &activated},
+		// Suspended after running.
+		"2": {Phase: slurmv1alpha1.NodePowerPhaseRunning, SlurmState: "IDLE", ActivationTime: &activated},
+		// Powered off before the pod ran.
+		"3": {Phase: slurmv1alpha1.NodePowerPhasePending, Message: "Pod worker-3 is not created yet", ActivationTime: &activated},
+		// Already failed, powered off.
+		"4": {Phase: slurmv1alpha1.NodePowerPhaseFailed, ActivationTime: &activated},
+		// Dropped by maxNodes and not started.
+		"5": {Phase: slurmv1alpha1.NodePowerPhasePending, Reason: slurmv1alpha1.NodePowerReasonMaxNodesReached},
+		// Back to idle after a job.
+		"6": {Phase: slurmv1alpha1.NodePowerPhaseRunning, SlurmState: "ALLOCATED", ActivationTime: &activated},
+	}
+	current := map[string]slurmv1alpha1.NodePowerStateInfo{
+		"0": {Phase: slurmv1alpha1.NodePowerPhaseRunning, SlurmState: "IDLE", ActivationTime: &activated},
+		"1": {Phase: slurmv1alpha1.NodePowerPhaseFailed, Message: "Evicted", ActivationTime: &activated},
+		"6": {Phase: slurmv1alpha1.NodePowerPhaseRunning, SlurmState: "IDLE", ActivationTime: &activated},
+	}
+	slurmNodes := map[int32]slurmapi.Node{
+		2: {Name: "worker-2", LastBusy: now.Add(-10 * time.Minute)},
+	}
+
+	assert.Equal(t, []nodePowerTransition{
+		{Type: nodePowerTransitionPodRunning, Ordinal: 0, Duration: 3 * time.Minute},
+		{Type: nodePowerTransitionSlurmReady, Ordinal: 0, Duration: 3 * time.Minute},
+		{Type: nodePowerTransitionResumeFailed, Ordinal: 1, Message: "Evicted"},
+		{Type: nodePowerTransitionSuspended, Ordinal: 2, Duration: 10 * time.Minute},
+		{Type: nodePowerTransitionResumeFailed, Ordinal: 3, Message: "Node was powered off before its pod ran: Pod worker-3 is not created yet"},
+	}, diffNodeStates(previous, current, slurmNodes, now))
+}
+
+func TestDiffNodeStatesNewRunningNode(t *testing.T) {
+	now := time.Date(2026, time.October, 16, 12, 0, 0, 0, time.UTC)
+	activated := metav1.NewTime(now.Add(-time.Minute))
+
+	current := map[string]slurmv1alpha1.NodePowerStateInfo{
+		"7": {Phase: slurmv1alpha1.NodePowerPhaseRunning, ActivationTime: &activated},
+	}
+
+	assert.Equal(t, []nodePowerTransition{
+		{Type: nodePowerTransitionPodRunning, Ordinal: 7, Duration: time.Minute},
+	}, diffNodeStates(nil, current, nil, now))
+}
@@ -0,0 +1,167 @@
+package nodesetcontroller
+
+import (
+	"fmt"
+	"strconv"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/slurmapi"
+)
+
+// buildNodeStates returns the state of each powered on and dropped node, keyed by ordinal.
+// slurmNodes holds the Slurm nodes by ordinal. It's nil if Slurm isn't reachable.
+// The transition time of a node is kept from the previous state unless its phase, reason or Slurm state changed,
+// and the activation time is kept for as long as the node stays powered on.
+func buildNodeStates(
+	statefulSetName string,
+	activeNodes, droppedNodes []int32,
+	pods []corev1.Pod,
+	slurmNodes map[int32]slurmapi.Node,
+	previous map[string]slurmv1alpha1.NodePowerStateInfo,
+	now metav1.Time,
+) map[string]slurmv1alpha1.NodePowerStateInfo {
+	if len(activeNodes) == 0 && len(droppedNodes) == 0 {
+		return nil
+	}
+
+	podsByName := make(map[string]*corev1.Pod, len(pods))
+	for i := range pods {
+		podsByName[pods[i].Name] = &pods[i]
+	}
+
+	res := make(map[string]slurmv1alpha1.NodePowerStateInfo, len(activeNodes)+len(droppedNodes))
+	for _, ordinal := range activeNodes {
+		podName := fmt.Sprintf("%s-%d", statefulSetName, ordinal)
+
+		state := slurmv1alpha1.NodePowerStateInfo{
+			Phase:   slurmv1alpha1.NodePowerPhasePending,
+			PodName: podName,
+			Reason:  slurmv1alpha1.NodePowerReasonPodNotCreated,
+			Message: fmt.Sprintf("Pod %s is not created yet", podName),
+		}
+		if pod, found := podsByName[podName]; found {
+			state = podPowerState(pod)
+		}
+		if node, found := slurmNodes[ordinal]; found {
+			state.SlurmState = slurmNodeState(node)
+		}
+
+		key := strconv.Itoa(int(ordinal))
+		state.ActivationTime = now.DeepCopy()
+		if prev, found := previous[key]; found && isPoweredOn(prev) {
+			state.ActivationTime = prev.ActivationTime
+		}
+		res[key] = state
+	}
+	for _, ordinal := range droppedNodes {
+		res[strconv.Itoa(int(ordinal))] = slurmv1alpha1.NodePowerStateInfo{
+			Phase:   slurmv1alpha1.NodePowerPhasePending,
+			PodName: fmt.Sprintf("%s-%d", statefulSetName, ordinal),
+			Reason:  slurmv1alpha1.NodePowerReasonMaxNodesReached,
+			Message: "Node is not started because the power policy's maxNodes is reached",
+		}
+	}
+
+	for key, state := range res {
+		prev, found := previous[key]
+		if found && prev.Phase == state.Phase && prev.Reason == state.Reason && prev.SlurmState == state.SlurmState {
+			state.LastTransitionTime = prev.LastTransitionTime
+		} else {
+			state.LastTransitionTime = now
+		}
+		res[key] = state
+	}
+
+	return res
+}
+
+// isPoweredOn reports whether the node was powered on, as opposed to being dropped because of the policy's maxNodes.
+func isPoweredOn(state slurmv1alpha1.NodePowerStateInfo) bool {
+	return state.ActivationTime != nil && state.Reason != slurmv1alpha1.NodePowerReasonMaxNodesReached
+}
+
+// podPowerState describes the worker pod of a node.
+// The reason and message explain why the pod isn't running yet: an unscheduled pod, a waiting container or a failed pod.
+func podPowerState(pod *corev1.Pod) slurmv1alpha1.NodePowerStateInfo {
+	res := slurmv1alpha1.NodePowerStateInfo{
+		Phase:   slurmv1alpha1.NodePowerPhase(pod.Status.Phase),
+		PodName: pod.Name,
+	}
+	if res.Phase == "" {
+		res.Phase = slurmv1alpha1.NodePowerPhasePending
+	}
+
+	if pod.Status.Phase == corev1.PodFailed {
+		res.Reason = pod.Status.Reason
+		res.Message = pod.Status.Message
+		return res
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse {
+			res.Reason = condition.Reason
+			res.Message = condition.Message
+			return res
+		}
+	}
+
+	containerStatuses := append(
+		append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...),
+		pod.Status.ContainerStatuses...,
+	)
+	for _, status := range containerStatuses {
+		waiting := status.State.Waiting
+		if waiting == nil {
+			continue
+		}
+		switch waiting.Reason {
+		case "", "PodInitializing", "ContainerCreating":
+			continue
+		}
+		res.Reason = waiting.Reason
+		res.Message = fmt.Sprintf("Container %s: %s", status.Name, waiting.Message)
+		if waiting.Message == "" {
+			res.Message = fmt.Sprintf("Container %s is waiting: %s", status.Name, waiting.Reason)
+		}
+		return res
+	}
+
+	return res
+}
+
+// slurmNodeState describes the state of a Slurm node with one of the values documented for
+// NodePowerStateInfo.SlurmState. Power saving and drain flags take precedence over the base state.
+func slurmNodeState(node slurmapi.Node) string {
+	switch {
+	case node.IsPowerUpState() || node.IsPoweringUpState():
+		return string(api.V0044NodeStatePOWERUP)
+	case node.IsPowerDownState() || node.IsPoweringDownState() || node.IsPoweredDownState():
+		return string(api.V0044NodeStatePOWERDOWN)
+	case node.IsDrainState():
+		return string(api.V0044NodeStateDRAIN)
+	}
+	return string(node.BaseState())
+}
+
+// isSlurmReady reports whether Slurm can run jobs on a node in the given state.
+func isSlurmReady(slurmState string) bool {
+	switch api.V0044NodeState(slurmState) {
+	case api.V0044NodeStateIDLE, api.V0044NodeStateALLOCATED, api.V0044NodeStateMIXED:
+		return true
+	}
+	return false
+}
+
+// isSlurmPoweringUp reports whether a node in the given state may still be on its way to become usable after a resume.
+// An empty state means the node hasn't been seen in Slurm yet.
+func isSlurmPoweringUp(slurmState string) bool {
+	switch api.V0044NodeState(slurmState) {
+	case "", api.V0044NodeStatePOWERUP, api.V0044NodeStatePOWERDOWN:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package nodesetcontroller
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/slurmapi"
+)
+
+func TestBuildNodeStates(t *testing.T) {
+	now := metav1.NewTime(time.Date(2026, time.October, 16, 12, 0, 0, 0, time.UTC))
+	before := metav1.NewTime(now.Add(-time.Minute))
+
+	pods := []corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-0"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+				Conditions: []corev1.PodCondition{{
+					Type:    corev1.PodScheduled,
+					Status:  corev1.ConditionFalse,
+					Reason:  corev1.PodReasonUnschedulable,
+					Message: "0/4 nodes are available: 4 Insufficient nvidia.com/gpu.",
+				}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-2"},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name: "slurmd",
+					State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+						Reason:  "ImagePullBackOff",
+						Message: "Back-off pulling image",
+					}},
+				}},
+			},
+		},
+	}
+	slurmNodes := map[int32]slurmapi.Node{
+		0: {Name: "worker-0", States: map[api.V0044NodeState]struct{}{api.V0044NodeStateIDLE: {}}},
+		2: {Name: "worker-2", States: map[api.V0044NodeState]struct{}{
+			api.V0044NodeStateIDLE:       {},
+			api.V0044NodeStatePOWERINGUP: {},
+		}},
+	}
+	previous := map[string]slurmv1alpha1.NodePowerStateInfo{
+		"0": {
+			Phase:              slurmv1alpha1.NodePowerPhaseRunning,
+			PodName:            "worker-0",
+			SlurmState:         "IDLE",
+			ActivationTime:     &before,
+			LastTransitionTime: before,
+		},
+		"1": {Phase: slurmv1alpha1.NodePowerPhasePending, PodName: "worker-1", LastTransitionTime: before},
+	}
+
+	got := buildNodeStates("worker", []int32{0, 1, 2, 3}, []int32{5}, pods, slurmNodes, previous, now)
+
+	assert.Equal(t, map[string]slurmv1alpha1.NodePowerStateInfo{
+		"0": {
+			Phase:              slurmv1alpha1.NodePowerPhaseRunning,
+			PodName:            "worker-0",
+			SlurmState:         "IDLE",
+			ActivationTime:     &before,
+			LastTransitionTime: before,
+		},
+		"1": {
+			Phase:              slurmv1alpha1.NodePowerPhasePending,
+			PodName:            "worker-1",
+			Reason:             corev1.PodReasonUnschedulable,
+			Message:            "0/4 nodes are available: 4 Insufficient nvidia.com/gpu.",
+			ActivationTime:     &now,
+			LastTransitionTime: now,
+		},
+		"2": {
+			Phase:              slurmv1alpha1.NodePowerPhasePending,
+			PodName:            "worker-2",
+			Reason:             "ImagePullBackOff",
+			Message:            "Container slurmd: Back-off pulling image",
+			SlurmState:         "POWER_UP",
+			ActivationTime:     &now,
+			LastTransitionTime: now,
+		},
+		"3": {
+			Phase:              slurmv1alpha1.NodePowerPhasePending,
+			PodName:            "worker-3",
+			Reason:             slurmv1alpha1.NodePowerReasonPodNotCreated,
+			Message:            "Pod worker-3 is not created yet",
+			ActivationTime:     &now,
+			LastTransitionTime: now,
+		},
+		"5": {
+			Phase:              slurmv1alpha1.NodePowerPhasePending,
+			PodName:            "worker-5",
+			Reason:             slurmv1alpha1.NodePowerReasonMaxNodesReached,
+			Message:            "Node is not started because the power policy's maxNodes is reached",
+			LastTransitionTime: now,
+		},
+	}, got)
+
+	assert.Nil(t, buildNodeStates("worker", nil, nil, pods, slurmNodes, previous, now))
+}
+
+func TestPodPowerStateFailedPod(t *testing.T) {
+	got := podPowerState(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-4"},
+		Status: corev1.PodStatus{
+			Phase:   corev1.PodFailed,
+			Reason:  "Evicted",
+			Message: "The node was low on resource: ephemeral-storage.",
+		},
+	})
+
+	assert.Equal(t, slurmv1alpha1.NodePowerStateInfo{
+		Phase:   slurmv1alpha1.NodePowerPhaseFailed,
+		PodName: "worker-4",
+		Reason:  "Evicted",
+		Message: "The node was low on resource: ephemeral-storage.",
+	}, got)
+}
@@ -0,0 +1,138 @@
+package nodesetcontroller
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/utils/timewindow"
+)
+
+// powerPolicyResult is the outcome of merging a NodeSetPowerPolicy with the nodes requested by Slurm.
+type powerPolicyResult struct {
+	// activeNodes is the sorted list of ordinals to power on.
+	activeNodes []int32
+	// warmNodes is the sorted list of ordinals powered on by the policy only.
+	warmNodes []int32
+	// droppedNodes is the sorted list of ordinals requested by Slurm which aren't powered on because of maxNodes.
+	droppedNodes     []int32
+	desiredWarmNodes int32
+	activeSchedules  []string
+	lastScaleUpTime  *metav1.Time
+	// requeueAfter is the time until the policy has to be applied again, e.g. because a schedule starts or ends.
+	// Zero if the result doesn't change over time.
+	requeueAfter time.Duration
+}
+
+// applyPowerPolicy merges the nodes requested by Slurm with the nodes the policy keeps warm.
+//
+// Requested nodes count towards the warm nodes. Warm nodes are picked among the ones kept warm before, so they don't
+// move between reconciliations, then among the lowest ordinals. When the number of warm nodes drops, the warm nodes
+// are kept until the scale-down cooldown since the last raise passes.
+func applyPowerPolicy(
+	policy *slurmv1alpha1.NodeSetPowerPolicy,
+	replicas int32,
+	requested []int32,
+	status slurmv1alpha1.NodeSetPowerStateStatus,
+	now time.Time,
+) (powerPolicyResult, error) {
+	requested = slices.Clone(requested)
+	slices.Sort(requested)
+	requested = slices.Compact(requested)
+
+	if policy == nil {
+		return powerPolicyResult{activeNodes: requested}, nil
+	}
+
+	res := powerPolicyResult{lastScaleUpTime: status.LastScaleUpTime}
+
+	maxNodes := replicas
+	if policy.MaxNodes != nil && *policy.MaxNodes < maxNodes {
+		maxNodes = *policy.MaxNodes
+	}
+
+	target := policy.MinWarmNodes
+	for i, schedule := range policy.Schedules {
+		active, next, err := powerScheduleWindow(schedule, now)
+		if err != nil {
+			return powerPolicyResult{}, fmt.Errorf("schedule %s: %w", powerScheduleName(schedule, i), err)
+		}
+		if active {
+			res.activeSchedules = append(res.activeSchedules, powerScheduleName(schedule, i))
+			target = max(target, schedule.MinWarmNodes)
+		}
+		res.requeueAfter = minRequeue(res.requeueAfter, next.Sub(now))
+	}
+	target = min(target, maxNodes)
+
+	switch {
+	case target > status.DesiredWarmNodes:
+		res.lastScaleUpTime = &metav1.Time{Time: now}
+	case target < status.DesiredWarmNodes && status.LastScaleUpTime != nil:
+		if untilCooldownEnd := status.LastScaleUpTime.Add(policy.ScaleDownCooldown.Duration).Sub(now); untilCooldownEnd > 0 {
+			target = min(status.DesiredWarmNodes, maxNodes)
+			res.requeueAfter = minRequeue(res.requeueAfter, untilCooldownEnd)
+		}
+	}
+	res.desiredWarmNodes = target
+
+	active := requested
+	if int32(len(active)) > maxNodes {
+		active, res.droppedNodes = active[:maxNodes], active[maxNodes:]
+	}
+
+	candidates := slices.Clone(status.WarmNodes)
+	for ordinal := int32(0); ordinal < replicas; ordinal++ {
+		candidates = append(candidates, ordinal)
+	}
+	for _, ordinal := range candidates {
+		if int32(len(active)+len(res.warmNodes)) >= target {
+			break
+		}
+		if ordinal < 0 || ordinal >= replicas || slices.Contains(active, ordinal) || slices.Contains(res.warmNodes, ordinal) {
+			continue
+		}
+		res.warmNodes = append(res.warmNodes, ordinal)
+	}
+	slices.Sort(res.warmNodes)
+
+	res.activeNodes = append(slices.Clone(active), res.warmNodes...)
+	slices.Sort(res.activeNodes)
+
+	return res, nil
+}
+
+// powerScheduleWindow reports whether the schedule window is active at the given moment, and when the schedule
+// next starts or ends.
+func powerScheduleWindow(schedule slurmv1alpha1.NodeSetPowerSchedule, now time.Time) (bool, time.Time, error) {
+	days := make([]string, 0, len(schedule.Days))
+	for _, day := range schedule.Days {
+		days = append(days, string(day))
+	}
+	return timewindow.Window{
+		Days:     days,
+		Start:    schedule.Start,
+		End:      schedule.End,
+		TimeZone: schedule.TimeZone,
+	}.Evaluate(now)
+}
+
+func powerScheduleName(schedule slurmv1alpha1.NodeSetPowerSchedule, index int) string {
+	if schedule.Name != "" {
+		return schedule.Name
+	}
+	return fmt.Sprintf("schedule-%d", index)
+}
+
+func minRequeue(a, b time.Duration) time.Duration {
+	if b <= 0 {
+		return a
+	}
+	if a <= 0 || b < a {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,205 @@
+package nodesetcontroller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+)
+
+func TestApplyPowerPolicy(t *testing.T) {
+	// Friday.
+	now := time.Date(2026, time.October, 16, 12, 0, 0, 0, time.UTC)
+
+	weekdays := slurmv1alpha1.NodeSetPowerSchedule{
+		Name:         "weekdays",
+		Days:         []slurmv1alpha1.Weekday{"Mon", "Tue", "Wed", "Thu", "Fri"},
+		Start:        "08:00",
+		End:          "20:00",
+		MinWarmNodes: 4,
+	}
+
+	tests := []struct {
+		name      string
+		policy    *slurmv1alpha1.NodeSetPowerPolicy
+		requested []int32
+		status    slurmv1alpha1.NodeSetPowerStateStatus
+		want      powerPolicyResult
+	}{
+		{
+			name:      "no policy",
+			requested: []int32{3, 1, 3},
+			want:      powerPolicyResult{activeNodes: []int32{1, 3}},
+		},
+		{
+			name:      "min warm nodes are filled with the lowest ordinals",
+			policy:    &slurmv1alpha1.NodeSetPowerPolicy{MinWarmNodes: 3},
+			requested: []int32{1},
+			want: powerPolicyResult{
+				activeNodes:      []int32{0, 1, 2},
+				warmNodes:        []int32{0, 2},
+				desiredWarmNodes: 3,
+				lastScaleUpTime:  &metav1.Time{Time: now},
+			},
+		},
+		{
+			name:      "requested nodes count towards warm nodes",
+			policy:    &slurmv1alpha1.NodeSetPowerPolicy{MinWarmNodes: 2},
+			requested: []int32{4, 5, 6},
+			status:    slurmv1alpha1.NodeSetPowerStateStatus{DesiredWarmNodes: 2},
+			want: powerPolicyResult{
+				activeNodes:      []int32{4, 5, 6},
+				desiredWarmNodes: 2,
+			},
+		},
+		{
+			name:   "previous warm nodes are kept",
+			policy: &slurmv1alpha1.NodeSetPowerPolicy{MinWarmNodes: 2},
+			status: slurmv1alpha1.NodeSetPowerStateStatus{DesiredWarmNodes: 2, WarmNodes: []int32{6, 7}},
+			want: powerPolicyResult{
+				activeNodes:      []int32{6, 7},
+				warmNodes:        []int32{6, 7},
+				desiredWarmNodes: 2,
+			},
+		},
+		{
+			name: "active schedule raises warm nodes until it ends",
+			policy: &slurmv1alpha1.NodeSetPowerPolicy{
+				MinWarmNodes: 1,
+				Schedules:    []slurmv1alpha1.NodeSetPowerSchedule{weekdays},
+			},
+			status: slurmv1alpha1.NodeSetPowerStateStatus{DesiredWarmNodes: 4, WarmNodes: []int32{0, 1, 2, 3}},
+			want: powerPolicyResult{
+				activeNodes:      []int32{0, 1, 2, 3},
+				warmNodes:        []int32{0, 1, 2, 3},
+				desiredWarmNodes: 4,
+				activeSchedules:  []string{"weekdays"},
+				requeueAfter:     8 * time.Hour,
+			},
+		},
+		{
+			name: "max nodes bounds warm and requested nodes",
+			policy: &slurmv1alpha1.NodeSetPowerPolicy{
+				MaxNodes:  ptr.To(int32(2)),
+				Schedules: []slurmv1alpha1.NodeSetPowerSchedule{weekdays},
+			},
+			requested: []int32{5, 6, 7},
+			status:    slurmv1alpha1.NodeSetPowerStateStatus{DesiredWarmNodes: 2},
+			want: powerPolicyResult{
+				activeNodes:      []int32{5, 6},
+				droppedNodes:     []int32{7},
+				desiredWarmNodes: 2,
+				activeSchedules:  []string{"weekdays"},
+				requeueAfter:     8 * time.Hour,
+			},
+		},
+		{
+			name: "warm nodes are kept during the cooldown",
+			policy: &slurmv1alpha1.NodeSetPowerPolicy{
+				MinWarmNodes:      1,
+				ScaleDownCooldown: metav1.Duration{Duration: 30 * time.Minute},
+			},
+			status: slurmv1alpha1.NodeSetPowerStateStatus{
+				DesiredWarmNodes: 3,
+				WarmNodes:        []int32{0, 1, 2},
+				LastScaleUpTime:  &metav1.Time{Time: now.Add(-10 * time.Minute)},
+			},
+			want: powerPolicyResult{
+				activeNodes:      []int32{0, 1, 2},
+				warmNodes:        []int32{0, 1, 2},
+				desiredWarmNodes: 3,
+				lastScaleUpTime:  &metav1.Time{Time: now.Add(-10 * time.Minute)},
+				requeueAfter:     20 * time.Minute,
+			},
+		},
+		{
+			name: "warm nodes are released after the cooldown",
+			policy: &slurmv1alpha1.NodeSetPowerPolicy{
+				MinWarmNodes:      1,
+				ScaleDownCooldown: metav1.Duration{Duration: 30 * time.Minute},
+			},
+			status: slurmv1alpha1.NodeSetPowerStateStatus{
+				DesiredWarmNodes: 3,
+				WarmNodes:        []int32{0, 1, 2},
+				LastScaleUpTime:  &metav1.Time{Time: now.Add(-time.Hour)},
+			},
+			want: powerPolicyResult{
+				activeNodes:      []int32{0},
+				warmNodes:        []int32{0},
+				desiredWarmNodes: 1,
+				lastScaleUpTime:  &metav1.Time{Time: now.Add(-time.Hour)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := applyPowerPolicy(tt.policy, 8, tt.requested, tt.status, now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPowerScheduleWindow(t *testing.T) {
+	tests := []struct {
+		name       string
+		schedule   slurmv1alpha1.NodeSetPowerSchedule
+		now        time.Time
+		wantActive bool
+		wantNext   time.Time
+	}{
+		{
+			name:       "inside the window",
+			schedule:   slurmv1alpha1.NodeSetPowerSchedule{Start: "08:00", End: "20:00"},
+			now:        time.Date(2026, time.October, 16, 12, 0, 0, 0, time.UTC),
+			wantActive: true,
+			wantNext:   time.Date(2026, time.October, 16, 20, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "before the window",
+			schedule: slurmv1alpha1.NodeSetPowerSchedule{Start: "08:00", End: "20:00"},
+			now:      time.Date(2026, time.October, 16, 6, 0, 0, 0, time.UTC),
+			wantNext: time.Date(2026, time.October, 16, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "weekend is skipped",
+			schedule: slurmv1alpha1.NodeSetPowerSchedule{Days: []slurmv1alpha1.Weekday{"Mon", "Fri"}, Start: "08:00", End: "20:00"},
+			now:      time.Date(2026, time.October, 17, 12, 0, 0, 0, time.UTC),
+			wantNext: time.Date(2026, time.October, 19, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "window over midnight started the day before",
+			schedule:   slurmv1alpha1.NodeSetPowerSchedule{Days: []slurmv1alpha1.Weekday{"Fri"}, Start: "22:00", End: "06:00"},
+			now:        time.Date(2026, time.October, 17, 2, 0, 0, 0, time.UTC),
+			wantActive: true,
+			wantNext:   time.Date(2026, time.October, 17, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "time zone",
+			schedule:   slurmv1alpha1.NodeSetPowerSchedule{Start: "08:00", End: "20:00", TimeZone: "Europe/Amsterdam"},
+			now:        time.Date(2026, time.October, 16, 6, 30, 0, 0, time.UTC),
+			wantActive: true,
+			wantNext:   time.Date(2026, time.October, 16, 18, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			active, next, err := powerScheduleWindow(tt.schedule, tt.now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantActive, active)
+			assert.True(t, tt.wantNext.Equal(next), "want %s, got %s", tt.wantNext, next)
+		})
+	}
+}
+
+func TestPowerScheduleWindow_InvalidTimeZone(t *testing.T) {
+	_, _, err := powerScheduleWindow(slurmv1alpha1.NodeSetPowerSchedule{Start: "08:00", End: "20:00", TimeZone: "Mars/Olympus"}, time.Now())
+	assert.Error(t, err)
+}
@@ -3,10 +3,14 @@ package nodesetcontroller
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strconv"
 	"time"
 
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
 	kruisev1b1 "github.com/openkruise/kruise-api/apps/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -24,6 +28,7 @@ import (
 	"nebius.ai/slurm-operator/internal/naming"
 	"nebius.ai/slurm-operator/internal/render/common"
 	"nebius.ai/slurm-operator/internal/render/worker"
+	"nebius.ai/slurm-operator/internal/slurmapi"
 	"nebius.ai/slurm-operator/internal/utils"
 	"nebius.ai/slurm-operator/internal/utils/resourcegetter"
 	"nebius.ai/slurm-operator/internal/values"
@@ -115,12 +120,14 @@ func (r *NodeSetReconciler) reconcile(ctx context.Context, nodeSet *slurmv1alpha
 	clusterWithGPU := values.BuildClusterWithGPUFromNodeSets(nodeSets)
 
 	// region Ephemeral nodes power state
+	var powerPolicyRequeueAfter time.Duration
 	if nodeSetValues.EphemeralNodes != nil && *nodeSetValues.EphemeralNodes {
-		activeNodes, err := r.reconcileNodeSetPowerState(ctx, nodeSet)
+		activeNodes, requeueAfter, err := r.reconcileNodeSetPowerState(ctx, nodeSet, time.Now())
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf("reconciling NodeSetPowerState: %w", err)
 		}
 		nodeSetValues.ActiveNodes = activeNodes
+		powerPolicyRequeueAfter = requeueAfter
 		logger.V(1).Info("Ephemeral nodes power state reconciled", "activeNodes", activeNodes)
 	}
 	// endregion Ephemeral nodes power state
@@ -174,12 +181,14 @@ func (r *NodeSetReconciler) reconcile(ctx context.Context, nodeSet *slurmv1alpha
 
 	if res.RequeueAfter > 0 {
 		logger.Info("Reconciliation requeued")
+		res.RequeueAfter = minRequeue(res.RequeueAfter, powerPolicyRequeueAfter)
 		return res, nil
 	}
 
 	logger.Info("Finished reconciliation")
 
-	return ctrl.Result{}, nil
+	// The power policy is re-applied when its schedules start or end.
+	return ctrl.Result{RequeueAfter: powerPolicyRequeueAfter}, nil
 }
 
 func (r *NodeSetReconciler) updatePhase(ctx context.Context, nodeSet *slurmv1alpha1.NodeSet) error {
@@ -607,18 +616,13 @@ func (r NodeSetReconciler) getWorkersStatefulSetDependencies(
 ) ([]metav1.Object, error) {
 	var res []metav1.Object
 
-	mungeKeySecret := &corev1.Secret{}
-	if err := r.Get(
-		ctx,
-		types.NamespacedName{
-			Namespace: nodeSet.ParentalCluster.Namespace,
-			Name:      naming.BuildSecretMungeKeyName(nodeSet.ParentalCluster.Name),
-		},
-		mungeKeySecret,
-	); err != nil {
-		return []metav1.Object{}, err
+	if !common.MungeContainerReloadsKey(&nodeSet.ContainerMunge) {
+		mungeKey, err := resourcegetter.GetMungeKeyDependency(ctx, r.Client, nodeSet.ParentalCluster)
+		if err != nil {
+			return []metav1.Object{}, err
+		}
+		res = append(res, mungeKey)
 	}
-	res = append(res, mungeKeySecret)
 
 	if cluster.Spec.SlurmNodes.Accounting.Enabled {
 		slurmdbdSecret := &corev1.Secret{}
@@ -671,11 +675,13 @@ func (r NodeSetReconciler) getWorkersStatefulSetDependencies(
 }
 
 // reconcileNodeSetPowerState ensures the NodeSetPowerState CR exists for this NodeSet
-// and returns the list of active node ordinals from it.
+// and returns the list of ordinals to power on: the active nodes from it merged with the nodes kept warm by its policy.
+// It also returns the time after which the policy has to be applied again.
 func (r *NodeSetReconciler) reconcileNodeSetPowerState(
 	ctx context.Context,
 	nodeSet *slurmv1alpha1.NodeSet,
-) ([]int32, error) {
+	now time.Time,
+) ([]int32, time.Duration, error) {
 	logger := log.FromContext(ctx)
 
 	powerStateName := nodeSet.Name
@@ -687,7 +693,7 @@ func (r *NodeSetReconciler) reconcileNodeSetPowerState(
 	}, existing)
 
 	if err != nil && !apierrors.IsNotFound(err) {
-		return nil, fmt.Errorf("getting NodeSetPowerState: %w", err)
+		return nil, 0, fmt.Errorf("getting NodeSetPowerState: %w", err)
 	}
 
 	desired := &slurmv1alpha1.NodeSetPowerState{
@@ -713,20 +719,37 @@ func (r *NodeSetReconciler) reconcileNodeSetPowerState(
 	}
 
 	if err := r.NodeSetPowerState.Reconcile(ctx, nodeSet, desired); err != nil {
-		return nil, fmt.Errorf("reconciling NodeSetPowerState: %w", err)
+		return nil, 0, fmt.Errorf("reconciling NodeSetPowerState: %w", err)
 	}
 
 	if err := r.Get(ctx, types.NamespacedName{
 		Namespace: nodeSet.Namespace,
 		Name:      powerStateName,
 	}, existing); err != nil {
-		return nil, fmt.Errorf("getting NodeSetPowerState after reconcile: %w", err)
+		return nil, 0, fmt.Errorf("getting NodeSetPowerState after reconcile: %w", err)
+	}
+
+	policyResult, err := applyPowerPolicy(existing.Spec.Policy, nodeSet.Spec.Replicas, existing.Spec.ActiveNodes, existing.Status, now)
+	if err != nil {
+		return nil, 0, fmt.Errorf("applying NodeSetPowerState policy: %w", err)
+	}
+	requeueAfter := policyResult.requeueAfter
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods,
+		client.InNamespace(nodeSet.Namespace),
+		client.MatchingLabels{consts.LabelNodeSetKey: nodeSet.Name},
+	); err != nil {
+		return nil, 0, fmt.Errorf("listing NodeSet pods: %w", err)
 	}
 
+	slurmNodes := r.getSlurmNodes(nodeSet, policyResult.activeNodes, existing.Status.NodeStates)
+
 	// Update status subresource so printer columns (ACTIVE, READY) are populated.
+	var transitions []nodePowerTransition
 	{
 		patch := client.MergeFrom(existing.DeepCopy())
-		activeCount := int32(len(existing.Spec.ActiveNodes))
+		activeCount := int32(len(policyResult.activeNodes))
 		needsPatch := false
 
 		if existing.Status.ActiveCount != activeCount {
@@ -746,9 +769,42 @@ func (r *NodeSetReconciler) reconcileNodeSetPowerState(
 			needsPatch = true
 		}
 
+		if setPowerPolicyStatus(&existing.Status, existing.Spec.Policy, policyResult) {
+			needsPatch = true
+		}
+
+		nodeStates := buildNodeStates(
+			naming.BuildNodeSetStatefulSetName(nodeSet.Name),
+			policyResult.activeNodes,
+			policyResult.droppedNodes,
+			pods.Items,
+			slurmNodes,
+			existing.Status.NodeStates,
+			metav1.NewTime(now),
+		)
+		transitions = diffNodeStates(existing.Status.NodeStates, nodeStates, slurmNodes, now)
+		if !apiequality.Semantic.DeepEqual(existing.Status.NodeStates, nodeStates) {
+			existing.Status.NodeStates = nodeStates
+			needsPatch = true
+		}
+
 		if needsPatch {
 			if err := r.Status().Patch(ctx, existing, patch); err != nil {
-				return nil, fmt.Errorf("patching NodeSetPowerState status: %w", err)
+				return nil, 0, fmt.Errorf("patching NodeSetPowerState status: %w", err)
+			}
+		}
+	}
+
+	// Transitions are recorded once the status is saved, so that they are not counted again on a retry.
+	observeNodePowerTransitions(nodeSet.Namespace, nodeSet.Name, existing.Status.ActiveCount, transitions)
+	r.recordNodePowerEvents(existing, nodeSet.Name, transitions)
+
+	// Slurm doesn't notify about node state changes, so they are polled until the resumed nodes are usable.
+	if slurmNodes != nil {
+		for _, state := range existing.Status.NodeStates {
+			if isPoweredOn(state) && state.SlurmState == string(api.V0044NodeStatePOWERUP) {
+				requeueAfter = minRequeue(requeueAfter, slurmNodeCacheRefreshInterval)
+				break
 			}
 		}
 	}
@@ -756,7 +812,105 @@ func (r *NodeSetReconciler) reconcileNodeSetPowerState(
 	logger.V(1).Info("NodeSetPowerState reconciled",
 		"name", powerStateName,
 		"activeNodes", existing.Spec.ActiveNodes,
+		"warmNodes", policyResult.warmNodes,
+		"droppedNodes", policyResult.droppedNodes,
 	)
 
-	return existing.Spec.ActiveNodes, nil
+	return policyResult.activeNodes, requeueAfter, nil
+}
+
+// getSlurmNodes returns the Slurm nodes of the given active ordinals and of the nodes in the previous states,
+// keyed by ordinal.
+// Returns nil if there is no Slurm API client for the cluster of the NodeSet.
+func (r *NodeSetReconciler) getSlurmNodes(
+	nodeSet *slurmv1alpha1.NodeSet,
+	activeNodes []int32,
+	previous map[string]slurmv1alpha1.NodePowerStateInfo,
+) map[int32]slurmapi.Node {
+	if r.SlurmAPIClients == nil {
+		return nil
+	}
+
+	clusterKey := types.NamespacedName{Namespace: nodeSet.Namespace, Name: nodeSet.Spec.ClusterName}
+	nodeCache := r.SlurmAPIClients.EnsureNodeCache(
+		clusterKey,
+		slurmNodeCacheRefreshInterval,
+		log.Log.WithName("NodeCache").WithValues("cluster", clusterKey),
+	)
+	if nodeCache == nil {
+		return nil
+	}
+
+	ordinals := slices.Clone(activeNodes)
+	for key := range previous {
+		if ordinal, err := strconv.ParseInt(key, 10, 32); err == nil {
+			ordinals = append(ordinals, int32(ordinal))
+		}
+	}
+
+	res := make(map[int32]slurmapi.Node, len(ordinals))
+	for _, ordinal := range ordinals {
+		if node, found := nodeCache.GetNode(fmt.Sprintf("%s-%d", nodeSet.Name, ordinal)); found {
+			res[ordinal] = node
+		}
+	}
+	return res
+}
+
+// recordNodePowerEvents records the transitions of the NodeSet's nodes as events of its NodeSetPowerState,
+// so that the power history of the nodes can be seen with kubectl.
+func (r *NodeSetReconciler) recordNodePowerEvents(
+	powerState *slurmv1alpha1.NodeSetPowerState,
+	nodeSetName string,
+	transitions []nodePowerTransition,
+) {
+	for _, transition := range transitions {
+		nodeName := fmt.Sprintf("%s-%d", nodeSetName, transition.Ordinal)
+		switch transition.Type {
+		case nodePowerTransitionPodRunning:
+			r.Recorder.Eventf(powerState, corev1.EventTypeNormal, consts.NodePowerEventResumed,
+				"Pod of node %s runs %s after the node was powered on", nodeName, transition.Duration.Round(time.Second))
+		case nodePowerTransitionResumeFailed:
+			r.Recorder.Eventf(powerState, corev1.EventTypeWarning, consts.NodePowerEventResumeFailed,
+				"Node %s failed to resume: %s", nodeName, transition.Message)
+		case nodePowerTransitionSuspended:
+			r.Recorder.Eventf(powerState, corev1.EventTypeNormal, consts.NodePowerEventSuspended,
+				"Node %s is powered off", nodeName)
+		}
+	}
+}
+
+// setPowerPolicyStatus records the outcome of the power policy in the NodeSetPowerState status.
+// Returns true if the status was changed.
+func setPowerPolicyStatus(
+	status *slurmv1alpha1.NodeSetPowerStateStatus,
+	policy *slurmv1alpha1.NodeSetPowerPolicy,
+	result powerPolicyResult,
+) bool {
+	before := status.DeepCopy()
+
+	status.WarmNodes = result.warmNodes
+	status.DesiredWarmNodes = result.desiredWarmNodes
+	status.ActiveSchedules = result.activeSchedules
+	status.LastScaleUpTime = result.lastScaleUpTime
+
+	if policy == nil {
+		meta.RemoveStatusCondition(&status.Conditions, slurmv1alpha1.ConditionNodeSetPowerStateScalingLimited)
+	} else if len(result.droppedNodes) > 0 {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:    slurmv1alpha1.ConditionNodeSetPowerStateScalingLimited,
+			Status:  metav1.ConditionTrue,
+			Reason:  "MaxNodesReached",
+			Message: fmt.Sprintf("Nodes %v requested by Slurm are not started because of maxNodes", result.droppedNodes),
+		})
+	} else {
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:    slurmv1alpha1.ConditionNodeSetPowerStateScalingLimited,
+			Status:  metav1.ConditionFalse,
+			Reason:  "WithinMaxNodes",
+			Message: "All nodes requested by Slurm are started",
+		})
+	}
+
+	return !apiequality.Semantic.DeepEqual(before, status)
 }
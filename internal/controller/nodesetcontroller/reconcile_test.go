@@ -3,9 +3,11 @@ package nodesetcontroller
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -113,6 +115,7 @@ func TestComputePhase(t *testing.T) {
 func TestReconcileNodeSetPowerState_AllowsZeroInitialEphemeralNodes(t *testing.T) {
 	scheme := runtime.NewScheme()
 	require.NoError(t, slurmv1alpha1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
 
 	nodeSet := &slurmv1alpha1.NodeSet{
 		ObjectMeta: metav1.ObjectMeta{
@@ -138,9 +141,10 @@ func TestReconcileNodeSetPowerState_AllowsZeroInitialEphemeralNodes(t *testing.T
 		),
 	}
 
-	activeNodes, err := r.reconcileNodeSetPowerState(context.Background(), nodeSet)
+	activeNodes, requeueAfter, err := r.reconcileNodeSetPowerState(context.Background(), nodeSet, time.Now())
 	require.NoError(t, err)
 	assert.Empty(t, activeNodes)
+	assert.Zero(t, requeueAfter)
 
 	var powerState slurmv1alpha1.NodeSetPowerState
 	err = fakeClient.Get(context.Background(), client.ObjectKey{
@@ -157,6 +161,73 @@ func TestReconcileNodeSetPowerState_AllowsZeroInitialEphemeralNodes(t *testing.T
 	assert.Equal(t, metav1.ConditionTrue, readyCondition.Status)
 }
 
+func TestReconcileNodeSetPowerState_MergesPolicyWarmNodes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, slurmv1alpha1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	nodeSet := &slurmv1alpha1.NodeSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-nodeset",
+			Namespace: "test-namespace",
+			UID:       "test-uid",
+		},
+		Spec: slurmv1alpha1.NodeSetSpec{
+			Replicas: 8,
+		},
+	}
+	powerState := &slurmv1alpha1.NodeSetPowerState{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      nodeSet.Name,
+			Namespace: nodeSet.Namespace,
+		},
+		Spec: slurmv1alpha1.NodeSetPowerStateSpec{
+			NodeSetRef:  nodeSet.Name,
+			ActiveNodes: []int32{5},
+			Policy: &slurmv1alpha1.NodeSetPowerPolicy{
+				MinWarmNodes: 1,
+				Schedules: []slurmv1alpha1.NodeSetPowerSchedule{{
+					Name:         "business-hours",
+					Start:        "08:00",
+					End:          "20:00",
+					MinWarmNodes: 3,
+				}},
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(nodeSet, powerState).
+		WithStatusSubresource(&slurmv1alpha1.NodeSetPowerState{}).
+		Build()
+
+	r := &NodeSetReconciler{
+		Reconciler: reconciler.NewReconciler(fakeClient, scheme, record.NewFakeRecorder(10)),
+		NodeSetPowerState: reconciler.NewNodeSetPowerStateReconciler(
+			reconciler.NewReconciler(fakeClient, scheme, record.NewFakeRecorder(10)),
+		),
+	}
+
+	now := time.Date(2026, time.October, 16, 12, 0, 0, 0, time.UTC)
+	activeNodes, requeueAfter, err := r.reconcileNodeSetPowerState(context.Background(), nodeSet, now)
+	require.NoError(t, err)
+	assert.Equal(t, []int32{0, 1, 5}, activeNodes)
+	assert.Equal(t, 8*time.Hour, requeueAfter)
+
+	require.NoError(t, fakeClient.Get(context.Background(), client.ObjectKeyFromObject(powerState), powerState))
+	assert.Equal(t, []int32{5}, powerState.Spec.ActiveNodes)
+	assert.Equal(t, []int32{0, 1}, powerState.Status.WarmNodes)
+	assert.Equal(t, int32(3), powerState.Status.DesiredWarmNodes)
+	assert.Equal(t, int32(3), powerState.Status.ActiveCount)
+	assert.Equal(t, []string{"business-hours"}, powerState.Status.ActiveSchedules)
+	require.NotNil(t, powerState.Status.LastScaleUpTime)
+	assert.True(t, now.Equal(powerState.Status.LastScaleUpTime.Time))
+
+	require.Len(t, powerState.Status.NodeStates, 3)
+	assert.Equal(t, slurmv1alpha1.NodePowerReasonPodNotCreated, powerState.Status.NodeStates["5"].Reason)
+}
+
 func TestExpectedReplicas(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -2,6 +2,7 @@ package soperatorchecks
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -17,8 +18,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	slurmv1 "nebius.ai/slurm-operator/api/v1"
 	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
@@ -30,6 +33,7 @@ import (
 	"nebius.ai/slurm-operator/internal/naming"
 	render "nebius.ai/slurm-operator/internal/render/soperatorchecks"
 	"nebius.ai/slurm-operator/internal/utils"
+	"nebius.ai/slurm-operator/internal/utils/resourcegetter"
 )
 
 var (
@@ -89,10 +93,43 @@ func (r *ActiveCheckReconciler) SetupWithManager(
 				},
 			},
 		)).
+		// Targets are resolved from the NodeSets and the partitions of the cluster, so their changes re-render the
+		// CronJobs of the checks with targets.
+		Watches(
+			&slurmv1alpha1.NodeSet{},
+			handler.EnqueueRequestsFromMapFunc(r.findActiveChecksWithTargets),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(
+			&slurmv1.SlurmCluster{},
+			handler.EnqueueRequestsFromMapFunc(r.findActiveChecksWithTargets),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
 		WithOptions(controllerconfig.ControllerOptions(maxConcurrency, cacheSyncTimeout)).
 		Complete(r)
 }
 
+// findActiveChecksWithTargets returns the checks with targets in the namespace of the object.
+func (r *ActiveCheckReconciler) findActiveChecksWithTargets(ctx context.Context, obj client.Object) []reconcile.Request {
+	checks := &slurmv1alpha1.ActiveCheckList{}
+	if err := r.List(ctx, checks, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list ActiveChecks")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, check := range checks.Items {
+		if check.Spec.Targets == nil {
+			continue
+		}
+		if _, ok := obj.(*slurmv1.SlurmCluster); ok && check.Spec.SlurmClusterRefName != obj.GetName() {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&check)})
+	}
+	return requests
+}
+
 // +kubebuilder:rbac:groups=slurm.nebius.ai,resources=activechecks,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=slurm.nebius.ai,resources=activechecks/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=slurm.nebius.ai,resources=activechecks/finalizers,verbs=update
@@ -120,6 +157,7 @@ func (r *ActiveCheckReconciler) Reconcile(
 	}
 
 	check.Spec.SetDefaults()
+	observeNodeResults(check)
 
 	if check.ObjectMeta.DeletionTimestamp.IsZero() == false {
 		if controllerutil.ContainsFinalizer(check, consts.ActiveCheckFinalizer) {
@@ -163,6 +201,19 @@ func (r *ActiveCheckReconciler) Reconcile(
 		}, nil
 	}
 
+	targetNodeList, err := r.resolveTargets(ctx, check, slurmCluster)
+	if err != nil {
+		if !errors.Is(err, errInvalidActiveCheckTargets) {
+			return ctrl.Result{}, fmt.Errorf("resolving targets: %w", err)
+		}
+		// The targeted NodeSets may appear later, so this isn't treated as a reconciliation error.
+		logger.Info("Targets can't be resolved, requeueing", "reason", err.Error())
+		r.Recorder.Event(check, corev1.EventTypeWarning, consts.ActiveCheckEventInvalidTargets, err.Error())
+		return ctrl.Result{
+			RequeueAfter: r.requeueAfter,
+		}, nil
+	}
+
 	reconcileActiveChecksImpl := func() error {
 		return utils.ExecuteMultiStep(ctx,
 			"Reconciliation of active check",
@@ -210,7 +261,7 @@ func (r *ActiveCheckReconciler) Reconcile(
 							return fmt.Errorf("getting PodTemplate: %w", err)
 						}
 					}
-					desired, err := render.RenderK8sCronJob(check, foundPodTemplate)
+					desired, err := render.RenderK8sCronJob(check, foundPodTemplate, targetNodeList)
 
 					if err != nil {
 						stepLogger.Error(err, "Failed to render")
@@ -246,6 +297,36 @@ func (r *ActiveCheckReconciler) Reconcile(
 	return ctrl.Result{}, nil
 }
 
+// resolveTargets resolves the targets of the check into the hostlist expression of the Slurm nodes, and keeps it in
+// the status of the check.
+func (r *ActiveCheckReconciler) resolveTargets(
+	ctx context.Context,
+	check *slurmv1alpha1.ActiveCheck,
+	slurmCluster *slurmv1.SlurmCluster,
+) (string, error) {
+	var targetNodeList string
+	if check.Spec.Targets != nil {
+		nodeSets, err := resourcegetter.ListNodeSetsByClusterRef(ctx, r.Client, client.ObjectKeyFromObject(slurmCluster))
+		if err != nil {
+			return "", err
+		}
+		targetNodeList, err = resolveTargetNodeList(check.Spec.Targets, slurmCluster, nodeSets)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if check.Status.TargetNodeList != targetNodeList {
+		patch := client.MergeFrom(check.DeepCopy())
+		check.Status.TargetNodeList = targetNodeList
+		if err := r.Status().Patch(ctx, check, patch); err != nil {
+			return "", fmt.Errorf("patching status: %w", err)
+		}
+	}
+
+	return targetNodeList, nil
+}
+
 func (r *ActiveCheckReconciler) reconcileDelete(ctx context.Context, check *slurmv1alpha1.ActiveCheck) (ctrl.Result, error) {
 	logger := log.FromContext(ctx).WithName("ActiveCheckController.reconcileDelete")
 
@@ -295,6 +376,7 @@ func (r *ActiveCheckReconciler) reconcileDelete(ctx context.Context, check *slur
 		logger.Error(err, "Failed to remove finalizer")
 		return ctrl.Result{}, err
 	}
+	deleteNodeResultMetrics(check.Namespace, check.Name)
 
 	return ctrl.Result{}, nil
 }
@@ -3,6 +3,7 @@ package soperatorchecks
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
@@ -43,6 +44,7 @@ type ActiveCheckJobReconciler struct {
 	Job             *reconciler.JobReconciler
 	slurmAPIClients *slurmapi.ClientSet
 	requeueAfter    time.Duration
+	httpClient      *http.Client
 }
 
 func NewActiveCheckJobController(
@@ -59,6 +61,7 @@ func NewActiveCheckJobController(
 		Job:             reconciler.NewJobReconciler(r),
 		slurmAPIClients: slurmAPIClients,
 		requeueAfter:    requeueAfter,
+		httpClient:      &http.Client{},
 	}
 }
 
@@ -150,6 +153,7 @@ func (r *ActiveCheckJobReconciler) Reconcile(
 		logger.Error(err, "Failed to get ActiveCheck")
 		return ctrl.Result{}, err
 	}
+	activeCheck.Spec.SetDefaults()
 
 	cronJob := &batchv1.CronJob{}
 	err = r.Get(ctx, types.NamespacedName{
@@ -317,27 +321,34 @@ func (r *ActiveCheckJobReconciler) Reconcile(
 					continue
 				}
 
-				switch {
-				case slurmJob.IsFailedState():
+				nodes, err := slurmJob.GetNodeList()
+				if err != nil {
+					return ctrl.Result{}, fmt.Errorf("get node list of slurm job %d: %w", slurmJob.ID, err)
+				}
+				outcome := evaluateSlurmJob(slurmJob, activeCheck.Spec.SlurmJobSpec.Results)
+				activeCheck.Status.NodeResults = recordNodeResults(activeCheck.Status.NodeResults, slurmJob, outcome, nodes)
+
+				switch outcome.Result {
+				case consts.ActiveCheckNodeResultFailed:
 					failJobsAndReasons = append(failJobsAndReasons, slurmv1alpha1.JobAndReason{
 						JobID:  fmt.Sprint(slurmJob.ID),
-						Reason: slurmJob.StateReason,
+						Reason: outcome.Reason,
 					})
-					err = executeFailureReactions(ctx, slurmJob, activeCheck, slurmAPIClient, logger)
+					err = r.executeFailureReactions(ctx, slurmJob, outcome, nodes, activeCheck, slurmAPIClient, logger)
 					if err != nil {
 						return ctrl.Result{}, fmt.Errorf("executing failure reactions: %w", err)
 					}
-				case slurmJob.IsCancelledState():
+				case consts.ActiveCheckNodeResultCancelled:
 					cancelledJobs = append(cancelledJobs, fmt.Sprint(slurmJob.ID))
-				case slurmJob.IsCompletedState():
-					err = executeSuccessReactions(ctx, slurmJob, activeCheck, slurmAPIClient, logger)
+				case consts.ActiveCheckNodeResultPassed:
+					err = r.executeSuccessReactions(ctx, slurmJob, outcome, nodes, activeCheck, slurmAPIClient, logger)
 					if err != nil {
 						return ctrl.Result{}, fmt.Errorf("executing success reactions: %w", err)
 					}
 				default:
 					errorJobsAndReasons = append(errorJobsAndReasons, slurmv1alpha1.JobAndReason{
 						JobID:  fmt.Sprint(slurmJob.ID),
-						Reason: slurmJob.StateReason,
+						Reason: outcome.Reason,
 					})
 					// Do nothing. The job could have been cancelled or interrupted. The job will run again.
 					logger.Info(fmt.Sprintf("unhandled state. The job is probably cancelled or interrupted and it will run again. Current state: %s ", slurmJob.State))
@@ -373,6 +384,15 @@ func (r *ActiveCheckJobReconciler) Reconcile(
 			return ctrl.Result{}, fmt.Errorf("failed to patch k8s Job: %w", err)
 		}
 
+		var removedNodes []string
+		if len(activeCheck.Status.NodeResults) != 0 {
+			targeted, err := targetedSlurmNodes(ctx, activeCheck, slurmAPIClient)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("getting targeted slurm nodes: %w", err)
+			}
+			activeCheck.Status.NodeResults, removedNodes = pruneNodeResults(activeCheck.Status.NodeResults, targeted)
+		}
+
 		activeCheck.Status.SlurmJobsStatus = slurmv1alpha1.ActiveCheckSlurmJobsStatus{
 			LastRunId:                  firstJobId,
 			LastRunName:                jobName,
@@ -392,6 +412,8 @@ func (r *ActiveCheckJobReconciler) Reconcile(
 			logger.Error(err, "Failed to reconcile ActiveCheckJob")
 			return ctrl.Result{}, fmt.Errorf("reconciling ActiveCheckJob: %w", err)
 		}
+		observeNodeResults(activeCheck)
+		deleteNodeResultMetricsOfNodes(activeCheck.Namespace, activeCheck.Name, removedNodes)
 
 		if requeue {
 			return ctrl.Result{RequeueAfter: r.requeueAfter}, nil
@@ -457,16 +479,12 @@ func updateSlurmNodeWithReactions(
 	ctx context.Context,
 	logger logr.Logger,
 	slurmJob slurmapi.Job,
+	nodes []string,
 	activeCheckName string,
 	reactions slurmv1alpha1.Reactions,
 	slurmAPIClient slurmapi.Client,
 ) error {
-	nodes, err := slurmJob.GetNodeList()
-	if err != nil {
-		return fmt.Errorf("get node list: %w", err)
-	}
-
-	failureMessage := fmt.Sprintf("%s: job %d [slurm_job]", activeCheckName, slurmJob.ID)
+	failureMessage := reactionMessage(activeCheckName, slurmJob)
 	for _, node := range nodes {
 		updateReq := api.V0044UpdateNodeMsg{}
 		if reactions.DrainSlurmNode != nil && reactions.DrainSlurmNode.DrainReasonPrefix != "" {
@@ -494,6 +512,28 @@ func updateSlurmNodeWithReactions(
 	return nil
 }
 
+// targetedSlurmNodes returns the names of the Slurm nodes targeted by the check. Checks without targets run on all
+// nodes of the cluster.
+func targetedSlurmNodes(
+	ctx context.Context,
+	activeCheck *slurmv1alpha1.ActiveCheck,
+	slurmAPIClient slurmapi.Client,
+) ([]string, error) {
+	if activeCheck.Status.TargetNodeList != "" {
+		return slurmapi.ParseNodeList(activeCheck.Status.TargetNodeList)
+	}
+
+	slurmNodes, err := slurmAPIClient.ListNodes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing slurm nodes: %w", err)
+	}
+	nodes := make([]string, 0, len(slurmNodes))
+	for _, slurmNode := range slurmNodes {
+		nodes = append(nodes, slurmNode.Name)
+	}
+	return nodes, nil
+}
+
 func (r *ActiveCheckJobReconciler) getActiveCheckNameFromJob(ctx context.Context, k8sJob *batchv1.Job) (string, bool, error) {
 	podList := &corev1.PodList{}
 	err := r.List(ctx, podList, client.InNamespace(k8sJob.Namespace), client.MatchingLabels{"job-name": k8sJob.Name})
@@ -540,15 +580,47 @@ func getK8sJobStatus(k8sJob *batchv1.Job) consts.ActiveCheckK8sJobStatus {
 	return consts.ActiveCheckK8sJobStatusUnknown
 }
 
-func executeFailureReactions(ctx context.Context, slurmJob slurmapi.Job, activeCheck *slurmv1alpha1.ActiveCheck, slurmAPIClient slurmapi.Client, logger logr.Logger) error {
-	return executeReactions(ctx, slurmJob, activeCheck.Name, activeCheck.Spec.FailureReactions, slurmAPIClient, logger)
+func (r *ActiveCheckJobReconciler) executeFailureReactions(
+	ctx context.Context,
+	slurmJob slurmapi.Job,
+	outcome slurmJobOutcome,
+	nodes []string,
+	activeCheck *slurmv1alpha1.ActiveCheck,
+	slurmAPIClient slurmapi.Client,
+	logger logr.Logger,
+) error {
+	nodes = nodesReachedFailureThreshold(activeCheck.Status.NodeResults, nodes, *activeCheck.Spec.FailureReactionsThreshold)
+	if len(nodes) == 0 {
+		logger.V(1).Info("Failure reactions threshold is not reached on any node, skipping execution")
+		return nil
+	}
+	return r.executeReactions(ctx, slurmJob, outcome, nodes, activeCheck, activeCheck.Spec.FailureReactions,
+		slurmAPIClient, logger)
 }
 
-func executeSuccessReactions(ctx context.Context, slurmJob slurmapi.Job, activeCheck *slurmv1alpha1.ActiveCheck, slurmAPIClient slurmapi.Client, logger logr.Logger) error {
-	return executeReactions(ctx, slurmJob, activeCheck.Name, activeCheck.Spec.SuccessReactions, slurmAPIClient, logger)
+func (r *ActiveCheckJobReconciler) executeSuccessReactions(
+	ctx context.Context,
+	slurmJob slurmapi.Job,
+	outcome slurmJobOutcome,
+	nodes []string,
+	activeCheck *slurmv1alpha1.ActiveCheck,
+	slurmAPIClient slurmapi.Client,
+	logger logr.Logger,
+) error {
+	return r.executeReactions(ctx, slurmJob, outcome, nodes, activeCheck, activeCheck.Spec.SuccessReactions,
+		slurmAPIClient, logger)
 }
 
-func executeReactions(ctx context.Context, slurmJob slurmapi.Job, activeCheckName string, reactions *slurmv1alpha1.Reactions, slurmAPIClient slurmapi.Client, logger logr.Logger) error {
+func (r *ActiveCheckJobReconciler) executeReactions(
+	ctx context.Context,
+	slurmJob slurmapi.Job,
+	outcome slurmJobOutcome,
+	nodes []string,
+	activeCheck *slurmv1alpha1.ActiveCheck,
+	reactions *slurmv1alpha1.Reactions,
+	slurmAPIClient slurmapi.Client,
+	logger logr.Logger,
+) error {
 	if reactions == nil {
 		logger.V(1).Info("No reactions defined, skipping execution")
 		return nil
@@ -556,11 +628,33 @@ func executeReactions(ctx context.Context, slurmJob slurmapi.Job, activeCheckNam
 
 	if (reactions.DrainSlurmNode != nil && reactions.DrainSlurmNode.DrainReasonPrefix != "") ||
 		(reactions.CommentSlurmNode != nil && reactions.CommentSlurmNode.CommentPrefix != "") {
-		err := updateSlurmNodeWithReactions(ctx, logger, slurmJob, activeCheckName, *reactions, slurmAPIClient)
+		err := updateSlurmNodeWithReactions(ctx, logger, slurmJob, nodes, activeCheck.Name, *reactions, slurmAPIClient)
 		if err != nil {
 			return fmt.Errorf("update slurm node with reaction: %w", err)
 		}
 	}
 
+	if reactions.RebootSlurmNode != nil {
+		err := rebootSlurmNodes(ctx, logger, slurmJob, nodes, activeCheck.Name, *reactions.RebootSlurmNode, slurmAPIClient)
+		if err != nil {
+			return fmt.Errorf("reboot slurm node with reaction: %w", err)
+		}
+	}
+
+	if reactions.SetHardwareIssuesSuspected != nil {
+		err := r.setHardwareIssuesSuspected(ctx, logger, slurmJob, nodes, activeCheck, *reactions.SetHardwareIssuesSuspected)
+		if err != nil {
+			return fmt.Errorf("set hardware issues suspected with reaction: %w", err)
+		}
+	}
+
+	// Notifications are best effort: an unavailable webhook must not block the status of the check.
+	if reactions.Notify != nil {
+		if err := r.notify(ctx, slurmJob, outcome, nodes, activeCheck, *reactions.Notify); err != nil {
+			logger.Error(err, "Failed to send notification")
+			r.Recorder.Event(activeCheck, corev1.EventTypeWarning, consts.ActiveCheckEventNotificationFailed, err.Error())
+		}
+	}
+
 	return nil
 }
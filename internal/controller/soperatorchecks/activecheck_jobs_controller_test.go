@@ -483,6 +483,7 @@ func TestActiveCheckJobReconciler_Reconcile_FailedSlurmJobWithoutReactionsOnlyUp
 		EndTime:     &failedEndTime,
 		Nodes:       "worker-0",
 	}}, nil).Once()
+	mockClient.EXPECT().ListNodes(mock.Anything).Return([]slurmapi.Node{{Name: "worker-0"}}, nil).Once()
 
 	reconciler, fakeClient := newActiveCheckJobTestReconciler(t, scheme, activeCheck, cronJob, k8sJob, pod, mockClient)
 
@@ -499,6 +500,51 @@ func TestActiveCheckJobReconciler_Reconcile_FailedSlurmJobWithoutReactionsOnlyUp
 	}}, updatedCheck.Status.SlurmJobsStatus.LastRunFailJobsAndReasons)
 }
 
+func TestActiveCheckJobReconciler_Reconcile_PrunesResultsOfUntargetedNodes(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	scheme := newActiveCheckJobTestScheme(t)
+
+	activeCheck, cronJob, k8sJob, pod := newActiveCheckJobTestObjects("pruned-check", "pruned-check-123", "slurmJob")
+	activeCheck.Status.TargetNodeList = "worker-[0-1]"
+	activeCheck.Status.NodeResults = []slurmv1alpha1.ActiveCheckNodeResult{
+		{Node: "worker-1", ConsecutiveFailures: 1},
+		{Node: "worker-5", ConsecutiveFailures: 1},
+	}
+	k8sJob.Annotations["unhandled-slurm-job-id"] = "101"
+	k8sJob.Annotations["slurm-job-id"] = "101"
+	activeCheckNodeResultFlips.WithLabelValues(activeCheck.Namespace, activeCheck.Name, "worker-5").Set(1)
+
+	submitTime := metav1.NewTime(time.Date(2026, time.April, 13, 10, 0, 0, 0, time.UTC))
+	endTime := metav1.NewTime(submitTime.Add(1 * time.Minute))
+
+	mockClient := slurmapifake.NewMockClient(t)
+	mockClient.EXPECT().GetJobsByIDFromAccounting(mock.Anything, "101").Return([]slurmapi.Job{{
+		ID:         101,
+		Name:       activeCheck.Name,
+		State:      string(api.V0044JobInfoJobStateCOMPLETED),
+		SubmitTime: &submitTime,
+		EndTime:    &endTime,
+		Nodes:      "worker-0",
+	}}, nil).Once()
+
+	reconciler, fakeClient := newActiveCheckJobTestReconciler(t, scheme, activeCheck, cronJob, k8sJob, pod, mockClient)
+
+	_, err := reconciler.Reconcile(ctx, newActiveCheckJobRequest(k8sJob))
+	require.NoError(t, err)
+
+	updatedCheck := &slurmv1alpha1.ActiveCheck{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(activeCheck), updatedCheck))
+	nodes := make([]string, 0, len(updatedCheck.Status.NodeResults))
+	for _, result := range updatedCheck.Status.NodeResults {
+		nodes = append(nodes, result.Node)
+	}
+	assert.Equal(t, []string{"worker-0", "worker-1"}, nodes)
+	assert.False(t, activeCheckNodeResultFlips.DeleteLabelValues(activeCheck.Namespace, activeCheck.Name, "worker-5"),
+		"series of the pruned node must be deleted")
+}
+
 // Slurmdbd lags slurmctld by some interval after sbatch — the just-submitted job ID may not yet
 // resolve on `/slurmdb/.../job/{id}`. The reconciler must requeue in that case rather than treat
 // "no rows" as success and finalize the run as Complete.
@@ -562,6 +608,7 @@ func TestActiveCheckJobReconciler_Reconcile_FailedSlurmJobExecutesCommentReactio
 		EndTime:     &failedEndTime,
 		Nodes:       "worker-0",
 	}}, nil).Once()
+	mockClient.EXPECT().ListNodes(mock.Anything).Return([]slurmapi.Node{{Name: "worker-0"}}, nil).Once()
 	mockClient.EXPECT().
 		SlurmV0044PostNodeWithResponse(
 			mock.Anything,
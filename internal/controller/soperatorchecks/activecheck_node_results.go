@@ -0,0 +1,212 @@
+package soperatorchecks
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/slurmapi"
+)
+
+var (
+	activeCheckNodeLastPassTime = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "soperator_activecheck_node_last_pass_timestamp_seconds",
+		Help: "End time of the last Slurm job of the ActiveCheck which passed on the node",
+	}, []string{"namespace", "activecheck", "node"})
+
+	activeCheckNodeLastFailTime = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "soperator_activecheck_node_last_fail_timestamp_seconds",
+		Help: "End time of the last Slurm job of the ActiveCheck which failed on the node",
+	}, []string{"namespace", "activecheck", "node"})
+
+	activeCheckNodeResultFlips = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "soperator_activecheck_node_result_flips",
+		Help: "Number of changes between passed and failed of the ActiveCheck on the node",
+	}, []string{"namespace", "activecheck", "node"})
+
+	activeCheckNodeResultValue = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "soperator_activecheck_node_result_value",
+		Help: "Value of a result metric published by the last Slurm job of the ActiveCheck on the node",
+	}, []string{"namespace", "activecheck", "node", "metric"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		activeCheckNodeLastPassTime,
+		activeCheckNodeLastFailTime,
+		activeCheckNodeResultFlips,
+		activeCheckNodeResultValue,
+	)
+}
+
+// nodeResultOfJob returns the outcome of a finished Slurm job of a check.
+func nodeResultOfJob(slurmJob slurmapi.Job) consts.ActiveCheckNodeResult {
+	switch {
+	case slurmJob.IsCompletedState():
+		return consts.ActiveCheckNodeResultPassed
+	case slurmJob.IsFailedState():
+		return consts.ActiveCheckNodeResultFailed
+	case slurmJob.IsCancelledState():
+		return consts.ActiveCheckNodeResultCancelled
+	default:
+		return consts.ActiveCheckNodeResultError
+	}
+}
+
+// recordNodeResults adds the outcome of a finished Slurm job to the results of every node the job ran on.
+func recordNodeResults(
+	results []slurmv1alpha1.ActiveCheckNodeResult,
+	slurmJob slurmapi.Job,
+	jobOutcome slurmJobOutcome,
+	nodes []string,
+) []slurmv1alpha1.ActiveCheckNodeResult {
+	if slurmJob.EndTime == nil {
+		return results
+	}
+
+	outcome := slurmv1alpha1.ActiveCheckNodeOutcome{
+		Result: jobOutcome.Result,
+		JobID:  slurmJob.GetIDString(),
+		Time:   *slurmJob.EndTime,
+		Reason: jobOutcome.Reason,
+	}
+
+	for _, node := range nodes {
+		i := sort.Search(len(results), func(i int) bool { return results[i].Node >= node })
+		if i == len(results) || results[i].Node != node {
+			results = append(results, slurmv1alpha1.ActiveCheckNodeResult{})
+			copy(results[i+1:], results[i:])
+			results[i] = slurmv1alpha1.ActiveCheckNodeResult{Node: node}
+		}
+		result := &results[i]
+
+		endTime := outcome.Time
+		// Jobs may be handled out of order of their end times, so older outcomes don't affect the counters.
+		if result.LastResult == nil || !endTime.Before(&result.LastResult.Time) {
+			previous := lastConclusiveResult(result)
+			switch outcome.Result {
+			case consts.ActiveCheckNodeResultPassed:
+				result.ConsecutiveFailures = 0
+				if previous == consts.ActiveCheckNodeResultFailed {
+					result.ResultFlips++
+				}
+			case consts.ActiveCheckNodeResultFailed:
+				result.ConsecutiveFailures++
+				if previous == consts.ActiveCheckNodeResultPassed {
+					result.ResultFlips++
+				}
+			}
+			lastResult := outcome
+			result.LastResult = &lastResult
+		}
+
+		switch outcome.Result {
+		case consts.ActiveCheckNodeResultPassed:
+			if result.LastPassTime == nil || result.LastPassTime.Before(&endTime) {
+				result.LastPassTime = &endTime
+			}
+		case consts.ActiveCheckNodeResultFailed:
+			if result.LastFailTime == nil || result.LastFailTime.Before(&endTime) {
+				result.LastFailTime = &endTime
+			}
+		}
+
+		if len(jobOutcome.Metrics) != 0 && (result.LastMetrics == nil || !endTime.Before(&result.LastMetrics.Time)) {
+			result.LastMetrics = &slurmv1alpha1.ActiveCheckNodeMetrics{
+				JobID:  outcome.JobID,
+				Time:   endTime,
+				Values: slices.Clone(jobOutcome.Metrics),
+			}
+		}
+	}
+
+	return results
+}
+
+// lastConclusiveResult returns the latest of passed and failed outcomes recorded for the node, if any.
+func lastConclusiveResult(result *slurmv1alpha1.ActiveCheckNodeResult) consts.ActiveCheckNodeResult {
+	switch {
+	case result.LastPassTime == nil && result.LastFailTime == nil:
+		return ""
+	case result.LastFailTime == nil || (result.LastPassTime != nil && result.LastFailTime.Before(result.LastPassTime)):
+		return consts.ActiveCheckNodeResultPassed
+	default:
+		return consts.ActiveCheckNodeResultFailed
+	}
+}
+
+// pruneNodeResults drops the results of nodes which aren't targeted by the check anymore, and returns the dropped
+// nodes.
+func pruneNodeResults(
+	results []slurmv1alpha1.ActiveCheckNodeResult,
+	targetedNodes []string,
+) ([]slurmv1alpha1.ActiveCheckNodeResult, []string) {
+	var removed []string
+	results = slices.DeleteFunc(results, func(result slurmv1alpha1.ActiveCheckNodeResult) bool {
+		if slices.Contains(targetedNodes, result.Node) {
+			return false
+		}
+		removed = append(removed, result.Node)
+		return true
+	})
+	return results, removed
+}
+
+// observeNodeResults sets the per-node metrics of the check from its status.
+func observeNodeResults(check *slurmv1alpha1.ActiveCheck) {
+	for _, result := range check.Status.NodeResults {
+		labels := []string{check.Namespace, check.Name, result.Node}
+		if result.LastPassTime != nil {
+			activeCheckNodeLastPassTime.WithLabelValues(labels...).Set(timestampSeconds(result.LastPassTime))
+		}
+		if result.LastFailTime != nil {
+			activeCheckNodeLastFailTime.WithLabelValues(labels...).Set(timestampSeconds(result.LastFailTime))
+		}
+		activeCheckNodeResultFlips.WithLabelValues(labels...).Set(float64(result.ResultFlips))
+
+		if result.LastMetrics != nil {
+			// Metrics published by older jobs may be gone from the results.
+			activeCheckNodeResultValue.DeletePartialMatch(prometheus.Labels{
+				"namespace": check.Namespace, "activecheck": check.Name, "node": result.Node,
+			})
+			for _, metric := range result.LastMetrics.Values {
+				value, err := strconv.ParseFloat(metric.Value, 64)
+				if err != nil {
+					continue
+				}
+				activeCheckNodeResultValue.WithLabelValues(append(labels, metric.Name)...).Set(value)
+			}
+		}
+	}
+}
+
+// deleteNodeResultMetrics removes the per-node series of a check.
+func deleteNodeResultMetrics(namespace, checkName string) {
+	labels := prometheus.Labels{"namespace": namespace, "activecheck": checkName}
+	activeCheckNodeLastPassTime.DeletePartialMatch(labels)
+	activeCheckNodeLastFailTime.DeletePartialMatch(labels)
+	activeCheckNodeResultFlips.DeletePartialMatch(labels)
+	activeCheckNodeResultValue.DeletePartialMatch(labels)
+}
+
+// deleteNodeResultMetricsOfNodes removes the series of the nodes whose results were dropped from the status of a check.
+func deleteNodeResultMetricsOfNodes(namespace, checkName string, nodes []string) {
+	for _, node := range nodes {
+		activeCheckNodeLastPassTime.DeleteLabelValues(namespace, checkName, node)
+		activeCheckNodeLastFailTime.DeleteLabelValues(namespace, checkName, node)
+		activeCheckNodeResultFlips.DeleteLabelValues(namespace, checkName, node)
+		activeCheckNodeResultValue.DeletePartialMatch(prometheus.Labels{
+			"namespace": namespace, "activecheck": checkName, "node": node,
+		})
+	}
+}
+
+func timestampSeconds(t *metav1.Time) float64 {
+	return float64(t.UnixNano()) / 1e9
+}
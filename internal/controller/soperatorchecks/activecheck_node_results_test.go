@@ -0,0 +1,111 @@
+package soperatorchecks
+
+import (
+	"testing"
+	"time"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/slurmapi"
+)
+
+func newTestFinishedSlurmJob(id int32, state api.V0044JobInfoJobState, endTime time.Time) slurmapi.Job {
+	end := metav1.NewTime(endTime)
+	return slurmapi.Job{ID: id, State: string(state), EndTime: &end, StateReason: "NonZeroExitCode"}
+}
+
+func recordTestNodeResults(
+	results []slurmv1alpha1.ActiveCheckNodeResult,
+	slurmJob slurmapi.Job,
+	nodes []string,
+) []slurmv1alpha1.ActiveCheckNodeResult {
+	return recordNodeResults(results, slurmJob, evaluateSlurmJob(slurmJob, nil), nodes)
+}
+
+func TestRecordNodeResults(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, time.October, 16, 12, 0, 0, 0, time.UTC)
+
+	var results []slurmv1alpha1.ActiveCheckNodeResult
+	results = recordTestNodeResults(results,
+		newTestFinishedSlurmJob(101, api.V0044JobInfoJobStateCOMPLETED, start), []string{"worker-3", "worker-1"})
+	results = recordTestNodeResults(results,
+		newTestFinishedSlurmJob(103, api.V0044JobInfoJobStateFAILED, start.Add(2*time.Hour)), []string{"worker-3"})
+	// Handled after a job which ended later.
+	results = recordTestNodeResults(results,
+		newTestFinishedSlurmJob(102, api.V0044JobInfoJobStateCOMPLETED, start.Add(time.Hour)), []string{"worker-3", "worker-2"})
+	results = recordTestNodeResults(results,
+		newTestFinishedSlurmJob(104, api.V0044JobInfoJobStateCANCELLED, start.Add(3*time.Hour)), []string{"worker-3"})
+
+	require.Len(t, results, 3)
+	assert.Equal(t, "worker-1", results[0].Node)
+	assert.Equal(t, "worker-2", results[1].Node)
+	assert.Equal(t, "worker-3", results[2].Node)
+
+	worker3 := results[2]
+	require.NotNil(t, worker3.LastResult)
+	assert.Equal(t, "104", worker3.LastResult.JobID)
+	assert.Equal(t, consts.ActiveCheckNodeResultCancelled, worker3.LastResult.Result)
+	// The cancelled job neither counts nor resets the failure of job 103.
+	assert.Equal(t, int32(1), worker3.ConsecutiveFailures)
+	assert.Equal(t, int32(1), worker3.ResultFlips)
+	assert.True(t, worker3.LastPassTime.Equal(ptrTime(start.Add(time.Hour))))
+	assert.True(t, worker3.LastFailTime.Equal(ptrTime(start.Add(2*time.Hour))))
+
+	assert.Nil(t, results[0].LastFailTime)
+	require.NotNil(t, results[0].LastResult)
+	assert.Equal(t, "101", results[0].LastResult.JobID)
+	assert.Empty(t, results[0].LastResult.Reason)
+}
+
+func TestRecordNodeResults_Counters(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, time.October, 16, 12, 0, 0, 0, time.UTC)
+	states := []api.V0044JobInfoJobState{
+		api.V0044JobInfoJobStateFAILED,
+		api.V0044JobInfoJobStateFAILED,
+		api.V0044JobInfoJobStatePREEMPTED,
+		api.V0044JobInfoJobStateCOMPLETED,
+		api.V0044JobInfoJobStateFAILED,
+		api.V0044JobInfoJobStateCANCELLED,
+		api.V0044JobInfoJobStateFAILED,
+	}
+
+	var results []slurmv1alpha1.ActiveCheckNodeResult
+	for i, state := range states {
+		results = recordTestNodeResults(results,
+			newTestFinishedSlurmJob(int32(101+i), state, start.Add(time.Duration(i)*time.Hour)), []string{"worker-1"})
+	}
+
+	require.Len(t, results, 1)
+	assert.Equal(t, int32(2), results[0].ConsecutiveFailures)
+	assert.Equal(t, int32(2), results[0].ResultFlips)
+	assert.Equal(t, "107", results[0].LastResult.JobID)
+	assert.Equal(t, "NonZeroExitCode", results[0].LastResult.Reason)
+}
+
+func TestPruneNodeResults(t *testing.T) {
+	t.Parallel()
+
+	results := []slurmv1alpha1.ActiveCheckNodeResult{
+		{Node: "worker-0"},
+		{Node: "worker-1"},
+		{Node: "worker-2"},
+	}
+
+	results, removed := pruneNodeResults(results, []string{"worker-0", "worker-2", "worker-3"})
+	assert.Equal(t, []slurmv1alpha1.ActiveCheckNodeResult{{Node: "worker-0"}, {Node: "worker-2"}}, results)
+	assert.Equal(t, []string{"worker-1"}, removed)
+}
+
+func ptrTime(t time.Time) *metav1.Time {
+	mt := metav1.NewTime(t)
+	return &mt
+}
@@ -0,0 +1,208 @@
+package soperatorchecks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/slurmapi"
+)
+
+const notificationTimeout = 10 * time.Second
+
+// activeCheckNotification is the payload posted to the webhook of the Notify reaction.
+type activeCheckNotification struct {
+	Namespace    string                       `json:"namespace"`
+	ActiveCheck  string                       `json:"activeCheck"`
+	Result       consts.ActiveCheckNodeResult `json:"result"`
+	SlurmJobID   string                       `json:"slurmJobID"`
+	SlurmJobName string                       `json:"slurmJobName,omitempty"`
+	Nodes        []string                     `json:"nodes"`
+	Reason       string                       `json:"reason,omitempty"`
+	EndTime      *time.Time                   `json:"endTime,omitempty"`
+
+	Metrics []slurmv1alpha1.ActiveCheckMetricValue `json:"metrics,omitempty"`
+}
+
+// reactionMessage returns the text identifying the check and the Slurm job in drain reasons, comments and conditions.
+func reactionMessage(activeCheckName string, slurmJob slurmapi.Job) string {
+	return fmt.Sprintf("%s: job %d [slurm_job]", activeCheckName, slurmJob.ID)
+}
+
+func withPrefix(prefix, message string) string {
+	if prefix == "" {
+		return message
+	}
+	return fmt.Sprintf("%s %s", prefix, message)
+}
+
+// consecutiveFailures returns the number of failures of the check on the node since its last pass.
+func consecutiveFailures(results []slurmv1alpha1.ActiveCheckNodeResult, node string) int {
+	for _, result := range results {
+		if result.Node == node {
+			return int(result.ConsecutiveFailures)
+		}
+	}
+	return 0
+}
+
+// nodesReachedFailureThreshold returns the nodes the check has failed on at least threshold times in a row.
+func nodesReachedFailureThreshold(results []slurmv1alpha1.ActiveCheckNodeResult, nodes []string, threshold int32) []string {
+	if threshold <= 1 {
+		return nodes
+	}
+
+	var reached []string
+	for _, node := range nodes {
+		if consecutiveFailures(results, node) >= int(threshold) {
+			reached = append(reached, node)
+		}
+	}
+	return reached
+}
+
+func rebootSlurmNodes(
+	ctx context.Context,
+	logger logr.Logger,
+	slurmJob slurmapi.Job,
+	nodes []string,
+	activeCheckName string,
+	spec slurmv1alpha1.RebootSlurmNodeSpec,
+	slurmAPIClient slurmapi.Client,
+) error {
+	request := slurmapi.RebootNodesRequest{
+		NodeList: strings.Join(nodes, ","),
+		ASAP:     true,
+		Reason:   withPrefix(spec.ReasonPrefix, reactionMessage(activeCheckName, slurmJob)),
+	}
+	if spec.NextState != "" {
+		request.NextState = []slurmapi.RebootNextState{slurmapi.RebootNextState(spec.NextState)}
+	}
+
+	if err := slurmAPIClient.RebootNodes(ctx, request); err != nil {
+		return fmt.Errorf("reboot slurm nodes: %w", err)
+	}
+
+	logger.V(1).Info("slurm nodes reboot is scheduled", "nodes", nodes)
+	return nil
+}
+
+// setHardwareIssuesSuspected sets the HardwareIssuesSuspected condition on the K8s nodes running the worker pods of
+// the slurm nodes. The K8s nodes controller then drains them, which leads to the node replacement.
+func (r *ActiveCheckJobReconciler) setHardwareIssuesSuspected(
+	ctx context.Context,
+	logger logr.Logger,
+	slurmJob slurmapi.Job,
+	nodes []string,
+	activeCheck *slurmv1alpha1.ActiveCheck,
+	spec slurmv1alpha1.SetHardwareIssuesSuspectedSpec,
+) error {
+	message := withPrefix(spec.MessagePrefix, reactionMessage(activeCheck.Name, slurmJob))
+	for _, node := range nodes {
+		workerPod := &corev1.Pod{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: activeCheck.Namespace, Name: node}, workerPod); err != nil {
+			if client.IgnoreNotFound(err) == nil {
+				logger.Info("Worker pod not found, skipping HardwareIssuesSuspected condition", "slurmNode", node)
+				continue
+			}
+			return fmt.Errorf("get worker pod %s: %w", node, err)
+		}
+		if workerPod.Spec.NodeName == "" {
+			logger.Info("Worker pod is not scheduled, skipping HardwareIssuesSuspected condition", "slurmNode", node)
+			continue
+		}
+
+		cond := newNodeCondition(
+			consts.HardwareIssuesSuspected,
+			corev1.ConditionTrue,
+			consts.ReasonActiveCheckFailed,
+			consts.MessageConditionType(message),
+		)
+		if err := setK8SNodeCondition(ctx, r.Client, workerPod.Spec.NodeName, cond); err != nil {
+			return fmt.Errorf("set k8s node condition: %w", err)
+		}
+
+		logger.V(1).Info("HardwareIssuesSuspected condition is set", "slurmNode", node, "k8sNode", workerPod.Spec.NodeName)
+	}
+
+	return nil
+}
+
+// notify posts the outcome of the Slurm job to the webhook of the Notify reaction.
+func (r *ActiveCheckJobReconciler) notify(
+	ctx context.Context,
+	slurmJob slurmapi.Job,
+	outcome slurmJobOutcome,
+	nodes []string,
+	activeCheck *slurmv1alpha1.ActiveCheck,
+	spec slurmv1alpha1.NotifySpec,
+) error {
+	url := spec.URL
+	if spec.URLSecretRef != nil {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{
+			Namespace: activeCheck.Namespace,
+			Name:      spec.URLSecretRef.Name,
+		}, secret); err != nil {
+			return fmt.Errorf("get webhook URL secret: %w", err)
+		}
+		value, ok := secret.Data[spec.URLSecretRef.Key]
+		if !ok {
+			return fmt.Errorf("key %q not found in secret %s", spec.URLSecretRef.Key, spec.URLSecretRef.Name)
+		}
+		url = strings.TrimSpace(string(value))
+	}
+
+	notification := activeCheckNotification{
+		Namespace:    activeCheck.Namespace,
+		ActiveCheck:  activeCheck.Name,
+		Result:       outcome.Result,
+		SlurmJobID:   slurmJob.GetIDString(),
+		SlurmJobName: slurmJob.Name,
+		Nodes:        nodes,
+		Reason:       outcome.Reason,
+		Metrics:      outcome.Metrics,
+	}
+	if slurmJob.EndTime != nil {
+		notification.EndTime = &slurmJob.EndTime.Time
+	}
+
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("marshal notification: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, notificationTimeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create notification request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := r.httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("post notification: %w", err)
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("notification webhook responded with status %d", response.StatusCode)
+	}
+
+	return nil
+}
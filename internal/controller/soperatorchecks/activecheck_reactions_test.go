@@ -0,0 +1,248 @@
+package soperatorchecks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/slurmapi"
+	slurmapifake "nebius.ai/slurm-operator/internal/slurmapi/fake"
+)
+
+func TestNodesReachedFailureThreshold(t *testing.T) {
+	t.Parallel()
+
+	results := []slurmv1alpha1.ActiveCheckNodeResult{
+		{Node: "worker-0", ConsecutiveFailures: 2},
+		{Node: "worker-1", ConsecutiveFailures: 1},
+	}
+	nodes := []string{"worker-0", "worker-1", "worker-2"}
+
+	assert.Equal(t, nodes, nodesReachedFailureThreshold(results, nodes, 1))
+	assert.Equal(t, []string{"worker-0"}, nodesReachedFailureThreshold(results, nodes, 2))
+	assert.Empty(t, nodesReachedFailureThreshold(results, nodes, 3))
+}
+
+func newActiveCheckReactionsTestReconciler(
+	t *testing.T,
+	activeCheck *slurmv1alpha1.ActiveCheck,
+	slurmClient slurmapi.Client,
+	objects ...client.Object,
+) (*ActiveCheckJobReconciler, client.Client) {
+	t.Helper()
+
+	scheme := newActiveCheckJobTestScheme(t)
+	fakeClient := clientfake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(activeCheck, &corev1.Node{}).
+		WithObjects(append(objects, activeCheck)...).
+		Build()
+
+	slurmClients := slurmapi.NewClientSet(context.Background())
+	slurmClients.AddClient(types.NamespacedName{
+		Namespace: activeCheck.Namespace,
+		Name:      activeCheck.Spec.SlurmClusterRefName,
+	}, slurmClient)
+
+	return NewActiveCheckJobController(
+		fakeClient,
+		scheme,
+		record.NewFakeRecorder(10),
+		slurmClients,
+		time.Minute,
+	), fakeClient
+}
+
+func TestActiveCheckJobReconciler_Reconcile_FailureReactionsAfterThreshold(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	activeCheck, cronJob, k8sJob, pod := newActiveCheckJobTestObjects("gpu-check", "gpu-check-123", "slurmJob")
+	activeCheck.Spec.FailureReactionsThreshold = ptr.To(int32(2))
+	activeCheck.Spec.FailureReactions = &slurmv1alpha1.Reactions{
+		RebootSlurmNode:            &slurmv1alpha1.RebootSlurmNodeSpec{ReasonPrefix: "[node_problem]", NextState: "DOWN"},
+		SetHardwareIssuesSuspected: &slurmv1alpha1.SetHardwareIssuesSuspectedSpec{},
+	}
+	previousEndTime := metav1.NewTime(time.Date(2026, time.April, 13, 9, 0, 0, 0, time.UTC))
+	activeCheck.Status.NodeResults = []slurmv1alpha1.ActiveCheckNodeResult{{
+		Node: "worker-0",
+		LastResult: &slurmv1alpha1.ActiveCheckNodeOutcome{
+			Result: consts.ActiveCheckNodeResultFailed,
+			JobID:  "100",
+			Time:   previousEndTime,
+		},
+		LastFailTime:        &previousEndTime,
+		ConsecutiveFailures: 1,
+	}}
+	k8sJob.Annotations["unhandled-slurm-job-id"] = "101"
+	k8sJob.Annotations["slurm-job-id"] = "101"
+
+	workerPods := []client.Object{
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-0", Namespace: activeCheck.Namespace},
+			Spec:       corev1.PodSpec{NodeName: "node-a"},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-1", Namespace: activeCheck.Namespace},
+			Spec:       corev1.PodSpec{NodeName: "node-b"},
+		},
+	}
+	k8sNodes := []client.Object{
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-b"}},
+	}
+
+	endTime := metav1.NewTime(time.Date(2026, time.April, 13, 10, 0, 0, 0, time.UTC))
+	mockClient := slurmapifake.NewMockClient(t)
+	mockClient.EXPECT().GetJobsByIDFromAccounting(mock.Anything, "101").Return([]slurmapi.Job{{
+		ID:          101,
+		Name:        activeCheck.Name,
+		State:       string(api.V0044JobInfoJobStateFAILED),
+		StateReason: "boom",
+		EndTime:     &endTime,
+		Nodes:       "worker-[0-1]",
+	}}, nil).Once()
+	mockClient.EXPECT().ListNodes(mock.Anything).Return([]slurmapi.Node{{Name: "worker-0"}, {Name: "worker-1"}}, nil).Once()
+	mockClient.EXPECT().RebootNodes(mock.Anything, slurmapi.RebootNodesRequest{
+		NodeList:  "worker-0",
+		ASAP:      true,
+		NextState: []slurmapi.RebootNextState{slurmapi.RebootNextStateDown},
+		Reason:    "[node_problem] gpu-check: job 101 [slurm_job]",
+	}).Return(nil).Once()
+
+	objects := append([]client.Object{cronJob, k8sJob, pod}, append(workerPods, k8sNodes...)...)
+	reconciler, fakeClient := newActiveCheckReactionsTestReconciler(t, activeCheck, mockClient, objects...)
+
+	_, err := reconciler.Reconcile(ctx, newActiveCheckJobRequest(k8sJob))
+	require.NoError(t, err)
+
+	nodeA := &corev1.Node{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: "node-a"}, nodeA))
+	require.Len(t, nodeA.Status.Conditions, 1)
+	assert.Equal(t, consts.HardwareIssuesSuspected, nodeA.Status.Conditions[0].Type)
+	assert.Equal(t, corev1.ConditionTrue, nodeA.Status.Conditions[0].Status)
+	assert.Equal(t, string(consts.ReasonActiveCheckFailed), nodeA.Status.Conditions[0].Reason)
+
+	nodeB := &corev1.Node{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Name: "node-b"}, nodeB))
+	assert.Empty(t, nodeB.Status.Conditions, "the first failure on worker-1 must not reach the threshold")
+}
+
+func TestActiveCheckJobReconciler_Reconcile_NotifyReaction(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	received := make(chan activeCheckNotification, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var notification activeCheckNotification
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&notification))
+		received <- notification
+	}))
+	defer server.Close()
+
+	activeCheck, cronJob, k8sJob, pod := newActiveCheckJobTestObjects("gpu-check", "gpu-check-123", "slurmJob")
+	activeCheck.Spec.SuccessReactions = &slurmv1alpha1.Reactions{
+		Notify: &slurmv1alpha1.NotifySpec{
+			URLSecretRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "webhook"},
+				Key:                  "url",
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "webhook", Namespace: activeCheck.Namespace},
+		Data:       map[string][]byte{"url": []byte(server.URL + "\n")},
+	}
+	k8sJob.Annotations["unhandled-slurm-job-id"] = "101"
+	k8sJob.Annotations["slurm-job-id"] = "101"
+
+	endTime := metav1.NewTime(time.Date(2026, time.April, 13, 10, 0, 0, 0, time.UTC))
+	mockClient := slurmapifake.NewMockClient(t)
+	mockClient.EXPECT().GetJobsByIDFromAccounting(mock.Anything, "101").Return([]slurmapi.Job{{
+		ID:      101,
+		Name:    activeCheck.Name,
+		State:   string(api.V0044JobInfoJobStateCOMPLETED),
+		EndTime: &endTime,
+		Nodes:   "worker-0",
+	}}, nil).Once()
+	mockClient.EXPECT().ListNodes(mock.Anything).Return([]slurmapi.Node{{Name: "worker-0"}}, nil).Once()
+
+	reconciler, _ := newActiveCheckReactionsTestReconciler(t, activeCheck, mockClient, cronJob, k8sJob, pod, secret)
+
+	_, err := reconciler.Reconcile(ctx, newActiveCheckJobRequest(k8sJob))
+	require.NoError(t, err)
+
+	select {
+	case notification := <-received:
+		assert.Equal(t, activeCheck.Namespace, notification.Namespace)
+		assert.Equal(t, "gpu-check", notification.ActiveCheck)
+		assert.Equal(t, consts.ActiveCheckNodeResultPassed, notification.Result)
+		assert.Equal(t, "101", notification.SlurmJobID)
+		assert.Equal(t, []string{"worker-0"}, notification.Nodes)
+		assert.True(t, endTime.Time.Equal(*notification.EndTime))
+	default:
+		t.Fatal("notification was not posted")
+	}
+}
+
+func TestActiveCheckJobReconciler_Reconcile_FailedNotificationDoesNotBlockStatus(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	activeCheck, cronJob, k8sJob, pod := newActiveCheckJobTestObjects("gpu-check", "gpu-check-123", "slurmJob")
+	activeCheck.Spec.FailureReactions = &slurmv1alpha1.Reactions{
+		Notify: &slurmv1alpha1.NotifySpec{URL: server.URL},
+	}
+	k8sJob.Annotations["unhandled-slurm-job-id"] = "101"
+	k8sJob.Annotations["slurm-job-id"] = "101"
+
+	endTime := metav1.NewTime(time.Date(2026, time.April, 13, 10, 0, 0, 0, time.UTC))
+	mockClient := slurmapifake.NewMockClient(t)
+	mockClient.EXPECT().GetJobsByIDFromAccounting(mock.Anything, "101").Return([]slurmapi.Job{{
+		ID:          101,
+		Name:        activeCheck.Name,
+		State:       string(api.V0044JobInfoJobStateFAILED),
+		StateReason: "boom",
+		EndTime:     &endTime,
+		Nodes:       "worker-0",
+	}}, nil).Once()
+	mockClient.EXPECT().ListNodes(mock.Anything).Return([]slurmapi.Node{{Name: "worker-0"}}, nil).Once()
+
+	reconciler, fakeClient := newActiveCheckReactionsTestReconciler(t, activeCheck, mockClient, cronJob, k8sJob, pod)
+
+	_, err := reconciler.Reconcile(ctx, newActiveCheckJobRequest(k8sJob))
+	require.NoError(t, err)
+
+	updatedCheck := &slurmv1alpha1.ActiveCheck{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(activeCheck), updatedCheck))
+	assert.Equal(t, consts.ActiveCheckSlurmRunStatusFailed, updatedCheck.Status.SlurmJobsStatus.LastRunStatus)
+
+	recorder := reconciler.Recorder.(*record.FakeRecorder)
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, consts.ActiveCheckEventNotificationFailed)
+}
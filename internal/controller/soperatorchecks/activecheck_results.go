@@ -0,0 +1,135 @@
+package soperatorchecks
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/slurmapi"
+)
+
+// maxResultMetrics limits the number of metrics kept per Slurm job, so that the results can't bloat the status.
+const maxResultMetrics = 32
+
+// slurmJobOutcome is the outcome of a finished Slurm job of a check, taking the results it published into account.
+type slurmJobOutcome struct {
+	Result  consts.ActiveCheckNodeResult
+	Reason  string
+	Metrics []slurmv1alpha1.ActiveCheckMetricValue
+}
+
+// evaluateSlurmJob returns the outcome of a finished Slurm job of a check.
+// If the check declares results, a completed job passes only if it published them and they're within the thresholds.
+func evaluateSlurmJob(slurmJob slurmapi.Job, spec *slurmv1alpha1.SlurmJobResultsSpec) slurmJobOutcome {
+	outcome := slurmJobOutcome{Result: nodeResultOfJob(slurmJob)}
+	if outcome.Result != consts.ActiveCheckNodeResultPassed {
+		outcome.Reason = slurmJob.StateReason
+	}
+	if spec == nil {
+		return outcome
+	}
+
+	metrics, err := parseJSONResults(slurmJob.Comment)
+	if err != nil {
+		// Jobs which didn't pass usually don't get to publish their results.
+		if outcome.Result == consts.ActiveCheckNodeResultPassed {
+			outcome.Result = consts.ActiveCheckNodeResultError
+			outcome.Reason = fmt.Sprintf("invalid results: %v", err)
+		}
+		return outcome
+	}
+	outcome.Metrics = metricValues(metrics)
+
+	if outcome.Result == consts.ActiveCheckNodeResultPassed {
+		if violations := checkThresholds(metrics, spec.Thresholds); len(violations) != 0 {
+			outcome.Result = consts.ActiveCheckNodeResultFailed
+			outcome.Reason = strings.Join(violations, "; ")
+		}
+	}
+
+	return outcome
+}
+
+// parseJSONResults parses the numeric values of a JSON object. Nested objects are flattened with dot-separated keys,
+// values of other types are ignored.
+func parseJSONResults(comment string) (map[string]float64, error) {
+	if strings.TrimSpace(comment) == "" {
+		return nil, fmt.Errorf("no results in the Slurm job comment")
+	}
+
+	var object map[string]any
+	if err := json.Unmarshal([]byte(comment), &object); err != nil {
+		return nil, fmt.Errorf("parse Slurm job comment as JSON object: %w", err)
+	}
+
+	metrics := map[string]float64{}
+	flattenJSONResults(metrics, "", object)
+	if len(metrics) == 0 {
+		return nil, fmt.Errorf("no numeric values in the Slurm job comment")
+	}
+	if len(metrics) > maxResultMetrics {
+		return nil, fmt.Errorf("too many values in the Slurm job comment: %d, at most %d are supported",
+			len(metrics), maxResultMetrics)
+	}
+
+	return metrics, nil
+}
+
+func flattenJSONResults(metrics map[string]float64, prefix string, object map[string]any) {
+	for key, value := range object {
+		name := key
+		if prefix != "" {
+			name = prefix + "." + key
+		}
+
+		switch v := value.(type) {
+		case float64:
+			metrics[name] = v
+		case map[string]any:
+			flattenJSONResults(metrics, name, v)
+		}
+	}
+}
+
+// checkThresholds returns the descriptions of the metrics which are missing or out of their thresholds.
+func checkThresholds(metrics map[string]float64, thresholds []slurmv1alpha1.SlurmJobResultThreshold) []string {
+	var violations []string
+	for _, threshold := range thresholds {
+		value, ok := metrics[threshold.Metric]
+		if !ok {
+			violations = append(violations, fmt.Sprintf("%s is missing", threshold.Metric))
+			continue
+		}
+
+		if threshold.Min != nil {
+			if minValue := threshold.Min.AsApproximateFloat64(); value < minValue {
+				violations = append(violations, fmt.Sprintf("%s=%s is below the minimum %s",
+					threshold.Metric, formatMetricValue(value), formatMetricValue(minValue)))
+			}
+		}
+		if threshold.Max != nil {
+			if maxValue := threshold.Max.AsApproximateFloat64(); value > maxValue {
+				violations = append(violations, fmt.Sprintf("%s=%s is above the maximum %s",
+					threshold.Metric, formatMetricValue(value), formatMetricValue(maxValue)))
+			}
+		}
+	}
+	return violations
+}
+
+func metricValues(metrics map[string]float64) []slurmv1alpha1.ActiveCheckMetricValue {
+	values := make([]slurmv1alpha1.ActiveCheckMetricValue, 0, len(metrics))
+	for name, value := range metrics {
+		values = append(values, slurmv1alpha1.ActiveCheckMetricValue{Name: name, Value: formatMetricValue(value)})
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].Name < values[j].Name })
+	return values
+}
+
+func formatMetricValue(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
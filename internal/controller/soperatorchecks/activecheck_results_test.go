@@ -0,0 +1,178 @@
+package soperatorchecks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/slurmapi"
+	slurmapifake "nebius.ai/slurm-operator/internal/slurmapi/fake"
+)
+
+func TestEvaluateSlurmJob(t *testing.T) {
+	t.Parallel()
+
+	spec := &slurmv1alpha1.SlurmJobResultsSpec{
+		Format: "JSON",
+		Thresholds: []slurmv1alpha1.SlurmJobResultThreshold{
+			{Metric: "busbw_gbps", Min: ptr.To(resource.MustParse("180"))},
+			{Metric: "latency.p99_us", Max: ptr.To(resource.MustParse("20.5"))},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		state       api.V0044JobInfoJobState
+		comment     string
+		spec        *slurmv1alpha1.SlurmJobResultsSpec
+		wantResult  consts.ActiveCheckNodeResult
+		wantReason  string
+		wantMetrics []slurmv1alpha1.ActiveCheckMetricValue
+	}{
+		{
+			name:       "results are not declared",
+			state:      api.V0044JobInfoJobStateCOMPLETED,
+			wantResult: consts.ActiveCheckNodeResultPassed,
+		},
+		{
+			name:       "within thresholds",
+			state:      api.V0044JobInfoJobStateCOMPLETED,
+			comment:    `{"busbw_gbps": 182.5, "latency": {"p99_us": 18}, "host": "worker-0"}`,
+			spec:       spec,
+			wantResult: consts.ActiveCheckNodeResultPassed,
+			wantMetrics: []slurmv1alpha1.ActiveCheckMetricValue{
+				{Name: "busbw_gbps", Value: "182.5"},
+				{Name: "latency.p99_us", Value: "18"},
+			},
+		},
+		{
+			name:       "out of thresholds",
+			state:      api.V0044JobInfoJobStateCOMPLETED,
+			comment:    `{"busbw_gbps": 150, "latency": {"p99_us": 21}}`,
+			spec:       spec,
+			wantResult: consts.ActiveCheckNodeResultFailed,
+			wantReason: "busbw_gbps=150 is below the minimum 180; latency.p99_us=21 is above the maximum 20.5",
+			wantMetrics: []slurmv1alpha1.ActiveCheckMetricValue{
+				{Name: "busbw_gbps", Value: "150"},
+				{Name: "latency.p99_us", Value: "21"},
+			},
+		},
+		{
+			name:       "missing metric",
+			state:      api.V0044JobInfoJobStateCOMPLETED,
+			comment:    `{"busbw_gbps": 190}`,
+			spec:       spec,
+			wantResult: consts.ActiveCheckNodeResultFailed,
+			wantReason: "latency.p99_us is missing",
+			wantMetrics: []slurmv1alpha1.ActiveCheckMetricValue{
+				{Name: "busbw_gbps", Value: "190"},
+			},
+		},
+		{
+			name:       "no results",
+			state:      api.V0044JobInfoJobStateCOMPLETED,
+			spec:       spec,
+			wantResult: consts.ActiveCheckNodeResultError,
+			wantReason: "invalid results: no results in the Slurm job comment",
+		},
+		{
+			name:       "failed job without results",
+			state:      api.V0044JobInfoJobStateFAILED,
+			spec:       spec,
+			wantResult: consts.ActiveCheckNodeResultFailed,
+			wantReason: "NonZeroExitCode",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			outcome := evaluateSlurmJob(slurmapi.Job{
+				ID:          101,
+				State:       string(tt.state),
+				StateReason: "NonZeroExitCode",
+				Comment:     tt.comment,
+			}, tt.spec)
+			assert.Equal(t, tt.wantResult, outcome.Result)
+			assert.Equal(t, tt.wantReason, outcome.Reason)
+			assert.Equal(t, tt.wantMetrics, outcome.Metrics)
+		})
+	}
+}
+
+func TestParseJSONResults_Invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, comment := range []string{
+		"busbw=180",
+		`["busbw", 180]`,
+		`{"host": "worker-0"}`,
+	} {
+		_, err := parseJSONResults(comment)
+		assert.Error(t, err, comment)
+	}
+}
+
+func TestActiveCheckJobReconciler_Reconcile_SlurmJobBelowThresholdFails(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	scheme := newActiveCheckJobTestScheme(t)
+
+	activeCheck, cronJob, k8sJob, pod := newActiveCheckJobTestObjects("nccl-check", "nccl-check-123", "slurmJob")
+	activeCheck.Spec.SlurmJobSpec.Results = &slurmv1alpha1.SlurmJobResultsSpec{
+		Format: "JSON",
+		Thresholds: []slurmv1alpha1.SlurmJobResultThreshold{
+			{Metric: "busbw_gbps", Min: ptr.To(resource.MustParse("180"))},
+		},
+	}
+	k8sJob.Annotations["unhandled-slurm-job-id"] = "101"
+	k8sJob.Annotations["slurm-job-id"] = "101"
+
+	endTime := metav1.NewTime(time.Date(2026, time.April, 13, 10, 0, 0, 0, time.UTC))
+	mockClient := slurmapifake.NewMockClient(t)
+	mockClient.EXPECT().GetJobsByIDFromAccounting(mock.Anything, "101").Return([]slurmapi.Job{{
+		ID:          101,
+		Name:        activeCheck.Name,
+		State:       string(api.V0044JobInfoJobStateCOMPLETED),
+		StateReason: "None",
+		EndTime:     &endTime,
+		Nodes:       "worker-0",
+		Comment:     `{"busbw_gbps": 150.25}`,
+	}}, nil).Once()
+	mockClient.EXPECT().ListNodes(mock.Anything).Return([]slurmapi.Node{{Name: "worker-0"}}, nil).Once()
+
+	reconciler, fakeClient := newActiveCheckJobTestReconciler(t, scheme, activeCheck, cronJob, k8sJob, pod, mockClient)
+
+	_, err := reconciler.Reconcile(ctx, newActiveCheckJobRequest(k8sJob))
+	require.NoError(t, err)
+
+	updatedCheck := &slurmv1alpha1.ActiveCheck{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(activeCheck), updatedCheck))
+	assert.Equal(t, consts.ActiveCheckSlurmRunStatusFailed, updatedCheck.Status.SlurmJobsStatus.LastRunStatus)
+	assert.Equal(t, []slurmv1alpha1.JobAndReason{{
+		JobID:  "101",
+		Reason: "busbw_gbps=150.25 is below the minimum 180",
+	}}, updatedCheck.Status.SlurmJobsStatus.LastRunFailJobsAndReasons)
+
+	require.Len(t, updatedCheck.Status.NodeResults, 1)
+	nodeResult := updatedCheck.Status.NodeResults[0]
+	require.NotNil(t, nodeResult.LastResult)
+	assert.Equal(t, consts.ActiveCheckNodeResultFailed, nodeResult.LastResult.Result)
+	require.NotNil(t, nodeResult.LastMetrics)
+	assert.Equal(t, "101", nodeResult.LastMetrics.JobID)
+	assert.Equal(t, []slurmv1alpha1.ActiveCheckMetricValue{{Name: "busbw_gbps", Value: "150.25"}},
+		nodeResult.LastMetrics.Values)
+}
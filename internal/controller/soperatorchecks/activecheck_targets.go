@@ -0,0 +1,94 @@
+package soperatorchecks
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	slurmv1 "nebius.ai/slurm-operator/api/v1"
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/render/common"
+)
+
+// errInvalidActiveCheckTargets is returned when the targets of a check can't be resolved into Slurm nodes.
+var errInvalidActiveCheckTargets = errors.New("invalid targets")
+
+// resolveTargetNodeList returns the hostlist expression of the Slurm nodes selected by the targets of a check.
+// nodeSets are the NodeSets of the Slurm cluster.
+func resolveTargetNodeList(
+	targets *slurmv1alpha1.ActiveCheckTargets,
+	slurmCluster *slurmv1.SlurmCluster,
+	nodeSets []slurmv1alpha1.NodeSet,
+) (string, error) {
+	if targets == nil {
+		return "", nil
+	}
+
+	selected := map[string]bool{}
+	exists := func(name string) bool {
+		return slices.ContainsFunc(nodeSets, func(nodeSet slurmv1alpha1.NodeSet) bool {
+			return nodeSet.Name == name
+		})
+	}
+
+	for _, nodeSetName := range targets.NodeSetRefs {
+		if !exists(nodeSetName) {
+			return "", fmt.Errorf("%w: NodeSet %s not found", errInvalidActiveCheckTargets, nodeSetName)
+		}
+		selected[nodeSetName] = true
+	}
+
+	if len(targets.Partitions) != 0 &&
+		slurmCluster.Spec.PartitionConfiguration.ConfigType != slurmv1.PartitionConfigTypeStructured {
+		return "", fmt.Errorf("%w: partitions can only be targeted with the %s partition configuration",
+			errInvalidActiveCheckTargets, slurmv1.PartitionConfigTypeStructured)
+	}
+	for _, partitionName := range targets.Partitions {
+		index := slices.IndexFunc(slurmCluster.Spec.PartitionConfiguration.Partitions, func(partition slurmv1.Partition) bool {
+			return partition.Name == partitionName
+		})
+		if index < 0 {
+			return "", fmt.Errorf("%w: partition %s not found", errInvalidActiveCheckTargets, partitionName)
+		}
+
+		partition := slurmCluster.Spec.PartitionConfiguration.Partitions[index]
+		if partition.IsAll {
+			for _, nodeSet := range nodeSets {
+				selected[nodeSet.Name] = true
+			}
+			continue
+		}
+		// NodeSets missing from the cluster are left out of the partition in slurm.conf as well.
+		for _, nodeSetName := range partition.NodeSetRefs {
+			if exists(nodeSetName) {
+				selected[nodeSetName] = true
+			}
+		}
+	}
+
+	for _, nodeSet := range nodeSets {
+		for _, feature := range nodeSet.Spec.NodeConfig.Features {
+			if slices.Contains(targets.Features, feature) {
+				selected[nodeSet.Name] = true
+				break
+			}
+		}
+	}
+
+	var nodeLists []string
+	for _, nodeSet := range nodeSets {
+		if selected[nodeSet.Name] && nodeSet.Spec.Replicas > 0 {
+			nodeLists = append(nodeLists, common.RenderNodeSetNodeList(nodeSet.Name, nodeSet.Spec.Replicas))
+		}
+	}
+	if targets.NodeList != "" {
+		nodeLists = append(nodeLists, targets.NodeList)
+	}
+
+	if len(nodeLists) == 0 {
+		return "", fmt.Errorf("%w: no Slurm nodes are targeted", errInvalidActiveCheckTargets)
+	}
+
+	return strings.Join(nodeLists, ","), nil
+}
@@ -0,0 +1,118 @@
+package soperatorchecks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	slurmv1 "nebius.ai/slurm-operator/api/v1"
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+)
+
+func TestResolveTargetNodeList(t *testing.T) {
+	t.Parallel()
+
+	newNodeSet := func(name string, replicas int32, features ...string) slurmv1alpha1.NodeSet {
+		return slurmv1alpha1.NodeSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: slurmv1alpha1.NodeSetSpec{
+				Replicas:   replicas,
+				NodeConfig: slurmv1alpha1.NodeConfig{Features: features},
+			},
+		}
+	}
+	nodeSets := []slurmv1alpha1.NodeSet{
+		newNodeSet("cpu", 4),
+		newNodeSet("h100", 8, "platform-h100"),
+		newNodeSet("h200", 1, "platform-h200"),
+		newNodeSet("spare", 0, "platform-h100"),
+	}
+
+	slurmCluster := &slurmv1.SlurmCluster{
+		Spec: slurmv1.SlurmClusterSpec{
+			PartitionConfiguration: slurmv1.PartitionConfiguration{
+				ConfigType: slurmv1.PartitionConfigTypeStructured,
+				Partitions: []slurmv1.Partition{
+					{Name: "main", IsAll: true},
+					{Name: "gpu", NodeSetRefs: []string{"h100", "h200", "gone"}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		targets *slurmv1alpha1.ActiveCheckTargets
+		cluster *slurmv1.SlurmCluster
+		want    string
+		wantErr string
+	}{
+		{
+			name: "no targets",
+			want: "",
+		},
+		{
+			name:    "NodeSets and node list",
+			targets: &slurmv1alpha1.ActiveCheckTargets{NodeSetRefs: []string{"h200", "cpu"}, NodeList: "worker-[0-1]"},
+			want:    "cpu-[0-3],h200-0,worker-[0-1]",
+		},
+		{
+			name:    "features",
+			targets: &slurmv1alpha1.ActiveCheckTargets{Features: []string{"platform-h100"}},
+			want:    "h100-[0-7]",
+		},
+		{
+			name:    "partition with NodeSet refs",
+			targets: &slurmv1alpha1.ActiveCheckTargets{Partitions: []string{"gpu"}, Features: []string{"platform-h100"}},
+			want:    "h100-[0-7],h200-0",
+		},
+		{
+			name:    "partition with all nodes",
+			targets: &slurmv1alpha1.ActiveCheckTargets{Partitions: []string{"main"}},
+			want:    "cpu-[0-3],h100-[0-7],h200-0",
+		},
+		{
+			name:    "missing NodeSet",
+			targets: &slurmv1alpha1.ActiveCheckTargets{NodeSetRefs: []string{"b200"}},
+			wantErr: "NodeSet b200 not found",
+		},
+		{
+			name:    "missing partition",
+			targets: &slurmv1alpha1.ActiveCheckTargets{Partitions: []string{"cpu"}},
+			wantErr: "partition cpu not found",
+		},
+		{
+			name:    "partitions without structured configuration",
+			targets: &slurmv1alpha1.ActiveCheckTargets{Partitions: []string{"main"}},
+			cluster: &slurmv1.SlurmCluster{},
+			wantErr: "partitions can only be targeted with the structured partition configuration",
+		},
+		{
+			name:    "no nodes",
+			targets: &slurmv1alpha1.ActiveCheckTargets{NodeSetRefs: []string{"spare"}},
+			wantErr: "no Slurm nodes are targeted",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cluster := tt.cluster
+			if cluster == nil {
+				cluster = slurmCluster
+			}
+
+			got, err := resolveTargetNodeList(tt.targets, cluster, nodeSets)
+			if tt.wantErr != "" {
+				require.ErrorIs(t, err, errInvalidActiveCheckTargets)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,304 @@
+package soperatorchecks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/controller/reconciler"
+	"nebius.ai/slurm-operator/internal/controllerconfig"
+	render "nebius.ai/slurm-operator/internal/render/soperatorchecks"
+	"nebius.ai/slurm-operator/internal/slurmapi"
+)
+
+var (
+	SlurmActiveCheckRunControllerName = "soperatorchecks.activecheckrun"
+)
+
+// ActiveCheckRunReconciler runs an ActiveCheck on demand for every ActiveCheckRun, and tracks the run to completion.
+// The K8s Job of the run is created from the job template of the check's CronJob, so the Slurm jobs it submits are
+// also handled by the ActiveCheckJobReconciler, which executes the check's reactions and updates its status.
+type ActiveCheckRunReconciler struct {
+	*reconciler.Reconciler
+	slurmAPIClients *slurmapi.ClientSet
+	requeueAfter    time.Duration
+}
+
+func NewActiveCheckRunController(
+	client client.Client,
+	scheme *runtime.Scheme,
+	recorder record.EventRecorder,
+	slurmAPIClients *slurmapi.ClientSet,
+	requeueAfter time.Duration,
+) *ActiveCheckRunReconciler {
+	return &ActiveCheckRunReconciler{
+		Reconciler:      reconciler.NewReconciler(client, scheme, recorder),
+		slurmAPIClients: slurmAPIClients,
+		requeueAfter:    requeueAfter,
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ActiveCheckRunReconciler) SetupWithManager(
+	mgr ctrl.Manager,
+	maxConcurrency int,
+	cacheSyncTimeout time.Duration,
+) error {
+	return ctrl.NewControllerManagedBy(mgr).Named(SlurmActiveCheckRunControllerName).
+		For(&slurmv1alpha1.ActiveCheckRun{}).
+		Owns(&batchv1.Job{}).
+		WithOptions(controllerconfig.ControllerOptions(maxConcurrency, cacheSyncTimeout)).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=slurm.nebius.ai,resources=activecheckruns,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=slurm.nebius.ai,resources=activecheckruns/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=slurm.nebius.ai,resources=activechecks,verbs=get;list;watch
+// +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create
+
+// Reconcile creates the K8s Job of an ActiveCheckRun and reflects its progress in the run status.
+func (r *ActiveCheckRunReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithName("ActiveCheckRunReconciler.reconcile")
+
+	run := &slurmv1alpha1.ActiveCheckRun{}
+	if err := r.Get(ctx, req.NamespacedName, run); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("ActiveCheckRun resource not found. Ignoring since object must be deleted.")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("getting ActiveCheckRun: %w", err)
+	}
+
+	if run.Status.IsFinished() || !run.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	check := &slurmv1alpha1.ActiveCheck{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: run.Namespace, Name: run.Spec.ActiveCheckName}, check); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, r.finish(ctx, run, consts.ActiveCheckRunPhaseError,
+				fmt.Sprintf("ActiveCheck %q not found", run.Spec.ActiveCheckName))
+		}
+		return ctrl.Result{}, fmt.Errorf("getting ActiveCheck: %w", err)
+	}
+
+	if len(run.Spec.Nodes) != 0 && check.Spec.CheckType != "slurmJob" {
+		return ctrl.Result{}, r.finish(ctx, run, consts.ActiveCheckRunPhaseError,
+			fmt.Sprintf("nodes are only supported for checks of the slurmJob type, ActiveCheck %q is of the %s type", check.Name, check.Spec.CheckType))
+	}
+	if run.Spec.Reservation != "" && check.Spec.CheckType != "slurmJob" {
+		return ctrl.Result{}, r.finish(ctx, run, consts.ActiveCheckRunPhaseError,
+			fmt.Sprintf("reservation is only supported for checks of the slurmJob type, ActiveCheck %q is of the %s type", check.Name, check.Spec.CheckType))
+	}
+
+	k8sJob := &batchv1.Job{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: run.Namespace, Name: render.RenderActiveCheckRunJobName(run)}, k8sJob)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, fmt.Errorf("getting K8s Job: %w", err)
+	}
+	if apierrors.IsNotFound(err) {
+		return r.startRun(ctx, run, check)
+	}
+
+	switch check.Spec.CheckType {
+	case "slurmJob":
+		err = r.updateSlurmJobRunStatus(ctx, run, check, k8sJob)
+	default:
+		r.updateK8sJobRunStatus(run, k8sJob)
+	}
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if run.Status.IsFinished() {
+		run.Status.CompletionTime = ptr.To(metav1.Now())
+		logger.Info("ActiveCheckRun finished", "phase", run.Status.Phase, "failedNodes", run.Status.FailedNodes)
+	}
+	if err := r.Status().Update(ctx, run); err != nil {
+		return ctrl.Result{}, fmt.Errorf("updating ActiveCheckRun status: %w", err)
+	}
+
+	if !run.Status.IsFinished() {
+		return ctrl.Result{RequeueAfter: r.requeueAfter}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// startRun creates the K8s Job of the run from the job template of the check's CronJob.
+func (r *ActiveCheckRunReconciler) startRun(
+	ctx context.Context,
+	run *slurmv1alpha1.ActiveCheckRun,
+	check *slurmv1alpha1.ActiveCheck,
+) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	cronJob := &batchv1.CronJob{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: check.Namespace, Name: check.Name}, cronJob); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("getting CronJob: %w", err)
+		}
+
+		// The CronJob is created by the ActiveCheckReconciler once the cluster and the check's dependencies are ready.
+		run.Status.Phase = consts.ActiveCheckRunPhasePending
+		run.Status.Message = fmt.Sprintf("Waiting for the CronJob of ActiveCheck %q", check.Name)
+		if err := r.Status().Update(ctx, run); err != nil {
+			return ctrl.Result{}, fmt.Errorf("updating ActiveCheckRun status: %w", err)
+		}
+		return ctrl.Result{RequeueAfter: r.requeueAfter}, nil
+	}
+
+	k8sJob := render.RenderActiveCheckRunJob(run, check, cronJob)
+	if err := r.Create(ctx, k8sJob); err != nil && !apierrors.IsAlreadyExists(err) {
+		return ctrl.Result{}, fmt.Errorf("creating K8s Job: %w", err)
+	}
+	logger.Info("Started ActiveCheckRun", "k8sJob", k8sJob.Name, "nodes", run.Spec.Nodes)
+
+	run.Status.Phase = consts.ActiveCheckRunPhaseRunning
+	run.Status.Message = ""
+	run.Status.K8sJobName = k8sJob.Name
+	run.Status.StartTime = ptr.To(metav1.Now())
+	if err := r.Status().Update(ctx, run); err != nil {
+		return ctrl.Result{}, fmt.Errorf("updating ActiveCheckRun status: %w", err)
+	}
+	return ctrl.Result{RequeueAfter: r.requeueAfter}, nil
+}
+
+// updateK8sJobRunStatus sets the phase of a run of a k8sJob check from its K8s Job.
+func (r *ActiveCheckRunReconciler) updateK8sJobRunStatus(run *slurmv1alpha1.ActiveCheckRun, k8sJob *batchv1.Job) {
+	switch getK8sJobStatus(k8sJob) {
+	case consts.ActiveCheckK8sJobStatusComplete:
+		run.Status.Phase = consts.ActiveCheckRunPhaseComplete
+	case consts.ActiveCheckK8sJobStatusFailed:
+		run.Status.Phase = consts.ActiveCheckRunPhaseFailed
+	default:
+		run.Status.Phase = consts.ActiveCheckRunPhaseRunning
+	}
+}
+
+// updateSlurmJobRunStatus sets the phase of a run of a slurmJob check.
+// The run is finished once the ActiveCheckJobReconciler has handled all Slurm jobs submitted by the K8s Job, then the
+// outcome of the Slurm jobs is read from accounting.
+func (r *ActiveCheckRunReconciler) updateSlurmJobRunStatus(
+	ctx context.Context,
+	run *slurmv1alpha1.ActiveCheckRun,
+	check *slurmv1alpha1.ActiveCheck,
+	k8sJob *batchv1.Job,
+) error {
+	k8sJobStatus := getK8sJobStatus(k8sJob)
+	k8sJobFinished := k8sJobStatus == consts.ActiveCheckK8sJobStatusComplete || k8sJobStatus == consts.ActiveCheckK8sJobStatusFailed
+
+	if reason, ok := k8sJob.Annotations[consts.ActiveCheckSkippedReasonAnnotation]; ok && k8sJobFinished {
+		run.Status.Phase = consts.ActiveCheckRunPhaseSkipped
+		run.Status.Message = reason
+		return nil
+	}
+
+	allSlurmJobIDs, ok := k8sJob.Annotations["slurm-job-id"]
+	if !ok {
+		if k8sJobStatus == consts.ActiveCheckK8sJobStatusFailed {
+			run.Status.Phase = consts.ActiveCheckRunPhaseError
+			run.Status.Message = fmt.Sprintf("K8s Job %s failed before submitting Slurm jobs", k8sJob.Name)
+			return nil
+		}
+		run.Status.Phase = consts.ActiveCheckRunPhaseRunning
+		return nil
+	}
+	run.Status.SlurmJobIDs = strings.Split(allSlurmJobIDs, ",")
+
+	if unhandled, ok := k8sJob.Annotations["unhandled-slurm-job-id"]; !ok || unhandled != "" {
+		run.Status.Phase = consts.ActiveCheckRunPhaseRunning
+		return nil
+	}
+
+	slurmClusterName := types.NamespacedName{Namespace: run.Namespace, Name: check.Spec.SlurmClusterRefName}
+	slurmAPIClient, found := r.slurmAPIClients.GetClient(slurmClusterName)
+	if !found {
+		return fmt.Errorf("slurm cluster %v not found", slurmClusterName)
+	}
+
+	var (
+		failJobsAndReasons  []slurmv1alpha1.JobAndReason
+		errorJobsAndReasons []slurmv1alpha1.JobAndReason
+		cancelledJobs       []string
+		failedNodes         []string
+	)
+	for _, slurmJobID := range run.Status.SlurmJobIDs {
+		slurmJobs, err := slurmAPIClient.GetJobsByIDFromAccounting(ctx, slurmJobID)
+		if err != nil {
+			return fmt.Errorf("getting slurm job %s from accounting: %w", slurmJobID, err)
+		}
+
+		for _, slurmJob := range slurmJobs {
+			outcome := evaluateSlurmJob(slurmJob, check.Spec.SlurmJobSpec.Results)
+			switch outcome.Result {
+			case consts.ActiveCheckNodeResultPassed:
+			case consts.ActiveCheckNodeResultCancelled:
+				cancelledJobs = append(cancelledJobs, slurmJob.GetIDString())
+			case consts.ActiveCheckNodeResultFailed:
+				failJobsAndReasons = append(failJobsAndReasons, slurmv1alpha1.JobAndReason{
+					JobID:  slurmJob.GetIDString(),
+					Reason: outcome.Reason,
+				})
+				nodes, err := slurmJob.GetNodeList()
+				if err != nil {
+					return fmt.Errorf("getting nodes of slurm job %d: %w", slurmJob.ID, err)
+				}
+				failedNodes = append(failedNodes, nodes...)
+			default:
+				errorJobsAndReasons = append(errorJobsAndReasons, slurmv1alpha1.JobAndReason{
+					JobID:  slurmJob.GetIDString(),
+					Reason: outcome.Reason,
+				})
+			}
+		}
+	}
+
+	run.Status.FailJobsAndReasons = failJobsAndReasons
+	run.Status.FailedNodes = failedNodes
+	switch deriveSlurmRunStatus(false, failJobsAndReasons, errorJobsAndReasons, cancelledJobs) {
+	case consts.ActiveCheckSlurmRunStatusFailed:
+		run.Status.Phase = consts.ActiveCheckRunPhaseFailed
+	case consts.ActiveCheckSlurmRunStatusError:
+		run.Status.Phase = consts.ActiveCheckRunPhaseError
+		run.Status.Message = fmt.Sprintf("Slurm jobs ended in an unexpected state: %v", errorJobsAndReasons)
+	case consts.ActiveCheckSlurmRunStatusCancelled:
+		run.Status.Phase = consts.ActiveCheckRunPhaseCancelled
+		run.Status.Message = fmt.Sprintf("Slurm jobs were cancelled: %s", strings.Join(cancelledJobs, ","))
+	default:
+		run.Status.Phase = consts.ActiveCheckRunPhaseComplete
+	}
+	return nil
+}
+
+// finish moves the run to a final phase without running it.
+func (r *ActiveCheckRunReconciler) finish(
+	ctx context.Context,
+	run *slurmv1alpha1.ActiveCheckRun,
+	phase consts.ActiveCheckRunPhase,
+	message string,
+) error {
+	log.FromContext(ctx).Info("ActiveCheckRun can't be started", "reason", message)
+
+	run.Status.Phase = phase
+	run.Status.Message = message
+	run.Status.CompletionTime = ptr.To(metav1.Now())
+	if err := r.Status().Update(ctx, run); err != nil {
+		return fmt.Errorf("updating ActiveCheckRun status: %w", err)
+	}
+	return nil
+}
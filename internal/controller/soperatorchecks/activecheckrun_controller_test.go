@@ -0,0 +1,257 @@
+package soperatorchecks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/slurmapi"
+	slurmapifake "nebius.ai/slurm-operator/internal/slurmapi/fake"
+)
+
+const (
+	testRunNamespace   = "test-ns"
+	testRunCheckName   = "gpu-checks"
+	testRunName        = "gpu-checks-worker-3"
+	testRunClusterName = "cluster-a"
+)
+
+func newTestActiveCheckRunReconciler(
+	t *testing.T,
+	slurmClient slurmapi.Client,
+	objects ...client.Object,
+) (*ActiveCheckRunReconciler, client.Client) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, batchv1.AddToScheme(scheme))
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, slurmv1alpha1.AddToScheme(scheme))
+
+	fakeClient := clientfake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&slurmv1alpha1.ActiveCheckRun{}).
+		WithObjects(objects...).
+		Build()
+
+	slurmClients := slurmapi.NewClientSet(context.Background())
+	if slurmClient != nil {
+		slurmClients.AddClient(types.NamespacedName{Namespace: testRunNamespace, Name: testRunClusterName}, slurmClient)
+	}
+
+	return NewActiveCheckRunController(fakeClient, scheme, record.NewFakeRecorder(10), slurmClients, time.Minute), fakeClient
+}
+
+func newTestActiveCheck(checkType string) *slurmv1alpha1.ActiveCheck {
+	return &slurmv1alpha1.ActiveCheck{
+		ObjectMeta: metav1.ObjectMeta{Name: testRunCheckName, Namespace: testRunNamespace},
+		Spec: slurmv1alpha1.ActiveCheckSpec{
+			Name:                testRunCheckName,
+			CheckType:           checkType,
+			SlurmClusterRefName: testRunClusterName,
+		},
+	}
+}
+
+func newTestActiveCheckRun(nodes ...string) *slurmv1alpha1.ActiveCheckRun {
+	return &slurmv1alpha1.ActiveCheckRun{
+		ObjectMeta: metav1.ObjectMeta{Name: testRunName, Namespace: testRunNamespace},
+		Spec: slurmv1alpha1.ActiveCheckRunSpec{
+			ActiveCheckName: testRunCheckName,
+			Nodes:           nodes,
+		},
+	}
+}
+
+func reconcileTestActiveCheckRun(t *testing.T, r *ActiveCheckRunReconciler, c client.Client) *slurmv1alpha1.ActiveCheckRun {
+	ctx := context.Background()
+	key := types.NamespacedName{Namespace: testRunNamespace, Name: testRunName}
+
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+
+	run := &slurmv1alpha1.ActiveCheckRun{}
+	require.NoError(t, c.Get(ctx, key, run))
+	return run
+}
+
+func TestActiveCheckRunReconciler_CreatesJobLimitedToNodes(t *testing.T) {
+	t.Parallel()
+
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: testRunCheckName, Namespace: testRunNamespace},
+		Spec: batchv1.CronJobSpec{
+			JobTemplate: batchv1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{Name: testRunCheckName},
+								{Name: "munge"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	activeCheckRun := newTestActiveCheckRun("worker-3", "worker-5")
+	activeCheckRun.Spec.Reservation = "soperator-update-worker-3"
+	r, c := newTestActiveCheckRunReconciler(t, nil, newTestActiveCheck("slurmJob"), cronJob, activeCheckRun)
+
+	run := reconcileTestActiveCheckRun(t, r, c)
+	assert.Equal(t, consts.ActiveCheckRunPhaseRunning, run.Status.Phase)
+	assert.Equal(t, "gpu-checks-worker-3-run", run.Status.K8sJobName)
+	assert.NotNil(t, run.Status.StartTime)
+
+	k8sJob := &batchv1.Job{}
+	require.NoError(t, c.Get(context.Background(), types.NamespacedName{
+		Namespace: testRunNamespace,
+		Name:      run.Status.K8sJobName,
+	}, k8sJob))
+	assert.Equal(t, testRunName, k8sJob.Labels[consts.LabelActiveCheckRunKey])
+	assert.True(t, isValidJob(k8sJob))
+	assert.Equal(t, testRunName, k8sJob.OwnerReferences[0].Name)
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: consts.ActiveCheckNodesEnv, Value: "worker-3,worker-5"},
+		{Name: consts.ActiveCheckReservationEnv, Value: "soperator-update-worker-3"},
+	}, k8sJob.Spec.Template.Spec.Containers[0].Env)
+	assert.Empty(t, k8sJob.Spec.Template.Spec.Containers[1].Env)
+}
+
+func TestActiveCheckRunReconciler_WaitsForCronJob(t *testing.T) {
+	t.Parallel()
+
+	r, c := newTestActiveCheckRunReconciler(t, nil, newTestActiveCheck("slurmJob"), newTestActiveCheckRun())
+
+	run := reconcileTestActiveCheckRun(t, r, c)
+	assert.Equal(t, consts.ActiveCheckRunPhasePending, run.Status.Phase)
+	assert.Empty(t, run.Status.K8sJobName)
+}
+
+func TestActiveCheckRunReconciler_RejectsNodesForK8sJobChecks(t *testing.T) {
+	t.Parallel()
+
+	r, c := newTestActiveCheckRunReconciler(t, nil, newTestActiveCheck("k8sJob"), newTestActiveCheckRun("worker-3"))
+
+	run := reconcileTestActiveCheckRun(t, r, c)
+	assert.Equal(t, consts.ActiveCheckRunPhaseError, run.Status.Phase)
+	assert.NotNil(t, run.Status.CompletionTime)
+}
+
+func TestActiveCheckRunReconciler_SlurmJobRunStatus(t *testing.T) {
+	t.Parallel()
+
+	endTime := metav1.NewTime(time.Date(2026, time.October, 16, 12, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name            string
+		annotations     map[string]string
+		k8sJobFailed    bool
+		slurmJobs       map[string]slurmapi.Job
+		wantPhase       consts.ActiveCheckRunPhase
+		wantFailedNodes []string
+	}{
+		{
+			name:        "not submitted yet",
+			annotations: map[string]string{},
+			wantPhase:   consts.ActiveCheckRunPhaseRunning,
+		},
+		{
+			name:         "submission failed",
+			annotations:  map[string]string{},
+			k8sJobFailed: true,
+			wantPhase:    consts.ActiveCheckRunPhaseError,
+		},
+		{
+			name: "Slurm jobs not handled yet",
+			annotations: map[string]string{
+				"slurm-job-id":           "101,102",
+				"unhandled-slurm-job-id": "102",
+			},
+			wantPhase: consts.ActiveCheckRunPhaseRunning,
+		},
+		{
+			name: "skipped",
+			annotations: map[string]string{
+				consts.ActiveCheckSkippedReasonAnnotation: "no GPU nodes",
+			},
+			wantPhase: consts.ActiveCheckRunPhaseSkipped,
+		},
+		{
+			name: "failed on a node",
+			annotations: map[string]string{
+				"slurm-job-id":           "101,102",
+				"unhandled-slurm-job-id": "",
+			},
+			slurmJobs: map[string]slurmapi.Job{
+				"101": {ID: 101, State: string(api.V0044JobInfoJobStateCOMPLETED), Nodes: "worker-3", EndTime: &endTime},
+				"102": {ID: 102, State: string(api.V0044JobInfoJobStateFAILED), Nodes: "worker-5", EndTime: &endTime, StateReason: "NonZeroExitCode"},
+			},
+			wantPhase:       consts.ActiveCheckRunPhaseFailed,
+			wantFailedNodes: []string{"worker-5"},
+		},
+		{
+			name: "complete",
+			annotations: map[string]string{
+				"slurm-job-id":           "101",
+				"unhandled-slurm-job-id": "",
+			},
+			slurmJobs: map[string]slurmapi.Job{
+				"101": {ID: 101, State: string(api.V0044JobInfoJobStateCOMPLETED), Nodes: "worker-3", EndTime: &endTime},
+			},
+			wantPhase: consts.ActiveCheckRunPhaseComplete,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			k8sJob := &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "gpu-checks-worker-3-run",
+					Namespace:   testRunNamespace,
+					Annotations: tt.annotations,
+				},
+			}
+			switch {
+			case tt.k8sJobFailed:
+				k8sJob.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}}
+			case len(tt.annotations) != 0:
+				k8sJob.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+			default:
+				k8sJob.Status.Active = 1
+			}
+
+			slurmClient := slurmapifake.NewMockClient(t)
+			for id, job := range tt.slurmJobs {
+				slurmClient.EXPECT().GetJobsByIDFromAccounting(mock.Anything, id).Return([]slurmapi.Job{job}, nil).Once()
+			}
+
+			run := newTestActiveCheckRun()
+			run.Status.Phase = consts.ActiveCheckRunPhaseRunning
+			r, c := newTestActiveCheckRunReconciler(t, slurmClient, newTestActiveCheck("slurmJob"), k8sJob, run)
+
+			run = reconcileTestActiveCheckRun(t, r, c)
+			assert.Equal(t, tt.wantPhase, run.Status.Phase)
+			assert.Equal(t, tt.wantFailedNodes, run.Status.FailedNodes)
+			assert.Equal(t, run.Status.IsFinished(), run.Status.CompletionTime != nil)
+		})
+	}
+}
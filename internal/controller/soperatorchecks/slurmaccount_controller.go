@@ -0,0 +1,497 @@
+package soperatorchecks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/controller/reconciler"
+	"nebius.ai/slurm-operator/internal/controllerconfig"
+	"nebius.ai/slurm-operator/internal/slurmapi"
+)
+
+var (
+	SlurmAccountControllerName = "soperatorchecks.slurmaccount"
+)
+
+// Drifted account fields, as reported in events.
+const (
+	accountFieldDescription          = "description"
+	accountFieldOrganization         = "organization"
+	accountFieldAssociation          = "association"
+	accountFieldParentAccount        = "parentAccount"
+	accountFieldFairshare            = "fairshare"
+	accountFieldQOS                  = "qos"
+	accountFieldDefaultQOS           = "defaultQOS"
+	accountFieldGrpTRES              = "grpTRES"
+	accountFieldGrpTRESMinutes       = "grpTRESMinutes"
+	accountFieldGrpJobs              = "grpJobs"
+	accountFieldMaxJobs              = "maxJobs"
+	accountFieldMaxTRESPerJob        = "maxTRESPerJob"
+	accountFieldMaxWallPerJobMinutes = "maxWallPerJobMinutes"
+	accountFieldUsers                = "users"
+)
+
+type SlurmAccountReconciler struct {
+	*reconciler.Reconciler
+	slurmAPIClients *slurmapi.ClientSet
+	requeueAfter    time.Duration
+}
+
+func NewSlurmAccountController(
+	client client.Client,
+	scheme *runtime.Scheme,
+	recorder record.EventRecorder,
+	slurmAPIClients *slurmapi.ClientSet,
+	requeueAfter time.Duration,
+) *SlurmAccountReconciler {
+	r := reconciler.NewReconciler(client, scheme, recorder)
+
+	return &SlurmAccountReconciler{
+		Reconciler:      r,
+		slurmAPIClients: slurmAPIClients,
+		requeueAfter:    requeueAfter,
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SlurmAccountReconciler) SetupWithManager(
+	mgr ctrl.Manager,
+	maxConcurrency int,
+	cacheSyncTimeout time.Duration,
+) error {
+	return ctrl.NewControllerManagedBy(mgr).Named(SlurmAccountControllerName).
+		For(&slurmv1alpha1.SlurmAccount{}, builder.WithPredicates(
+			predicate.Funcs{
+				CreateFunc: func(e event.CreateEvent) bool {
+					return true
+				},
+				DeleteFunc: func(e event.DeleteEvent) bool {
+					return false
+				},
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					return e.ObjectNew.GetDeletionTimestamp() != nil ||
+						e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration()
+				},
+				GenericFunc: func(e event.GenericEvent) bool {
+					return false
+				},
+			},
+		)).
+		WithOptions(controllerconfig.ControllerOptions(maxConcurrency, cacheSyncTimeout)).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=slurm.nebius.ai,resources=slurmaccounts,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=slurm.nebius.ai,resources=slurmaccounts/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=slurm.nebius.ai,resources=slurmaccounts/finalizers,verbs=update
+
+// Reconcile creates the slurmdbd account declared by a SlurmAccount together with its association and user
+// associations, and keeps them in sync with the spec. Accounts are re-checked every requeueAfter, so changes made
+// with sacctmgr are reverted.
+func (r *SlurmAccountReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithName("SlurmAccountController.reconcile")
+
+	account := &slurmv1alpha1.SlurmAccount{}
+	if err := r.Get(ctx, req.NamespacedName, account); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.V(1).Info("SlurmAccount resource not found. Ignoring since object must be deleted.")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get SlurmAccount")
+		return ctrl.Result{}, err
+	}
+
+	if !account.DeletionTimestamp.IsZero() {
+		if controllerutil.ContainsFinalizer(account, consts.SlurmAccountFinalizer) {
+			return r.reconcileDelete(ctx, account)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if !controllerutil.ContainsFinalizer(account, consts.SlurmAccountFinalizer) {
+		controllerutil.AddFinalizer(account, consts.SlurmAccountFinalizer)
+		if err := r.Update(ctx, account); err != nil {
+			logger.Error(err, "Failed to add finalizer")
+			return ctrl.Result{}, err
+		}
+	}
+
+	patch := client.MergeFrom(account.DeepCopy())
+	result, syncErr := r.syncAccount(ctx, account, time.Now())
+	account.Status.ObservedGeneration = account.Generation
+	if err := r.Status().Patch(ctx, account, patch); err != nil {
+		logger.Error(err, "Failed to update SlurmAccount status")
+		return ctrl.Result{}, errors.Join(syncErr, err)
+	}
+	if syncErr != nil {
+		logger.Error(syncErr, "Failed to sync slurmdbd account")
+		return ctrl.Result{}, syncErr
+	}
+
+	return result, nil
+}
+
+// syncAccount creates or updates the account, its association and its user associations in slurmdbd and records
+// the outcome in the status.
+func (r *SlurmAccountReconciler) syncAccount(
+	ctx context.Context,
+	account *slurmv1alpha1.SlurmAccount,
+	now time.Time,
+) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithName("SlurmAccountController.syncAccount")
+
+	name := account.GetAccountName()
+	account.Status.AccountName = name
+	setSynced := func(status metav1.ConditionStatus, reason, message string) {
+		setSlurmAccountingSynced(&account.Status.Conditions, account.Generation, status, reason, message)
+	}
+	fail := func(err error) (ctrl.Result, error) {
+		setSynced(metav1.ConditionFalse, slurmv1alpha1.ReasonSlurmAccountingSlurmAPIError, err.Error())
+		r.Recorder.Event(account, corev1.EventTypeWarning, consts.SlurmAccountingEventSyncFailed, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	slurmClusterName := types.NamespacedName{
+		Namespace: account.Namespace,
+		Name:      account.Spec.SlurmClusterRefName,
+	}
+	slurmAPIClient, found := r.slurmAPIClients.GetClient(slurmClusterName)
+	if !found {
+		logger.Info("Slurm API client is not registered yet, requeueing", "slurmCluster", slurmClusterName)
+		setSynced(metav1.ConditionFalse, slurmv1alpha1.ReasonSlurmAccountingSlurmAPIUnavailable,
+			fmt.Sprintf("Slurm API client for cluster %s is not available", slurmClusterName.Name))
+		return ctrl.Result{RequeueAfter: r.requeueAfter}, nil
+	}
+
+	desiredAccount, desiredAssociation := desiredSlurmAccount(account)
+
+	// The account.
+	actualAccount, accountFound, err := findSlurmAccount(ctx, slurmAPIClient, name)
+	if err != nil {
+		return fail(err)
+	}
+	var drifted []string
+	if !accountFound {
+		logger.Info("Creating slurmdbd account", "account", name)
+		if err := slurmAPIClient.CreateAccount(ctx, desiredAccount); err != nil {
+			return fail(err)
+		}
+	} else if accountDrifted := slurmAccountDrift(desiredAccount, actualAccount); len(accountDrifted) > 0 {
+		logger.Info("Updating drifted slurmdbd account", "account", name, "fields", accountDrifted)
+		if err := slurmAPIClient.UpdateAccount(ctx, desiredAccount); err != nil {
+			return fail(err)
+		}
+		drifted = append(drifted, accountDrifted...)
+	}
+
+	// The account association.
+	associations, err := slurmAPIClient.ListAssociations(ctx)
+	if err != nil {
+		return fail(fmt.Errorf("list associations: %w", err))
+	}
+	actualAssociation, associationFound := findSlurmAssociation(associations, desiredAssociation.Key())
+	if !associationFound {
+		logger.Info("Creating slurmdbd account association", "association", desiredAssociation.Key())
+		if err := slurmAPIClient.CreateAssociation(ctx, desiredAssociation); err != nil {
+			return fail(err)
+		}
+		if accountFound {
+			drifted = append(drifted, accountFieldAssociation)
+		}
+	} else if associationDrifted := slurmAccountAssociationDrift(desiredAssociation, actualAssociation); len(associationDrifted) > 0 {
+		logger.Info("Updating drifted slurmdbd account association", "association", desiredAssociation.Key(), "fields", associationDrifted)
+		if err := slurmAPIClient.UpdateAssociation(ctx, desiredAssociation); err != nil {
+			return fail(err)
+		}
+		drifted = append(drifted, associationDrifted...)
+	}
+
+	// The user associations.
+	usersDrifted, err := r.syncAccountUsers(ctx, slurmAPIClient, account, associations)
+	if err != nil {
+		return fail(err)
+	}
+	if usersDrifted && accountFound {
+		drifted = append(drifted, accountFieldUsers)
+	}
+	account.Status.Users = slices.Sorted(slices.Values(account.Spec.Users))
+
+	reason := slurmv1alpha1.ReasonSlurmAccountingInSync
+	switch {
+	case !accountFound:
+		r.Recorder.Eventf(account, corev1.EventTypeNormal, consts.SlurmAccountingEventCreated, "Created account %s", name)
+		reason = slurmv1alpha1.ReasonSlurmAccountingCreated
+	case len(drifted) > 0:
+		r.Recorder.Eventf(account, corev1.EventTypeNormal, consts.SlurmAccountingEventDriftCorrected,
+			"Updated account %s, drifted fields: %s", name, strings.Join(drifted, ", "))
+		account.Status.LastDriftCorrectionTime = &metav1.Time{Time: now}
+		reason = slurmv1alpha1.ReasonSlurmAccountingUpdated
+	}
+	setSynced(metav1.ConditionTrue, reason, "Account matches the spec")
+
+	return ctrl.Result{RequeueAfter: r.requeueAfter}, nil
+}
+
+// syncAccountUsers creates the users of the account and their associations, and deletes the user associations of
+// the account whose users aren't listed in the spec. It returns whether anything was changed.
+func (r *SlurmAccountReconciler) syncAccountUsers(
+	ctx context.Context,
+	slurmAPIClient slurmapi.Client,
+	account *slurmv1alpha1.SlurmAccount,
+	associations []slurmapi.Association,
+) (bool, error) {
+	logger := log.FromContext(ctx).WithName("SlurmAccountController.syncAccountUsers")
+
+	name := account.GetAccountName()
+	cluster := account.Spec.SlurmClusterRefName
+	changed := false
+
+	var existingUsers []string
+	associatedUsers := map[string]bool{}
+	for _, association := range associations {
+		if association.Cluster != cluster || association.Account != name || !association.IsUserAssociation() {
+			continue
+		}
+		if association.Partition == "" {
+			associatedUsers[association.User] = true
+		}
+		if slices.Contains(account.Spec.Users, association.User) {
+			continue
+		}
+		logger.Info("Deleting slurmdbd user association", "association", association.Key())
+		if err := slurmAPIClient.DeleteAssociation(ctx, association.Key()); err != nil {
+			return changed, err
+		}
+		changed = true
+	}
+
+	for _, user := range account.Spec.Users {
+		if associatedUsers[user] {
+			continue
+		}
+
+		if existingUsers == nil {
+			users, err := slurmAPIClient.ListUsers(ctx)
+			if err != nil {
+				return changed, fmt.Errorf("list users: %w", err)
+			}
+			existingUsers = make([]string, 0, len(users))
+			for _, u := range users {
+				existingUsers = append(existingUsers, u.Name)
+			}
+		}
+		if !slices.Contains(existingUsers, user) {
+			logger.Info("Creating slurmdbd user", "user", user)
+			if err := slurmAPIClient.CreateUser(ctx, slurmapi.User{Name: user, DefaultAccount: name}); err != nil {
+				return changed, err
+			}
+		}
+
+		association := slurmapi.Association{Cluster: cluster, Account: name, User: user}
+		logger.Info("Creating slurmdbd user association", "association", association.Key())
+		if err := slurmAPIClient.CreateAssociation(ctx, association); err != nil {
+			return changed, err
+		}
+		changed = true
+	}
+
+	return changed, nil
+}
+
+func (r *SlurmAccountReconciler) reconcileDelete(
+	ctx context.Context,
+	account *slurmv1alpha1.SlurmAccount,
+) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithName("SlurmAccountController.reconcileDelete")
+
+	name := account.GetAccountName()
+	slurmClusterName := types.NamespacedName{
+		Namespace: account.Namespace,
+		Name:      account.Spec.SlurmClusterRefName,
+	}
+
+	if account.Spec.DeletionPolicy == slurmv1alpha1.DeletionPolicyDelete {
+		slurmAPIClient, found := r.slurmAPIClients.GetClient(slurmClusterName)
+		if found {
+			if err := deleteSlurmAccount(ctx, slurmAPIClient, name, account.Spec.SlurmClusterRefName); err != nil {
+				r.Recorder.Event(account, corev1.EventTypeWarning, consts.SlurmAccountingEventSyncFailed, err.Error())
+				return ctrl.Result{}, err
+			}
+			logger.Info("SlurmAccount is being deleted. Deleted slurmdbd account", "account", name)
+			r.Recorder.Eventf(account, corev1.EventTypeNormal, consts.SlurmAccountingEventDeleted, "Deleted account %s", name)
+		} else {
+			exists, err := slurmClusterExists(ctx, r.Client, slurmClusterName)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if exists {
+				logger.Info("Slurm API client is not registered yet, requeueing deletion", "slurmCluster", slurmClusterName)
+				return ctrl.Result{RequeueAfter: r.requeueAfter}, nil
+			}
+			logger.Info("SlurmCluster not found. Nothing to delete", "slurmCluster", slurmClusterName)
+		}
+	}
+
+	controllerutil.RemoveFinalizer(account, consts.SlurmAccountFinalizer)
+	if err := r.Update(ctx, account); err != nil {
+		logger.Error(err, "Failed to remove finalizer")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// deleteSlurmAccount deletes the account association in the cluster, which also deletes the associations of its
+// users, and then the account itself. Objects which are already gone are skipped.
+func deleteSlurmAccount(ctx context.Context, slurmAPIClient slurmapi.Client, name, cluster string) error {
+	associations, err := slurmAPIClient.ListAssociations(ctx)
+	if err != nil {
+		return fmt.Errorf("list associations: %w", err)
+	}
+	key := slurmapi.AssociationKey{Cluster: cluster, Account: name}
+	if _, found := findSlurmAssociation(associations, key); found {
+		if err := slurmAPIClient.DeleteAssociation(ctx, key); err != nil {
+			return err
+		}
+	}
+
+	_, found, err := findSlurmAccount(ctx, slurmAPIClient, name)
+	if err != nil {
+		return err
+	}
+	if found {
+		return slurmAPIClient.DeleteAccount(ctx, name)
+	}
+	return nil
+}
+
+// desiredSlurmAccount builds the slurmdbd account and its association in the cluster from the spec.
+func desiredSlurmAccount(account *slurmv1alpha1.SlurmAccount) (slurmapi.Account, slurmapi.Association) {
+	spec := account.Spec
+	name := account.GetAccountName()
+
+	desiredAccount := slurmapi.Account{
+		Name:         name,
+		Description:  spec.Description,
+		Organization: spec.Organization,
+	}
+	// As with sacctmgr, the description and the organization default to the account name.
+	if desiredAccount.Description == "" {
+		desiredAccount.Description = name
+	}
+	if desiredAccount.Organization == "" {
+		desiredAccount.Organization = name
+	}
+
+	desiredAssociation := slurmapi.Association{
+		Cluster:              spec.SlurmClusterRefName,
+		Account:              name,
+		ParentAccount:        spec.ParentAccount,
+		SharesRaw:            spec.Fairshare,
+		DefaultQOS:           spec.DefaultQOS,
+		QOS:                  spec.QOS,
+		GrpTRES:              spec.Limits.GrpTRES,
+		GrpTRESMinutes:       spec.Limits.GrpTRESMinutes,
+		GrpJobs:              spec.Limits.GrpJobs,
+		MaxJobs:              spec.Limits.MaxJobs,
+		MaxTRESPerJob:        spec.Limits.MaxTRESPerJob,
+		MaxWallPerJobMinutes: spec.Limits.MaxWallPerJobMinutes,
+	}
+
+	return desiredAccount, desiredAssociation
+}
+
+func findSlurmAccount(ctx context.Context, slurmAPIClient slurmapi.Client, name string) (slurmapi.Account, bool, error) {
+	accounts, err := slurmAPIClient.ListAccounts(ctx)
+	if err != nil {
+		return slurmapi.Account{}, false, fmt.Errorf("list accounts: %w", err)
+	}
+	for _, account := range accounts {
+		if account.Name == name {
+			return account, true, nil
+		}
+	}
+	return slurmapi.Account{}, false, nil
+}
+
+func findSlurmAssociation(associations []slurmapi.Association, key slurmapi.AssociationKey) (slurmapi.Association, bool) {
+	for _, association := range associations {
+		if association.Key() == key {
+			return association, true
+		}
+	}
+	return slurmapi.Association{}, false
+}
+
+// slurmAccountDrift returns the fields of the actual account which don't match the desired one.
+func slurmAccountDrift(desired, actual slurmapi.Account) []string {
+	var drifted []string
+
+	if desired.Description != actual.Description {
+		drifted = append(drifted, accountFieldDescription)
+	}
+	if desired.Organization != actual.Organization {
+		drifted = append(drifted, accountFieldOrganization)
+	}
+
+	return drifted
+}
+
+// slurmAccountAssociationDrift returns the fields of the actual account association which don't match the desired
+// one. Fields which aren't set in the desired association are left as they are in slurmdbd, so they are never drifted.
+func slurmAccountAssociationDrift(desired, actual slurmapi.Association) []string {
+	var drifted []string
+
+	if desired.ParentAccount != "" && desired.ParentAccount != actual.ParentAccount {
+		drifted = append(drifted, accountFieldParentAccount)
+	}
+	if desired.SharesRaw != nil && (actual.SharesRaw == nil || *actual.SharesRaw != *desired.SharesRaw) {
+		drifted = append(drifted, accountFieldFairshare)
+	}
+	if len(desired.QOS) > 0 && !sameStringSet(desired.QOS, actual.QOS) {
+		drifted = append(drifted, accountFieldQOS)
+	}
+	if desired.DefaultQOS != "" && desired.DefaultQOS != actual.DefaultQOS {
+		drifted = append(drifted, accountFieldDefaultQOS)
+	}
+	if tresLimitsDrifted(desired.GrpTRES, actual.GrpTRES) {
+		drifted = append(drifted, accountFieldGrpTRES)
+	}
+	if tresLimitsDrifted(desired.GrpTRESMinutes, actual.GrpTRESMinutes) {
+		drifted = append(drifted, accountFieldGrpTRESMinutes)
+	}
+	if int32LimitDrifted(desired.GrpJobs, actual.GrpJobs) {
+		drifted = append(drifted, accountFieldGrpJobs)
+	}
+	if int32LimitDrifted(desired.MaxJobs, actual.MaxJobs) {
+		drifted = append(drifted, accountFieldMaxJobs)
+	}
+	if tresLimitsDrifted(desired.MaxTRESPerJob, actual.MaxTRESPerJob) {
+		drifted = append(drifted, accountFieldMaxTRESPerJob)
+	}
+	if int32LimitDrifted(desired.MaxWallPerJobMinutes, actual.MaxWallPerJobMinutes) {
+		drifted = append(drifted, accountFieldMaxWallPerJobMinutes)
+	}
+
+	return drifted
+}
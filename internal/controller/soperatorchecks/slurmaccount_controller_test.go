@@ -0,0 +1,296 @@
+package soperatorchecks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slurmv1 "nebius.ai/slurm-operator/api/v1"
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/slurmapi"
+	slurmapifake "nebius.ai/slurm-operator/internal/slurmapi/fake"
+)
+
+func newSlurmAccountTestReconciler(
+	t *testing.T,
+	slurmAPIClient slurmapi.Client,
+	objects ...client.Object,
+) (*SlurmAccountReconciler, client.Client) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, slurmv1.AddToScheme(scheme))
+	require.NoError(t, slurmv1alpha1.AddToScheme(scheme))
+
+	fakeClient := clientfake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&slurmv1alpha1.SlurmAccount{}).
+		WithObjects(objects...).
+		Build()
+
+	clientSet := slurmapi.NewClientSet(context.Background())
+	if slurmAPIClient != nil {
+		clientSet.AddClient(types.NamespacedName{Namespace: testAccountingNamespace, Name: testAccountingCluster}, slurmAPIClient)
+	}
+
+	return NewSlurmAccountController(fakeClient, scheme, record.NewFakeRecorder(10), clientSet, time.Minute), fakeClient
+}
+
+func newTestSlurmAccount() *slurmv1alpha1.SlurmAccount {
+	return &slurmv1alpha1.SlurmAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "team-a",
+			Namespace:  testAccountingNamespace,
+			Generation: 1,
+		},
+		Spec: slurmv1alpha1.SlurmAccountSpec{
+			SlurmClusterRefName: testAccountingCluster,
+			ParentAccount:       "root",
+			Fairshare:           ptr.To(int32(100)),
+			QOS:                 []string{"normal", "high"},
+			DefaultQOS:          "normal",
+			Limits: slurmv1alpha1.SlurmAccountLimits{
+				GrpTRES: map[string]int64{"gres/gpu": 64},
+				MaxJobs: ptr.To(int32(50)),
+			},
+			Users:          []string{"bob", "alice"},
+			DeletionPolicy: slurmv1alpha1.DeletionPolicyRetain,
+		},
+	}
+}
+
+func newTestDesiredSlurmAccountAssociation() slurmapi.Association {
+	return slurmapi.Association{
+		Cluster:       testAccountingCluster,
+		Account:       "team-a",
+		ParentAccount: "root",
+		SharesRaw:     ptr.To(int32(100)),
+		QOS:           []string{"normal", "high"},
+		DefaultQOS:    "normal",
+		GrpTRES:       map[string]int64{"gres/gpu": 64},
+		MaxJobs:       ptr.To(int32(50)),
+	}
+}
+
+func newTestSlurmUserAssociation(user string) slurmapi.Association {
+	return slurmapi.Association{Cluster: testAccountingCluster, Account: "team-a", User: user}
+}
+
+func reconcileTestSlurmAccount(t *testing.T, r *SlurmAccountReconciler) {
+	t.Helper()
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{
+		Namespace: testAccountingNamespace,
+		Name:      "team-a",
+	}})
+	require.NoError(t, err)
+}
+
+func TestSlurmAccountReconciler_CreatesAccount(t *testing.T) {
+	t.Parallel()
+
+	mockClient := slurmapifake.NewMockClient(t)
+	mockClient.EXPECT().ListAccounts(mock.Anything).Return([]slurmapi.Account{{Name: "root"}}, nil).Once()
+	mockClient.EXPECT().CreateAccount(mock.Anything, slurmapi.Account{
+		Name:         "team-a",
+		Description:  "team-a",
+		Organization: "team-a",
+	}).Return(nil).Once()
+	mockClient.EXPECT().ListAssociations(mock.Anything).Return([]slurmapi.Association{
+		{Cluster: testAccountingCluster, Account: "root"},
+	}, nil).Once()
+	mockClient.EXPECT().CreateAssociation(mock.Anything, newTestDesiredSlurmAccountAssociation()).Return(nil).Once()
+	mockClient.EXPECT().ListUsers(mock.Anything).Return([]slurmapi.User{{Name: "root"}, {Name: "alice"}}, nil).Once()
+	mockClient.EXPECT().CreateUser(mock.Anything, slurmapi.User{Name: "bob", DefaultAccount: "team-a"}).Return(nil).Once()
+	mockClient.EXPECT().CreateAssociation(mock.Anything, newTestSlurmUserAssociation("bob")).Return(nil).Once()
+	mockClient.EXPECT().CreateAssociation(mock.Anything, newTestSlurmUserAssociation("alice")).Return(nil).Once()
+
+	r, fakeClient := newSlurmAccountTestReconciler(t, mockClient, newTestSlurmAccount())
+
+	reconcileTestSlurmAccount(t, r)
+
+	account := &slurmv1alpha1.SlurmAccount{}
+	require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Namespace: testAccountingNamespace, Name: "team-a"}, account))
+
+	assert.Contains(t, account.Finalizers, consts.SlurmAccountFinalizer)
+	assert.Equal(t, "team-a", account.Status.AccountName)
+	assert.Equal(t, []string{"alice", "bob"}, account.Status.Users)
+	assert.Nil(t, account.Status.LastDriftCorrectionTime)
+	condition := meta.FindStatusCondition(account.Status.Conditions, slurmv1alpha1.ConditionSlurmAccountingSynced)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, slurmv1alpha1.ReasonSlurmAccountingCreated, condition.Reason)
+}
+
+func TestSlurmAccountReconciler_InSync(t *testing.T) {
+	t.Parallel()
+
+	actualAssociation := newTestDesiredSlurmAccountAssociation()
+	// Limits set outside of the spec are kept.
+	actualAssociation.GrpTRES = map[string]int64{"gres/gpu": 64, "cpu": 1024}
+	actualAssociation.QOS = []string{"high", "normal"}
+
+	mockClient := slurmapifake.NewMockClient(t)
+	mockClient.EXPECT().ListAccounts(mock.Anything).Return([]slurmapi.Account{
+		{Name: "team-a", Description: "team-a", Organization: "team-a"},
+	}, nil).Once()
+	mockClient.EXPECT().ListAssociations(mock.Anything).Return([]slurmapi.Association{
+		actualAssociation,
+		newTestSlurmUserAssociation("alice"),
+		newTestSlurmUserAssociation("bob"),
+	}, nil).Once()
+
+	r, fakeClient := newSlurmAccountTestReconciler(t, mockClient, newTestSlurmAccount())
+
+	reconcileTestSlurmAccount(t, r)
+
+	account := &slurmv1alpha1.SlurmAccount{}
+	require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Namespace: testAccountingNamespace, Name: "team-a"}, account))
+
+	assert.Nil(t, account.Status.LastDriftCorrectionTime)
+	condition := meta.FindStatusCondition(account.Status.Conditions, slurmv1alpha1.ConditionSlurmAccountingSynced)
+	require.NotNil(t, condition)
+	assert.Equal(t, slurmv1alpha1.ReasonSlurmAccountingInSync, condition.Reason)
+}
+
+func TestSlurmAccountReconciler_CorrectsDrift(t *testing.T) {
+	t.Parallel()
+
+	actualAssociation := newTestDesiredSlurmAccountAssociation()
+	actualAssociation.MaxJobs = ptr.To(int32(500))
+
+	mockClient := slurmapifake.NewMockClient(t)
+	mockClient.EXPECT().ListAccounts(mock.Anything).Return([]slurmapi.Account{
+		{Name: "team-a", Description: "team-a", Organization: "team-a"},
+	}, nil).Once()
+	mockClient.EXPECT().ListAssociations(mock.Anything).Return([]slurmapi.Association{
+		actualAssociation,
+		newTestSlurmUserAssociation("alice"),
+		newTestSlurmUserAssociation("bob"),
+		newTestSlurmUserAssociation("mallory"),
+		{Cluster: testAccountingCluster, Account: "team-b", User: "mallory"},
+	}, nil).Once()
+	mockClient.EXPECT().UpdateAssociation(mock.Anything, newTestDesiredSlurmAccountAssociation()).Return(nil).Once()
+	mockClient.EXPECT().DeleteAssociation(mock.Anything, slurmapi.AssociationKey{Cluster: testAccountingCluster, Account: "team-a", User: "mallory"}).Return(nil).Once()
+
+	r, fakeClient := newSlurmAccountTestReconciler(t, mockClient, newTestSlurmAccount())
+
+	reconcileTestSlurmAccount(t, r)
+
+	account := &slurmv1alpha1.SlurmAccount{}
+	require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Namespace: testAccountingNamespace, Name: "team-a"}, account))
+
+	assert.NotNil(t, account.Status.LastDriftCorrectionTime)
+	condition := meta.FindStatusCondition(account.Status.Conditions, slurmv1alpha1.ConditionSlurmAccountingSynced)
+	require.NotNil(t, condition)
+	assert.Equal(t, slurmv1alpha1.ReasonSlurmAccountingUpdated, condition.Reason)
+}
+
+func TestSlurmAccountReconciler_DeletesAccount(t *testing.T) {
+	t.Parallel()
+
+	account := newTestSlurmAccount()
+	account.Spec.DeletionPolicy = slurmv1alpha1.DeletionPolicyDelete
+	account.Finalizers = []string{consts.SlurmAccountFinalizer}
+	account.DeletionTimestamp = ptr.To(metav1.Now())
+
+	mockClient := slurmapifake.NewMockClient(t)
+	mockClient.EXPECT().ListAssociations(mock.Anything).Return([]slurmapi.Association{
+		newTestDesiredSlurmAccountAssociation(),
+		newTestSlurmUserAssociation("alice"),
+	}, nil).Once()
+	mockClient.EXPECT().DeleteAssociation(mock.Anything, slurmapi.AssociationKey{
+		Cluster: testAccountingCluster,
+		Account: "team-a",
+	}).Return(nil).Once()
+	mockClient.EXPECT().ListAccounts(mock.Anything).Return([]slurmapi.Account{{Name: "team-a"}}, nil).Once()
+	mockClient.EXPECT().DeleteAccount(mock.Anything, "team-a").Return(nil).Once()
+
+	r, fakeClient := newSlurmAccountTestReconciler(t, mockClient, account)
+
+	reconcileTestSlurmAccount(t, r)
+
+	err := fakeClient.Get(context.Background(), types.NamespacedName{Namespace: testAccountingNamespace, Name: "team-a"}, &slurmv1alpha1.SlurmAccount{})
+	assert.True(t, client.IgnoreNotFound(err) == nil && err != nil, "SlurmAccount must be gone once the finalizer is removed")
+}
+
+func TestSlurmAccountReconciler_SlurmAPIUnavailable(t *testing.T) {
+	t.Parallel()
+
+	r, fakeClient := newSlurmAccountTestReconciler(t, nil, newTestSlurmAccount())
+
+	reconcileTestSlurmAccount(t, r)
+
+	account := &slurmv1alpha1.SlurmAccount{}
+	require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Namespace: testAccountingNamespace, Name: "team-a"}, account))
+
+	condition := meta.FindStatusCondition(account.Status.Conditions, slurmv1alpha1.ConditionSlurmAccountingSynced)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, slurmv1alpha1.ReasonSlurmAccountingSlurmAPIUnavailable, condition.Reason)
+}
+
+// TestDesiredSlurmAccount_JobLimitsRequest checks the job limits of the SlurmAccount in the request to slurmdbd,
+// as the mocked Slurm API client compares them before they're converted to the REST fields.
+func TestDesiredSlurmAccount_JobLimitsRequest(t *testing.T) {
+	t.Parallel()
+
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/slurmdb/v0.0.44/associations/", r.URL.Path)
+		var err error
+		body, err = io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	slurmAPIClient, err := slurmapi.NewClient(server.URL, nil, server.Client())
+	require.NoError(t, err)
+
+	account := newTestSlurmAccount()
+	account.Spec.Limits.GrpJobs = ptr.To(int32(200))
+	account.Spec.Limits.MaxJobs = ptr.To(int32(50))
+	_, association := desiredSlurmAccount(account)
+	require.NoError(t, slurmAPIClient.CreateAssociation(context.Background(), association))
+
+	var request struct {
+		Associations []struct {
+			Max struct {
+				Jobs struct {
+					Active struct {
+						Number int `json:"number"`
+					} `json:"active"`
+					Per struct {
+						Count struct {
+							Number int `json:"number"`
+						} `json:"count"`
+					} `json:"per"`
+				} `json:"jobs"`
+			} `json:"max"`
+		} `json:"associations"`
+	}
+	require.NoError(t, json.Unmarshal(body, &request))
+	require.Len(t, request.Associations, 1)
+	assert.Equal(t, 200, request.Associations[0].Max.Jobs.Per.Count.Number, "spec.limits.grpJobs is max.jobs.per.count")
+	assert.Equal(t, 50, request.Associations[0].Max.Jobs.Active.Number, "spec.limits.maxJobs is max.jobs.active")
+}
@@ -0,0 +1,73 @@
+package soperatorchecks
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	slurmv1 "nebius.ai/slurm-operator/api/v1"
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+)
+
+// setSlurmAccountingSynced sets the Synced condition of SlurmAccount and SlurmQOS.
+func setSlurmAccountingSynced(
+	conditions *[]metav1.Condition,
+	generation int64,
+	status metav1.ConditionStatus,
+	reason, message string,
+) {
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:               slurmv1alpha1.ConditionSlurmAccountingSynced,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: generation,
+	})
+}
+
+// slurmClusterExists tells whether the SlurmCluster is still there when its Slurm API client isn't registered.
+// Deletion of slurmdbd objects waits for the client in this case, and is skipped once the cluster is gone.
+func slurmClusterExists(ctx context.Context, c client.Client, slurmClusterName types.NamespacedName) (bool, error) {
+	err := c.Get(ctx, slurmClusterName, &slurmv1.SlurmCluster{})
+	if err == nil {
+		return true, nil
+	}
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	return false, fmt.Errorf("getting SlurmCluster: %w", err)
+}
+
+// tresLimitsDrifted tells whether the actual TRES limits don't match the desired ones. Only the desired TRES are
+// compared, as limits removed from the spec are left unchanged. A limit of -1 matches a missing limit.
+func tresLimitsDrifted(desired, actual map[string]int64) bool {
+	for tres, limit := range desired {
+		actualLimit, found := actual[tres]
+		if limit < 0 {
+			if found && actualLimit >= 0 {
+				return true
+			}
+			continue
+		}
+		if !found || actualLimit != limit {
+			return true
+		}
+	}
+	return false
+}
+
+// int32LimitDrifted is tresLimitsDrifted for a single limit. A nil desired limit is left unchanged.
+func int32LimitDrifted(desired, actual *int32) bool {
+	if desired == nil {
+		return false
+	}
+	if *desired < 0 {
+		return actual != nil && *actual >= 0
+	}
+	return actual == nil || *actual != *desired
+}
@@ -0,0 +1,85 @@
+package soperatorchecks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	testAccountingNamespace = "soperator"
+	testAccountingCluster   = "slurm1"
+)
+
+func TestTRESLimitsDrifted(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		desired map[string]int64
+		actual  map[string]int64
+		want    bool
+	}{
+		{
+			name:    "no desired limits",
+			desired: nil,
+			actual:  map[string]int64{"cpu": 10},
+			want:    false,
+		},
+		{
+			name:    "equal",
+			desired: map[string]int64{"cpu": 10, "gres/gpu": 8},
+			actual:  map[string]int64{"cpu": 10, "gres/gpu": 8},
+			want:    false,
+		},
+		{
+			name:    "extra actual limit is kept",
+			desired: map[string]int64{"cpu": 10},
+			actual:  map[string]int64{"cpu": 10, "mem": 1024},
+			want:    false,
+		},
+		{
+			name:    "changed",
+			desired: map[string]int64{"cpu": 10},
+			actual:  map[string]int64{"cpu": 20},
+			want:    true,
+		},
+		{
+			name:    "missing",
+			desired: map[string]int64{"gres/gpu": 8},
+			actual:  map[string]int64{"cpu": 10},
+			want:    true,
+		},
+		{
+			name:    "removed limit is absent",
+			desired: map[string]int64{"gres/gpu": -1},
+			actual:  nil,
+			want:    false,
+		},
+		{
+			name:    "removed limit is still set",
+			desired: map[string]int64{"gres/gpu": -1},
+			actual:  map[string]int64{"gres/gpu": 8},
+			want:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tresLimitsDrifted(tt.desired, tt.actual))
+		})
+	}
+}
+
+func TestInt32LimitDrifted(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, int32LimitDrifted(nil, ptr.To(int32(10))))
+	assert.False(t, int32LimitDrifted(ptr.To(int32(10)), ptr.To(int32(10))))
+	assert.True(t, int32LimitDrifted(ptr.To(int32(10)), ptr.To(int32(20))))
+	assert.True(t, int32LimitDrifted(ptr.To(int32(10)), nil))
+	assert.False(t, int32LimitDrifted(ptr.To(int32(-1)), nil))
+	assert.True(t, int32LimitDrifted(ptr.To(int32(-1)), ptr.To(int32(10))))
+}
@@ -0,0 +1,343 @@
+package soperatorchecks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/controller/reconciler"
+	"nebius.ai/slurm-operator/internal/controllerconfig"
+	"nebius.ai/slurm-operator/internal/slurmapi"
+)
+
+var (
+	SlurmQOSControllerName = "soperatorchecks.slurmqos"
+)
+
+// Drifted QOS fields, as reported in events.
+const (
+	qosFieldDescription          = "description"
+	qosFieldPriority             = "priority"
+	qosFieldUsageFactor          = "usageFactor"
+	qosFieldGrpTRES              = "grpTRES"
+	qosFieldMaxTRESPerUser       = "maxTRESPerUser"
+	qosFieldMaxJobsPerUser       = "maxJobsPerUser"
+	qosFieldMaxWallPerJobMinutes = "maxWallPerJobMinutes"
+)
+
+type SlurmQOSReconciler struct {
+	*reconciler.Reconciler
+	slurmAPIClients *slurmapi.ClientSet
+	requeueAfter    time.Duration
+}
+
+func NewSlurmQOSController(
+	client client.Client,
+	scheme *runtime.Scheme,
+	recorder record.EventRecorder,
+	slurmAPIClients *slurmapi.ClientSet,
+	requeueAfter time.Duration,
+) *SlurmQOSReconciler {
+	r := reconciler.NewReconciler(client, scheme, recorder)
+
+	return &SlurmQOSReconciler{
+		Reconciler:      r,
+		slurmAPIClients: slurmAPIClients,
+		requeueAfter:    requeueAfter,
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SlurmQOSReconciler) SetupWithManager(
+	mgr ctrl.Manager,
+	maxConcurrency int,
+	cacheSyncTimeout time.Duration,
+) error {
+	return ctrl.NewControllerManagedBy(mgr).Named(SlurmQOSControllerName).
+		For(&slurmv1alpha1.SlurmQOS{}, builder.WithPredicates(
+			predicate.Funcs{
+				CreateFunc: func(e event.CreateEvent) bool {
+					return true
+				},
+				DeleteFunc: func(e event.DeleteEvent) bool {
+					return false
+				},
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					return e.ObjectNew.GetDeletionTimestamp() != nil ||
+						e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration()
+				},
+				GenericFunc: func(e event.GenericEvent) bool {
+					return false
+				},
+			},
+		)).
+		WithOptions(controllerconfig.ControllerOptions(maxConcurrency, cacheSyncTimeout)).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=slurm.nebius.ai,resources=slurmqoses,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=slurm.nebius.ai,resources=slurmqoses/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=slurm.nebius.ai,resources=slurmqoses/finalizers,verbs=update
+
+// Reconcile creates the slurmdbd QOS declared by a SlurmQOS and keeps it in sync with the spec.
+// QOS are re-checked every requeueAfter, so changes made with sacctmgr are reverted.
+func (r *SlurmQOSReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithName("SlurmQOSController.reconcile")
+
+	qos := &slurmv1alpha1.SlurmQOS{}
+	if err := r.Get(ctx, req.NamespacedName, qos); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.V(1).Info("SlurmQOS resource not found. Ignoring since object must be deleted.")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get SlurmQOS")
+		return ctrl.Result{}, err
+	}
+
+	if !qos.DeletionTimestamp.IsZero() {
+		if controllerutil.ContainsFinalizer(qos, consts.SlurmQOSFinalizer) {
+			return r.reconcileDelete(ctx, qos)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if !controllerutil.ContainsFinalizer(qos, consts.SlurmQOSFinalizer) {
+		controllerutil.AddFinalizer(qos, consts.SlurmQOSFinalizer)
+		if err := r.Update(ctx, qos); err != nil {
+			logger.Error(err, "Failed to add finalizer")
+			return ctrl.Result{}, err
+		}
+	}
+
+	patch := client.MergeFrom(qos.DeepCopy())
+	result, syncErr := r.syncQOS(ctx, qos, time.Now())
+	qos.Status.ObservedGeneration = qos.Generation
+	if err := r.Status().Patch(ctx, qos, patch); err != nil {
+		logger.Error(err, "Failed to update SlurmQOS status")
+		return ctrl.Result{}, errors.Join(syncErr, err)
+	}
+	if syncErr != nil {
+		logger.Error(syncErr, "Failed to sync slurmdbd QOS")
+		return ctrl.Result{}, syncErr
+	}
+
+	return result, nil
+}
+
+// syncQOS creates or updates the QOS in slurmdbd and records the outcome in the status.
+func (r *SlurmQOSReconciler) syncQOS(
+	ctx context.Context,
+	qos *slurmv1alpha1.SlurmQOS,
+	now time.Time,
+) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithName("SlurmQOSController.syncQOS")
+
+	name := qos.GetQOSName()
+	qos.Status.QOSName = name
+	setSynced := func(status metav1.ConditionStatus, reason, message string) {
+		setSlurmAccountingSynced(&qos.Status.Conditions, qos.Generation, status, reason, message)
+	}
+
+	slurmClusterName := types.NamespacedName{
+		Namespace: qos.Namespace,
+		Name:      qos.Spec.SlurmClusterRefName,
+	}
+	slurmAPIClient, found := r.slurmAPIClients.GetClient(slurmClusterName)
+	if !found {
+		logger.Info("Slurm API client is not registered yet, requeueing", "slurmCluster", slurmClusterName)
+		setSynced(metav1.ConditionFalse, slurmv1alpha1.ReasonSlurmAccountingSlurmAPIUnavailable,
+			fmt.Sprintf("Slurm API client for cluster %s is not available", slurmClusterName.Name))
+		return ctrl.Result{RequeueAfter: r.requeueAfter}, nil
+	}
+
+	desired, err := desiredSlurmQOS(qos)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("build desired qos: %w", err)
+	}
+
+	actual, found, err := findSlurmQOS(ctx, slurmAPIClient, name)
+	if err != nil {
+		setSynced(metav1.ConditionFalse, slurmv1alpha1.ReasonSlurmAccountingSlurmAPIError, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	reason := slurmv1alpha1.ReasonSlurmAccountingInSync
+	if !found {
+		logger.Info("Creating slurmdbd QOS", "qos", name)
+		if err := slurmAPIClient.CreateQOS(ctx, desired); err != nil {
+			setSynced(metav1.ConditionFalse, slurmv1alpha1.ReasonSlurmAccountingSlurmAPIError, err.Error())
+			r.Recorder.Event(qos, corev1.EventTypeWarning, consts.SlurmAccountingEventSyncFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(qos, corev1.EventTypeNormal, consts.SlurmAccountingEventCreated, "Created QOS %s", name)
+		reason = slurmv1alpha1.ReasonSlurmAccountingCreated
+	} else if drifted := slurmQOSDrift(desired, actual); len(drifted) > 0 {
+		logger.Info("Updating drifted slurmdbd QOS", "qos", name, "fields", drifted)
+		// Slurmdbd leaves the limits which aren't set unchanged, so the whole QOS is submitted.
+		if err := slurmAPIClient.UpdateQOS(ctx, desired); err != nil {
+			setSynced(metav1.ConditionFalse, slurmv1alpha1.ReasonSlurmAccountingSlurmAPIError, err.Error())
+			r.Recorder.Event(qos, corev1.EventTypeWarning, consts.SlurmAccountingEventSyncFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(qos, corev1.EventTypeNormal, consts.SlurmAccountingEventDriftCorrected,
+			"Updated QOS %s, drifted fields: %s", name, strings.Join(drifted, ", "))
+		qos.Status.LastDriftCorrectionTime = &metav1.Time{Time: now}
+		reason = slurmv1alpha1.ReasonSlurmAccountingUpdated
+	}
+
+	if reason == slurmv1alpha1.ReasonSlurmAccountingCreated {
+		actual, found, err = findSlurmQOS(ctx, slurmAPIClient, name)
+		if err != nil {
+			setSynced(metav1.ConditionFalse, slurmv1alpha1.ReasonSlurmAccountingSlurmAPIError, err.Error())
+			return ctrl.Result{}, err
+		}
+		if !found {
+			err = fmt.Errorf("qos %s not found after it was created", name)
+			setSynced(metav1.ConditionFalse, slurmv1alpha1.ReasonSlurmAccountingSlurmAPIError, err.Error())
+			return ctrl.Result{}, err
+		}
+	}
+
+	qos.Status.ID = actual.ID
+	setSynced(metav1.ConditionTrue, reason, "QOS matches the spec")
+
+	return ctrl.Result{RequeueAfter: r.requeueAfter}, nil
+}
+
+func (r *SlurmQOSReconciler) reconcileDelete(
+	ctx context.Context,
+	qos *slurmv1alpha1.SlurmQOS,
+) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithName("SlurmQOSController.reconcileDelete")
+
+	name := qos.GetQOSName()
+	slurmClusterName := types.NamespacedName{
+		Namespace: qos.Namespace,
+		Name:      qos.Spec.SlurmClusterRefName,
+	}
+
+	if qos.Spec.DeletionPolicy == slurmv1alpha1.DeletionPolicyDelete {
+		slurmAPIClient, found := r.slurmAPIClients.GetClient(slurmClusterName)
+		if found {
+			_, exists, err := findSlurmQOS(ctx, slurmAPIClient, name)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if exists {
+				logger.Info("SlurmQOS is being deleted. Deleting slurmdbd QOS", "qos", name)
+				if err := slurmAPIClient.DeleteQOS(ctx, name); err != nil {
+					r.Recorder.Event(qos, corev1.EventTypeWarning, consts.SlurmAccountingEventSyncFailed, err.Error())
+					return ctrl.Result{}, err
+				}
+				r.Recorder.Eventf(qos, corev1.EventTypeNormal, consts.SlurmAccountingEventDeleted, "Deleted QOS %s", name)
+			}
+		} else {
+			exists, err := slurmClusterExists(ctx, r.Client, slurmClusterName)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if exists {
+				logger.Info("Slurm API client is not registered yet, requeueing deletion", "slurmCluster", slurmClusterName)
+				return ctrl.Result{RequeueAfter: r.requeueAfter}, nil
+			}
+			logger.Info("SlurmCluster not found. Nothing to delete", "slurmCluster", slurmClusterName)
+		}
+	}
+
+	controllerutil.RemoveFinalizer(qos, consts.SlurmQOSFinalizer)
+	if err := r.Update(ctx, qos); err != nil {
+		logger.Error(err, "Failed to remove finalizer")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// desiredSlurmQOS builds the slurmdbd QOS from the spec.
+func desiredSlurmQOS(qos *slurmv1alpha1.SlurmQOS) (slurmapi.QOS, error) {
+	spec := qos.Spec
+
+	res := slurmapi.QOS{
+		Name:                 qos.GetQOSName(),
+		Description:          spec.Description,
+		Priority:             spec.Priority,
+		GrpTRES:              spec.Limits.GrpTRES,
+		MaxTRESPerUser:       spec.Limits.MaxTRESPerUser,
+		MaxJobsPerUser:       spec.Limits.MaxJobsPerUser,
+		MaxWallPerJobMinutes: spec.Limits.MaxWallPerJobMinutes,
+	}
+	if spec.UsageFactor != nil {
+		usageFactor, err := strconv.ParseFloat(*spec.UsageFactor, 64)
+		if err != nil {
+			return slurmapi.QOS{}, fmt.Errorf("parsing usage factor: %w", err)
+		}
+		res.UsageFactor = &usageFactor
+	}
+
+	return res, nil
+}
+
+// findSlurmQOS looks the QOS up in the list of all QOS, as slurmdbd doesn't tell a missing QOS apart from other
+// errors when it is requested by name.
+func findSlurmQOS(ctx context.Context, slurmAPIClient slurmapi.Client, name string) (slurmapi.QOS, bool, error) {
+	qosList, err := slurmAPIClient.ListQOS(ctx)
+	if err != nil {
+		return slurmapi.QOS{}, false, fmt.Errorf("list qos: %w", err)
+	}
+	for _, qos := range qosList {
+		if qos.Name == name {
+			return qos, true, nil
+		}
+	}
+	return slurmapi.QOS{}, false, nil
+}
+
+// slurmQOSDrift returns the fields of the actual QOS which don't match the desired one.
+// Fields which aren't set in the desired QOS are left as they are in slurmdbd, so they are never drifted.
+func slurmQOSDrift(desired, actual slurmapi.QOS) []string {
+	var drifted []string
+
+	if desired.Description != "" && desired.Description != actual.Description {
+		drifted = append(drifted, qosFieldDescription)
+	}
+	if desired.Priority != nil && (actual.Priority == nil || *actual.Priority != *desired.Priority) {
+		drifted = append(drifted, qosFieldPriority)
+	}
+	if desired.UsageFactor != nil && (actual.UsageFactor == nil || math.Abs(*actual.UsageFactor-*desired.UsageFactor) > 1e-9) {
+		drifted = append(drifted, qosFieldUsageFactor)
+	}
+	if tresLimitsDrifted(desired.GrpTRES, actual.GrpTRES) {
+		drifted = append(drifted, qosFieldGrpTRES)
+	}
+	if tresLimitsDrifted(desired.MaxTRESPerUser, actual.MaxTRESPerUser) {
+		drifted = append(drifted, qosFieldMaxTRESPerUser)
+	}
+	if int32LimitDrifted(desired.MaxJobsPerUser, actual.MaxJobsPerUser) {
+		drifted = append(drifted, qosFieldMaxJobsPerUser)
+	}
+	if int32LimitDrifted(desired.MaxWallPerJobMinutes, actual.MaxWallPerJobMinutes) {
+		drifted = append(drifted, qosFieldMaxWallPerJobMinutes)
+	}
+
+	return drifted
+}
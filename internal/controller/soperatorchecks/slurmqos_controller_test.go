@@ -0,0 +1,187 @@
+package soperatorchecks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slurmv1 "nebius.ai/slurm-operator/api/v1"
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/slurmapi"
+	slurmapifake "nebius.ai/slurm-operator/internal/slurmapi/fake"
+)
+
+func newSlurmQOSTestReconciler(
+	t *testing.T,
+	slurmAPIClient slurmapi.Client,
+	objects ...client.Object,
+) (*SlurmQOSReconciler, client.Client) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, slurmv1.AddToScheme(scheme))
+	require.NoError(t, slurmv1alpha1.AddToScheme(scheme))
+
+	fakeClient := clientfake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&slurmv1alpha1.SlurmQOS{}).
+		WithObjects(objects...).
+		Build()
+
+	clientSet := slurmapi.NewClientSet(context.Background())
+	if slurmAPIClient != nil {
+		clientSet.AddClient(types.NamespacedName{Namespace: testAccountingNamespace, Name: testAccountingCluster}, slurmAPIClient)
+	}
+
+	return NewSlurmQOSController(fakeClient, scheme, record.NewFakeRecorder(10), clientSet, time.Minute), fakeClient
+}
+
+func newTestSlurmQOS() *slurmv1alpha1.SlurmQOS {
+	return &slurmv1alpha1.SlurmQOS{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "high",
+			Namespace:  testAccountingNamespace,
+			Generation: 1,
+		},
+		Spec: slurmv1alpha1.SlurmQOSSpec{
+			SlurmClusterRefName: testAccountingCluster,
+			Priority:            ptr.To(int32(1000)),
+			UsageFactor:         ptr.To("2.0"),
+			Limits: slurmv1alpha1.SlurmQOSLimits{
+				MaxTRESPerUser:       map[string]int64{"gres/gpu": 16},
+				MaxWallPerJobMinutes: ptr.To(int32(1440)),
+			},
+			DeletionPolicy: slurmv1alpha1.DeletionPolicyRetain,
+		},
+	}
+}
+
+func newTestDesiredSlurmQOS() slurmapi.QOS {
+	return slurmapi.QOS{
+		Name:                 "high",
+		Priority:             ptr.To(int32(1000)),
+		UsageFactor:          ptr.To(2.0),
+		MaxTRESPerUser:       map[string]int64{"gres/gpu": 16},
+		MaxWallPerJobMinutes: ptr.To(int32(1440)),
+	}
+}
+
+func reconcileTestSlurmQOS(t *testing.T, r *SlurmQOSReconciler) {
+	t.Helper()
+
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{
+		Namespace: testAccountingNamespace,
+		Name:      "high",
+	}})
+	require.NoError(t, err)
+}
+
+func TestSlurmQOSReconciler_CreatesQOS(t *testing.T) {
+	t.Parallel()
+
+	created := newTestDesiredSlurmQOS()
+	created.ID = ptr.To(int32(7))
+
+	mockClient := slurmapifake.NewMockClient(t)
+	mockClient.EXPECT().ListQOS(mock.Anything).Return([]slurmapi.QOS{{Name: "normal"}}, nil).Once()
+	mockClient.EXPECT().CreateQOS(mock.Anything, newTestDesiredSlurmQOS()).Return(nil).Once()
+	mockClient.EXPECT().ListQOS(mock.Anything).Return([]slurmapi.QOS{{Name: "normal"}, created}, nil).Once()
+
+	r, fakeClient := newSlurmQOSTestReconciler(t, mockClient, newTestSlurmQOS())
+
+	reconcileTestSlurmQOS(t, r)
+
+	qos := &slurmv1alpha1.SlurmQOS{}
+	require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Namespace: testAccountingNamespace, Name: "high"}, qos))
+
+	assert.Contains(t, qos.Finalizers, consts.SlurmQOSFinalizer)
+	assert.Equal(t, "high", qos.Status.QOSName)
+	assert.Equal(t, ptr.To(int32(7)), qos.Status.ID)
+	assert.Nil(t, qos.Status.LastDriftCorrectionTime)
+	condition := meta.FindStatusCondition(qos.Status.Conditions, slurmv1alpha1.ConditionSlurmAccountingSynced)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, slurmv1alpha1.ReasonSlurmAccountingCreated, condition.Reason)
+}
+
+func TestSlurmQOSReconciler_CorrectsDrift(t *testing.T) {
+	t.Parallel()
+
+	actual := newTestDesiredSlurmQOS()
+	actual.ID = ptr.To(int32(7))
+	actual.MaxTRESPerUser = map[string]int64{"gres/gpu": 32, "cpu": 128}
+
+	mockClient := slurmapifake.NewMockClient(t)
+	mockClient.EXPECT().ListQOS(mock.Anything).Return([]slurmapi.QOS{actual}, nil).Once()
+	mockClient.EXPECT().UpdateQOS(mock.Anything, newTestDesiredSlurmQOS()).Return(nil).Once()
+
+	r, fakeClient := newSlurmQOSTestReconciler(t, mockClient, newTestSlurmQOS())
+
+	reconcileTestSlurmQOS(t, r)
+
+	qos := &slurmv1alpha1.SlurmQOS{}
+	require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Namespace: testAccountingNamespace, Name: "high"}, qos))
+
+	assert.NotNil(t, qos.Status.LastDriftCorrectionTime)
+	condition := meta.FindStatusCondition(qos.Status.Conditions, slurmv1alpha1.ConditionSlurmAccountingSynced)
+	require.NotNil(t, condition)
+	assert.Equal(t, slurmv1alpha1.ReasonSlurmAccountingUpdated, condition.Reason)
+}
+
+func TestSlurmQOSReconciler_Deletion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		deletionPolicy slurmv1alpha1.DeletionPolicy
+		expectDelete   bool
+	}{
+		{
+			name:           "retain",
+			deletionPolicy: slurmv1alpha1.DeletionPolicyRetain,
+		},
+		{
+			name:           "delete",
+			deletionPolicy: slurmv1alpha1.DeletionPolicyDelete,
+			expectDelete:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			qos := newTestSlurmQOS()
+			qos.Spec.DeletionPolicy = tt.deletionPolicy
+			qos.Finalizers = []string{consts.SlurmQOSFinalizer}
+			qos.DeletionTimestamp = ptr.To(metav1.Now())
+
+			mockClient := slurmapifake.NewMockClient(t)
+			if tt.expectDelete {
+				mockClient.EXPECT().ListQOS(mock.Anything).Return([]slurmapi.QOS{{Name: "high"}}, nil).Once()
+				mockClient.EXPECT().DeleteQOS(mock.Anything, "high").Return(nil).Once()
+			}
+
+			r, fakeClient := newSlurmQOSTestReconciler(t, mockClient, qos)
+
+			reconcileTestSlurmQOS(t, r)
+
+			err := fakeClient.Get(context.Background(), types.NamespacedName{Namespace: testAccountingNamespace, Name: "high"}, &slurmv1alpha1.SlurmQOS{})
+			assert.True(t, client.IgnoreNotFound(err) == nil && err != nil, "SlurmQOS must be gone once the finalizer is removed")
+		})
+	}
+}
@@ -0,0 +1,499 @@
+package soperatorchecks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	slurmv1 "nebius.ai/slurm-operator/api/v1"
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/controller/reconciler"
+	"nebius.ai/slurm-operator/internal/controllerconfig"
+	"nebius.ai/slurm-operator/internal/render/common"
+	"nebius.ai/slurm-operator/internal/slurmapi"
+)
+
+var (
+	SlurmReservationControllerName = "soperatorchecks.slurmreservation"
+)
+
+// Drifted reservation fields, as reported in events.
+const (
+	reservationFieldNodes     = "nodes"
+	reservationFieldNodeCount = "nodeCount"
+	reservationFieldPartition = "partition"
+	reservationFieldStartTime = "startTime"
+	reservationFieldEndTime   = "endTime"
+	reservationFieldFlags     = "flags"
+	reservationFieldUsers     = "users"
+	reservationFieldAccounts  = "accounts"
+)
+
+// errInvalidReservationNodes is returned when the reserved nodes can't be resolved from the spec.
+var errInvalidReservationNodes = errors.New("invalid reservation nodes")
+
+type SlurmReservationReconciler struct {
+	*reconciler.Reconciler
+	slurmAPIClients *slurmapi.ClientSet
+	requeueAfter    time.Duration
+}
+
+func NewSlurmReservationController(
+	client client.Client,
+	scheme *runtime.Scheme,
+	recorder record.EventRecorder,
+	slurmAPIClients *slurmapi.ClientSet,
+	requeueAfter time.Duration,
+) *SlurmReservationReconciler {
+	r := reconciler.NewReconciler(client, scheme, recorder)
+
+	return &SlurmReservationReconciler{
+		Reconciler:      r,
+		slurmAPIClients: slurmAPIClients,
+		requeueAfter:    requeueAfter,
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *SlurmReservationReconciler) SetupWithManager(
+	mgr ctrl.Manager,
+	maxConcurrency int,
+	cacheSyncTimeout time.Duration,
+) error {
+	return ctrl.NewControllerManagedBy(mgr).Named(SlurmReservationControllerName).
+		For(&slurmv1alpha1.SlurmReservation{}, builder.WithPredicates(
+			predicate.Funcs{
+				CreateFunc: func(e event.CreateEvent) bool {
+					return true
+				},
+				DeleteFunc: func(e event.DeleteEvent) bool {
+					return false
+				},
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					return e.ObjectNew.GetDeletionTimestamp() != nil ||
+						e.ObjectOld.GetGeneration() != e.ObjectNew.GetGeneration()
+				},
+				GenericFunc: func(e event.GenericEvent) bool {
+					return false
+				},
+			},
+		)).
+		WithOptions(controllerconfig.ControllerOptions(maxConcurrency, cacheSyncTimeout)).
+		Complete(r)
+}
+
+// +kubebuilder:rbac:groups=slurm.nebius.ai,resources=slurmreservations,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=slurm.nebius.ai,resources=slurmreservations/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=slurm.nebius.ai,resources=slurmreservations/finalizers,verbs=update
+// +kubebuilder:rbac:groups=slurm.nebius.ai,resources=nodesets,verbs=get;list;watch
+
+// Reconcile creates the Slurm reservation declared by a SlurmReservation and keeps it in sync with the spec.
+// Reservations are re-checked every requeueAfter, so changes made in Slurm directly are reverted.
+func (r *SlurmReservationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithName("SlurmReservationController.reconcile")
+
+	reservation := &slurmv1alpha1.SlurmReservation{}
+	if err := r.Get(ctx, req.NamespacedName, reservation); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.V(1).Info("SlurmReservation resource not found. Ignoring since object must be deleted.")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get SlurmReservation")
+		return ctrl.Result{}, err
+	}
+
+	if !reservation.DeletionTimestamp.IsZero() {
+		if controllerutil.ContainsFinalizer(reservation, consts.SlurmReservationFinalizer) {
+			return r.reconcileDelete(ctx, reservation)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if !controllerutil.ContainsFinalizer(reservation, consts.SlurmReservationFinalizer) {
+		controllerutil.AddFinalizer(reservation, consts.SlurmReservationFinalizer)
+		if err := r.Update(ctx, reservation); err != nil {
+			logger.Error(err, "Failed to add finalizer")
+			return ctrl.Result{}, err
+		}
+	}
+
+	patch := client.MergeFrom(reservation.DeepCopy())
+	result, syncErr := r.syncReservation(ctx, reservation, time.Now())
+	reservation.Status.ObservedGeneration = reservation.Generation
+	if err := r.Status().Patch(ctx, reservation, patch); err != nil {
+		logger.Error(err, "Failed to update SlurmReservation status")
+		return ctrl.Result{}, errors.Join(syncErr, err)
+	}
+	if syncErr != nil {
+		logger.Error(syncErr, "Failed to sync Slurm reservation")
+		return ctrl.Result{}, syncErr
+	}
+
+	return result, nil
+}
+
+// syncReservation creates or updates the reservation in Slurm and records the outcome in the status.
+func (r *SlurmReservationReconciler) syncReservation(
+	ctx context.Context,
+	reservation *slurmv1alpha1.SlurmReservation,
+	now time.Time,
+) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithName("SlurmReservationController.syncReservation")
+
+	name := reservation.GetReservationName()
+	reservation.Status.ReservationName = name
+
+	if !reservation.Spec.IsRecurring() && !now.Before(reservation.Spec.EndTime.Time) {
+		logger.V(1).Info("Reservation has ended, skipping", "reservation", name)
+		reservation.Status.Phase = slurmv1alpha1.PhaseSlurmReservationExpired
+		setSlurmReservationSynced(reservation, metav1.ConditionFalse, slurmv1alpha1.ReasonSlurmReservationExpired,
+			fmt.Sprintf("Reservation ended at %s", reservation.Spec.EndTime.UTC().Format(time.RFC3339)))
+		return ctrl.Result{}, nil
+	}
+
+	slurmClusterName := types.NamespacedName{
+		Namespace: reservation.Namespace,
+		Name:      reservation.Spec.SlurmClusterRefName,
+	}
+	slurmAPIClient, found := r.slurmAPIClients.GetClient(slurmClusterName)
+	if !found {
+		logger.Info("Slurm API client is not registered yet, requeueing", "slurmCluster", slurmClusterName)
+		setSlurmReservationSynced(reservation, metav1.ConditionFalse, slurmv1alpha1.ReasonSlurmReservationSlurmAPIUnavailable,
+			fmt.Sprintf("Slurm API client for cluster %s is not available", slurmClusterName.Name))
+		return ctrl.Result{RequeueAfter: r.requeueAfter}, nil
+	}
+
+	desired, err := r.desiredReservation(ctx, reservation, now)
+	if err != nil {
+		if !errors.Is(err, errInvalidReservationNodes) {
+			return ctrl.Result{}, fmt.Errorf("build desired reservation: %w", err)
+		}
+		// The referenced NodeSets may appear later, so this isn't treated as a reconciliation error.
+		setSlurmReservationSynced(reservation, metav1.ConditionFalse, slurmv1alpha1.ReasonSlurmReservationInvalidNodes, err.Error())
+		r.Recorder.Event(reservation, corev1.EventTypeWarning, consts.SlurmReservationEventSyncFailed, err.Error())
+		return ctrl.Result{RequeueAfter: r.requeueAfter}, nil
+	}
+
+	actual, found, err := findSlurmReservation(ctx, slurmAPIClient, name)
+	if err != nil {
+		setSlurmReservationSynced(reservation, metav1.ConditionFalse, slurmv1alpha1.ReasonSlurmReservationSlurmAPIError, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	reason := slurmv1alpha1.ReasonSlurmReservationInSync
+	if !found {
+		logger.Info("Creating Slurm reservation", "reservation", name)
+		if err := slurmAPIClient.CreateReservation(ctx, desired); err != nil {
+			setSlurmReservationSynced(reservation, metav1.ConditionFalse, slurmv1alpha1.ReasonSlurmReservationSlurmAPIError, err.Error())
+			r.Recorder.Event(reservation, corev1.EventTypeWarning, consts.SlurmReservationEventSyncFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(reservation, corev1.EventTypeNormal, consts.SlurmReservationEventCreated,
+			"Created Slurm reservation %s", name)
+		reason = slurmv1alpha1.ReasonSlurmReservationCreated
+	} else if drifted := slurmReservationDrift(desired, actual, reservation.Spec.IsRecurring(), now); len(drifted) > 0 {
+		logger.Info("Updating drifted Slurm reservation", "reservation", name, "fields", drifted)
+		if err := slurmAPIClient.UpdateReservation(ctx, slurmReservationUpdate(desired, actual, drifted)); err != nil {
+			setSlurmReservationSynced(reservation, metav1.ConditionFalse, slurmv1alpha1.ReasonSlurmReservationSlurmAPIError, err.Error())
+			r.Recorder.Event(reservation, corev1.EventTypeWarning, consts.SlurmReservationEventSyncFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+		r.Recorder.Eventf(reservation, corev1.EventTypeNormal, consts.SlurmReservationEventDriftCorrected,
+			"Updated Slurm reservation %s, drifted fields: %s", name, strings.Join(drifted, ", "))
+		reservation.Status.LastDriftCorrectionTime = &metav1.Time{Time: now}
+		reason = slurmv1alpha1.ReasonSlurmReservationUpdated
+	}
+
+	if reason != slurmv1alpha1.ReasonSlurmReservationInSync {
+		actual, found, err = findSlurmReservation(ctx, slurmAPIClient, name)
+		if err != nil {
+			setSlurmReservationSynced(reservation, metav1.ConditionFalse, slurmv1alpha1.ReasonSlurmReservationSlurmAPIError, err.Error())
+			return ctrl.Result{}, err
+		}
+		if !found {
+			err = fmt.Errorf("reservation %s not found after it was submitted", name)
+			setSlurmReservationSynced(reservation, metav1.ConditionFalse, slurmv1alpha1.ReasonSlurmReservationSlurmAPIError, err.Error())
+			return ctrl.Result{}, err
+		}
+	}
+
+	setSlurmReservationStatus(reservation, actual, now)
+	setSlurmReservationSynced(reservation, metav1.ConditionTrue, reason, "Slurm reservation matches the spec")
+
+	requeueAfter := r.requeueAfter
+	if untilEnd := reservation.Spec.EndTime.Sub(now); !reservation.Spec.IsRecurring() && untilEnd < requeueAfter {
+		// Pick the reservation up right after it ends to mark it expired.
+		requeueAfter = untilEnd + time.Second
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+func (r *SlurmReservationReconciler) reconcileDelete(
+	ctx context.Context,
+	reservation *slurmv1alpha1.SlurmReservation,
+) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithName("SlurmReservationController.reconcileDelete")
+
+	name := reservation.GetReservationName()
+	slurmClusterName := types.NamespacedName{
+		Namespace: reservation.Namespace,
+		Name:      reservation.Spec.SlurmClusterRefName,
+	}
+
+	slurmAPIClient, found := r.slurmAPIClients.GetClient(slurmClusterName)
+	if found {
+		_, exists, err := findSlurmReservation(ctx, slurmAPIClient, name)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if exists {
+			logger.Info("SlurmReservation is being deleted. Deleting Slurm reservation", "reservation", name)
+			if err := slurmAPIClient.DeleteReservation(ctx, name); err != nil {
+				return ctrl.Result{}, err
+			}
+			r.Recorder.Eventf(reservation, corev1.EventTypeNormal, consts.SlurmReservationEventDeleted,
+				"Deleted Slurm reservation %s", name)
+		}
+	} else {
+		err := r.Get(ctx, slurmClusterName, &slurmv1.SlurmCluster{})
+		if err == nil {
+			logger.Info("Slurm API client is not registered yet, requeueing deletion", "slurmCluster", slurmClusterName)
+			return ctrl.Result{RequeueAfter: r.requeueAfter}, nil
+		}
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("getting SlurmCluster: %w", err)
+		}
+		logger.Info("SlurmCluster not found. Nothing to delete", "slurmCluster", slurmClusterName)
+	}
+
+	controllerutil.RemoveFinalizer(reservation, consts.SlurmReservationFinalizer)
+	if err := r.Update(ctx, reservation); err != nil {
+		logger.Error(err, "Failed to remove finalizer")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// desiredReservation builds the Slurm reservation from the spec, resolving NodeSet references into Slurm node names.
+func (r *SlurmReservationReconciler) desiredReservation(
+	ctx context.Context,
+	reservation *slurmv1alpha1.SlurmReservation,
+	now time.Time,
+) (slurmapi.Reservation, error) {
+	spec := reservation.Spec
+
+	var nodeLists []string
+	for _, nodeSetName := range spec.NodeSetRefs {
+		nodeSet := &slurmv1alpha1.NodeSet{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: reservation.Namespace, Name: nodeSetName}, nodeSet); err != nil {
+			if apierrors.IsNotFound(err) {
+				return slurmapi.Reservation{}, fmt.Errorf("%w: NodeSet %s not found", errInvalidReservationNodes, nodeSetName)
+			}
+			return slurmapi.Reservation{}, fmt.Errorf("getting NodeSet %s: %w", nodeSetName, err)
+		}
+		if nodeSet.Spec.Replicas <= 0 {
+			return slurmapi.Reservation{}, fmt.Errorf("%w: NodeSet %s has no replicas", errInvalidReservationNodes, nodeSetName)
+		}
+		nodeLists = append(nodeLists, common.RenderNodeSetNodeList(nodeSet.Name, nodeSet.Spec.Replicas))
+	}
+	if spec.NodeList != "" {
+		nodeLists = append(nodeLists, spec.NodeList)
+	}
+
+	res := slurmapi.Reservation{
+		Name:      reservation.GetReservationName(),
+		NodeList:  strings.Join(nodeLists, ","),
+		NodeCount: spec.NodeCount,
+		Partition: spec.Partition,
+		StartTime: spec.StartTime.Time,
+		EndTime:   spec.EndTime.Time,
+		Users:     spec.Users,
+		Accounts:  spec.Accounts,
+	}
+	// Slurm doesn't accept start times in the past.
+	if res.StartTime.Before(now) {
+		res.StartTime = now
+	}
+	for _, flag := range spec.Flags {
+		res.Flags = append(res.Flags, api.V0044ReservationInfoFlags(flag))
+	}
+
+	return res, nil
+}
+
+// findSlurmReservation looks the reservation up in the list of all reservations, as Slurm doesn't tell a missing
+// reservation apart from other errors when it is requested by name.
+func findSlurmReservation(
+	ctx context.Context,
+	slurmAPIClient slurmapi.Client,
+	name string,
+) (slurmapi.Reservation, bool, error) {
+	reservations, err := slurmAPIClient.ListReservations(ctx)
+	if err != nil {
+		return slurmapi.Reservation{}, false, fmt.Errorf("list reservations: %w", err)
+	}
+	for _, reservation := range reservations {
+		if reservation.Name == name {
+			return reservation, true, nil
+		}
+	}
+	return slurmapi.Reservation{}, false, nil
+}
+
+// slurmReservationDrift returns the fields of the actual reservation which don't match the desired one.
+//
+// Fields which Slurm manages on its own are compared loosely: extra flags are ignored, as Slurm sets some of them
+// itself, times of recurring reservations are ignored, as Slurm moves them on every repetition, and start times
+// in the past are only compared to check that the reservation has started.
+func slurmReservationDrift(desired, actual slurmapi.Reservation, recurring bool, now time.Time) []string {
+	var drifted []string
+
+	if desired.NodeList != "" {
+		desiredNodes, desiredErr := desired.GetNodeList()
+		actualNodes, actualErr := actual.GetNodeList()
+		if desiredErr != nil || actualErr != nil || !sameStringSet(desiredNodes, actualNodes) {
+			drifted = append(drifted, reservationFieldNodes)
+		}
+	} else if desired.NodeCount != nil && (actual.NodeCount == nil || *actual.NodeCount != *desired.NodeCount) {
+		drifted = append(drifted, reservationFieldNodeCount)
+	}
+
+	if desired.Partition != "" && desired.Partition != actual.Partition {
+		drifted = append(drifted, reservationFieldPartition)
+	}
+
+	if !recurring {
+		if desired.StartTime.After(now) {
+			if desired.StartTime.Unix() != actual.StartTime.Unix() {
+				drifted = append(drifted, reservationFieldStartTime)
+			}
+		} else if actual.StartTime.After(now) {
+			drifted = append(drifted, reservationFieldStartTime)
+		}
+
+		if desired.EndTime.Unix() != actual.EndTime.Unix() {
+			drifted = append(drifted, reservationFieldEndTime)
+		}
+	}
+
+	if len(missingReservationFlags(desired.Flags, actual.Flags)) > 0 {
+		drifted = append(drifted, reservationFieldFlags)
+	}
+	if len(desired.Users) > 0 && !sameStringSet(desired.Users, actual.Users) {
+		drifted = append(drifted, reservationFieldUsers)
+	}
+	if len(desired.Accounts) > 0 && !sameStringSet(desired.Accounts, actual.Accounts) {
+		drifted = append(drifted, reservationFieldAccounts)
+	}
+
+	return drifted
+}
+
+// slurmReservationUpdate builds the update request for the drifted fields only. Slurm keeps the fields which
+// aren't set, and adds flags to the existing ones instead of replacing them.
+func slurmReservationUpdate(desired, actual slurmapi.Reservation, drifted []string) slurmapi.Reservation {
+	res := slurmapi.Reservation{Name: desired.Name}
+
+	for _, field := range drifted {
+		switch field {
+		case reservationFieldNodes:
+			res.NodeList = desired.NodeList
+		case reservationFieldNodeCount:
+			res.NodeCount = desired.NodeCount
+		case reservationFieldPartition:
+			res.Partition = desired.Partition
+		case reservationFieldStartTime:
+			res.StartTime = desired.StartTime
+		case reservationFieldEndTime:
+			res.EndTime = desired.EndTime
+		case reservationFieldFlags:
+			res.Flags = missingReservationFlags(desired.Flags, actual.Flags)
+		case reservationFieldUsers:
+			res.Users = desired.Users
+		case reservationFieldAccounts:
+			res.Accounts = desired.Accounts
+		}
+	}
+
+	return res
+}
+
+func missingReservationFlags(desired, actual []api.V0044ReservationInfoFlags) []api.V0044ReservationInfoFlags {
+	var res []api.V0044ReservationInfoFlags
+	for _, flag := range desired {
+		if !slices.Contains(actual, flag) {
+			res = append(res, flag)
+		}
+	}
+	return res
+}
+
+func sameStringSet(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
+
+func setSlurmReservationStatus(reservation *slurmv1alpha1.SlurmReservation, actual slurmapi.Reservation, now time.Time) {
+	status := &reservation.Status
+
+	status.NodeList = actual.NodeList
+	status.NodeCount = 0
+	if actual.NodeCount != nil {
+		status.NodeCount = *actual.NodeCount
+	}
+	status.StartTime = &metav1.Time{Time: actual.StartTime}
+	status.EndTime = nil
+	if !actual.EndTime.IsZero() {
+		status.EndTime = &metav1.Time{Time: actual.EndTime}
+	}
+	status.Flags = nil
+	for _, flag := range actual.Flags {
+		status.Flags = append(status.Flags, string(flag))
+	}
+	status.Users = actual.Users
+	status.Accounts = actual.Accounts
+
+	status.Phase = slurmv1alpha1.PhaseSlurmReservationScheduled
+	if actual.IsActive(now) {
+		status.Phase = slurmv1alpha1.PhaseSlurmReservationActive
+	}
+}
+
+func setSlurmReservationSynced(
+	reservation *slurmv1alpha1.SlurmReservation,
+	status metav1.ConditionStatus,
+	reason, message string,
+) {
+	if status == metav1.ConditionFalse && reservation.Status.Phase == "" {
+		reservation.Status.Phase = slurmv1alpha1.PhaseSlurmReservationPending
+	}
+	reservation.Status.SetCondition(metav1.Condition{
+		Type:               slurmv1alpha1.ConditionSlurmReservationSynced,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: reservation.Generation,
+	})
+}
@@ -0,0 +1,339 @@
+package soperatorchecks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slurmv1 "nebius.ai/slurm-operator/api/v1"
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/slurmapi"
+	slurmapifake "nebius.ai/slurm-operator/internal/slurmapi/fake"
+)
+
+const (
+	testReservationNamespace = "soperator"
+	testReservationCluster   = "slurm1"
+)
+
+func newSlurmReservationTestReconciler(
+	t *testing.T,
+	slurmAPIClient slurmapi.Client,
+	objects ...client.Object,
+) (*SlurmReservationReconciler, client.Client) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, slurmv1.AddToScheme(scheme))
+	require.NoError(t, slurmv1alpha1.AddToScheme(scheme))
+
+	fakeClient := clientfake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&slurmv1alpha1.SlurmReservation{}).
+		WithObjects(objects...).
+		Build()
+
+	clientSet := slurmapi.NewClientSet(context.Background())
+	if slurmAPIClient != nil {
+		clientSet.AddClient(types.NamespacedName{Namespace: testReservationNamespace, Name: testReservationCluster}, slurmAPIClient)
+	}
+
+	return NewSlurmReservationController(fakeClient, scheme, record.NewFakeRecorder(10), clientSet, time.Minute), fakeClient
+}
+
+func newTestSlurmReservation(start, end time.Time) *slurmv1alpha1.SlurmReservation {
+	return &slurmv1alpha1.SlurmReservation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "maintenance",
+			Namespace:  testReservationNamespace,
+			Generation: 1,
+		},
+		Spec: slurmv1alpha1.SlurmReservationSpec{
+			SlurmClusterRefName: testReservationCluster,
+			NodeSetRefs:         []string{"gpu"},
+			NodeList:            "cpu-3",
+			StartTime:           metav1.NewTime(start),
+			EndTime:             metav1.NewTime(end),
+			Flags:               []slurmv1alpha1.SlurmReservationFlag{slurmv1alpha1.SlurmReservationFlagMaint},
+			Users:               []string{"root"},
+		},
+	}
+}
+
+func newTestNodeSet(name string, replicas int32) *slurmv1alpha1.NodeSet {
+	return &slurmv1alpha1.NodeSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testReservationNamespace,
+		},
+		Spec: slurmv1alpha1.NodeSetSpec{
+			Replicas: replicas,
+		},
+	}
+}
+
+func reconcileTestSlurmReservation(t *testing.T, r *SlurmReservationReconciler) ctrl.Result {
+	t.Helper()
+
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{
+		Namespace: testReservationNamespace,
+		Name:      "maintenance",
+	}})
+	require.NoError(t, err)
+	return result
+}
+
+func TestSlurmReservationReconciler_CreatesReservation(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now().Add(time.Hour).Truncate(time.Second)
+	end := start.Add(4 * time.Hour)
+
+	created := slurmapi.Reservation{
+		Name:      "maintenance",
+		NodeList:  "cpu-3,gpu-[0-3]",
+		NodeCount: ptr.To(int32(5)),
+		StartTime: start,
+		EndTime:   end,
+		Flags:     []api.V0044ReservationInfoFlags{api.V0044ReservationInfoFlagsMAINT, api.V0044ReservationInfoFlagsSPECNODES},
+		Users:     []string{"root"},
+	}
+
+	mockClient := slurmapifake.NewMockClient(t)
+	mockClient.EXPECT().ListReservations(mock.Anything).Return(nil, nil).Once()
+	mockClient.EXPECT().CreateReservation(mock.Anything, slurmapi.Reservation{
+		Name:      "maintenance",
+		NodeList:  "gpu-[0-3],cpu-3",
+		StartTime: start,
+		EndTime:   end,
+		Flags:     []api.V0044ReservationInfoFlags{api.V0044ReservationInfoFlagsMAINT},
+		Users:     []string{"root"},
+	}).Return(nil).Once()
+	mockClient.EXPECT().ListReservations(mock.Anything).Return([]slurmapi.Reservation{created}, nil).Once()
+
+	r, fakeClient := newSlurmReservationTestReconciler(t, mockClient, newTestSlurmReservation(start, end), newTestNodeSet("gpu", 4))
+
+	result := reconcileTestSlurmReservation(t, r)
+	assert.Equal(t, time.Minute, result.RequeueAfter)
+
+	reservation := &slurmv1alpha1.SlurmReservation{}
+	require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Namespace: testReservationNamespace, Name: "maintenance"}, reservation))
+
+	assert.Contains(t, reservation.Finalizers, consts.SlurmReservationFinalizer)
+	assert.Equal(t, slurmv1alpha1.PhaseSlurmReservationScheduled, reservation.Status.Phase)
+	assert.Equal(t, "maintenance", reservation.Status.ReservationName)
+	assert.Equal(t, "cpu-3,gpu-[0-3]", reservation.Status.NodeList)
+	assert.Equal(t, int32(5), reservation.Status.NodeCount)
+	assert.Equal(t, []string{"MAINT", "SPEC_NODES"}, reservation.Status.Flags)
+	assert.Equal(t, int64(1), reservation.Status.ObservedGeneration)
+
+	synced := meta.FindStatusCondition(reservation.Status.Conditions, slurmv1alpha1.ConditionSlurmReservationSynced)
+	require.NotNil(t, synced)
+	assert.Equal(t, metav1.ConditionTrue, synced.Status)
+	assert.Equal(t, slurmv1alpha1.ReasonSlurmReservationCreated, synced.Reason)
+}
+
+func TestSlurmReservationReconciler_CorrectsDrift(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now().Add(time.Hour).Truncate(time.Second)
+	end := start.Add(4 * time.Hour)
+
+	drifted := slurmapi.Reservation{
+		Name:      "maintenance",
+		NodeList:  "cpu-3,gpu-[0-3]",
+		StartTime: start,
+		EndTime:   end.Add(time.Hour),
+		Flags:     []api.V0044ReservationInfoFlags{api.V0044ReservationInfoFlagsSPECNODES},
+		Users:     []string{"root"},
+	}
+	corrected := drifted
+	corrected.EndTime = end
+	corrected.Flags = []api.V0044ReservationInfoFlags{api.V0044ReservationInfoFlagsMAINT, api.V0044ReservationInfoFlagsSPECNODES}
+
+	mockClient := slurmapifake.NewMockClient(t)
+	mockClient.EXPECT().ListReservations(mock.Anything).Return([]slurmapi.Reservation{drifted}, nil).Once()
+	mockClient.EXPECT().UpdateReservation(mock.Anything, slurmapi.Reservation{
+		Name:    "maintenance",
+		EndTime: end,
+		Flags:   []api.V0044ReservationInfoFlags{api.V0044ReservationInfoFlagsMAINT},
+	}).Return(nil).Once()
+	mockClient.EXPECT().ListReservations(mock.Anything).Return([]slurmapi.Reservation{corrected}, nil).Once()
+
+	r, fakeClient := newSlurmReservationTestReconciler(t, mockClient, newTestSlurmReservation(start, end), newTestNodeSet("gpu", 4))
+
+	reconcileTestSlurmReservation(t, r)
+
+	reservation := &slurmv1alpha1.SlurmReservation{}
+	require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Namespace: testReservationNamespace, Name: "maintenance"}, reservation))
+
+	assert.NotNil(t, reservation.Status.LastDriftCorrectionTime)
+	assert.True(t, reservation.Status.EndTime.Equal(&metav1.Time{Time: end}))
+	synced := meta.FindStatusCondition(reservation.Status.Conditions, slurmv1alpha1.ConditionSlurmReservationSynced)
+	require.NotNil(t, synced)
+	assert.Equal(t, slurmv1alpha1.ReasonSlurmReservationUpdated, synced.Reason)
+}
+
+func TestSlurmReservationReconciler_MissingNodeSet(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now().Add(time.Hour).Truncate(time.Second)
+	mockClient := slurmapifake.NewMockClient(t)
+
+	r, fakeClient := newSlurmReservationTestReconciler(t, mockClient, newTestSlurmReservation(start, start.Add(time.Hour)))
+
+	result := reconcileTestSlurmReservation(t, r)
+	assert.Equal(t, time.Minute, result.RequeueAfter)
+
+	reservation := &slurmv1alpha1.SlurmReservation{}
+	require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Namespace: testReservationNamespace, Name: "maintenance"}, reservation))
+
+	assert.Equal(t, slurmv1alpha1.PhaseSlurmReservationPending, reservation.Status.Phase)
+	synced := meta.FindStatusCondition(reservation.Status.Conditions, slurmv1alpha1.ConditionSlurmReservationSynced)
+	require.NotNil(t, synced)
+	assert.Equal(t, metav1.ConditionFalse, synced.Status)
+	assert.Equal(t, slurmv1alpha1.ReasonSlurmReservationInvalidNodes, synced.Reason)
+}
+
+func TestSlurmReservationReconciler_Expired(t *testing.T) {
+	t.Parallel()
+
+	end := time.Now().Add(-time.Minute).Truncate(time.Second)
+	r, fakeClient := newSlurmReservationTestReconciler(t, nil, newTestSlurmReservation(end.Add(-time.Hour), end))
+
+	result := reconcileTestSlurmReservation(t, r)
+	assert.Zero(t, result.RequeueAfter)
+
+	reservation := &slurmv1alpha1.SlurmReservation{}
+	require.NoError(t, fakeClient.Get(context.Background(), types.NamespacedName{Namespace: testReservationNamespace, Name: "maintenance"}, reservation))
+	assert.Equal(t, slurmv1alpha1.PhaseSlurmReservationExpired, reservation.Status.Phase)
+}
+
+func TestSlurmReservationReconciler_DeletesReservation(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now().Add(time.Hour).Truncate(time.Second)
+	reservation := newTestSlurmReservation(start, start.Add(time.Hour))
+	reservation.Finalizers = []string{consts.SlurmReservationFinalizer}
+	reservation.DeletionTimestamp = ptr.To(metav1.Now())
+
+	mockClient := slurmapifake.NewMockClient(t)
+	mockClient.EXPECT().ListReservations(mock.Anything).Return([]slurmapi.Reservation{{Name: "maintenance"}}, nil).Once()
+	mockClient.EXPECT().DeleteReservation(mock.Anything, "maintenance").Return(nil).Once()
+
+	r, fakeClient := newSlurmReservationTestReconciler(t, mockClient, reservation)
+
+	reconcileTestSlurmReservation(t, r)
+
+	err := fakeClient.Get(context.Background(), types.NamespacedName{Namespace: testReservationNamespace, Name: "maintenance"}, &slurmv1alpha1.SlurmReservation{})
+	assert.True(t, client.IgnoreNotFound(err) == nil && err != nil, "SlurmReservation must be gone once the finalizer is removed")
+}
+
+func TestSlurmReservationDrift(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, time.October, 16, 12, 0, 0, 0, time.UTC)
+	desired := slurmapi.Reservation{
+		Name:      "maintenance",
+		NodeList:  "worker-[0-3]",
+		StartTime: now.Add(time.Hour),
+		EndTime:   now.Add(2 * time.Hour),
+		Flags:     []api.V0044ReservationInfoFlags{api.V0044ReservationInfoFlagsMAINT},
+		Users:     []string{"root", "ops"},
+	}
+
+	tests := []struct {
+		name      string
+		desired   func(slurmapi.Reservation) slurmapi.Reservation
+		actual    func(slurmapi.Reservation) slurmapi.Reservation
+		recurring bool
+		want      []string
+	}{
+		{
+			name: "same reservation in another notation",
+			actual: func(r slurmapi.Reservation) slurmapi.Reservation {
+				r.NodeList = "worker-[0-1],worker-2,worker-3"
+				r.Users = []string{"ops", "root"}
+				r.Flags = append(r.Flags, api.V0044ReservationInfoFlagsSPECNODES)
+				return r
+			},
+		},
+		{
+			name: "nodes, users and flags drifted",
+			actual: func(r slurmapi.Reservation) slurmapi.Reservation {
+				r.NodeList = "worker-[0-2]"
+				r.Users = []string{"root"}
+				r.Flags = nil
+				return r
+			},
+			want: []string{reservationFieldNodes, reservationFieldFlags, reservationFieldUsers},
+		},
+		{
+			name: "times drifted",
+			actual: func(r slurmapi.Reservation) slurmapi.Reservation {
+				r.StartTime = r.StartTime.Add(time.Minute)
+				r.EndTime = r.EndTime.Add(time.Minute)
+				return r
+			},
+			want: []string{reservationFieldStartTime, reservationFieldEndTime},
+		},
+		{
+			name: "times of recurring reservation are ignored",
+			actual: func(r slurmapi.Reservation) slurmapi.Reservation {
+				r.StartTime = r.StartTime.Add(24 * time.Hour)
+				r.EndTime = r.EndTime.Add(24 * time.Hour)
+				return r
+			},
+			recurring: true,
+		},
+		{
+			name: "started reservation keeps its start time",
+			desired: func(r slurmapi.Reservation) slurmapi.Reservation {
+				r.StartTime = now
+				return r
+			},
+			actual: func(r slurmapi.Reservation) slurmapi.Reservation {
+				r.StartTime = now.Add(-time.Hour)
+				return r
+			},
+		},
+		{
+			name: "postponed reservation",
+			desired: func(r slurmapi.Reservation) slurmapi.Reservation {
+				r.StartTime = now
+				return r
+			},
+			want: []string{reservationFieldStartTime},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			d, a := desired, desired
+			if tt.desired != nil {
+				d = tt.desired(d)
+			}
+			if tt.actual != nil {
+				a = tt.actual(a)
+			}
+			assert.Equal(t, tt.want, slurmReservationDrift(d, a, tt.recurring, now))
+		})
+	}
+}
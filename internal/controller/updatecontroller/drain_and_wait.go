@@ -0,0 +1,454 @@
+package updatecontroller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	kruisev1b1 "github.com/openkruise/kruise-api/apps/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/slurmapi"
+)
+
+const (
+	drainReservationPrefix          = "soperator-drain-"
+	defaultDrainReservationDuration = time.Hour
+)
+
+var defaultMaxDrainedPerPartition = intstr.FromString("10%")
+
+// drainAndWait is the drainAndWait rollout mode of the slurm-aware rolling update of a StatefulSet.
+// Outdated workers whose jobs finish soonest are picked first. A picked worker is reserved from the time its jobs
+// finish, so that only jobs ending before that can still be scheduled on it, and is rebooted once they're done.
+type drainAndWait struct {
+	reservationDuration    time.Duration
+	maxDrainedPerPartition intstr.IntOrString
+}
+
+// getDrainAndWait returns the drainAndWait mode configured for the StatefulSet. Empty annotations keep the defaults.
+func getDrainAndWait(sts *kruisev1b1.StatefulSet) (*drainAndWait, error) {
+	drain := &drainAndWait{
+		reservationDuration:    defaultDrainReservationDuration,
+		maxDrainedPerPartition: defaultMaxDrainedPerPartition,
+	}
+
+	if value := sts.Annotations[consts.AnnotationRolloutDrainReservationDuration]; value != "" {
+		duration, err := time.ParseDuration(value)
+		if err != nil || duration <= 0 {
+			return nil, fmt.Errorf("invalid annotation %s on statefulset %s/%s: %q",
+				consts.AnnotationRolloutDrainReservationDuration, sts.Namespace, sts.Name, value)
+		}
+		drain.reservationDuration = duration
+	}
+
+	if value := sts.Annotations[consts.AnnotationRolloutMaxDrainedPerPartition]; value != "" {
+		maxDrained := intstr.Parse(value)
+		if _, err := intstr.GetScaledValueFromIntOrPercent(&maxDrained, 100, false); err != nil {
+			return nil, fmt.Errorf("invalid annotation %s on statefulset %s/%s: %q",
+				consts.AnnotationRolloutMaxDrainedPerPartition, sts.Namespace, sts.Name, value)
+		}
+		drain.maxDrainedPerPartition = maxDrained
+	}
+
+	return drain, nil
+}
+
+func drainReservationName(nodeName string) string {
+	return drainReservationPrefix + nodeName
+}
+
+// nodesFreeAt returns when the running jobs on each busy node end, by their time limits.
+// Idle nodes are missing from the result, and nodes running jobs without a known end time map to the zero time.
+func nodesFreeAt(jobs []slurmapi.Job) (map[string]time.Time, error) {
+	freeAt := make(map[string]time.Time)
+	unknownEnd := make(map[string]struct{})
+
+	for _, job := range jobs {
+		if job.State != string(api.V0044JobInfoJobStateRUNNING) &&
+			job.State != string(api.V0044JobInfoJobStateCOMPLETING) {
+			continue
+		}
+		nodeNames, err := job.GetNodeList()
+		if err != nil {
+			return nil, fmt.Errorf("parse node list of slurm job %d: %w", job.ID, err)
+		}
+
+		for _, nodeName := range nodeNames {
+			if job.EndTime == nil {
+				unknownEnd[nodeName] = struct{}{}
+				freeAt[nodeName] = time.Time{}
+				continue
+			}
+			if _, unknown := unknownEnd[nodeName]; unknown {
+				continue
+			}
+			if end := job.EndTime.Time; end.After(freeAt[nodeName]) {
+				freeAt[nodeName] = end
+			}
+		}
+	}
+
+	return freeAt, nil
+}
+
+// sortByFreeAt orders the candidates by when their nodes are free: idle nodes first, nodes running jobs without
+// a known end time last.
+func sortByFreeAt(candidates []rebootCandidate, freeAt map[string]time.Time, now time.Time) {
+	freeTime := func(nodeName string) time.Time {
+		end, busy := freeAt[nodeName]
+		if !busy {
+			return now
+		}
+		return end
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		ti, tj := freeTime(candidates[i].slurmNode.Name), freeTime(candidates[j].slurmNode.Name)
+		if ti.IsZero() != tj.IsZero() {
+			return tj.IsZero()
+		}
+		if !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		return candidates[i].slurmNode.Name < candidates[j].slurmNode.Name
+	})
+}
+
+// partitionDrainCaps tracks the nodes out of service in each Slurm partition against the cap of the drainAndWait mode.
+type partitionDrainCaps struct {
+	caps         map[string]int
+	drained      map[string]int
+	outOfService map[string]struct{}
+}
+
+func newPartitionDrainCaps(
+	slurmNodes []slurmapi.Node,
+	drainReservations map[string]slurmapi.Reservation,
+	maxDrained intstr.IntOrString,
+) partitionDrainCaps {
+	c := partitionDrainCaps{
+		caps:         make(map[string]int),
+		drained:      make(map[string]int),
+		outOfService: make(map[string]struct{}),
+	}
+
+	totals := make(map[string]int)
+	for _, node := range slurmNodes {
+		_, reserved := drainReservations[drainReservationName(node.Name)]
+		outOfService := reserved || slurmNodeOutOfService(&node)
+		if outOfService {
+			c.outOfService[node.Name] = struct{}{}
+		}
+		for _, partition := range node.Partitions {
+			totals[partition]++
+			if outOfService {
+				c.drained[partition]++
+			}
+		}
+	}
+
+	for partition, total := range totals {
+		maxNodes, err := intstr.GetScaledValueFromIntOrPercent(&maxDrained, total, false)
+		if err != nil || maxNodes < 1 {
+			maxNodes = 1
+		}
+		c.caps[partition] = maxNodes
+	}
+
+	return c
+}
+
+// take counts the node as out of service in all of its partitions, unless that exceeds the cap of any of them.
+func (c partitionDrainCaps) take(node *slurmapi.Node) bool {
+	if _, found := c.outOfService[node.Name]; found {
+		return true
+	}
+	for _, partition := range node.Partitions {
+		if c.drained[partition] >= c.caps[partition] {
+			return false
+		}
+	}
+
+	for _, partition := range node.Partitions {
+		c.drained[partition]++
+	}
+	c.outOfService[node.Name] = struct{}{}
+	return true
+}
+
+func slurmNodeOutOfService(node *slurmapi.Node) bool {
+	return node.IsDrainState() || node.IsRebootRequestedState() || node.IsRebootIssuedState()
+}
+
+// processDrainAndWait takes the outdated workers out of service in the drainAndWait rollout mode.
+// Workers which were reserved before are rebooted once their jobs finish, even if the rollout is paused or halted.
+func (r *RollingUpdateReconciler) processDrainAndWait(
+	ctx context.Context,
+	slurmClient slurmapi.Client,
+	sts *kruisev1b1.StatefulSet,
+	operationID string,
+	slurmNodes []slurmapi.Node,
+	candidates []rebootCandidate,
+	readyPodsConsumingBudget int,
+	controls rolloutControls,
+	verification *updateVerification,
+	verificationStatus updateVerificationStatus,
+) error {
+	logger := log.FromContext(ctx).WithName("rolling-update-reconciler")
+	now := time.Now()
+
+	jobs, err := slurmClient.ListJobs(ctx)
+	if err != nil {
+		return fmt.Errorf("list slurm jobs: %w", err)
+	}
+	freeAt, err := nodesFreeAt(jobs)
+	if err != nil {
+		return err
+	}
+
+	reservations, err := slurmClient.ListReservations(ctx)
+	if err != nil {
+		return fmt.Errorf("list slurm reservations: %w", err)
+	}
+	drainReservations := make(map[string]slurmapi.Reservation)
+	for _, reservation := range reservations {
+		if strings.HasPrefix(reservation.Name, drainReservationPrefix) {
+			drainReservations[reservation.Name] = reservation
+		}
+	}
+
+	var pending []rebootCandidate
+	var nodesToReboot []string
+	waiting := 0
+	for _, candidate := range candidates {
+		nodeName := candidate.slurmNode.Name
+		reservation, reserved := drainReservations[drainReservationName(nodeName)]
+		if !reserved ||
+			workerOperationPhase(&candidate.pod, operationID) != consts.LabelSoperatorWorkerOperationPhaseStopping {
+			pending = append(pending, candidate)
+			continue
+		}
+
+		waiting++
+		if _, busy := freeAt[nodeName]; !busy || !now.Before(reservation.StartTime) {
+			nodesToReboot = append(nodesToReboot, nodeName)
+		}
+	}
+
+	if rolloutBlocked(ctx, sts, controls, verification, verificationStatus) {
+		return rebootDrainedWorkers(ctx, slurmClient, nodesToReboot, drainReservations, verification)
+	}
+
+	// Updated workers are held out of service until their verification finishes,
+	// and reserved workers until their jobs finish.
+	readyPodsConsumingBudget += verificationStatus.inFlight + waiting
+
+	availableSlots := availableRebootSlots(ctx, sts, readyPodsConsumingBudget)
+	if availableSlots <= 0 {
+		return rebootDrainedWorkers(ctx, slurmClient, nodesToReboot, drainReservations, verification)
+	}
+
+	caps := newPartitionDrainCaps(slurmNodes, drainReservations, controls.drainAndWait.maxDrainedPerPartition)
+	sortByFreeAt(pending, freeAt, now)
+
+	picked := 0
+	var cappedNodes []string
+	for _, candidate := range pending {
+		if picked >= availableSlots {
+			break
+		}
+
+		pod := candidate.pod
+		if !controls.includes(sts, &pod) {
+			continue
+		}
+		if !caps.take(&candidate.slurmNode) {
+			cappedNodes = append(cappedNodes, candidate.slurmNode.Name)
+			continue
+		}
+		if workerOperationPhase(&pod, operationID) != consts.LabelSoperatorWorkerOperationPhaseStopping {
+			if err := r.startWorkerOperation(ctx, &pod, operationID); err != nil {
+				return err
+			}
+		}
+		picked++
+
+		nodeName := candidate.slurmNode.Name
+		end, busy := freeAt[nodeName]
+		if !busy {
+			nodesToReboot = append(nodesToReboot, nodeName)
+			continue
+		}
+
+		reservationName := drainReservationName(nodeName)
+		if _, found := drainReservations[reservationName]; found {
+			continue
+		}
+		// Workers running jobs without a known end time, or past it, are reserved right away.
+		start := end
+		if start.Before(now) {
+			start = now
+		}
+		if err := slurmClient.CreateReservation(ctx, slurmapi.Reservation{
+			Name:      reservationName,
+			NodeList:  nodeName,
+			StartTime: start,
+			EndTime:   start.Add(controls.drainAndWait.reservationDuration),
+			Flags: []api.V0044ReservationInfoFlags{
+				api.V0044ReservationInfoFlagsMAINT,
+				api.V0044ReservationInfoFlagsIGNOREJOBS,
+			},
+			Users: []string{slurmapi.SlurmUserSoperatorchecks},
+		}); err != nil {
+			return fmt.Errorf("create drain reservation %s: %w", reservationName, err)
+		}
+		logger.Info("reserved worker until its jobs finish", "pod", pod.Name, "reservation", reservationName, "start", start)
+	}
+	if len(cappedNodes) > 0 {
+		logger.Info("drained capacity of partitions is exhausted", "nodes", cappedNodes,
+			"maxDrainedPerPartition", controls.drainAndWait.maxDrainedPerPartition.String())
+	}
+
+	return rebootDrainedWorkers(ctx, slurmClient, nodesToReboot, drainReservations, verification)
+}
+
+// rebootDrainedWorkers schedules the reboot of the workers picked by the drainAndWait rollout mode.
+// Their drain reservations are deleted once the reboot drains them.
+func rebootDrainedWorkers(
+	ctx context.Context,
+	slurmClient slurmapi.Client,
+	nodeNames []string,
+	drainReservations map[string]slurmapi.Reservation,
+	verification *updateVerification,
+) error {
+	if len(nodeNames) == 0 {
+		return nil
+	}
+	logger := log.FromContext(ctx).WithName("rolling-update-reconciler")
+
+	if err := slurmClient.RebootNodes(ctx, slurmapi.RebootNodesRequest{
+		NodeList:    strings.Join(nodeNames, ","),
+		ASAP:        true,
+		Reason:      defaultRebootReason,
+		PowerAction: consts.SlurmPowerActionWorkerHandoff,
+	}); err != nil {
+		return fmt.Errorf("schedule slurm reboot through rest api: %w", err)
+	}
+
+	for _, nodeName := range nodeNames {
+		reservationName := drainReservationName(nodeName)
+		if _, found := drainReservations[reservationName]; !found {
+			continue
+		}
+		if err := slurmClient.DeleteReservation(ctx, reservationName); err != nil {
+			return fmt.Errorf("delete drain reservation %s: %w", reservationName, err)
+		}
+	}
+
+	// The verification reservations can only be created once the drain reservations are gone, as they'd overlap.
+	if verification != nil {
+		if err := reserveForVerification(ctx, slurmClient, nodeNames); err != nil {
+			return err
+		}
+	}
+
+	logger.Info("scheduled slurm reboot through rest api", "nodes", nodeNames)
+	return nil
+}
+
+// cleanupStaleDrainReservations deletes the drain reservations left behind by the drainAndWait rollout mode, e.g. if
+// a worker was updated some other way or the rollout mode was changed before the worker was rebooted.
+// The reservation of a worker of the StatefulSet is stale once it's on the update revision, and the reservation of
+// a node is stale once it's not a worker of the cluster anymore. Reservations of the other StatefulSets are kept.
+func (r *RollingUpdateReconciler) cleanupStaleDrainReservations(
+	ctx context.Context,
+	clusterName string,
+	sts *kruisev1b1.StatefulSet,
+	pods []corev1.Pod,
+) error {
+	logger := log.FromContext(ctx).WithName("rolling-update-reconciler")
+
+	slurmClient, ok := r.slurmAPIClients.GetClient(types.NamespacedName{
+		Namespace: sts.Namespace,
+		Name:      clusterName,
+	})
+	if !ok {
+		return fmt.Errorf("no slurm api client for %s/%s", sts.Namespace, clusterName)
+	}
+	reservations, err := slurmClient.ListReservations(ctx)
+	if err != nil {
+		return fmt.Errorf("list slurm reservations: %w", err)
+	}
+
+	podsByName := make(map[string]*corev1.Pod, len(pods))
+	for i := range pods {
+		podsByName[pods[i].Name] = &pods[i]
+	}
+
+	var clusterWorkers map[string]struct{}
+	var deleted []string
+	for _, reservation := range reservations {
+		nodeName, found := strings.CutPrefix(reservation.Name, drainReservationPrefix)
+		if !found {
+			continue
+		}
+
+		if pod, found := podsByName[nodeName]; found {
+			if pod.Labels["controller-revision-hash"] != sts.Status.UpdateRevision {
+				continue
+			}
+		} else {
+			if clusterWorkers == nil {
+				if clusterWorkers, err = r.listClusterWorkerNames(ctx, sts.Namespace, clusterName); err != nil {
+					return err
+				}
+			}
+			if _, found := clusterWorkers[nodeName]; found {
+				continue
+			}
+		}
+
+		if err := slurmClient.DeleteReservation(ctx, reservation.Name); err != nil {
+			return fmt.Errorf("delete stale drain reservation %s: %w", reservation.Name, err)
+		}
+		deleted = append(deleted, reservation.Name)
+	}
+
+	if len(deleted) > 0 {
+		logger.Info("deleted stale drain reservations", "reservations", deleted)
+	}
+	return nil
+}
+
+// listClusterWorkerNames returns the names of the worker pods of all the StatefulSets of the cluster.
+func (r *RollingUpdateReconciler) listClusterWorkerNames(
+	ctx context.Context,
+	namespace string,
+	clusterName string,
+) (map[string]struct{}, error) {
+	podList := &corev1.PodList{}
+	if err := r.List(ctx, podList,
+		client.InNamespace(namespace),
+		client.MatchingLabels{
+			consts.LabelInstanceKey: clusterName,
+			consts.LabelWorkerKey:   consts.LabelWorkerValue,
+		},
+	); err != nil {
+		return nil, fmt.Errorf("list worker pods of cluster %s/%s: %w", namespace, clusterName, err)
+	}
+
+	names := make(map[string]struct{}, len(podList.Items))
+	for _, pod := range podList.Items {
+		names[pod.Name] = struct{}{}
+	}
+	return names, nil
+}
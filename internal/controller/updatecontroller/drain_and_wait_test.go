@@ -0,0 +1,254 @@
+package updatecontroller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/slurmapi"
+	slurmapifake "nebius.ai/slurm-operator/internal/slurmapi/fake"
+)
+
+func TestNodesFreeAt(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	runningJob := func(nodes string, end *time.Time) slurmapi.Job {
+		job := slurmapi.Job{State: string(api.V0044JobInfoJobStateRUNNING), Nodes: nodes}
+		if end != nil {
+			job.EndTime = ptr.To(metav1.NewTime(*end))
+		}
+		return job
+	}
+
+	got, err := nodesFreeAt([]slurmapi.Job{
+		runningJob("worker-[0-1]", ptr.To(now.Add(time.Hour))),
+		runningJob("worker-1", ptr.To(now.Add(3*time.Hour))),
+		runningJob("worker-2", nil),
+		runningJob("worker-2", ptr.To(now.Add(time.Hour))),
+		{State: string(api.V0044JobInfoJobStatePENDING), ScheduledNodes: "worker-3"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]time.Time{
+		"worker-0": now.Add(time.Hour),
+		"worker-1": now.Add(3 * time.Hour),
+		"worker-2": {},
+	}, got)
+}
+
+func TestPartitionDrainCaps(t *testing.T) {
+	var slurmNodes []slurmapi.Node
+	for i := range 10 {
+		node := slurmapi.Node{
+			Name:       fmt.Sprintf("worker-%d", i),
+			States:     nodeStates(api.V0044NodeStateIDLE),
+			Partitions: []string{"main"},
+		}
+		if i >= 8 {
+			node.Partitions = append(node.Partitions, "gpu")
+		}
+		slurmNodes = append(slurmNodes, node)
+	}
+	slurmNodes[0].States = nodeStates(api.V0044NodeStateIDLE, api.V0044NodeStateDRAIN)
+
+	caps := newPartitionDrainCaps(slurmNodes, map[string]slurmapi.Reservation{
+		drainReservationName("worker-9"): {Name: drainReservationName("worker-9")},
+	}, intstr.FromString("30%"))
+
+	// main allows 3 nodes out of service, and gpu at least 1.
+	assert.True(t, caps.take(&slurmNodes[0]), "already drained node")
+	assert.True(t, caps.take(&slurmNodes[9]), "already reserved node")
+	assert.False(t, caps.take(&slurmNodes[8]), "gpu is at its cap")
+	assert.True(t, caps.take(&slurmNodes[1]))
+	assert.False(t, caps.take(&slurmNodes[2]), "main is at its cap")
+}
+
+func TestProcessRollingUpdateDrainAndWaitPicksWorkersFinishingSoonest(t *testing.T) {
+	now := time.Now()
+	sts := testStatefulSet()
+	sts.Name = "worker"
+	sts.Spec.Replicas = ptr.To(int32(3))
+	sts.Status.ReadyReplicas = 3
+	setMaxUnavailable(sts, intstr.FromInt32(2))
+
+	pods := make([]corev1.Pod, 3)
+	slurmNodes := make([]slurmapi.Node, 3)
+	for i := range pods {
+		pods[i] = testOutdatedPod()
+		pods[i].Name = fmt.Sprintf("worker-%d", i)
+		slurmNodes[i] = slurmapi.Node{
+			Name:       pods[i].Name,
+			States:     nodeStates(api.V0044NodeStateALLOCATED),
+			Partitions: []string{"main"},
+		}
+	}
+	slurmNodes[2].States = nodeStates(api.V0044NodeStateIDLE)
+	soonEnd := now.Add(20 * time.Minute).Truncate(time.Second)
+
+	slurmClient := &slurmapifake.MockClient{}
+	slurmClient.On("ListNodes", mock.Anything).Return(slurmNodes, nil).Once()
+	slurmClient.On("ListJobs", mock.Anything).Return([]slurmapi.Job{
+		{
+			ID:      1,
+			State:   string(api.V0044JobInfoJobStateRUNNING),
+			Nodes:   "worker-0",
+			EndTime: ptr.To(metav1.NewTime(now.Add(10 * time.Hour))),
+		},
+		{
+			ID:      2,
+			State:   string(api.V0044JobInfoJobStateRUNNING),
+			Nodes:   "worker-1",
+			EndTime: ptr.To(metav1.NewTime(soonEnd)),
+		},
+	}, nil).Once()
+	slurmClient.On("ListReservations", mock.Anything).Return([]slurmapi.Reservation{}, nil).Once()
+	slurmClient.On("CreateReservation", mock.Anything, mock.MatchedBy(func(reservation slurmapi.Reservation) bool {
+		return reservation.Name == "soperator-drain-worker-1" &&
+			reservation.NodeList == "worker-1" &&
+			reservation.StartTime.Equal(soonEnd) &&
+			reservation.EndTime.Equal(soonEnd.Add(30*time.Minute)) &&
+			assert.ObjectsAreEqual([]string{slurmapi.SlurmUserSoperatorchecks}, reservation.Users)
+	})).Return(nil).Once()
+	slurmClient.On("RebootNodes", mock.Anything, slurmapi.RebootNodesRequest{
+		NodeList:    "worker-2",
+		ASAP:        true,
+		Reason:      defaultRebootReason,
+		PowerAction: consts.SlurmPowerActionWorkerHandoff,
+	}).Return(nil).Once()
+
+	reconciler, kubeClient := testRollingUpdateReconcilerWithPods(t, slurmClient, &pods[0], &pods[1], &pods[2])
+	err := reconciler.processRollingUpdate(
+		context.Background(),
+		"cluster",
+		"new-revision",
+		sts,
+		pods,
+		rolloutControls{drainAndWait: &drainAndWait{
+			reservationDuration:    30 * time.Minute,
+			maxDrainedPerPartition: intstr.FromString("100%"),
+		}},
+		nil,
+		updateVerificationStatus{},
+	)
+	require.NoError(t, err)
+
+	wantPhases := []string{"", consts.LabelSoperatorWorkerOperationPhaseStopping, consts.LabelSoperatorWorkerOperationPhaseStopping}
+	for i, wantPhase := range wantPhases {
+		got := &corev1.Pod{}
+		require.NoError(t, kubeClient.Get(context.Background(), client.ObjectKeyFromObject(&pods[i]), got))
+		assert.Equal(t, wantPhase, workerOperationPhase(got, "new-revision"), got.Name)
+	}
+	slurmClient.AssertExpectations(t)
+}
+
+func TestProcessRollingUpdateDrainAndWaitRebootsReservedWorkersOnceJobsFinish(t *testing.T) {
+	now := time.Now()
+	sts := testStatefulSet()
+	sts.Name = "worker"
+	sts.Spec.Replicas = ptr.To(int32(2))
+	sts.Status.ReadyReplicas = 2
+	setMaxUnavailable(sts, intstr.FromInt32(2))
+
+	pods := make([]corev1.Pod, 2)
+	slurmNodes := make([]slurmapi.Node, 2)
+	reservations := make([]slurmapi.Reservation, 2)
+	for i := range pods {
+		pods[i] = testOutdatedPod()
+		pods[i].Name = fmt.Sprintf("worker-%d", i)
+		pods[i].Labels = map[string]string{
+			consts.LabelSoperatorWorkerOperationID:    "new-revision",
+			consts.LabelSoperatorWorkerOperationPhase: consts.LabelSoperatorWorkerOperationPhaseStopping,
+		}
+		slurmNodes[i] = slurmapi.Node{Name: pods[i].Name, States: nodeStates(api.V0044NodeStateIDLE)}
+		reservations[i] = slurmapi.Reservation{
+			Name:      drainReservationName(pods[i].Name),
+			NodeList:  pods[i].Name,
+			StartTime: now.Add(time.Hour),
+			EndTime:   now.Add(2 * time.Hour),
+		}
+	}
+	slurmNodes[1].States = nodeStates(api.V0044NodeStateALLOCATED)
+
+	slurmClient := &slurmapifake.MockClient{}
+	slurmClient.On("ListNodes", mock.Anything).Return(slurmNodes, nil).Once()
+	slurmClient.On("ListJobs", mock.Anything).Return([]slurmapi.Job{{
+		ID:      1,
+		State:   string(api.V0044JobInfoJobStateRUNNING),
+		Nodes:   "worker-1",
+		EndTime: ptr.To(metav1.NewTime(now.Add(time.Hour))),
+	}}, nil).Once()
+	slurmClient.On("ListReservations", mock.Anything).Return(reservations, nil).Once()
+	slurmClient.On("RebootNodes", mock.Anything, slurmapi.RebootNodesRequest{
+		NodeList:    "worker-0",
+		ASAP:        true,
+		Reason:      defaultRebootReason,
+		PowerAction: consts.SlurmPowerActionWorkerHandoff,
+	}).Return(nil).Once()
+	slurmClient.On("DeleteReservation", mock.Anything, "soperator-drain-worker-0").Return(nil).Once()
+
+	reconciler, _ := testRollingUpdateReconcilerWithPods(t, slurmClient, &pods[0], &pods[1])
+	err := reconciler.processRollingUpdate(
+		context.Background(),
+		"cluster",
+		"new-revision",
+		sts,
+		pods,
+		rolloutControls{paused: true, drainAndWait: &drainAndWait{
+			reservationDuration:    time.Hour,
+			maxDrainedPerPartition: intstr.FromString("10%"),
+		}},
+		nil,
+		updateVerificationStatus{},
+	)
+	require.NoError(t, err)
+	slurmClient.AssertExpectations(t)
+}
+
+func TestCleanupStaleDrainReservations(t *testing.T) {
+	sts := testStatefulSet()
+	sts.Name = "worker"
+	sts.Status.UpdateRevision = "new-revision"
+
+	updatedPod := testOutdatedPod()
+	updatedPod.Labels = map[string]string{"controller-revision-hash": "new-revision"}
+	outdatedPod := testOutdatedPod()
+	outdatedPod.Name = "worker-1"
+	outdatedPod.Labels = map[string]string{"controller-revision-hash": "old-revision"}
+	otherNodeSetPod := testOutdatedPod()
+	otherNodeSetPod.Name = "gpu-0"
+	otherNodeSetPod.Labels = map[string]string{
+		consts.LabelInstanceKey: "cluster",
+		consts.LabelWorkerKey:   consts.LabelWorkerValue,
+	}
+
+	slurmClient := &slurmapifake.MockClient{}
+	slurmClient.On("ListReservations", mock.Anything).Return([]slurmapi.Reservation{
+		{Name: drainReservationName("worker-0")},
+		{Name: drainReservationName("worker-1")},
+		{Name: drainReservationName("worker-9")},
+		{Name: drainReservationName("gpu-0")},
+		{Name: updateVerificationReservationName("worker-0")},
+		{Name: "maintenance"},
+	}, nil).Once()
+	// The updated worker and the one scaled down are released, while the worker waiting for its update and the worker
+	// of another NodeSet stay reserved.
+	slurmClient.On("DeleteReservation", mock.Anything, "soperator-drain-worker-0").Return(nil).Once()
+	slurmClient.On("DeleteReservation", mock.Anything, "soperator-drain-worker-9").Return(nil).Once()
+
+	reconciler, _ := testRollingUpdateReconcilerWithPods(t, slurmClient, &updatedPod, &outdatedPod, &otherNodeSetPod)
+	err := reconciler.cleanupStaleDrainReservations(
+		context.Background(), "cluster", sts, []corev1.Pod{updatedPod, outdatedPod},
+	)
+	require.NoError(t, err)
+	slurmClient.AssertExpectations(t)
+}
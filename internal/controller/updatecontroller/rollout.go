@@ -0,0 +1,192 @@
+package updatecontroller
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	kruisev1b1 "github.com/openkruise/kruise-api/apps/v1beta1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/consts"
+)
+
+// rolloutControls are the controls of the slurm-aware rolling update of a StatefulSet.
+// They're configured by the NodeSet through the StatefulSet annotations.
+type rolloutControls struct {
+	paused    bool
+	partition int
+	// drainAndWait is set in the drainAndWait rollout mode.
+	drainAndWait *drainAndWait
+}
+
+// getRolloutControls returns the rollout controls of the StatefulSet. Missing annotations keep their defaults.
+func getRolloutControls(sts *kruisev1b1.StatefulSet) (rolloutControls, error) {
+	var controls rolloutControls
+
+	if value, found := sts.Annotations[consts.AnnotationRolloutPaused]; found {
+		paused, err := strconv.ParseBool(value)
+		if err != nil {
+			return controls, fmt.Errorf("invalid annotation %s on statefulset %s/%s: %q",
+				consts.AnnotationRolloutPaused, sts.Namespace, sts.Name, value)
+		}
+		controls.paused = paused
+	}
+
+	if value, found := sts.Annotations[consts.AnnotationRolloutPartition]; found {
+		partition, err := strconv.Atoi(value)
+		if err != nil || partition < 0 {
+			return controls, fmt.Errorf("invalid annotation %s on statefulset %s/%s: %q",
+				consts.AnnotationRolloutPartition, sts.Namespace, sts.Name, value)
+		}
+		controls.partition = partition
+	}
+
+	switch mode := consts.RolloutMode(sts.Annotations[consts.AnnotationRolloutMode]); mode {
+	case "", consts.RolloutModeReboot:
+	case consts.RolloutModeDrainAndWait:
+		drain, err := getDrainAndWait(sts)
+		if err != nil {
+			return controls, err
+		}
+		controls.drainAndWait = drain
+	default:
+		return controls, fmt.Errorf("invalid annotation %s on statefulset %s/%s: %q",
+			consts.AnnotationRolloutMode, sts.Namespace, sts.Name, mode)
+	}
+
+	return controls, nil
+}
+
+// includes reports whether the pod is allowed to be updated by the partition.
+// Pods with an unknown ordinal aren't, as they might be held back by it.
+func (c rolloutControls) includes(sts *kruisev1b1.StatefulSet, pod *corev1.Pod) bool {
+	if c.partition == 0 {
+		return true
+	}
+	ordinal, found := podOrdinal(sts, pod)
+	return found && ordinal >= c.partition
+}
+
+// excludes reports whether the pod is held back from the update by the partition.
+// Pods with an unknown ordinal aren't, so that they're still pending.
+func (c rolloutControls) excludes(sts *kruisev1b1.StatefulSet, pod *corev1.Pod) bool {
+	if c.partition == 0 {
+		return false
+	}
+	ordinal, found := podOrdinal(sts, pod)
+	return found && ordinal < c.partition
+}
+
+// statefulSetPodNameRegexp matches the name of a StatefulSet pod: the name of its StatefulSet and its ordinal.
+var statefulSetPodNameRegexp = regexp.MustCompile(`^(.*)-([0-9]+)$`)
+
+// podOrdinal returns the ordinal of the pod of the StatefulSet from its pod index label.
+// For the pods created before the label was set, it's taken from the pod name the way Kruise does.
+func podOrdinal(sts *kruisev1b1.StatefulSet, pod *corev1.Pod) (int, bool) {
+	if value, found := pod.Labels[appsv1.PodIndexLabel]; found {
+		ordinal, err := strconv.Atoi(value)
+		return ordinal, err == nil && ordinal >= 0
+	}
+
+	match := statefulSetPodNameRegexp.FindStringSubmatch(pod.Name)
+	if match == nil || match[1] != sts.Name {
+		return 0, false
+	}
+	ordinal, err := strconv.Atoi(match[2])
+	return ordinal, err == nil
+}
+
+// buildRolloutStatus returns the progress of the rollout of the update revision of the StatefulSet.
+func buildRolloutStatus(
+	sts *kruisev1b1.StatefulSet,
+	pods []corev1.Pod,
+	controls rolloutControls,
+	verification *updateVerification,
+	verificationStatus updateVerificationStatus,
+) *slurmv1alpha1.RolloutStatus {
+	status := &slurmv1alpha1.RolloutStatus{
+		OperationID:               sts.Status.UpdateRevision,
+		FailedVerificationWorkers: verificationStatus.failedNodes,
+	}
+
+	pendingInPartition := 0
+	for _, pod := range pods {
+		switch {
+		case pod.Labels["controller-revision-hash"] == sts.Status.UpdateRevision:
+			status.UpdatedWorkers++
+		case workerOperationPhase(&pod, sts.Status.UpdateRevision) != "":
+			status.InFlightWorkers++
+		default:
+			status.PendingWorkers++
+			if !controls.excludes(sts, &pod) {
+				pendingInPartition++
+			}
+		}
+	}
+	// Updated workers are in flight until their verification finishes.
+	status.InFlightWorkers += int32(verificationStatus.inFlight)
+
+	switch {
+	case status.InFlightWorkers == 0 && pendingInPartition == 0:
+		status.State = slurmv1alpha1.RolloutStateComplete
+	case verification.halted(verificationStatus):
+		status.State = slurmv1alpha1.RolloutStateHalted
+	case controls.paused:
+		status.State = slurmv1alpha1.RolloutStatePaused
+	default:
+		status.State = slurmv1alpha1.RolloutStateProgressing
+	}
+
+	return status
+}
+
+// updateRolloutStatus sets the rollout status of the NodeSet the StatefulSet belongs to.
+// The rollout is reported as halted by a Warning event once, when its state turns to Halted.
+func (r *RollingUpdateReconciler) updateRolloutStatus(
+	ctx context.Context,
+	sts *kruisev1b1.StatefulSet,
+	status *slurmv1alpha1.RolloutStatus,
+	verification *updateVerification,
+) error {
+	nodeSetName := sts.Labels[consts.LabelNodeSetKey]
+	if nodeSetName == "" {
+		return nil
+	}
+
+	nodeSet := &slurmv1alpha1.NodeSet{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: sts.Namespace, Name: nodeSetName}, nodeSet); err != nil {
+		if client.IgnoreNotFound(err) == nil {
+			return nil
+		}
+		return fmt.Errorf("get nodeset %s/%s: %w", sts.Namespace, nodeSetName, err)
+	}
+	if apiequality.Semantic.DeepEqual(nodeSet.Status.Rollout, status) {
+		return nil
+	}
+
+	wasHalted := nodeSet.Status.Rollout != nil &&
+		nodeSet.Status.Rollout.OperationID == status.OperationID &&
+		nodeSet.Status.Rollout.State == slurmv1alpha1.RolloutStateHalted
+
+	patch := client.MergeFrom(nodeSet.DeepCopy())
+	nodeSet.Status.Rollout = status
+	if err := r.Status().Patch(ctx, nodeSet, patch); err != nil {
+		return fmt.Errorf("patch rollout status of nodeset %s/%s: %w", sts.Namespace, nodeSetName, err)
+	}
+
+	if status.State == slurmv1alpha1.RolloutStateHalted && !wasHalted {
+		r.Recorder.Eventf(sts, corev1.EventTypeWarning, consts.RollingUpdateEventHalted,
+			"Rolling update to %s is halted: %d updated workers failed verification, at most %d are allowed: %s",
+			status.OperationID, len(status.FailedVerificationWorkers), verification.maxFailedNodes,
+			strings.Join(status.FailedVerificationWorkers, ","))
+	}
+	return nil
+}
@@ -0,0 +1,408 @@
+package updatecontroller
+
+import (
+	"context"
+	"maps"
+	"testing"
+	"time"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/slurmapi"
+	slurmapifake "nebius.ai/slurm-operator/internal/slurmapi/fake"
+)
+
+func TestGetRolloutControls(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        rolloutControls
+		wantErr     bool
+	}{
+		{
+			name: "not annotated",
+		},
+		{
+			name: "paused canary",
+			annotations: map[string]string{
+				consts.AnnotationRolloutPaused:    "true",
+				consts.AnnotationRolloutPartition: "8",
+			},
+			want: rolloutControls{paused: true, partition: 8},
+		},
+		{
+			name: "drain and wait with defaults",
+			annotations: map[string]string{
+				consts.AnnotationRolloutMode:                     "drainAndWait",
+				consts.AnnotationRolloutDrainReservationDuration: "",
+				consts.AnnotationRolloutMaxDrainedPerPartition:   "",
+			},
+			want: rolloutControls{drainAndWait: &drainAndWait{
+				reservationDuration:    time.Hour,
+				maxDrainedPerPartition: intstr.FromString("10%"),
+			}},
+		},
+		{
+			name: "drain and wait",
+			annotations: map[string]string{
+				consts.AnnotationRolloutMode:                     "drainAndWait",
+				consts.AnnotationRolloutDrainReservationDuration: "30m0s",
+				consts.AnnotationRolloutMaxDrainedPerPartition:   "2",
+			},
+			want: rolloutControls{drainAndWait: &drainAndWait{
+				reservationDuration:    30 * time.Minute,
+				maxDrainedPerPartition: intstr.FromInt32(2),
+			}},
+		},
+		{
+			name: "drain and wait settings are ignored in the reboot mode",
+			annotations: map[string]string{
+				consts.AnnotationRolloutMode:                     "reboot",
+				consts.AnnotationRolloutDrainReservationDuration: "30m0s",
+			},
+		},
+		{
+			name:        "invalid mode",
+			annotations: map[string]string{consts.AnnotationRolloutMode: "recreate"},
+			wantErr:     true,
+		},
+		{
+			name: "invalid max drained per partition",
+			annotations: map[string]string{
+				consts.AnnotationRolloutMode:                   "drainAndWait",
+				consts.AnnotationRolloutMaxDrainedPerPartition: "half",
+			},
+			wantErr: true,
+		},
+		{
+			name:        "invalid paused",
+			annotations: map[string]string{consts.AnnotationRolloutPaused: "yes please"},
+			wantErr:     true,
+		},
+		{
+			name:        "negative partition",
+			annotations: map[string]string{consts.AnnotationRolloutPartition: "-1"},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sts := testStatefulSet()
+			sts.Annotations = tt.annotations
+
+			got, err := getRolloutControls(sts)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestProcessRollingUpdatePausedSchedulesNoReboots(t *testing.T) {
+	pod := testOutdatedPod()
+	sts := testStatefulSet()
+	sts.Status.ReadyReplicas = 1
+
+	slurmClient := &slurmapifake.MockClient{}
+	slurmClient.On("ListNodes", mock.Anything).Return([]slurmapi.Node{{
+		Name:   pod.Name,
+		States: nodeStates(api.V0044NodeStateIDLE),
+	}}, nil).Once()
+
+	reconciler, _ := testRollingUpdateReconciler(t, &pod, slurmClient)
+	err := reconciler.processRollingUpdate(
+		context.Background(),
+		"cluster",
+		"new-revision",
+		sts,
+		[]corev1.Pod{pod},
+		rolloutControls{paused: true},
+		nil,
+		updateVerificationStatus{},
+	)
+	require.NoError(t, err)
+	slurmClient.AssertNotCalled(t, "RebootNodes", mock.Anything, mock.Anything)
+	slurmClient.AssertExpectations(t)
+}
+
+func TestProcessRollingUpdateRebootsOnlyWorkersInPartition(t *testing.T) {
+	sts := testStatefulSet()
+	sts.Name = "worker"
+	sts.Spec.Replicas = ptr.To(int32(2))
+	sts.Status.ReadyReplicas = 2
+	setMaxUnavailable(sts, intstr.FromInt32(2))
+
+	canaryPod := testOutdatedPod()
+	canaryPod.Name = "worker-1"
+	canaryPod.Labels = map[string]string{appsv1.PodIndexLabel: "1"}
+	heldBackPod := testOutdatedPod()
+	heldBackPod.Labels = map[string]string{appsv1.PodIndexLabel: "0"}
+
+	slurmClient := &slurmapifake.MockClient{}
+	slurmClient.On("ListNodes", mock.Anything).Return([]slurmapi.Node{
+		{Name: heldBackPod.Name, States: nodeStates(api.V0044NodeStateIDLE)},
+		{Name: canaryPod.Name, States: nodeStates(api.V0044NodeStateIDLE)},
+	}, nil).Once()
+	slurmClient.On("RebootNodes", mock.Anything, slurmapi.RebootNodesRequest{
+		NodeList:    canaryPod.Name,
+		ASAP:        true,
+		Reason:      defaultRebootReason,
+		PowerAction: consts.SlurmPowerActionWorkerHandoff,
+	}).Return(nil).Once()
+
+	reconciler, kubeClient := testRollingUpdateReconcilerWithPods(t, slurmClient, &heldBackPod, &canaryPod)
+	err := reconciler.processRollingUpdate(
+		context.Background(),
+		"cluster",
+		"new-revision",
+		sts,
+		[]corev1.Pod{heldBackPod, canaryPod},
+		rolloutControls{partition: 1},
+		nil,
+		updateVerificationStatus{},
+	)
+	require.NoError(t, err)
+
+	got := &corev1.Pod{}
+	require.NoError(t, kubeClient.Get(context.Background(), client.ObjectKeyFromObject(&heldBackPod), got))
+	assert.Empty(t, got.Labels[consts.LabelSoperatorWorkerOperationID])
+	slurmClient.AssertExpectations(t)
+}
+
+func TestPodOrdinal(t *testing.T) {
+	sts := testStatefulSet()
+	sts.Name = "worker"
+
+	tests := []struct {
+		name        string
+		pod         corev1.Pod
+		wantOrdinal int
+		wantFound   bool
+	}{
+		{
+			name: "pod index label",
+			pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Name: "worker-3", Labels: map[string]string{appsv1.PodIndexLabel: "3"},
+			}},
+			wantOrdinal: 3,
+			wantFound:   true,
+		},
+		{
+			name:        "name without pod index label",
+			pod:         corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "worker-12"}},
+			wantOrdinal: 12,
+			wantFound:   true,
+		},
+		{
+			name: "invalid pod index label",
+			pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Name: "worker-3", Labels: map[string]string{appsv1.PodIndexLabel: "three"},
+			}},
+		},
+		{
+			name: "name of another StatefulSet",
+			pod:  corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "worker-gpu-3"}},
+		},
+		{
+			name: "name without ordinal",
+			pod:  corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "worker-canary"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ordinal, found := podOrdinal(sts, &tt.pod)
+			assert.Equal(t, tt.wantFound, found)
+			if tt.wantFound {
+				assert.Equal(t, tt.wantOrdinal, ordinal)
+			}
+		})
+	}
+}
+
+func TestBuildRolloutStatus(t *testing.T) {
+	sts := testStatefulSet()
+	sts.Name = "worker"
+	sts.Status.UpdateRevision = "worker-7d9f8c"
+
+	newPod := func(ordinal string, labels map[string]string) corev1.Pod {
+		labels = maps.Clone(labels)
+		labels[appsv1.PodIndexLabel] = ordinal
+		return corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "worker-" + ordinal, Labels: labels}}
+	}
+	updated := map[string]string{"controller-revision-hash": sts.Status.UpdateRevision}
+	stopping := map[string]string{
+		"controller-revision-hash":                "worker-5c6b7a",
+		consts.LabelSoperatorWorkerOperationID:    sts.Status.UpdateRevision,
+		consts.LabelSoperatorWorkerOperationPhase: consts.LabelSoperatorWorkerOperationPhaseStopping,
+	}
+	outdated := map[string]string{"controller-revision-hash": "worker-5c6b7a"}
+	unknownOrdinalPod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "worker-canary", Labels: outdated}}
+
+	tests := []struct {
+		name               string
+		pods               []corev1.Pod
+		controls           rolloutControls
+		verification       *updateVerification
+		verificationStatus updateVerificationStatus
+		want               slurmv1alpha1.RolloutStatus
+	}{
+		{
+			name:     "progressing",
+			pods:     []corev1.Pod{newPod("0", outdated), newPod("1", stopping), newPod("2", updated)},
+			controls: rolloutControls{partition: 1},
+			want: slurmv1alpha1.RolloutStatus{
+				State:           slurmv1alpha1.RolloutStateProgressing,
+				UpdatedWorkers:  1,
+				PendingWorkers:  1,
+				InFlightWorkers: 1,
+			},
+		},
+		{
+			name:     "canary complete",
+			pods:     []corev1.Pod{newPod("0", outdated), newPod("1", outdated), newPod("2", updated)},
+			controls: rolloutControls{paused: true, partition: 2},
+			want: slurmv1alpha1.RolloutStatus{
+				State:          slurmv1alpha1.RolloutStateComplete,
+				UpdatedWorkers: 1,
+				PendingWorkers: 2,
+			},
+		},
+		{
+			name:     "pod with unknown ordinal is pending",
+			pods:     []corev1.Pod{newPod("0", outdated), unknownOrdinalPod, newPod("2", updated)},
+			controls: rolloutControls{partition: 2},
+			want: slurmv1alpha1.RolloutStatus{
+				State:          slurmv1alpha1.RolloutStateProgressing,
+				UpdatedWorkers: 1,
+				PendingWorkers: 2,
+			},
+		},
+		{
+			name:     "paused",
+			pods:     []corev1.Pod{newPod("0", outdated), newPod("1", updated)},
+			controls: rolloutControls{paused: true},
+			want: slurmv1alpha1.RolloutStatus{
+				State:          slurmv1alpha1.RolloutStatePaused,
+				UpdatedWorkers: 1,
+				PendingWorkers: 1,
+			},
+		},
+		{
+			name:               "halted",
+			pods:               []corev1.Pod{newPod("0", outdated), newPod("1", updated), newPod("2", updated)},
+			verification:       &updateVerification{activeCheckName: "gpu-checks"},
+			verificationStatus: updateVerificationStatus{inFlight: 1, failedNodes: []string{"worker-2"}},
+			want: slurmv1alpha1.RolloutStatus{
+				State:                     slurmv1alpha1.RolloutStateHalted,
+				UpdatedWorkers:            2,
+				PendingWorkers:            1,
+				InFlightWorkers:           1,
+				FailedVerificationWorkers: []string{"worker-2"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.want.OperationID = sts.Status.UpdateRevision
+			got := buildRolloutStatus(sts, tt.pods, tt.controls, tt.verification, tt.verificationStatus)
+			assert.Equal(t, &tt.want, got)
+		})
+	}
+}
+
+func TestReconcileUpdatesNodeSetRolloutStatus(t *testing.T) {
+	sts := testStatefulSet()
+	sts.Name = "worker"
+	sts.Labels = map[string]string{
+		consts.LabelSoperatorRollingUpdateEnabled: consts.LabelSoperatorRollingUpdateValue,
+		consts.LabelInstanceKey:                   "cluster",
+		consts.LabelNodeSetKey:                    "worker",
+	}
+	sts.Annotations = map[string]string{consts.AnnotationRolloutPaused: "true"}
+	sts.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "worker"}}
+	sts.Status.UpdateRevision = "worker-7d9f8c"
+	sts.Status.ReadyReplicas = 1
+
+	pod := testOutdatedPod()
+	pod.Labels = map[string]string{
+		"app":                      "worker",
+		"controller-revision-hash": "worker-5c6b7a",
+	}
+	nodeSet := &slurmv1alpha1.NodeSet{ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "default"}}
+
+	slurmClient := &slurmapifake.MockClient{}
+	slurmClient.On("ListNodes", mock.Anything).Return([]slurmapi.Node{{
+		Name:   pod.Name,
+		States: nodeStates(api.V0044NodeStateIDLE),
+	}}, nil).Once()
+	slurmClient.On("ListReservations", mock.Anything).Return(nil, nil).Once()
+
+	reconciler, kubeClient := testUpdateVerificationReconciler(t, slurmClient, sts, &pod, nodeSet)
+	_, err := reconciler.Reconcile(context.Background(), ctrl.Request{
+		NamespacedName: client.ObjectKeyFromObject(sts),
+	})
+	require.NoError(t, err)
+
+	got := &slurmv1alpha1.NodeSet{}
+	require.NoError(t, kubeClient.Get(context.Background(), client.ObjectKeyFromObject(nodeSet), got))
+	assert.Equal(t, &slurmv1alpha1.RolloutStatus{
+		OperationID:    "worker-7d9f8c",
+		State:          slurmv1alpha1.RolloutStatePaused,
+		PendingWorkers: 1,
+	}, got.Status.Rollout)
+	slurmClient.AssertNotCalled(t, "RebootNodes", mock.Anything, mock.Anything)
+	slurmClient.AssertExpectations(t)
+}
+
+func TestUpdateRolloutStatusReportsHaltOnce(t *testing.T) {
+	sts := testStatefulSet()
+	sts.Labels = map[string]string{consts.LabelNodeSetKey: "worker"}
+	nodeSet := &slurmv1alpha1.NodeSet{ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: "default"}}
+	verification := &updateVerification{activeCheckName: "gpu-checks", maxFailedNodes: 1}
+
+	reconciler, _ := testUpdateVerificationReconciler(t, nil, nodeSet)
+	recorder := record.NewFakeRecorder(2)
+	reconciler.Recorder = recorder
+
+	progressing := &slurmv1alpha1.RolloutStatus{
+		OperationID:               "worker-7d9f8c",
+		State:                     slurmv1alpha1.RolloutStateProgressing,
+		FailedVerificationWorkers: []string{"worker-1"},
+	}
+	require.NoError(t, reconciler.updateRolloutStatus(context.Background(), sts, progressing, verification))
+	assert.Empty(t, recorder.Events)
+
+	halted := &slurmv1alpha1.RolloutStatus{
+		OperationID:               "worker-7d9f8c",
+		State:                     slurmv1alpha1.RolloutStateHalted,
+		FailedVerificationWorkers: []string{"worker-1", "worker-2"},
+	}
+	require.NoError(t, reconciler.updateRolloutStatus(context.Background(), sts, halted, verification))
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, consts.RollingUpdateEventHalted)
+
+	// The rollout stays halted on the next reconciliations, and one more worker failing doesn't halt it again.
+	require.NoError(t, reconciler.updateRolloutStatus(context.Background(), sts, halted, verification))
+	halted.FailedVerificationWorkers = append(halted.FailedVerificationWorkers, "worker-3")
+	require.NoError(t, reconciler.updateRolloutStatus(context.Background(), sts, halted, verification))
+	assert.Empty(t, recorder.Events)
+}
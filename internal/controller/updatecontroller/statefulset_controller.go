@@ -38,6 +38,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
 	"nebius.ai/slurm-operator/internal/consts"
 	"nebius.ai/slurm-operator/internal/controller/reconciler"
 	"nebius.ai/slurm-operator/internal/controllerconfig"
@@ -71,6 +72,11 @@ type workerUpdateDecision struct {
 	managedRebootInProgress bool
 }
 
+type rebootCandidate struct {
+	pod       corev1.Pod
+	slurmNode slurmapi.Node
+}
+
 type RollingUpdateReconciler struct {
 	*reconciler.Reconciler
 
@@ -93,6 +99,10 @@ func NewRollingUpdateReconciler(
 // +kubebuilder:rbac:groups=apps.kruise.io,resources=statefulsets/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=apps.kruise.io,resources=statefulsets/finalizers,verbs=update
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=slurm.nebius.ai,resources=activecheckruns,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=slurm.nebius.ai,resources=nodesets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=slurm.nebius.ai,resources=nodesets/status,verbs=get;update;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -134,6 +144,31 @@ func (r *RollingUpdateReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, err
 	}
 
+	verification, err := getUpdateVerification(sts)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	var verificationStatus updateVerificationStatus
+	if verification != nil {
+		verificationStatus, err = r.verifyUpdatedWorkers(ctx, clusterName, sts, podList, verification)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	controls, err := getRolloutControls(sts)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	rolloutStatus := buildRolloutStatus(sts, podList, controls, verification, verificationStatus)
+	if err := r.updateRolloutStatus(ctx, sts, rolloutStatus, verification); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.cleanupStaleDrainReservations(ctx, clusterName, sts, podList); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if sts.Status.UpdatedReplicas == replicas {
 		undrainedNodes, err := r.cleanupStaleRollingUpdateDrains(ctx, clusterName, sts, podList)
 		if err != nil {
@@ -142,6 +177,11 @@ func (r *RollingUpdateReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		if undrainedNodes > 0 {
 			return ctrl.Result{RequeueAfter: time.Second}, nil
 		}
+		if verificationStatus.inFlight > 0 {
+			logger.Info("waiting for update verification", "namespace", req.Namespace, "name", req.Name,
+				"inFlight", verificationStatus.inFlight)
+			return ctrl.Result{RequeueAfter: time.Minute}, nil
+		}
 
 		logger.Info("statefulset is up to date", "namespace", req.Namespace, "name", req.Name)
 		return ctrl.Result{}, nil
@@ -154,7 +194,9 @@ func (r *RollingUpdateReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return ctrl.Result{}, fmt.Errorf("missing update revision on statefulset %s/%s", sts.Namespace, sts.Name)
 	}
 
-	if err := r.processRollingUpdate(ctx, clusterName, operationID, sts, outdatedPodList); err != nil {
+	if err := r.processRollingUpdate(
+		ctx, clusterName, operationID, sts, outdatedPodList, controls, verification, verificationStatus,
+	); err != nil {
 		return ctrl.Result{}, err
 	}
 
@@ -218,6 +260,9 @@ func (r *RollingUpdateReconciler) processRollingUpdate(
 	operationID string,
 	sts *kruisev1b1.StatefulSet,
 	outdatedPods []corev1.Pod,
+	controls rolloutControls,
+	verification *updateVerification,
+	verificationStatus updateVerificationStatus,
 ) error {
 	logger := log.FromContext(ctx).WithName("rolling-update-reconciler")
 
@@ -278,11 +323,6 @@ func (r *RollingUpdateReconciler) processRollingUpdate(
 	}
 	slurmNodesByName := indexSlurmNodesForPods(slurmNodes, podsToStop)
 
-	type rebootCandidate struct {
-		pod       corev1.Pod
-		slurmNode slurmapi.Node
-	}
-
 	candidates := make([]rebootCandidate, 0, len(podsToStop))
 	var undrainedNodes []string
 	readyPodsConsumingBudget := 0
@@ -340,16 +380,22 @@ func (r *RollingUpdateReconciler) processRollingUpdate(
 		logger.Info("undrained stale rolling update nodes before reboot", "nodes", undrainedNodes)
 	}
 
-	budget := rebootBudget(sts)
-	unavailable := unavailableReplicas(sts)
-	availableSlots := budget - unavailable - readyPodsConsumingBudget
-	if availableSlots <= 0 {
-		logger.Info(
-			"rolling update budget is exhausted",
-			"budget", budget,
-			"unavailable", unavailable,
-			"readyPodsConsumingBudget", readyPodsConsumingBudget,
+	if controls.drainAndWait != nil {
+		return r.processDrainAndWait(
+			ctx, slurmClient, sts, operationID, slurmNodes, candidates,
+			readyPodsConsumingBudget, controls, verification, verificationStatus,
 		)
+	}
+
+	if rolloutBlocked(ctx, sts, controls, verification, verificationStatus) {
+		return nil
+	}
+
+	// Updated workers are held out of service until their verification finishes.
+	readyPodsConsumingBudget += verificationStatus.inFlight
+
+	availableSlots := availableRebootSlots(ctx, sts, readyPodsConsumingBudget)
+	if availableSlots <= 0 {
 		return nil
 	}
 
@@ -360,20 +406,12 @@ func (r *RollingUpdateReconciler) processRollingUpdate(
 		}
 
 		pod := candidate.pod
+		if !controls.includes(sts, &pod) {
+			continue
+		}
 		if workerOperationPhase(&pod, operationID) != consts.LabelSoperatorWorkerOperationPhaseStopping {
-			patchBase := pod.DeepCopy()
-			if pod.Labels == nil {
-				pod.Labels = map[string]string{}
-			}
-			pod.Labels[consts.LabelSoperatorWorkerOperationID] = operationID
-			pod.Labels[consts.LabelSoperatorWorkerOperationPhase] =
-				consts.LabelSoperatorWorkerOperationPhaseStopping
-			if err := r.Patch(
-				ctx,
-				&pod,
-				client.StrategicMergeFrom(patchBase, client.MergeFromWithOptimisticLock{}),
-			); err != nil {
-				return fmt.Errorf("start worker operation %s on pod %s/%s: %w", operationID, pod.Namespace, pod.Name, err)
+			if err := r.startWorkerOperation(ctx, &pod, operationID); err != nil {
+				return err
 			}
 		}
 
@@ -385,6 +423,12 @@ func (r *RollingUpdateReconciler) processRollingUpdate(
 		return nil
 	}
 
+	if verification != nil {
+		if err := reserveForVerification(ctx, slurmClient, slurmNodesToReboot); err != nil {
+			return err
+		}
+	}
+
 	if err := slurmClient.RebootNodes(ctx, slurmapi.RebootNodesRequest{
 		NodeList:    strings.Join(slurmNodesToReboot, ","),
 		ASAP:        true,
@@ -399,6 +443,67 @@ func (r *RollingUpdateReconciler) processRollingUpdate(
 	return nil
 }
 
+// rolloutBlocked reports whether no more workers may be taken out of service, as the rollout is halted or paused.
+func rolloutBlocked(
+	ctx context.Context,
+	sts *kruisev1b1.StatefulSet,
+	controls rolloutControls,
+	verification *updateVerification,
+	verificationStatus updateVerificationStatus,
+) bool {
+	logger := log.FromContext(ctx).WithName("rolling-update-reconciler")
+
+	if verification.halted(verificationStatus) {
+		logger.Info(
+			"rolling update is halted by failed update verifications",
+			"failedNodes", verificationStatus.failedNodes,
+			"maxFailedNodes", verification.maxFailedNodes,
+		)
+		return true
+	}
+
+	if controls.paused {
+		logger.Info("rolling update is paused", "namespace", sts.Namespace, "name", sts.Name)
+		return true
+	}
+
+	return false
+}
+
+// availableRebootSlots returns how many more workers may be taken out of service within the budget of the StatefulSet.
+func availableRebootSlots(ctx context.Context, sts *kruisev1b1.StatefulSet, readyPodsConsumingBudget int) int {
+	budget := rebootBudget(sts)
+	unavailable := unavailableReplicas(sts)
+	availableSlots := budget - unavailable - readyPodsConsumingBudget
+	if availableSlots <= 0 {
+		log.FromContext(ctx).WithName("rolling-update-reconciler").Info(
+			"rolling update budget is exhausted",
+			"budget", budget,
+			"unavailable", unavailable,
+			"readyPodsConsumingBudget", readyPodsConsumingBudget,
+		)
+	}
+	return availableSlots
+}
+
+// startWorkerOperation labels the pod as stopping for the worker operation.
+func (r *RollingUpdateReconciler) startWorkerOperation(ctx context.Context, pod *corev1.Pod, operationID string) error {
+	patchBase := pod.DeepCopy()
+	if pod.Labels == nil {
+		pod.Labels = map[string]string{}
+	}
+	pod.Labels[consts.LabelSoperatorWorkerOperationID] = operationID
+	pod.Labels[consts.LabelSoperatorWorkerOperationPhase] = consts.LabelSoperatorWorkerOperationPhaseStopping
+	if err := r.Patch(
+		ctx,
+		pod,
+		client.StrategicMergeFrom(patchBase, client.MergeFromWithOptimisticLock{}),
+	); err != nil {
+		return fmt.Errorf("start worker operation %s on pod %s/%s: %w", operationID, pod.Namespace, pod.Name, err)
+	}
+	return nil
+}
+
 func (r *RollingUpdateReconciler) cleanupStaleRollingUpdateDrains(
 	ctx context.Context,
 	clusterName string,
@@ -598,6 +703,7 @@ func (r *RollingUpdateReconciler) SetupWithManager(
 			DeleteFunc:  func(tde event.TypedDeleteEvent[client.Object]) bool { return false },
 			GenericFunc: func(tge event.TypedGenericEvent[client.Object]) bool { return false },
 		})).
+		Owns(&slurmv1alpha1.ActiveCheckRun{}).
 		Named(RollingUpdateControllerName).
 		WithOptions(controllerconfig.ControllerOptions(maxConcurrency, cacheSyncTimeout))
 
@@ -245,6 +245,9 @@ func TestProcessRollingUpdateDeletesCrashLoopingWorkerInit(t *testing.T) {
 		"new-revision",
 		testStatefulSet(),
 		[]corev1.Pod{pod},
+		rolloutControls{},
+		nil,
+		updateVerificationStatus{},
 	)
 	require.NoError(t, err)
 	assertPodDeleted(t, kubeClient, &pod)
@@ -264,6 +267,9 @@ func TestProcessRollingUpdateDeletesPodWithCompletedWorkerHandoff(t *testing.T)
 		"new-revision",
 		testStatefulSet(),
 		[]corev1.Pod{pod},
+		rolloutControls{},
+		nil,
+		updateVerificationStatus{},
 	)
 	require.NoError(t, err)
 	assertPodDeleted(t, kubeClient, &pod)
@@ -294,6 +300,9 @@ func TestProcessRollingUpdateDeletesSafelyOfflineCrashLoopingSlurmd(t *testing.T
 		"new-revision",
 		testStatefulSet(),
 		[]corev1.Pod{pod},
+		rolloutControls{},
+		nil,
+		updateVerificationStatus{},
 	)
 	require.NoError(t, err)
 	assertPodDeleted(t, kubeClient, &pod)
@@ -329,6 +338,9 @@ func TestProcessRollingUpdateDeletesSafelyOfflineRebootHandoff(t *testing.T) {
 		"new-revision",
 		testStatefulSet(),
 		[]corev1.Pod{pod},
+		rolloutControls{},
+		nil,
+		updateVerificationStatus{},
 	)
 	require.NoError(t, err)
 	assertPodDeleted(t, kubeClient, &pod)
@@ -395,6 +407,9 @@ func TestProcessRollingUpdateContinuesWithinBudgetAfterSafeDelete(t *testing.T)
 		"new-revision",
 		sts,
 		[]corev1.Pod{deletingPod, candidatePod, waitingPod},
+		rolloutControls{},
+		nil,
+		updateVerificationStatus{},
 	)
 	require.NoError(t, err)
 	assertPodDeleted(t, kubeClient, &deletingPod)
@@ -436,6 +451,9 @@ func TestProcessRollingUpdateKeepsSafelyOfflineUnmanagedRebootWithoutHandoff(t *
 		"new-revision",
 		testStatefulSet(),
 		[]corev1.Pod{pod},
+		rolloutControls{},
+		nil,
+		updateVerificationStatus{},
 	)
 	require.NoError(t, err)
 
@@ -470,6 +488,9 @@ func TestProcessRollingUpdateDeletesSafelyOfflineManagedRebootWithoutHandoff(t *
 		"new-revision",
 		testStatefulSet(),
 		[]corev1.Pod{pod},
+		rolloutControls{},
+		nil,
+		updateVerificationStatus{},
 	)
 	require.NoError(t, err)
 	assertPodDeleted(t, kubeClient, &pod)
@@ -497,6 +518,9 @@ func TestProcessRollingUpdateKeepsCrashLoopingSlurmdWithAllocations(t *testing.T
 		"new-revision",
 		testStatefulSet(),
 		[]corev1.Pod{pod},
+		rolloutControls{},
+		nil,
+		updateVerificationStatus{},
 	)
 	require.NoError(t, err)
 
@@ -533,6 +557,9 @@ func TestProcessRollingUpdateStartsRevisionScopedWorkerOperation(t *testing.T) {
 		"new-revision",
 		sts,
 		[]corev1.Pod{pod},
+		rolloutControls{},
+		nil,
+		updateVerificationStatus{},
 	)
 	require.NoError(t, err)
 
@@ -559,6 +586,9 @@ func TestProcessRollingUpdateFailsWhenPodIsMissingFromSlurmNodeList(t *testing.T
 		"new-revision",
 		testStatefulSet(),
 		[]corev1.Pod{pod},
+		rolloutControls{},
+		nil,
+		updateVerificationStatus{},
 	)
 	require.EqualError(t, err, "slurm node worker-0 is missing from list nodes response")
 	slurmClient.AssertExpectations(t)
@@ -581,6 +611,9 @@ func TestProcessRollingUpdateUndrainsStaleDrainBeforeReboot(t *testing.T) {
 		"new-revision",
 		testStatefulSet(),
 		[]corev1.Pod{pod},
+		rolloutControls{},
+		nil,
+		updateVerificationStatus{},
 	)
 	require.NoError(t, err)
 
@@ -635,6 +668,9 @@ func TestProcessRollingUpdateContinuesWithinBudgetAfterUndrain(t *testing.T) {
 		"new-revision",
 		sts,
 		[]corev1.Pod{undrainedPod, candidatePod},
+		rolloutControls{},
+		nil,
+		updateVerificationStatus{},
 	)
 	require.NoError(t, err)
 	slurmClient.AssertExpectations(t)
@@ -666,6 +702,11 @@ func TestReconcileUndrainsStaleDrainAfterUpdate(t *testing.T) {
 		Reason: &slurmapi.NodeReason{Reason: defaultRebootReason + " : reboot issued [root@timestamp]"},
 	}}, nil).Once()
 	slurmClient.On("UndrainNode", mock.Anything, pod.Name).Return(nil).Once()
+	// The drain reservation of the updated worker is stale.
+	slurmClient.On("ListReservations", mock.Anything).Return([]slurmapi.Reservation{
+		{Name: drainReservationName(pod.Name), NodeList: pod.Name},
+	}, nil).Once()
+	slurmClient.On("DeleteReservation", mock.Anything, drainReservationName(pod.Name)).Return(nil).Once()
 
 	scheme := runtime.NewScheme()
 	require.NoError(t, corev1.AddToScheme(scheme))
@@ -0,0 +1,284 @@
+package updatecontroller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	kruisev1b1 "github.com/openkruise/kruise-api/apps/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/slurmapi"
+)
+
+const (
+	updateVerificationReservationPrefix = "soperator-update-"
+	// updateVerificationReservationDuration bounds how long a worker can be held out of service by its verification.
+	updateVerificationReservationDuration = 7 * 24 * time.Hour
+	updateVerificationFailedReason        = "soperator rolling update verification failed"
+)
+
+// updateVerification is the verification of the workers updated by the slurm-aware rolling update of a StatefulSet.
+// It's configured by the NodeSet through the StatefulSet annotations.
+type updateVerification struct {
+	activeCheckName string
+	maxFailedNodes  int
+}
+
+// updateVerificationStatus is the status of the verification of the workers updated to the update revision.
+type updateVerificationStatus struct {
+	// inFlight is the number of updated workers which are held out of service until their verification finishes.
+	inFlight int
+	// failedNodes are the updated workers which failed the verification.
+	failedNodes []string
+}
+
+// getUpdateVerification returns the verification configured for the StatefulSet, or nil if it's disabled.
+func getUpdateVerification(sts *kruisev1b1.StatefulSet) (*updateVerification, error) {
+	checkName := sts.Annotations[consts.AnnotationUpdateVerificationCheck]
+	if checkName == "" {
+		return nil, nil
+	}
+
+	maxFailedNodes, err := strconv.Atoi(sts.Annotations[consts.AnnotationUpdateVerificationMaxFailedNodes])
+	if err != nil || maxFailedNodes < 0 {
+		return nil, fmt.Errorf("invalid annotation %s on statefulset %s/%s: %q",
+			consts.AnnotationUpdateVerificationMaxFailedNodes, sts.Namespace, sts.Name,
+			sts.Annotations[consts.AnnotationUpdateVerificationMaxFailedNodes])
+	}
+
+	return &updateVerification{activeCheckName: checkName, maxFailedNodes: maxFailedNodes}, nil
+}
+
+// halted reports whether more updated workers failed the verification than allowed.
+// The rollout is resumed by updating the NodeSet again, as failures are counted per update revision.
+func (v *updateVerification) halted(status updateVerificationStatus) bool {
+	return v != nil && len(status.failedNodes) > v.maxFailedNodes
+}
+
+func updateVerificationReservationName(nodeName string) string {
+	return updateVerificationReservationPrefix + nodeName
+}
+
+func updateVerificationRunName(sts *kruisev1b1.StatefulSet, podName string) string {
+	revisionHash := strings.TrimPrefix(sts.Status.UpdateRevision, sts.Name+"-")
+	return fmt.Sprintf("%s-verify-%s", podName, revisionHash)
+}
+
+// updateVerificationPassed reports whether a finished verification run let the worker return to service.
+func updateVerificationPassed(run *slurmv1alpha1.ActiveCheckRun) bool {
+	return run.Status.Phase == consts.ActiveCheckRunPhaseComplete || run.Status.Phase == consts.ActiveCheckRunPhaseSkipped
+}
+
+// slurmNodeBackAfterUpdate reports whether the updated worker is registered in Slurm and can run the verification.
+func slurmNodeBackAfterUpdate(node *slurmapi.Node) bool {
+	return !node.IsRebootIssuedState() && !node.IsRebootRequestedState() &&
+		!node.IsDownState() && !node.IsNotRespondingState()
+}
+
+// reserveForVerification reserves the workers for the checks user before they are rebooted, so that no jobs other
+// than the verification are scheduled on them once they are back.
+func reserveForVerification(ctx context.Context, slurmClient slurmapi.Client, nodeNames []string) error {
+	reservations, err := slurmClient.ListReservations(ctx)
+	if err != nil {
+		return fmt.Errorf("list slurm reservations: %w", err)
+	}
+	existing := make(map[string]struct{}, len(reservations))
+	for _, reservation := range reservations {
+		existing[reservation.Name] = struct{}{}
+	}
+
+	now := time.Now()
+	for _, nodeName := range nodeNames {
+		name := updateVerificationReservationName(nodeName)
+		if _, found := existing[name]; found {
+			continue
+		}
+		if err := slurmClient.CreateReservation(ctx, slurmapi.Reservation{
+			Name:      name,
+			NodeList:  nodeName,
+			StartTime: now,
+			EndTime:   now.Add(updateVerificationReservationDuration),
+			Flags:     []api.V0044ReservationInfoFlags{api.V0044ReservationInfoFlagsIGNOREJOBS},
+			Users:     []string{slurmapi.SlurmUserSoperatorchecks},
+		}); err != nil {
+			return fmt.Errorf("create update verification reservation %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// verifyUpdatedWorkers runs the verification check on the workers updated to the update revision of the StatefulSet.
+// Once a reserved worker is back after its update, the check is run on it in its reservation. The reservation is
+// deleted when the check finishes, and the worker is drained if it didn't pass.
+func (r *RollingUpdateReconciler) verifyUpdatedWorkers(
+	ctx context.Context,
+	clusterName string,
+	sts *kruisev1b1.StatefulSet,
+	pods []corev1.Pod,
+	verification *updateVerification,
+) (updateVerificationStatus, error) {
+	logger := log.FromContext(ctx).WithName("rolling-update-reconciler")
+	var status updateVerificationStatus
+
+	var updatedPods []corev1.Pod
+	for _, pod := range pods {
+		if pod.Labels["controller-revision-hash"] == sts.Status.UpdateRevision {
+			updatedPods = append(updatedPods, pod)
+		}
+	}
+	if len(updatedPods) == 0 {
+		return status, nil
+	}
+
+	runList := &slurmv1alpha1.ActiveCheckRunList{}
+	if err := r.List(ctx, runList,
+		client.InNamespace(sts.Namespace),
+		client.MatchingLabels{consts.LabelSoperatorWorkerOperationID: sts.Status.UpdateRevision},
+	); err != nil {
+		return status, fmt.Errorf("list update verification runs: %w", err)
+	}
+	runs := make(map[string]*slurmv1alpha1.ActiveCheckRun, len(runList.Items))
+	for i := range runList.Items {
+		runs[runList.Items[i].Name] = &runList.Items[i]
+	}
+
+	slurmClient, ok := r.slurmAPIClients.GetClient(types.NamespacedName{
+		Namespace: sts.Namespace,
+		Name:      clusterName,
+	})
+	if !ok {
+		return status, fmt.Errorf("no slurm api client for %s/%s", sts.Namespace, clusterName)
+	}
+	reservations, err := slurmClient.ListReservations(ctx)
+	if err != nil {
+		return status, fmt.Errorf("list slurm reservations: %w", err)
+	}
+	reserved := make(map[string]struct{}, len(reservations))
+	for _, reservation := range reservations {
+		reserved[reservation.Name] = struct{}{}
+	}
+	slurmNodes, err := slurmClient.ListNodes(ctx)
+	if err != nil {
+		return status, err
+	}
+	slurmNodesByName := indexSlurmNodesForPods(slurmNodes, updatedPods)
+
+	for _, pod := range updatedPods {
+		reservationName := updateVerificationReservationName(pod.Name)
+		_, isReserved := reserved[reservationName]
+		run, found := runs[updateVerificationRunName(sts, pod.Name)]
+
+		switch {
+		case found && run.Status.IsFinished():
+			passed := updateVerificationPassed(run)
+			if !passed {
+				status.failedNodes = append(status.failedNodes, pod.Name)
+			}
+			if !isReserved {
+				continue
+			}
+
+			if !passed {
+				reason := fmt.Sprintf("%s: ActiveCheckRun %s is %s", updateVerificationFailedReason, run.Name, run.Status.Phase)
+				if err := drainSlurmNode(ctx, slurmClient, pod.Name, reason); err != nil {
+					return status, err
+				}
+				r.Recorder.Eventf(sts, corev1.EventTypeWarning, consts.RollingUpdateEventVerificationFailed,
+					"Drained updated worker %s: ActiveCheckRun %s is %s: %s", pod.Name, run.Name, run.Status.Phase, run.Status.Message)
+			}
+			if err := slurmClient.DeleteReservation(ctx, reservationName); err != nil {
+				return status, fmt.Errorf("delete update verification reservation %s: %w", reservationName, err)
+			}
+			logger.Info("finished update verification", "pod", pod.Name, "activeCheckRun", run.Name, "phase", run.Status.Phase)
+
+		case found:
+			status.inFlight++
+
+		case isReserved:
+			// The worker was reserved before its reboot and stays reserved until it is verified.
+			status.inFlight++
+
+			slurmNode, found := slurmNodesByName[pod.Name]
+			if !found || !podReady(&pod) || !slurmNodeBackAfterUpdate(&slurmNode) {
+				continue
+			}
+			if staleRollingUpdateDrain(&slurmNode) {
+				if err := slurmClient.UndrainNode(ctx, slurmNode.Name); err != nil {
+					return status, fmt.Errorf("undrain rolling update node %s before verification: %w", slurmNode.Name, err)
+				}
+			}
+			if err := r.createUpdateVerificationRun(ctx, sts, &pod, reservationName, verification); err != nil {
+				return status, err
+			}
+			logger.Info("started update verification", "pod", pod.Name, "activeCheck", verification.activeCheckName)
+		}
+	}
+
+	return status, nil
+}
+
+func (r *RollingUpdateReconciler) createUpdateVerificationRun(
+	ctx context.Context,
+	sts *kruisev1b1.StatefulSet,
+	pod *corev1.Pod,
+	reservationName string,
+	verification *updateVerification,
+) error {
+	run := &slurmv1alpha1.ActiveCheckRun{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      updateVerificationRunName(sts, pod.Name),
+			Namespace: sts.Namespace,
+			Labels: map[string]string{
+				consts.LabelSoperatorWorkerOperationID: sts.Status.UpdateRevision,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         kruisev1b1.GroupVersion.String(),
+					Kind:               "StatefulSet",
+					Name:               sts.Name,
+					UID:                sts.UID,
+					Controller:         ptr.To(true),
+					BlockOwnerDeletion: ptr.To(true),
+				},
+			},
+		},
+		Spec: slurmv1alpha1.ActiveCheckRunSpec{
+			ActiveCheckName: verification.activeCheckName,
+			Nodes:           []string{pod.Name},
+			Reservation:     reservationName,
+		},
+	}
+	if err := r.Create(ctx, run); client.IgnoreAlreadyExists(err) != nil {
+		return fmt.Errorf("create update verification run %s: %w", run.Name, err)
+	}
+	return nil
+}
+
+func drainSlurmNode(ctx context.Context, slurmClient slurmapi.Client, nodeName, reason string) error {
+	resp, err := slurmClient.SlurmV0044PostNodeWithResponse(ctx, nodeName, api.V0044UpdateNodeMsg{
+		Reason: ptr.To(reason),
+		State:  ptr.To([]api.V0044UpdateNodeMsgState{api.V0044UpdateNodeMsgStateDRAIN}),
+	})
+	if err != nil {
+		return fmt.Errorf("post drain slurm node %s: %w", nodeName, err)
+	}
+	if resp.JSON200 == nil {
+		return fmt.Errorf("drain slurm node %s: status=%d", nodeName, resp.StatusCode())
+	}
+	if resp.JSON200.Errors != nil && len(*resp.JSON200.Errors) != 0 {
+		return fmt.Errorf("drain slurm node %s returned errors: %v", nodeName, *resp.JSON200.Errors)
+	}
+	return nil
+}
@@ -0,0 +1,325 @@
+package updatecontroller
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	kruisev1b1 "github.com/openkruise/kruise-api/apps/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/slurmapi"
+	slurmapifake "nebius.ai/slurm-operator/internal/slurmapi/fake"
+)
+
+func TestGetUpdateVerification(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        *updateVerification
+		wantErr     bool
+	}{
+		{
+			name: "not annotated",
+		},
+		{
+			name: "disabled",
+			annotations: map[string]string{
+				consts.AnnotationUpdateVerificationCheck:          "",
+				consts.AnnotationUpdateVerificationMaxFailedNodes: "0",
+			},
+		},
+		{
+			name: "enabled",
+			annotations: map[string]string{
+				consts.AnnotationUpdateVerificationCheck:          "gpu-checks",
+				consts.AnnotationUpdateVerificationMaxFailedNodes: "2",
+			},
+			want: &updateVerification{activeCheckName: "gpu-checks", maxFailedNodes: 2},
+		},
+		{
+			name: "invalid max failed nodes",
+			annotations: map[string]string{
+				consts.AnnotationUpdateVerificationCheck:          "gpu-checks",
+				consts.AnnotationUpdateVerificationMaxFailedNodes: "-1",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sts := testStatefulSet()
+			sts.Annotations = tt.annotations
+
+			got, err := getUpdateVerification(sts)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestProcessRollingUpdateReservesWorkersForVerification(t *testing.T) {
+	pod := testOutdatedPod()
+	sts := testStatefulSet()
+	sts.Status.ReadyReplicas = 1
+
+	slurmClient := &slurmapifake.MockClient{}
+	slurmClient.On("ListNodes", mock.Anything).Return([]slurmapi.Node{{
+		Name:   pod.Name,
+		States: nodeStates(api.V0044NodeStateIDLE),
+	}}, nil).Once()
+	slurmClient.On("ListReservations", mock.Anything).Return([]slurmapi.Reservation{}, nil).Once()
+	slurmClient.On("CreateReservation", mock.Anything, mock.MatchedBy(func(reservation slurmapi.Reservation) bool {
+		return reservation.Name == "soperator-update-worker-0" &&
+			reservation.NodeList == pod.Name &&
+			assert.ObjectsAreEqual([]string{slurmapi.SlurmUserSoperatorchecks}, reservation.Users) &&
+			assert.ObjectsAreEqual([]api.V0044ReservationInfoFlags{api.V0044ReservationInfoFlagsIGNOREJOBS}, reservation.Flags) &&
+			reservation.EndTime.Sub(reservation.StartTime) == updateVerificationReservationDuration
+	})).Return(nil).Once()
+	slurmClient.On("RebootNodes", mock.Anything, slurmapi.RebootNodesRequest{
+		NodeList:    pod.Name,
+		ASAP:        true,
+		Reason:      defaultRebootReason,
+		PowerAction: consts.SlurmPowerActionWorkerHandoff,
+	}).Return(nil).Once()
+
+	reconciler, _ := testRollingUpdateReconciler(t, &pod, slurmClient)
+	err := reconciler.processRollingUpdate(
+		context.Background(),
+		"cluster",
+		"new-revision",
+		sts,
+		[]corev1.Pod{pod},
+		rolloutControls{},
+		&updateVerification{activeCheckName: "gpu-checks"},
+		updateVerificationStatus{},
+	)
+	require.NoError(t, err)
+	slurmClient.AssertExpectations(t)
+}
+
+func TestProcessRollingUpdateCountsVerificationsInBudget(t *testing.T) {
+	pod := testOutdatedPod()
+	sts := testStatefulSet()
+	sts.Status.ReadyReplicas = 1
+
+	slurmClient := &slurmapifake.MockClient{}
+	slurmClient.On("ListNodes", mock.Anything).Return([]slurmapi.Node{{
+		Name:   pod.Name,
+		States: nodeStates(api.V0044NodeStateIDLE),
+	}}, nil).Once()
+
+	reconciler, _ := testRollingUpdateReconciler(t, &pod, slurmClient)
+	err := reconciler.processRollingUpdate(
+		context.Background(),
+		"cluster",
+		"new-revision",
+		sts,
+		[]corev1.Pod{pod},
+		rolloutControls{},
+		&updateVerification{activeCheckName: "gpu-checks"},
+		updateVerificationStatus{inFlight: 1},
+	)
+	require.NoError(t, err)
+	slurmClient.AssertNotCalled(t, "RebootNodes", mock.Anything, mock.Anything)
+	slurmClient.AssertExpectations(t)
+}
+
+func TestProcessRollingUpdateHaltedByFailedVerifications(t *testing.T) {
+	pod := testOutdatedPod()
+	sts := testStatefulSet()
+	sts.Status.ReadyReplicas = 1
+
+	slurmClient := &slurmapifake.MockClient{}
+	slurmClient.On("ListNodes", mock.Anything).Return([]slurmapi.Node{{
+		Name:   pod.Name,
+		States: nodeStates(api.V0044NodeStateIDLE),
+	}}, nil).Once()
+
+	reconciler, _ := testRollingUpdateReconciler(t, &pod, slurmClient)
+	err := reconciler.processRollingUpdate(
+		context.Background(),
+		"cluster",
+		"new-revision",
+		sts,
+		[]corev1.Pod{pod},
+		rolloutControls{},
+		&updateVerification{activeCheckName: "gpu-checks", maxFailedNodes: 1},
+		updateVerificationStatus{failedNodes: []string{"worker-1", "worker-2"}},
+	)
+	require.NoError(t, err)
+	slurmClient.AssertNotCalled(t, "RebootNodes", mock.Anything, mock.Anything)
+	slurmClient.AssertExpectations(t)
+}
+
+func TestVerifyUpdatedWorkersStartsRunOnReservedWorker(t *testing.T) {
+	sts, pod := testUpdatedWorker()
+
+	slurmClient := &slurmapifake.MockClient{}
+	slurmClient.On("ListReservations", mock.Anything).Return([]slurmapi.Reservation{
+		{Name: "soperator-update-worker-0"},
+	}, nil).Once()
+	slurmClient.On("ListNodes", mock.Anything).Return([]slurmapi.Node{{
+		Name:   pod.Name,
+		States: nodeStates(api.V0044NodeStateIDLE, api.V0044NodeStateRESERVED),
+	}}, nil).Once()
+
+	reconciler, kubeClient := testUpdateVerificationReconciler(t, slurmClient, sts, pod)
+	status, err := reconciler.verifyUpdatedWorkers(context.Background(), "cluster", sts, []corev1.Pod{*pod},
+		&updateVerification{activeCheckName: "gpu-checks"})
+	require.NoError(t, err)
+	assert.Equal(t, updateVerificationStatus{inFlight: 1}, status)
+
+	run := &slurmv1alpha1.ActiveCheckRun{}
+	require.NoError(t, kubeClient.Get(context.Background(), types.NamespacedName{
+		Namespace: "default",
+		Name:      "worker-0-verify-7d9f8c",
+	}, run))
+	assert.Equal(t, slurmv1alpha1.ActiveCheckRunSpec{
+		ActiveCheckName: "gpu-checks",
+		Nodes:           []string{"worker-0"},
+		Reservation:     "soperator-update-worker-0",
+	}, run.Spec)
+	assert.Equal(t, sts.Status.UpdateRevision, run.Labels[consts.LabelSoperatorWorkerOperationID])
+	assert.Equal(t, sts.Name, run.OwnerReferences[0].Name)
+	slurmClient.AssertExpectations(t)
+}
+
+func TestVerifyUpdatedWorkersWaitsForRebootedWorker(t *testing.T) {
+	sts, pod := testUpdatedWorker()
+
+	slurmClient := &slurmapifake.MockClient{}
+	slurmClient.On("ListReservations", mock.Anything).Return([]slurmapi.Reservation{
+		{Name: "soperator-update-worker-0"},
+	}, nil).Once()
+	slurmClient.On("ListNodes", mock.Anything).Return([]slurmapi.Node{{
+		Name:   pod.Name,
+		States: nodeStates(api.V0044NodeStateIDLE, api.V0044NodeStateNOTRESPONDING),
+	}}, nil).Once()
+
+	reconciler, kubeClient := testUpdateVerificationReconciler(t, slurmClient, sts, pod)
+	status, err := reconciler.verifyUpdatedWorkers(context.Background(), "cluster", sts, []corev1.Pod{*pod},
+		&updateVerification{activeCheckName: "gpu-checks"})
+	require.NoError(t, err)
+	assert.Equal(t, updateVerificationStatus{inFlight: 1}, status)
+
+	runs := &slurmv1alpha1.ActiveCheckRunList{}
+	require.NoError(t, kubeClient.List(context.Background(), runs))
+	assert.Empty(t, runs.Items)
+	slurmClient.AssertExpectations(t)
+}
+
+func TestVerifyUpdatedWorkersFinishesVerification(t *testing.T) {
+	tests := []struct {
+		name       string
+		phase      consts.ActiveCheckRunPhase
+		wantFailed []string
+	}{
+		{name: "passed", phase: consts.ActiveCheckRunPhaseComplete},
+		{name: "skipped", phase: consts.ActiveCheckRunPhaseSkipped},
+		{name: "failed", phase: consts.ActiveCheckRunPhaseFailed, wantFailed: []string{"worker-0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sts, pod := testUpdatedWorker()
+			run := &slurmv1alpha1.ActiveCheckRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "worker-0-verify-7d9f8c",
+					Namespace: "default",
+					Labels:    map[string]string{consts.LabelSoperatorWorkerOperationID: sts.Status.UpdateRevision},
+				},
+				Status: slurmv1alpha1.ActiveCheckRunStatus{Phase: tt.phase},
+			}
+
+			slurmClient := &slurmapifake.MockClient{}
+			slurmClient.On("ListReservations", mock.Anything).Return([]slurmapi.Reservation{
+				{Name: "soperator-update-worker-0"},
+			}, nil).Once()
+			slurmClient.On("ListNodes", mock.Anything).Return([]slurmapi.Node{{
+				Name:   pod.Name,
+				States: nodeStates(api.V0044NodeStateIDLE, api.V0044NodeStateRESERVED),
+			}}, nil).Once()
+			if tt.wantFailed != nil {
+				slurmClient.On(
+					"SlurmV0044PostNodeWithResponse",
+					mock.Anything,
+					pod.Name,
+					mock.MatchedBy(func(body api.SlurmV0044PostNodeJSONRequestBody) bool {
+						return body.State != nil &&
+							len(*body.State) == 1 &&
+							(*body.State)[0] == api.V0044UpdateNodeMsgStateDRAIN &&
+							body.Reason != nil &&
+							*body.Reason == updateVerificationFailedReason+": ActiveCheckRun worker-0-verify-7d9f8c is Failed"
+					}),
+				).Return(&api.SlurmV0044PostNodeResponse{
+					JSON200: &api.V0044OpenapiResp{Errors: &[]api.V0044OpenapiError{}},
+				}, nil).Once()
+			}
+			slurmClient.On("DeleteReservation", mock.Anything, "soperator-update-worker-0").Return(nil).Once()
+
+			reconciler, _ := testUpdateVerificationReconciler(t, slurmClient, sts, pod, run)
+			status, err := reconciler.verifyUpdatedWorkers(context.Background(), "cluster", sts, []corev1.Pod{*pod},
+				&updateVerification{activeCheckName: "gpu-checks"})
+			require.NoError(t, err)
+			assert.Equal(t, updateVerificationStatus{failedNodes: tt.wantFailed}, status)
+			slurmClient.AssertExpectations(t)
+		})
+	}
+}
+
+func testUpdatedWorker() (*kruisev1b1.StatefulSet, *corev1.Pod) {
+	sts := testStatefulSet()
+	sts.Name = "worker"
+	sts.Status.UpdateRevision = "worker-7d9f8c"
+
+	pod := testOutdatedPod()
+	pod.Labels = map[string]string{"controller-revision-hash": sts.Status.UpdateRevision}
+	pod.Status.Conditions = []corev1.PodCondition{
+		{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+	}
+	return sts, &pod
+}
+
+func testUpdateVerificationReconciler(
+	t *testing.T,
+	slurmClient slurmapi.Client,
+	objects ...client.Object,
+) (*RollingUpdateReconciler, client.Client) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, kruisev1b1.AddToScheme(scheme))
+	require.NoError(t, slurmv1alpha1.AddToScheme(scheme))
+	kubeClient := clientfake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithStatusSubresource(&slurmv1alpha1.NodeSet{}).
+		Build()
+	slurmClients := slurmapi.NewClientSet(context.Background())
+	slurmClients.AddClient(types.NamespacedName{Namespace: "default", Name: "cluster"}, slurmClient)
+
+	return NewRollingUpdateReconciler(
+		kubeClient,
+		scheme,
+		record.NewFakeRecorder(1),
+		slurmClients,
+	), kubeClient
+}
@@ -53,6 +53,44 @@ type MetricsCollector struct {
 	rpcUserDurationSecondsTotal *prometheus.Desc
 	controllerServerThreadCount *prometheus.Desc
 
+	partitionInfo          *prometheus.Desc
+	partitionNodesTotal    *prometheus.Desc
+	partitionNodes         *prometheus.Desc
+	partitionCPUsTotal     *prometheus.Desc
+	partitionCPUsAllocated *prometheus.Desc
+	partitionCPUsIdle      *prometheus.Desc
+	partitionGPUsTotal     *prometheus.Desc
+	partitionGPUsAllocated *prometheus.Desc
+	partitionPendingJobs   *prometheus.Desc
+
+	qosPriority       *prometheus.Desc
+	qosUsageFactor    *prometheus.Desc
+	qosGrpTRESLimit   *prometheus.Desc
+	qosMaxJobsPerUser *prometheus.Desc
+	qosJobs           *prometheus.Desc
+	qosCPUsAllocated  *prometheus.Desc
+	qosGPUsAllocated  *prometheus.Desc
+
+	reservationInfo      *prometheus.Desc
+	reservationNode      *prometheus.Desc
+	reservationNodes     *prometheus.Desc
+	reservationCores     *prometheus.Desc
+	reservationStartTime *prometheus.Desc
+	reservationEndTime   *prometheus.Desc
+	reservationActive    *prometheus.Desc
+
+	associationSharesRaw        *prometheus.Desc
+	associationSharesNormalized *prometheus.Desc
+	associationUsageRaw         *prometheus.Desc
+	associationUsageNormalized  *prometheus.Desc
+	associationUsageEffective   *prometheus.Desc
+	associationFairshareFactor  *prometheus.Desc
+	associationTRESRunSeconds   *prometheus.Desc
+	associationGrpTRESLimit     *prometheus.Desc
+	associationGrpJobsLimit     *prometheus.Desc
+	associationJobs             *prometheus.Desc
+	associationTRESAllocated    *prometheus.Desc
+
 	// Atomic pointer to the current state for lock-free reads
 	stateMu sync.Mutex
 	state   atomic.Pointer[metricsCollectorState]
@@ -194,6 +232,49 @@ func newMetricsCollector(
 		rpcUserCallsTotal:           prometheus.NewDesc("slurm_controller_rpc_user_calls_total", "Total count of RPC calls by user", []string{"user", "user_id"}, nil),
 		rpcUserDurationSecondsTotal: prometheus.NewDesc("slurm_controller_rpc_user_duration_seconds_total", "Total time spent on user RPCs", []string{"user", "user_id"}, nil),
 		controllerServerThreadCount: prometheus.NewDesc("slurm_controller_server_thread_count", "Number of server threads", nil, nil),
+
+		partitionInfo:          prometheus.NewDesc("slurm_partition_info", "Slurm partition info", []string{"partition", "state", "qos"}, nil),
+		partitionNodesTotal:    prometheus.NewDesc("slurm_partition_nodes_total", "Total nodes configured in the partition", []string{"partition"}, nil),
+		partitionNodes:         prometheus.NewDesc("slurm_partition_nodes", "Nodes in the partition by state", []string{"partition", "state"}, nil),
+		partitionCPUsTotal:     prometheus.NewDesc("slurm_partition_cpus_total", "Total CPUs in the partition", []string{"partition"}, nil),
+		partitionCPUsAllocated: prometheus.NewDesc("slurm_partition_cpus_allocated", "CPUs allocated in the partition", []string{"partition"}, nil),
+		partitionCPUsIdle:      prometheus.NewDesc("slurm_partition_cpus_idle", "Idle CPUs in the partition", []string{"partition"}, nil),
+		partitionGPUsTotal:     prometheus.NewDesc("slurm_partition_gpus_total", "Total GPUs in the partition", []string{"partition"}, nil),
+		partitionGPUsAllocated: prometheus.NewDesc("slurm_partition_gpus_allocated", "GPUs allocated in the partition", []string{"partition"}, nil),
+		partitionPendingJobs:   prometheus.NewDesc("slurm_partition_pending_jobs", "Pending jobs in the partition by pending reason", []string{"partition", "reason"}, nil),
+
+		qosPriority:       prometheus.NewDesc("slurm_qos_priority", "Priority of the QOS", []string{"qos"}, nil),
+		qosUsageFactor:    prometheus.NewDesc("slurm_qos_usage_factor", "Usage factor of the QOS", []string{"qos"}, nil),
+		qosGrpTRESLimit:   prometheus.NewDesc("slurm_qos_grp_tres_limit", "GrpTRES limit of the QOS", []string{"qos", "tres"}, nil),
+		qosMaxJobsPerUser: prometheus.NewDesc("slurm_qos_max_jobs_per_user", "Maximum running jobs per user in the QOS", []string{"qos"}, nil),
+		qosJobs:           prometheus.NewDesc("slurm_qos_jobs", "Non-terminal jobs in the QOS by state", []string{"qos", "job_state"}, nil),
+		qosCPUsAllocated:  prometheus.NewDesc("slurm_qos_cpus_allocated", "CPUs allocated to running jobs in the QOS", []string{"qos"}, nil),
+		qosGPUsAllocated:  prometheus.NewDesc("slurm_qos_gpus_allocated", "GPUs allocated to running jobs in the QOS", []string{"qos"}, nil),
+
+		reservationInfo: prometheus.NewDesc(
+			"slurm_reservation_info",
+			"Slurm reservation info",
+			[]string{"reservation_name", "partition", "flags", "users", "accounts"},
+			nil,
+		),
+		reservationNode:      prometheus.NewDesc("slurm_reservation_node", "Slurm reservation node mapping", []string{"reservation_name", "node_name"}, nil),
+		reservationNodes:     prometheus.NewDesc("slurm_reservation_nodes", "Number of nodes in the reservation", []string{"reservation_name"}, nil),
+		reservationCores:     prometheus.NewDesc("slurm_reservation_cores", "Number of cores in the reservation", []string{"reservation_name"}, nil),
+		reservationStartTime: prometheus.NewDesc("slurm_reservation_start_time_seconds", "Start time of the reservation as Unix timestamp", []string{"reservation_name"}, nil),
+		reservationEndTime:   prometheus.NewDesc("slurm_reservation_end_time_seconds", "End time of the reservation as Unix timestamp", []string{"reservation_name"}, nil),
+		reservationActive:    prometheus.NewDesc("slurm_reservation_active", "Whether the reservation time window is currently active", []string{"reservation_name"}, nil),
+
+		associationSharesRaw:        prometheus.NewDesc("slurm_association_shares_raw", "Raw fairshare shares of the association", []string{"account", "user"}, nil),
+		associationSharesNormalized: prometheus.NewDesc("slurm_association_shares_normalized", "Normalized fairshare shares of the association", []string{"account", "user"}, nil),
+		associationUsageRaw:         prometheus.NewDesc("slurm_association_usage_raw", "Raw decayed usage of the association in TRES billing seconds", []string{"account", "user"}, nil),
+		associationUsageNormalized:  prometheus.NewDesc("slurm_association_usage_normalized", "Normalized usage of the association", []string{"account", "user"}, nil),
+		associationUsageEffective:   prometheus.NewDesc("slurm_association_usage_effective", "Effective usage of the association", []string{"account", "user"}, nil),
+		associationFairshareFactor:  prometheus.NewDesc("slurm_association_fairshare_factor", "Fairshare factor of the association", []string{"account", "user"}, nil),
+		associationTRESRunSeconds:   prometheus.NewDesc("slurm_association_tres_run_seconds", "TRES-seconds remaining for running jobs of the association", []string{"account", "user", "tres"}, nil),
+		associationGrpTRESLimit:     prometheus.NewDesc("slurm_association_grp_tres_limit", "GrpTRES limit of the association", []string{"account", "user", "tres"}, nil),
+		associationGrpJobsLimit:     prometheus.NewDesc("slurm_association_grp_jobs_limit", "GrpJobs limit of the association", []string{"account", "user"}, nil),
+		associationJobs:             prometheus.NewDesc("slurm_association_jobs", "Non-terminal jobs of the association by state", []string{"account", "user", "job_state"}, nil),
+		associationTRESAllocated:    prometheus.NewDesc("slurm_association_tres_allocated", "TRES allocated to running jobs of the association", []string{"account", "user", "tres"}, nil),
 	}
 
 	collector.state.Store(newMetricsCollectorState())
@@ -357,6 +438,44 @@ func (c *MetricsCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.rpcUserCallsTotal
 	ch <- c.rpcUserDurationSecondsTotal
 	ch <- c.controllerServerThreadCount
+
+	ch <- c.partitionInfo
+	ch <- c.partitionNodesTotal
+	ch <- c.partitionNodes
+	ch <- c.partitionCPUsTotal
+	ch <- c.partitionCPUsAllocated
+	ch <- c.partitionCPUsIdle
+	ch <- c.partitionGPUsTotal
+	ch <- c.partitionGPUsAllocated
+	ch <- c.partitionPendingJobs
+
+	ch <- c.qosPriority
+	ch <- c.qosUsageFactor
+	ch <- c.qosGrpTRESLimit
+	ch <- c.qosMaxJobsPerUser
+	ch <- c.qosJobs
+	ch <- c.qosCPUsAllocated
+	ch <- c.qosGPUsAllocated
+
+	ch <- c.reservationInfo
+	ch <- c.reservationNode
+	ch <- c.reservationNodes
+	ch <- c.reservationCores
+	ch <- c.reservationStartTime
+	ch <- c.reservationEndTime
+	ch <- c.reservationActive
+
+	ch <- c.associationSharesRaw
+	ch <- c.associationSharesNormalized
+	ch <- c.associationUsageRaw
+	ch <- c.associationUsageNormalized
+	ch <- c.associationUsageEffective
+	ch <- c.associationFairshareFactor
+	ch <- c.associationTRESRunSeconds
+	ch <- c.associationGrpTRESLimit
+	ch <- c.associationGrpJobsLimit
+	ch <- c.associationJobs
+	ch <- c.associationTRESAllocated
 }
 
 func (c *MetricsCollector) listNodes(ctx context.Context) ([]slurmapi.Node, error) {
@@ -402,6 +521,15 @@ func cloneMetricsCollectorState(previousState *metricsCollectorState) *metricsCo
 	newState.diagCollectionSequence = previousState.diagCollectionSequence
 	newState.nodeTopologies = previousState.nodeTopologies
 	newState.topologyCollectionSequence = previousState.topologyCollectionSequence
+	newState.partitions = previousState.partitions
+	newState.partitionsCollectionSequence = previousState.partitionsCollectionSequence
+	newState.reservations = previousState.reservations
+	newState.reservationsCollectionSequence = previousState.reservationsCollectionSequence
+	newState.qos = previousState.qos
+	newState.qosCollectionSequence = previousState.qosCollectionSequence
+	newState.shares = previousState.shares
+	newState.associations = previousState.associations
+	newState.fairshareCollectionSequence = previousState.fairshareCollectionSequence
 	maps.Copy(newState.nodeUnavailabilityStartTimes, previousState.nodeUnavailabilityStartTimes)
 	maps.Copy(newState.nodeDrainingStartTimes, previousState.nodeDrainingStartTimes)
 	return newState
@@ -605,6 +733,22 @@ func (c *MetricsCollector) collectImpl(ch chan<- prometheus.Metric) {
 	for rpcMetric := range c.slurmRPCMetrics(state.diag) {
 		ch <- rpcMetric
 	}
+
+	for partitionMetric := range c.slurmPartitionMetrics(ctx, state.partitions, state.nodes, state.jobs) {
+		ch <- partitionMetric
+	}
+
+	for qosMetric := range c.slurmQOSMetrics(ctx, state.qos, state.jobs) {
+		ch <- qosMetric
+	}
+
+	for reservationMetric := range c.slurmReservationMetrics(ctx, state.reservations, time.Now()) {
+		ch <- reservationMetric
+	}
+
+	for associationMetric := range c.slurmAssociationMetrics(ctx, state.shares, state.associations, state.jobs) {
+		ch <- associationMetric
+	}
 }
 
 func (c *MetricsCollector) slurmNodeMetrics(
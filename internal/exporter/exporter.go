@@ -31,6 +31,9 @@ type Params struct {
 	MaxCollectorInflight int
 	JobListParams        slurmapi.ListJobsParams
 	NodeTopologySource   NodeTopologySource
+	// AccountingCollectors enables sub-collectors that read from the Slurm accounting API (slurmdbd).
+	// They fail on every run on clusters without accounting, so they are disabled by default.
+	AccountingCollectors bool
 }
 
 // Exporter collects metrics from a SLURM cluster and exports them in Prometheus format
@@ -143,10 +146,18 @@ func (e *Exporter) collectionLoop(ctx context.Context) {
 		{name: "nodes", run: e.collector.refreshNodes},
 		{name: "jobs", run: e.collector.refreshJobs},
 		{name: "diag", run: e.collector.refreshDiag},
+		{name: "partitions", run: e.collector.refreshPartitions},
+		{name: "reservations", run: e.collector.refreshReservations},
 	}
 	if e.collector.nodeTopologySource != nil {
 		collectors = append(collectors, &asyncSubCollector{name: "topology", run: e.collector.refreshNodeTopologies})
 	}
+	if e.params.AccountingCollectors {
+		collectors = append(collectors,
+			&asyncSubCollector{name: "qos", run: e.collector.refreshQOS},
+			&asyncSubCollector{name: "fairshare", run: e.collector.refreshFairshare},
+		)
+	}
 
 	startCollectors := func() {
 		start := time.Now()
@@ -31,6 +31,8 @@ func TestExporterCollectionLoopSkipsRunningSubCollector(t *testing.T) {
 
 	mockClient.EXPECT().ListNodes(mock.Anything).Return([]slurmapi.Node{}, nil).Maybe()
 	mockClient.EXPECT().GetDiag(mock.Anything).Return(&api.V0044OpenapiDiagResp{}, nil).Maybe()
+	mockClient.EXPECT().ListPartitions(mock.Anything).Return([]slurmapi.Partition{}, nil).Maybe()
+	mockClient.EXPECT().ListReservations(mock.Anything).Return([]slurmapi.Reservation{}, nil).Maybe()
 	mockClient.EXPECT().ListJobsWithParams(mock.Anything, mock.Anything).RunAndReturn(
 		func(ctx context.Context, _ slurmapi.ListJobsParams) ([]slurmapi.Job, error) {
 			jobsCalls.Add(1)
@@ -94,6 +96,8 @@ func TestExporterCollectionLoopAllowsConfiguredOverlap(t *testing.T) {
 
 	mockClient.EXPECT().ListNodes(mock.Anything).Return([]slurmapi.Node{}, nil).Maybe()
 	mockClient.EXPECT().GetDiag(mock.Anything).Return(&api.V0044OpenapiDiagResp{}, nil).Maybe()
+	mockClient.EXPECT().ListPartitions(mock.Anything).Return([]slurmapi.Partition{}, nil).Maybe()
+	mockClient.EXPECT().ListReservations(mock.Anything).Return([]slurmapi.Reservation{}, nil).Maybe()
 	mockClient.EXPECT().ListJobsWithParams(mock.Anything, mock.Anything).RunAndReturn(
 		func(ctx context.Context, _ slurmapi.ListJobsParams) ([]slurmapi.Job, error) {
 			jobsCalls.Add(1)
@@ -0,0 +1,212 @@
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"time"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"nebius.ai/slurm-operator/internal/slurmapi"
+)
+
+// TRES names reported by slurm_association_tres_allocated. Memory is reported in megabytes, so that
+// it can be compared with GrpTRES limits.
+const (
+	tresCPU = "cpu"
+	tresMem = "mem"
+	tresGPU = "gres/gpu"
+)
+
+// associationKey identifies an account (empty user) or a user within an account.
+type associationKey struct {
+	account string
+	user    string
+}
+
+func (c *MetricsCollector) listFairshare(ctx context.Context) ([]slurmapi.Share, []slurmapi.Association, error) {
+	logger := log.FromContext(ctx).WithName(ControllerName)
+
+	sharesStart := time.Now()
+	shares, err := c.slurmAPIClient.ListShares(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get shares from SLURM API: %w", err)
+	}
+	logger.Info("Fetched shares", "count", len(shares), "elapsed_seconds", time.Since(sharesStart).Seconds())
+
+	associationsStart := time.Now()
+	associations, err := c.slurmAPIClient.ListAssociations(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get associations from SLURM API: %w", err)
+	}
+	logger.Info("Fetched associations", "count", len(associations), "elapsed_seconds", time.Since(associationsStart).Seconds())
+
+	return shares, associations, nil
+}
+
+func (c *MetricsCollector) refreshFairshare(ctx context.Context, sequence uint64) (err error) {
+	start := time.Now()
+	defer func() {
+		c.recordCollectorRun(ctx, "fairshare", start, err)
+	}()
+
+	shares, associations, err := c.listFairshare(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+
+	previousState := c.state.Load()
+	if previousState == nil {
+		previousState = newMetricsCollectorState()
+	}
+	if sequence != 0 && sequence <= previousState.fairshareCollectionSequence {
+		return nil
+	}
+	newState := cloneMetricsCollectorState(previousState)
+	newState.shares = shares
+	newState.associations = associations
+	if sequence != 0 {
+		newState.fairshareCollectionSequence = sequence
+	}
+	c.state.Store(newState)
+	return nil
+}
+
+// associationUsage aggregates jobs running or waiting under an account or a user within an account.
+type associationUsage struct {
+	jobsByState   map[string]int
+	tresAllocated map[string]float64
+}
+
+// Fairshare data and limits come from the fairshare collector, while the current consumption is
+// derived from jobs. Partition-specific associations are skipped: they would collide with the
+// account-wide ones, and the consumption derived from jobs isn't split by partition anyway.
+func (c *MetricsCollector) slurmAssociationMetrics(
+	ctx context.Context,
+	shares []slurmapi.Share,
+	associations []slurmapi.Association,
+	jobs []slurmapi.Job,
+) iter.Seq[prometheus.Metric] {
+	return func(yield func(prometheus.Metric) bool) {
+		logger := log.FromContext(ctx).WithName(ControllerName)
+
+		for _, share := range shares {
+			if share.Partition != "" {
+				continue
+			}
+			account, user := share.Account(), share.User()
+
+			if share.RawShares != nil {
+				if !yield(prometheus.MustNewConstMetric(c.associationSharesRaw, prometheus.GaugeValue, float64(*share.RawShares), account, user)) {
+					return
+				}
+			}
+			if share.NormalizedShares != nil {
+				if !yield(prometheus.MustNewConstMetric(c.associationSharesNormalized, prometheus.GaugeValue, *share.NormalizedShares, account, user)) {
+					return
+				}
+			}
+			if share.RawUsage != nil {
+				if !yield(prometheus.MustNewConstMetric(c.associationUsageRaw, prometheus.GaugeValue, float64(*share.RawUsage), account, user)) {
+					return
+				}
+			}
+			if share.NormalizedUsage != nil {
+				if !yield(prometheus.MustNewConstMetric(c.associationUsageNormalized, prometheus.GaugeValue, *share.NormalizedUsage, account, user)) {
+					return
+				}
+			}
+			if share.EffectiveUsage != nil {
+				if !yield(prometheus.MustNewConstMetric(c.associationUsageEffective, prometheus.GaugeValue, *share.EffectiveUsage, account, user)) {
+					return
+				}
+			}
+			if share.FairshareFactor != nil {
+				if !yield(prometheus.MustNewConstMetric(c.associationFairshareFactor, prometheus.GaugeValue, *share.FairshareFactor, account, user)) {
+					return
+				}
+			}
+			for tres, seconds := range share.TRESRunSeconds {
+				if !yield(prometheus.MustNewConstMetric(c.associationTRESRunSeconds, prometheus.GaugeValue, float64(seconds), account, user, tres)) {
+					return
+				}
+			}
+		}
+
+		for _, association := range associations {
+			if association.Partition != "" {
+				continue
+			}
+			for tres, limit := range association.GrpTRES {
+				if !yield(prometheus.MustNewConstMetric(c.associationGrpTRESLimit, prometheus.GaugeValue, float64(limit),
+					association.Account, association.User, tres)) {
+					return
+				}
+			}
+			if association.GrpJobs != nil {
+				if !yield(prometheus.MustNewConstMetric(c.associationGrpJobsLimit, prometheus.GaugeValue, float64(*association.GrpJobs),
+					association.Account, association.User)) {
+					return
+				}
+			}
+		}
+
+		usage := make(map[associationKey]*associationUsage)
+		usageFor := func(key associationKey) *associationUsage {
+			u, ok := usage[key]
+			if !ok {
+				u = &associationUsage{
+					jobsByState:   make(map[string]int),
+					tresAllocated: make(map[string]float64),
+				}
+				usage[key] = u
+			}
+			return u
+		}
+		for _, job := range jobs {
+			if job.Account == "" || job.IsTerminalState() {
+				continue
+			}
+			var tres *slurmapi.TrackableResources
+			if job.State == string(api.V0044JobInfoJobStateRUNNING) && job.TresAllocated != "" {
+				var err error
+				if tres, err = slurmapi.ParseTrackableResources(job.TresAllocated); err != nil {
+					logger.Error(err, "Failed to parse job allocated resources", "job_id", job.GetIDString(), "tres", job.TresAllocated)
+				}
+			}
+
+			keys := []associationKey{{account: job.Account}}
+			if job.UserName != "" {
+				keys = append(keys, associationKey{account: job.Account, user: job.UserName})
+			}
+			for _, key := range keys {
+				u := usageFor(key)
+				u.jobsByState[job.State]++
+				if tres != nil {
+					u.tresAllocated[tresCPU] += float64(tres.CPUCount)
+					u.tresAllocated[tresMem] += float64(tres.MemoryBytes) / (1024 * 1024)
+					u.tresAllocated[tresGPU] += float64(tres.GPUCount)
+				}
+			}
+		}
+
+		for key, u := range usage {
+			for state, count := range u.jobsByState {
+				if !yield(prometheus.MustNewConstMetric(c.associationJobs, prometheus.GaugeValue, float64(count), key.account, key.user, state)) {
+					return
+				}
+			}
+			for tres, value := range u.tresAllocated {
+				if !yield(prometheus.MustNewConstMetric(c.associationTRESAllocated, prometheus.GaugeValue, value, key.account, key.user, tres)) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package exporter
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	"nebius.ai/slurm-operator/internal/slurmapi"
+	"nebius.ai/slurm-operator/internal/slurmapi/fake"
+)
+
+func TestMetricsCollector_RefreshFairshareDropsStaleSequence(t *testing.T) {
+	mockClient := &fake.MockClient{}
+	collector := newTestMetricsCollector(mockClient)
+
+	mockClient.EXPECT().ListShares(mock.Anything).
+		Return([]slurmapi.Share{{Name: "team-a"}}, nil).Once()
+	mockClient.EXPECT().ListAssociations(mock.Anything).
+		Return([]slurmapi.Association{{Account: "team-a"}}, nil).Once()
+	mockClient.EXPECT().ListShares(mock.Anything).
+		Return([]slurmapi.Share{{Name: "stale"}}, nil).Once()
+	mockClient.EXPECT().ListAssociations(mock.Anything).
+		Return([]slurmapi.Association{{Account: "stale"}}, nil).Once()
+
+	require.NoError(t, collector.refreshFairshare(context.Background(), 2))
+	require.NoError(t, collector.refreshFairshare(context.Background(), 1))
+
+	state := collector.state.Load()
+	require.Len(t, state.shares, 1)
+	assert.Equal(t, "team-a", state.shares[0].Name)
+	require.Len(t, state.associations, 1)
+	assert.Equal(t, "team-a", state.associations[0].Account)
+}
+
+func TestMetricsCollector_RefreshFairshareFailsOnAssociationsError(t *testing.T) {
+	mockClient := &fake.MockClient{}
+	collector := newTestMetricsCollector(mockClient)
+
+	mockClient.EXPECT().ListShares(mock.Anything).Return([]slurmapi.Share{{Name: "team-a"}}, nil).Once()
+	mockClient.EXPECT().ListAssociations(mock.Anything).Return(nil, assert.AnError).Once()
+
+	require.Error(t, collector.refreshFairshare(context.Background(), 1))
+	assert.Empty(t, collector.state.Load().shares)
+}
+
+func TestMetricsCollector_SlurmAssociationMetrics(t *testing.T) {
+	collector := newTestMetricsCollector(&fake.MockClient{})
+
+	shares := []slurmapi.Share{
+		{
+			Name:            "team-a",
+			Parent:          "root",
+			RawShares:       ptr.To(int32(10)),
+			RawUsage:        ptr.To(int64(7200)),
+			FairshareFactor: ptr.To(0.5),
+		},
+		{
+			Name:            "alice",
+			Parent:          "team-a",
+			IsUser:          true,
+			EffectiveUsage:  ptr.To(0.25),
+			FairshareFactor: ptr.To(0.75),
+			TRESRunSeconds:  map[string]int64{"gres/gpu": 1800},
+		},
+		{
+			Name:            "team-a",
+			Parent:          "root",
+			Partition:       "debug",
+			FairshareFactor: ptr.To(0.1),
+		},
+	}
+	associations := []slurmapi.Association{
+		{Account: "team-a", GrpTRES: map[string]int64{"gres/gpu": 16}, GrpJobs: ptr.To(int32(100))},
+		{Account: "team-a", User: "alice", GrpTRES: map[string]int64{"gres/gpu": 8}},
+		{Account: "team-a", Partition: "debug", GrpTRES: map[string]int64{"gres/gpu": 1}},
+	}
+	jobs := []slurmapi.Job{
+		{ID: 1, Account: "team-a", UserName: "alice", State: string(api.V0044JobInfoJobStateRUNNING), TresAllocated: "cpu=16,mem=1024M,gres/gpu=8"},
+		{ID: 2, Account: "team-a", UserName: "alice", State: string(api.V0044JobInfoJobStatePENDING)},
+		{ID: 3, Account: "team-a", UserName: "alice", State: string(api.V0044JobInfoJobStateCOMPLETED), TresAllocated: "cpu=16,mem=1024M,gres/gpu=8"},
+		{ID: 4, State: string(api.V0044JobInfoJobStateRUNNING), TresAllocated: "cpu=16,mem=1024M,gres/gpu=8"},
+	}
+
+	var metrics []string
+	for metric := range collector.slurmAssociationMetrics(context.Background(), shares, associations, jobs) {
+		metrics = append(metrics, toPrometheusLikeString(t, metric))
+	}
+
+	assert.ElementsMatch(t, []string{
+		`GAUGE; slurm_association_shares_raw{account="team-a",user=""} 10`,
+		`GAUGE; slurm_association_usage_raw{account="team-a",user=""} 7200`,
+		`GAUGE; slurm_association_fairshare_factor{account="team-a",user=""} 0.5`,
+		`GAUGE; slurm_association_usage_effective{account="team-a",user="alice"} 0.25`,
+		`GAUGE; slurm_association_fairshare_factor{account="team-a",user="alice"} 0.75`,
+		`GAUGE; slurm_association_tres_run_seconds{account="team-a",tres="gres/gpu",user="alice"} 1800`,
+		`GAUGE; slurm_association_grp_tres_limit{account="team-a",tres="gres/gpu",user=""} 16`,
+		`GAUGE; slurm_association_grp_jobs_limit{account="team-a",user=""} 100`,
+		`GAUGE; slurm_association_grp_tres_limit{account="team-a",tres="gres/gpu",user="alice"} 8`,
+		`GAUGE; slurm_association_jobs{account="team-a",job_state="RUNNING",user=""} 1`,
+		`GAUGE; slurm_association_jobs{account="team-a",job_state="PENDING",user=""} 1`,
+		`GAUGE; slurm_association_tres_allocated{account="team-a",tres="cpu",user=""} 16`,
+		`GAUGE; slurm_association_tres_allocated{account="team-a",tres="mem",user=""} 1024`,
+		`GAUGE; slurm_association_tres_allocated{account="team-a",tres="gres/gpu",user=""} 8`,
+		`GAUGE; slurm_association_jobs{account="team-a",job_state="RUNNING",user="alice"} 1`,
+		`GAUGE; slurm_association_jobs{account="team-a",job_state="PENDING",user="alice"} 1`,
+		`GAUGE; slurm_association_tres_allocated{account="team-a",tres="cpu",user="alice"} 16`,
+		`GAUGE; slurm_association_tres_allocated{account="team-a",tres="mem",user="alice"} 1024`,
+		`GAUGE; slurm_association_tres_allocated{account="team-a",tres="gres/gpu",user="alice"} 8`,
+	}, metrics)
+}
@@ -0,0 +1,237 @@
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"strings"
+	"time"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"nebius.ai/slurm-operator/internal/slurmapi"
+)
+
+// Node states reported by slurm_partition_nodes. Each node is counted in exactly one of them.
+const (
+	partitionNodeStateIdle        = "idle"
+	partitionNodeStateAllocated   = "allocated"
+	partitionNodeStateMixed       = "mixed"
+	partitionNodeStateDraining    = "draining"
+	partitionNodeStateUnavailable = "unavailable"
+	partitionNodeStatePoweredDown = "powered_down"
+	partitionNodeStateOther       = "other"
+)
+
+// partitionNodeState maps a node to the single state it is counted in for partition metrics.
+// Unavailability and draining take precedence over the base state, so that idle+total nodes
+// always reflect the capacity which can accept new jobs.
+func partitionNodeState(node slurmapi.Node) string {
+	switch {
+	case node.IsPoweredDownState():
+		return partitionNodeStatePoweredDown
+	case isNodeUnavailable(node):
+		return partitionNodeStateUnavailable
+	case isNodeDraining(node):
+		return partitionNodeStateDraining
+	}
+
+	switch node.BaseState() {
+	case api.V0044NodeStateIDLE:
+		return partitionNodeStateIdle
+	case api.V0044NodeStateALLOCATED:
+		return partitionNodeStateAllocated
+	case api.V0044NodeStateMIXED:
+		return partitionNodeStateMixed
+	default:
+		return partitionNodeStateOther
+	}
+}
+
+// jobPartitions splits the partition of a job. Pending jobs submitted to several partitions report
+// them as a comma-separated list.
+func jobPartitions(job slurmapi.Job) []string {
+	if job.Partition == "" {
+		return nil
+	}
+	return strings.Split(job.Partition, ",")
+}
+
+func (c *MetricsCollector) listPartitions(ctx context.Context) ([]slurmapi.Partition, error) {
+	logger := log.FromContext(ctx).WithName(ControllerName)
+
+	partitionsStart := time.Now()
+	partitions, err := c.slurmAPIClient.ListPartitions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get partitions from SLURM API: %w", err)
+	}
+	logger.Info("Fetched partitions", "count", len(partitions), "elapsed_seconds", time.Since(partitionsStart).Seconds())
+
+	return partitions, nil
+}
+
+func (c *MetricsCollector) refreshPartitions(ctx context.Context, sequence uint64) (err error) {
+	start := time.Now()
+	defer func() {
+		c.recordCollectorRun(ctx, "partitions", start, err)
+	}()
+
+	partitions, err := c.listPartitions(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+
+	previousState := c.state.Load()
+	if previousState == nil {
+		previousState = newMetricsCollectorState()
+	}
+	if sequence != 0 && sequence <= previousState.partitionsCollectionSequence {
+		return nil
+	}
+	newState := cloneMetricsCollectorState(previousState)
+	newState.partitions = partitions
+	if sequence != 0 {
+		newState.partitionsCollectionSequence = sequence
+	}
+	c.state.Store(newState)
+	return nil
+}
+
+// partitionUsage aggregates node and job data for a single partition.
+type partitionUsage struct {
+	nodeCount    int
+	nodesByState map[string]int
+	cpusTotal    float64
+	cpusAlloc    float64
+	cpusIdle     float64
+	gpusTotal    float64
+	gpusAlloc    float64
+	pendingJobs  map[string]int
+}
+
+func newPartitionUsage() *partitionUsage {
+	return &partitionUsage{
+		nodesByState: make(map[string]int),
+		pendingJobs:  make(map[string]int),
+	}
+}
+
+// Node partitions come from the nodes collector and partition definitions from the partitions
+// collector, so a node that belongs to several partitions is counted in each of them.
+func (c *MetricsCollector) slurmPartitionMetrics(
+	ctx context.Context,
+	partitions []slurmapi.Partition,
+	nodes []slurmapi.Node,
+	jobs []slurmapi.Job,
+) iter.Seq[prometheus.Metric] {
+	return func(yield func(prometheus.Metric) bool) {
+		if len(partitions) == 0 {
+			return
+		}
+		logger := log.FromContext(ctx).WithName(ControllerName)
+
+		usage := make(map[string]*partitionUsage, len(partitions))
+		for _, partition := range partitions {
+			usage[partition.Name] = newPartitionUsage()
+		}
+
+		for _, node := range nodes {
+			var gpusTotal, gpusAlloc float64
+			if tres, err := slurmapi.ParseTrackableResources(node.Tres); err != nil {
+				logger.Error(err, "Failed to parse trackable resources", "tres", node.Tres)
+			} else {
+				gpusTotal = float64(tres.GPUCount)
+			}
+			if tresUsed, err := slurmapi.ParseTrackableResources(node.TresUsed); err != nil {
+				logger.Error(err, "Failed to parse used trackable resources", "tres_used", node.TresUsed)
+			} else {
+				gpusAlloc = float64(tresUsed.GPUCount)
+			}
+			state := partitionNodeState(node)
+
+			for _, partitionName := range node.Partitions {
+				u, ok := usage[partitionName]
+				if !ok {
+					continue
+				}
+				u.nodeCount++
+				u.nodesByState[state]++
+				if cpuTotal, ok := node.CPUTotal(); ok {
+					u.cpusTotal += cpuTotal
+				}
+				if cpuAlloc, ok := node.CPUAllocated(); ok {
+					u.cpusAlloc += cpuAlloc
+				}
+				if cpuIdle, ok := node.CPUIdle(); ok {
+					u.cpusIdle += cpuIdle
+				}
+				u.gpusTotal += gpusTotal
+				u.gpusAlloc += gpusAlloc
+			}
+		}
+
+		for _, job := range jobs {
+			if job.State != string(api.V0044JobInfoJobStatePENDING) {
+				continue
+			}
+			for _, partitionName := range jobPartitions(job) {
+				if u, ok := usage[partitionName]; ok {
+					u.pendingJobs[job.StateReason]++
+				}
+			}
+		}
+
+		for _, partition := range partitions {
+			u := usage[partition.Name]
+
+			if !yield(prometheus.MustNewConstMetric(c.partitionInfo, prometheus.GaugeValue, 1,
+				partition.Name, string(partition.State()), partition.QOS)) {
+				return
+			}
+
+			nodesTotal := float64(u.nodeCount)
+			if partition.TotalNodes != nil {
+				nodesTotal = float64(*partition.TotalNodes)
+			}
+			if !yield(prometheus.MustNewConstMetric(c.partitionNodesTotal, prometheus.GaugeValue, nodesTotal, partition.Name)) {
+				return
+			}
+			for state, count := range u.nodesByState {
+				if !yield(prometheus.MustNewConstMetric(c.partitionNodes, prometheus.GaugeValue, float64(count), partition.Name, state)) {
+					return
+				}
+			}
+
+			cpusTotal := u.cpusTotal
+			if partition.TotalCPUs != nil {
+				cpusTotal = float64(*partition.TotalCPUs)
+			}
+			if !yield(prometheus.MustNewConstMetric(c.partitionCPUsTotal, prometheus.GaugeValue, cpusTotal, partition.Name)) {
+				return
+			}
+			if !yield(prometheus.MustNewConstMetric(c.partitionCPUsAllocated, prometheus.GaugeValue, u.cpusAlloc, partition.Name)) {
+				return
+			}
+			if !yield(prometheus.MustNewConstMetric(c.partitionCPUsIdle, prometheus.GaugeValue, u.cpusIdle, partition.Name)) {
+				return
+			}
+			if !yield(prometheus.MustNewConstMetric(c.partitionGPUsTotal, prometheus.GaugeValue, u.gpusTotal, partition.Name)) {
+				return
+			}
+			if !yield(prometheus.MustNewConstMetric(c.partitionGPUsAllocated, prometheus.GaugeValue, u.gpusAlloc, partition.Name)) {
+				return
+			}
+
+			for reason, count := range u.pendingJobs {
+				if !yield(prometheus.MustNewConstMetric(c.partitionPendingJobs, prometheus.GaugeValue, float64(count), partition.Name, reason)) {
+					return
+				}
+			}
+		}
+	}
+}
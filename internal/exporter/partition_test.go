@@ -0,0 +1,133 @@
+package exporter
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	"nebius.ai/slurm-operator/internal/slurmapi"
+	"nebius.ai/slurm-operator/internal/slurmapi/fake"
+)
+
+func TestPartitionNodeState(t *testing.T) {
+	tests := []struct {
+		name   string
+		states []api.V0044NodeState
+		want   string
+	}{
+		{name: "idle", states: []api.V0044NodeState{api.V0044NodeStateIDLE}, want: partitionNodeStateIdle},
+		{name: "allocated", states: []api.V0044NodeState{api.V0044NodeStateALLOCATED}, want: partitionNodeStateAllocated},
+		{name: "mixed", states: []api.V0044NodeState{api.V0044NodeStateMIXED}, want: partitionNodeStateMixed},
+		{name: "draining", states: []api.V0044NodeState{api.V0044NodeStateMIXED, api.V0044NodeStateDRAIN}, want: partitionNodeStateDraining},
+		{name: "down", states: []api.V0044NodeState{api.V0044NodeStateDOWN}, want: partitionNodeStateUnavailable},
+		{name: "powered down", states: []api.V0044NodeState{api.V0044NodeStateIDLE, api.V0044NodeStatePOWEREDDOWN}, want: partitionNodeStatePoweredDown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := slurmapi.Node{Name: "node", States: make(map[api.V0044NodeState]struct{})}
+			for _, state := range tt.states {
+				node.States[state] = struct{}{}
+			}
+			assert.Equal(t, tt.want, partitionNodeState(node))
+		})
+	}
+}
+
+func TestMetricsCollector_RefreshPartitionsDropsStaleSequence(t *testing.T) {
+	mockClient := &fake.MockClient{}
+	collector := newTestMetricsCollector(mockClient)
+
+	mockClient.EXPECT().ListPartitions(mock.Anything).
+		Return([]slurmapi.Partition{{Name: "main"}}, nil).Once()
+	mockClient.EXPECT().ListPartitions(mock.Anything).
+		Return([]slurmapi.Partition{{Name: "stale"}}, nil).Once()
+
+	require.NoError(t, collector.refreshPartitions(context.Background(), 2))
+	require.NoError(t, collector.refreshPartitions(context.Background(), 1))
+
+	state := collector.state.Load()
+	require.Len(t, state.partitions, 1)
+	assert.Equal(t, "main", state.partitions[0].Name)
+}
+
+func TestMetricsCollector_SlurmPartitionMetrics(t *testing.T) {
+	collector := newTestMetricsCollector(&fake.MockClient{})
+
+	partitions := []slurmapi.Partition{
+		{
+			Name:   "main",
+			States: []api.V0044PartitionInfoPartitionState{api.V0044PartitionInfoPartitionStateUP},
+			QOS:    "normal",
+		},
+		{
+			Name:       "debug",
+			States:     []api.V0044PartitionInfoPartitionState{api.V0044PartitionInfoPartitionStateDRAIN},
+			TotalNodes: ptr.To(int32(4)),
+			TotalCPUs:  ptr.To(int32(64)),
+		},
+	}
+	nodes := []slurmapi.Node{
+		{
+			Name:          "worker-0",
+			States:        map[api.V0044NodeState]struct{}{api.V0044NodeStateMIXED: {}},
+			Partitions:    []string{"main", "debug"},
+			Tres:          "cpu=16,mem=64000M,gres/gpu=8",
+			TresUsed:      "cpu=8,mem=32000M,gres/gpu=4",
+			CPUs:          ptr.To(int32(16)),
+			AllocCPUs:     ptr.To(int32(8)),
+			AllocIdleCPUs: ptr.To(int32(8)),
+		},
+		{
+			Name:          "worker-1",
+			States:        map[api.V0044NodeState]struct{}{api.V0044NodeStateIDLE: {}, api.V0044NodeStateDRAIN: {}},
+			Partitions:    []string{"main"},
+			Tres:          "cpu=16,mem=64000M,gres/gpu=8",
+			CPUs:          ptr.To(int32(16)),
+			AllocCPUs:     ptr.To(int32(0)),
+			AllocIdleCPUs: ptr.To(int32(16)),
+		},
+		{
+			Name:       "worker-2",
+			States:     map[api.V0044NodeState]struct{}{api.V0044NodeStateIDLE: {}},
+			Partitions: []string{"unknown"},
+		},
+	}
+	jobs := []slurmapi.Job{
+		{ID: 1, State: string(api.V0044JobInfoJobStatePENDING), StateReason: "Resources", Partition: "main,debug"},
+		{ID: 2, State: string(api.V0044JobInfoJobStatePENDING), StateReason: "Priority", Partition: "main"},
+		{ID: 3, State: string(api.V0044JobInfoJobStateRUNNING), StateReason: "None", Partition: "main"},
+	}
+
+	var metrics []string
+	for metric := range collector.slurmPartitionMetrics(context.Background(), partitions, nodes, jobs) {
+		metrics = append(metrics, toPrometheusLikeString(t, metric))
+	}
+
+	assert.ElementsMatch(t, []string{
+		`GAUGE; slurm_partition_info{partition="main",qos="normal",state="UP"} 1`,
+		`GAUGE; slurm_partition_nodes_total{partition="main"} 2`,
+		`GAUGE; slurm_partition_nodes{partition="main",state="mixed"} 1`,
+		`GAUGE; slurm_partition_nodes{partition="main",state="unavailable"} 1`,
+		`GAUGE; slurm_partition_cpus_total{partition="main"} 32`,
+		`GAUGE; slurm_partition_cpus_allocated{partition="main"} 8`,
+		`GAUGE; slurm_partition_cpus_idle{partition="main"} 24`,
+		`GAUGE; slurm_partition_gpus_total{partition="main"} 16`,
+		`GAUGE; slurm_partition_gpus_allocated{partition="main"} 4`,
+		`GAUGE; slurm_partition_pending_jobs{partition="main",reason="Resources"} 1`,
+		`GAUGE; slurm_partition_pending_jobs{partition="main",reason="Priority"} 1`,
+		`GAUGE; slurm_partition_info{partition="debug",qos="",state="DRAIN"} 1`,
+		`GAUGE; slurm_partition_nodes_total{partition="debug"} 4`,
+		`GAUGE; slurm_partition_nodes{partition="debug",state="mixed"} 1`,
+		`GAUGE; slurm_partition_cpus_total{partition="debug"} 64`,
+		`GAUGE; slurm_partition_cpus_allocated{partition="debug"} 8`,
+		`GAUGE; slurm_partition_cpus_idle{partition="debug"} 8`,
+		`GAUGE; slurm_partition_gpus_total{partition="debug"} 8`,
+		`GAUGE; slurm_partition_gpus_allocated{partition="debug"} 4`,
+		`GAUGE; slurm_partition_pending_jobs{partition="debug",reason="Resources"} 1`,
+	}, metrics)
+}
@@ -0,0 +1,138 @@
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"time"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"nebius.ai/slurm-operator/internal/slurmapi"
+)
+
+func (c *MetricsCollector) listQOS(ctx context.Context) ([]slurmapi.QOS, error) {
+	logger := log.FromContext(ctx).WithName(ControllerName)
+
+	qosStart := time.Now()
+	qos, err := c.slurmAPIClient.ListQOS(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get qos from SLURM API: %w", err)
+	}
+	logger.Info("Fetched QOS", "count", len(qos), "elapsed_seconds", time.Since(qosStart).Seconds())
+
+	return qos, nil
+}
+
+func (c *MetricsCollector) refreshQOS(ctx context.Context, sequence uint64) (err error) {
+	start := time.Now()
+	defer func() {
+		c.recordCollectorRun(ctx, "qos", start, err)
+	}()
+
+	qos, err := c.listQOS(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+
+	previousState := c.state.Load()
+	if previousState == nil {
+		previousState = newMetricsCollectorState()
+	}
+	if sequence != 0 && sequence <= previousState.qosCollectionSequence {
+		return nil
+	}
+	newState := cloneMetricsCollectorState(previousState)
+	newState.qos = qos
+	if sequence != 0 {
+		newState.qosCollectionSequence = sequence
+	}
+	c.state.Store(newState)
+	return nil
+}
+
+// qosUsage aggregates jobs running or waiting under a single QOS.
+type qosUsage struct {
+	jobsByState map[string]int
+	cpusAlloc   float64
+	gpusAlloc   float64
+}
+
+// QOS limits come from slurmdbd through the qos collector, while QOS usage is derived from jobs.
+// Usage is therefore exported even on clusters where the qos collector is disabled.
+func (c *MetricsCollector) slurmQOSMetrics(
+	ctx context.Context,
+	qosList []slurmapi.QOS,
+	jobs []slurmapi.Job,
+) iter.Seq[prometheus.Metric] {
+	return func(yield func(prometheus.Metric) bool) {
+		logger := log.FromContext(ctx).WithName(ControllerName)
+
+		for _, qos := range qosList {
+			if qos.Priority != nil {
+				if !yield(prometheus.MustNewConstMetric(c.qosPriority, prometheus.GaugeValue, float64(*qos.Priority), qos.Name)) {
+					return
+				}
+			}
+			if qos.UsageFactor != nil {
+				if !yield(prometheus.MustNewConstMetric(c.qosUsageFactor, prometheus.GaugeValue, *qos.UsageFactor, qos.Name)) {
+					return
+				}
+			}
+			if qos.MaxJobsPerUser != nil {
+				if !yield(prometheus.MustNewConstMetric(c.qosMaxJobsPerUser, prometheus.GaugeValue, float64(*qos.MaxJobsPerUser), qos.Name)) {
+					return
+				}
+			}
+			for tres, limit := range qos.GrpTRES {
+				if !yield(prometheus.MustNewConstMetric(c.qosGrpTRESLimit, prometheus.GaugeValue, float64(limit), qos.Name, tres)) {
+					return
+				}
+			}
+		}
+
+		usage := make(map[string]*qosUsage)
+		for _, job := range jobs {
+			if job.QOS == "" || job.IsTerminalState() {
+				continue
+			}
+			u, ok := usage[job.QOS]
+			if !ok {
+				u = &qosUsage{jobsByState: make(map[string]int)}
+				usage[job.QOS] = u
+			}
+			u.jobsByState[job.State]++
+
+			if job.State != string(api.V0044JobInfoJobStateRUNNING) {
+				continue
+			}
+			if cpus, ok, _, _ := jobAllocatedResources(logger, job); ok {
+				u.cpusAlloc += cpus
+			}
+			if job.TresAllocated != "" {
+				if tres, err := slurmapi.ParseTrackableResources(job.TresAllocated); err == nil {
+					u.gpusAlloc += float64(tres.GPUCount)
+				}
+			}
+		}
+
+		for name, u := range usage {
+			for state, count := range u.jobsByState {
+				if !yield(prometheus.MustNewConstMetric(c.qosJobs, prometheus.GaugeValue, float64(count), name, state)) {
+					return
+				}
+			}
+			if !yield(prometheus.MustNewConstMetric(c.qosCPUsAllocated, prometheus.GaugeValue, u.cpusAlloc, name)) {
+				return
+			}
+			if !yield(prometheus.MustNewConstMetric(c.qosGPUsAllocated, prometheus.GaugeValue, u.gpusAlloc, name)) {
+				return
+			}
+		}
+	}
+}
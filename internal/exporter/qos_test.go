@@ -0,0 +1,72 @@
+package exporter
+
+import (
+	"context"
+	"testing"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	"nebius.ai/slurm-operator/internal/slurmapi"
+	"nebius.ai/slurm-operator/internal/slurmapi/fake"
+)
+
+func TestMetricsCollector_RefreshQOSDropsStaleSequence(t *testing.T) {
+	mockClient := &fake.MockClient{}
+	collector := newTestMetricsCollector(mockClient)
+
+	mockClient.EXPECT().ListQOS(mock.Anything).
+		Return([]slurmapi.QOS{{Name: "normal"}}, nil).Once()
+	mockClient.EXPECT().ListQOS(mock.Anything).
+		Return([]slurmapi.QOS{{Name: "stale"}}, nil).Once()
+
+	require.NoError(t, collector.refreshQOS(context.Background(), 2))
+	require.NoError(t, collector.refreshQOS(context.Background(), 1))
+
+	state := collector.state.Load()
+	require.Len(t, state.qos, 1)
+	assert.Equal(t, "normal", state.qos[0].Name)
+}
+
+func TestMetricsCollector_SlurmQOSMetrics(t *testing.T) {
+	collector := newTestMetricsCollector(&fake.MockClient{})
+
+	qos := []slurmapi.QOS{
+		{
+			Name:           "normal",
+			Priority:       ptr.To(int32(10)),
+			UsageFactor:    ptr.To(1.5),
+			MaxJobsPerUser: ptr.To(int32(4)),
+			GrpTRES:        map[string]int64{"gres/gpu": 16},
+		},
+		{
+			Name: "low",
+		},
+	}
+	jobs := []slurmapi.Job{
+		{ID: 1, QOS: "normal", State: string(api.V0044JobInfoJobStateRUNNING), TresAllocated: "cpu=16,mem=64000M,gres/gpu=8"},
+		{ID: 2, QOS: "normal", State: string(api.V0044JobInfoJobStateRUNNING), TresAllocated: "cpu=4,mem=1000M,gres/gpu=1"},
+		{ID: 3, QOS: "normal", State: string(api.V0044JobInfoJobStatePENDING), TresRequested: "cpu=8,mem=1000M"},
+		{ID: 4, QOS: "normal", State: string(api.V0044JobInfoJobStateCOMPLETED), TresAllocated: "cpu=8,mem=1000M"},
+		{ID: 5, State: string(api.V0044JobInfoJobStateRUNNING), TresAllocated: "cpu=8,mem=1000M"},
+	}
+
+	var metrics []string
+	for metric := range collector.slurmQOSMetrics(context.Background(), qos, jobs) {
+		metrics = append(metrics, toPrometheusLikeString(t, metric))
+	}
+
+	assert.ElementsMatch(t, []string{
+		`GAUGE; slurm_qos_priority{qos="normal"} 10`,
+		`GAUGE; slurm_qos_usage_factor{qos="normal"} 1.5`,
+		`GAUGE; slurm_qos_max_jobs_per_user{qos="normal"} 4`,
+		`GAUGE; slurm_qos_grp_tres_limit{qos="normal",tres="gres/gpu"} 16`,
+		`GAUGE; slurm_qos_jobs{job_state="RUNNING",qos="normal"} 2`,
+		`GAUGE; slurm_qos_jobs{job_state="PENDING",qos="normal"} 1`,
+		`GAUGE; slurm_qos_cpus_allocated{qos="normal"} 20`,
+		`GAUGE; slurm_qos_gpus_allocated{qos="normal"} 9`,
+	}, metrics)
+}
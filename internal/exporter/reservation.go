@@ -1,6 +1,18 @@
 package exporter
 
-import "unicode/utf8"
+import (
+	"context"
+	"fmt"
+	"iter"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"nebius.ai/slurm-operator/internal/slurmapi"
+)
 
 const maxReservationNameLength = 50
 
@@ -24,3 +36,112 @@ func trimReservationName(name string) string {
 
 	return name
 }
+
+func (c *MetricsCollector) listReservations(ctx context.Context) ([]slurmapi.Reservation, error) {
+	logger := log.FromContext(ctx).WithName(ControllerName)
+
+	reservationsStart := time.Now()
+	reservations, err := c.slurmAPIClient.ListReservations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get reservations from SLURM API: %w", err)
+	}
+	logger.Info("Fetched reservations", "count", len(reservations), "elapsed_seconds", time.Since(reservationsStart).Seconds())
+
+	return reservations, nil
+}
+
+func (c *MetricsCollector) refreshReservations(ctx context.Context, sequence uint64) (err error) {
+	start := time.Now()
+	defer func() {
+		c.recordCollectorRun(ctx, "reservations", start, err)
+	}()
+
+	reservations, err := c.listReservations(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+
+	previousState := c.state.Load()
+	if previousState == nil {
+		previousState = newMetricsCollectorState()
+	}
+	if sequence != 0 && sequence <= previousState.reservationsCollectionSequence {
+		return nil
+	}
+	newState := cloneMetricsCollectorState(previousState)
+	newState.reservations = reservations
+	if sequence != 0 {
+		newState.reservationsCollectionSequence = sequence
+	}
+	c.state.Store(newState)
+	return nil
+}
+
+func (c *MetricsCollector) slurmReservationMetrics(
+	ctx context.Context,
+	reservations []slurmapi.Reservation,
+	now time.Time,
+) iter.Seq[prometheus.Metric] {
+	return func(yield func(prometheus.Metric) bool) {
+		logger := log.FromContext(ctx).WithName(ControllerName)
+
+		for _, reservation := range reservations {
+			// The full name is used, as trimmed names of different reservations may collide into the same series.
+			name := reservation.Name
+
+			flags := make([]string, 0, len(reservation.Flags))
+			for _, flag := range reservation.Flags {
+				flags = append(flags, string(flag))
+			}
+			if !yield(prometheus.MustNewConstMetric(c.reservationInfo, prometheus.GaugeValue, 1,
+				name,
+				reservation.Partition,
+				strings.Join(flags, ","),
+				strings.Join(reservation.Users, ","),
+				strings.Join(reservation.Accounts, ","),
+			)) {
+				return
+			}
+
+			if reservation.NodeCount != nil {
+				if !yield(prometheus.MustNewConstMetric(c.reservationNodes, prometheus.GaugeValue, float64(*reservation.NodeCount), name)) {
+					return
+				}
+			}
+			if reservation.CoreCount != nil {
+				if !yield(prometheus.MustNewConstMetric(c.reservationCores, prometheus.GaugeValue, float64(*reservation.CoreCount), name)) {
+					return
+				}
+			}
+			if !yield(prometheus.MustNewConstMetric(c.reservationStartTime, prometheus.GaugeValue, float64(reservation.StartTime.Unix()), name)) {
+				return
+			}
+			if !reservation.EndTime.IsZero() {
+				if !yield(prometheus.MustNewConstMetric(c.reservationEndTime, prometheus.GaugeValue, float64(reservation.EndTime.Unix()), name)) {
+					return
+				}
+			}
+			var active float64
+			if reservation.IsActive(now) {
+				active = 1
+			}
+			if !yield(prometheus.MustNewConstMetric(c.reservationActive, prometheus.GaugeValue, active, name)) {
+				return
+			}
+
+			nodeList, err := reservation.GetNodeList()
+			if err != nil {
+				logger.Error(err, "Failed to parse node list for reservation", "reservation_name", reservation.Name, "nodes", reservation.NodeList)
+				continue
+			}
+			for _, nodeName := range nodeList {
+				if !yield(prometheus.MustNewConstMetric(c.reservationNode, prometheus.GaugeValue, 1, name, nodeName)) {
+					return
+				}
+			}
+		}
+	}
+}
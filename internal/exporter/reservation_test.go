@@ -1,10 +1,19 @@
 package exporter
 
 import (
+	"context"
 	"strings"
 	"testing"
+	"time"
 
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	"nebius.ai/slurm-operator/internal/slurmapi"
+	"nebius.ai/slurm-operator/internal/slurmapi/fake"
 )
 
 func TestTrimReservationName(t *testing.T) {
@@ -22,3 +31,73 @@ func TestTrimReservationName(t *testing.T) {
 		require.Equal(t, "", trimReservationName(""))
 	})
 }
+
+func TestMetricsCollector_RefreshReservationsDropsStaleSequence(t *testing.T) {
+	mockClient := &fake.MockClient{}
+	collector := newTestMetricsCollector(mockClient)
+
+	mockClient.EXPECT().ListReservations(mock.Anything).
+		Return([]slurmapi.Reservation{{Name: "maintenance"}}, nil).Once()
+	mockClient.EXPECT().ListReservations(mock.Anything).
+		Return([]slurmapi.Reservation{{Name: "stale"}}, nil).Once()
+
+	require.NoError(t, collector.refreshReservations(context.Background(), 2))
+	require.NoError(t, collector.refreshReservations(context.Background(), 1))
+
+	state := collector.state.Load()
+	require.Len(t, state.reservations, 1)
+	assert.Equal(t, "maintenance", state.reservations[0].Name)
+}
+
+func TestMetricsCollector_SlurmReservationMetrics(t *testing.T) {
+	collector := newTestMetricsCollector(&fake.MockClient{})
+	longReservationPrefix := strings.Repeat("r", maxReservationNameLength)
+
+	now := time.Unix(1_700_000_000, 0)
+	reservations := []slurmapi.Reservation{
+		{
+			Name:      "maintenance",
+			NodeList:  "worker-[0-1]",
+			NodeCount: ptr.To(int32(2)),
+			CoreCount: ptr.To(int32(32)),
+			StartTime: now.Add(-time.Hour),
+			EndTime:   now.Add(time.Hour),
+			Flags:     []api.V0044ReservationInfoFlags{api.V0044ReservationInfoFlagsMAINT, api.V0044ReservationInfoFlagsIGNOREJOBS},
+			Users:     []string{"root"},
+			Partition: "main",
+		},
+		{
+			Name:      "future",
+			StartTime: now.Add(time.Hour),
+			Accounts:  []string{"team-a", "team-b"},
+		},
+		// Reservations whose names only differ past the label length limit of slurm_node_info are kept apart.
+		{Name: longReservationPrefix + "-a", StartTime: now.Add(time.Hour)},
+		{Name: longReservationPrefix + "-b", StartTime: now.Add(time.Hour)},
+	}
+
+	var metrics []string
+	for metric := range collector.slurmReservationMetrics(context.Background(), reservations, now) {
+		metrics = append(metrics, toPrometheusLikeString(t, metric))
+	}
+
+	assert.ElementsMatch(t, []string{
+		`GAUGE; slurm_reservation_info{accounts="",flags="MAINT,IGNORE_JOBS",partition="main",reservation_name="maintenance",users="root"} 1`,
+		`GAUGE; slurm_reservation_nodes{reservation_name="maintenance"} 2`,
+		`GAUGE; slurm_reservation_cores{reservation_name="maintenance"} 32`,
+		`GAUGE; slurm_reservation_start_time_seconds{reservation_name="maintenance"} 1.6999964e+09`,
+		`GAUGE; slurm_reservation_end_time_seconds{reservation_name="maintenance"} 1.7000036e+09`,
+		`GAUGE; slurm_reservation_active{reservation_name="maintenance"} 1`,
+		`GAUGE; slurm_reservation_node{node_name="worker-0",reservation_name="maintenance"} 1`,
+		`GAUGE; slurm_reservation_node{node_name="worker-1",reservation_name="maintenance"} 1`,
+		`GAUGE; slurm_reservation_info{accounts="team-a,team-b",flags="",partition="",reservation_name="future",users=""} 1`,
+		`GAUGE; slurm_reservation_start_time_seconds{reservation_name="future"} 1.7000036e+09`,
+		`GAUGE; slurm_reservation_active{reservation_name="future"} 0`,
+		`GAUGE; slurm_reservation_info{accounts="",flags="",partition="",reservation_name="` + longReservationPrefix + `-a",users=""} 1`,
+		`GAUGE; slurm_reservation_start_time_seconds{reservation_name="` + longReservationPrefix + `-a"} 1.7000036e+09`,
+		`GAUGE; slurm_reservation_active{reservation_name="` + longReservationPrefix + `-a"} 0`,
+		`GAUGE; slurm_reservation_info{accounts="",flags="",partition="",reservation_name="` + longReservationPrefix + `-b",users=""} 1`,
+		`GAUGE; slurm_reservation_start_time_seconds{reservation_name="` + longReservationPrefix + `-b"} 1.7000036e+09`,
+		`GAUGE; slurm_reservation_active{reservation_name="` + longReservationPrefix + `-b"} 0`,
+	}, metrics)
+}
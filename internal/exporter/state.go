@@ -10,17 +10,26 @@ import (
 
 // metricsCollectorState holds the raw data collected from SLURM APIs
 type metricsCollectorState struct {
-	lastGPUSecondsUpdate         time.Time
-	nodes                        []slurmapi.Node
-	nodesCollectionSequence      uint64
-	jobs                         []slurmapi.Job
-	jobsCollectionSequence       uint64
-	diag                         *api.V0044OpenapiDiagResp
-	diagCollectionSequence       uint64
-	nodeTopologies               map[string]NodeTopology
-	topologyCollectionSequence   uint64
-	nodeUnavailabilityStartTimes map[string]time.Time
-	nodeDrainingStartTimes       map[string]time.Time
+	lastGPUSecondsUpdate           time.Time
+	nodes                          []slurmapi.Node
+	nodesCollectionSequence        uint64
+	jobs                           []slurmapi.Job
+	jobsCollectionSequence         uint64
+	diag                           *api.V0044OpenapiDiagResp
+	diagCollectionSequence         uint64
+	nodeTopologies                 map[string]NodeTopology
+	topologyCollectionSequence     uint64
+	partitions                     []slurmapi.Partition
+	partitionsCollectionSequence   uint64
+	reservations                   []slurmapi.Reservation
+	reservationsCollectionSequence uint64
+	qos                            []slurmapi.QOS
+	qosCollectionSequence          uint64
+	shares                         []slurmapi.Share
+	associations                   []slurmapi.Association
+	fairshareCollectionSequence    uint64
+	nodeUnavailabilityStartTimes   map[string]time.Time
+	nodeDrainingStartTimes         map[string]time.Time
 }
 
 // newMetricsCollectorState initializes a new metrics collector state
@@ -11,5 +11,6 @@ var (
 
 const (
 	SigningKeyLength       = 32
+	RSASigningKeyBits      = 2048
 	DefaultMaxCacheEntries = 10
 )
@@ -0,0 +1,117 @@
+package jwt
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+)
+
+// JSONWebKey is an RSA public key in a JWKS, as described in RFC 7517.
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+	KeyID     string `json:"kid"`
+	Modulus   string `json:"n"`
+	Exponent  string `json:"e"`
+}
+
+// JSONWebKeySet is a set of public keys verifying the JWTs, as described in RFC 7517.
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// GenerateRSASigningKey generates a PEM-encoded RSA private key signing RS256 JWTs.
+func GenerateRSASigningKey() ([]byte, error) {
+	key, err := rsa.GenerateKey(rand.Reader, RSASigningKeyBits)
+	if err != nil {
+		return nil, fmt.Errorf("generating RSA key: %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	}), nil
+}
+
+// ParseRSASigningKey parses a PEM-encoded RSA private key generated by GenerateRSASigningKey.
+func ParseRSASigningKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+	key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing RSA key: %w", err)
+	}
+	return key, nil
+}
+
+// RSAKeyID returns the JWK thumbprint of the public key, as described in RFC 7638.
+// It's used as the ID of the key in the JWKS and in the header of the JWTs it signs.
+func RSAKeyID(key *rsa.PublicKey) string {
+	// The thumbprint is the hash of the required members of the JWK in lexicographic order, without whitespaces.
+	thumbprintInput := fmt.Sprintf(`{"e":%q,"kty":"RSA","n":%q}`, encodeExponent(key.E), encodeBigInt(key.N))
+	sum := sha256.Sum256([]byte(thumbprintInput))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// BuildJWKS builds the JWKS of the public keys of the signing keys, sorted by their IDs.
+func BuildJWKS(signingKeys map[string][]byte) ([]byte, error) {
+	res := JSONWebKeySet{Keys: []JSONWebKey{}}
+	for keyID, data := range signingKeys {
+		key, err := ParseRSASigningKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("parsing signing key %s: %w", keyID, err)
+		}
+		res.Keys = append(res.Keys, JSONWebKey{
+			KeyType:   "RSA",
+			Algorithm: "RS256",
+			Use:       "sig",
+			KeyID:     keyID,
+			Modulus:   encodeBigInt(key.N),
+			Exponent:  encodeExponent(key.E),
+		})
+	}
+	slices.SortFunc(res.Keys, func(a, b JSONWebKey) int {
+		return strings.Compare(a.KeyID, b.KeyID)
+	})
+
+	return json.Marshal(res)
+}
+
+// MergeJWKS merges the keys of the JWK sets into one, as Slurm reads only one JWKS.
+// The keys are kept as is, so that the ones of any type and algorithm supported by Slurm can be merged.
+func MergeJWKS(sets ...[]byte) ([]byte, error) {
+	res := struct {
+		Keys []json.RawMessage `json:"keys"`
+	}{Keys: []json.RawMessage{}}
+	for i, data := range sets {
+		set := struct {
+			Keys []json.RawMessage `json:"keys"`
+		}{}
+		if err := json.Unmarshal(data, &set); err != nil {
+			return nil, fmt.Errorf("parsing JWKS %d: %w", i, err)
+		}
+		res.Keys = append(res.Keys, set.Keys...)
+	}
+
+	return json.Marshal(res)
+}
+
+func encodeBigInt(n *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(n.Bytes())
+}
+
+func encodeExponent(e int) string {
+	return encodeBigInt(big.NewInt(int64(e)))
+}
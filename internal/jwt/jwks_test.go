@@ -0,0 +1,189 @@
+package jwt
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	jwtImpl "github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/naming"
+)
+
+func generateTestSigningKey(t *testing.T) (string, []byte) {
+	t.Helper()
+	data, err := GenerateRSASigningKey()
+	require.NoError(t, err)
+	key, err := ParseRSASigningKey(data)
+	require.NoError(t, err)
+	return RSAKeyID(&key.PublicKey), data
+}
+
+func TestBuildJWKS(t *testing.T) {
+	keyID1, key1 := generateTestSigningKey(t)
+	keyID2, key2 := generateTestSigningKey(t)
+
+	data, err := BuildJWKS(map[string][]byte{keyID1: key1, keyID2: key2})
+	require.NoError(t, err)
+
+	jwks := JSONWebKeySet{}
+	require.NoError(t, json.Unmarshal(data, &jwks))
+	require.Len(t, jwks.Keys, 2)
+	assert.Less(t, jwks.Keys[0].KeyID, jwks.Keys[1].KeyID, "keys are sorted by ID")
+	for _, key := range jwks.Keys {
+		assert.Equal(t, "RSA", key.KeyType)
+		assert.Equal(t, "RS256", key.Algorithm)
+		assert.Equal(t, "AQAB", key.Exponent)
+		assert.NotEmpty(t, key.Modulus)
+	}
+
+	data, err = BuildJWKS(nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"keys":[]}`, string(data))
+
+	_, err = BuildJWKS(map[string][]byte{"broken": []byte("not a key")})
+	assert.Error(t, err)
+}
+
+func TestMergeJWKS(t *testing.T) {
+	keyID, key := generateTestSigningKey(t)
+	operatorJWKS, err := BuildJWKS(map[string][]byte{keyID: key})
+	require.NoError(t, err)
+	externalJWKS := []byte(`{"keys":[{"kty":"EC","crv":"P-256","kid":"sso","x":"x","y":"y"}]}`)
+
+	data, err := MergeJWKS(operatorJWKS, externalJWKS)
+	require.NoError(t, err)
+
+	merged := struct {
+		Keys []map[string]any `json:"keys"`
+	}{}
+	require.NoError(t, json.Unmarshal(data, &merged))
+	require.Len(t, merged.Keys, 2)
+	assert.Equal(t, keyID, merged.Keys[0]["kid"])
+	assert.Equal(t, map[string]any{"kty": "EC", "crv": "P-256", "kid": "sso", "x": "x", "y": "y"}, merged.Keys[1],
+		"external keys are kept as is")
+
+	_, err = MergeJWKS(operatorJWKS, []byte("not a JWKS"))
+	assert.Error(t, err)
+}
+
+func TestToken_Issue_ActiveSigningKey(t *testing.T) {
+	cluster := types.NamespacedName{Namespace: "default", Name: "slurm"}
+	keyID, keyData := generateTestSigningKey(t)
+	key, err := ParseRSASigningKey(keyData)
+	require.NoError(t, err)
+
+	signingKeysSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cluster.Namespace,
+			Name:      naming.BuildSecretJWTSigningKeysName(cluster.Name),
+		},
+		Data: map[string][]byte{keyID: keyData},
+	}
+	restSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cluster.Namespace,
+			Name:      naming.BuildSecretSlurmRESTSecretName(cluster.Name),
+		},
+		Data: map[string][]byte{consts.SecretRESTJWTKeyFileName: []byte("hs256-key")},
+	}
+	c := fake.NewClientBuilder().WithObjects(signingKeysSecret, restSecret).Build()
+	registry := NewTokenRegistry().Build()
+
+	// The signing key isn't active until it's published
+	token, err := NewToken(c).For(cluster, "root").WithRegistry(registry).Issue(context.Background())
+	require.NoError(t, err)
+	_, err = jwtImpl.Parse(token, func(*jwtImpl.Token) (any, error) { return []byte("hs256-key"), nil },
+		jwtImpl.WithValidMethods([]string{jwtImpl.SigningMethodHS256.Alg()}))
+	require.NoError(t, err)
+
+	signingKeysSecret.Annotations = map[string]string{consts.AnnotationActiveSigningKeyID: keyID}
+	require.NoError(t, c.Update(context.Background(), signingKeysSecret))
+
+	rotatedToken, err := NewToken(c).For(cluster, "root").WithRegistry(registry).Issue(context.Background())
+	require.NoError(t, err)
+	assert.NotEqual(t, token, rotatedToken, "cached token of the previous key isn't used")
+
+	parsedToken, err := jwtImpl.Parse(rotatedToken, func(*jwtImpl.Token) (any, error) { return &key.PublicKey, nil },
+		jwtImpl.WithValidMethods([]string{jwtImpl.SigningMethodRS256.Alg()}))
+	require.NoError(t, err)
+	assert.Equal(t, keyID, parsedToken.Header["kid"])
+}
+
+func TestToken_Issue_UsernameClaim(t *testing.T) {
+	cluster := types.NamespacedName{Namespace: "default", Name: "slurm"}
+	keyID, keyData := generateTestSigningKey(t)
+	key, err := ParseRSASigningKey(keyData)
+	require.NoError(t, err)
+
+	signingKeysSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cluster.Namespace,
+			Name:      naming.BuildSecretJWTSigningKeysName(cluster.Name),
+			Annotations: map[string]string{
+				consts.AnnotationActiveSigningKeyID: keyID,
+				consts.AnnotationJWTUsernameClaim:   "email",
+			},
+		},
+		Data: map[string][]byte{keyID: keyData},
+	}
+	c := fake.NewClientBuilder().WithObjects(signingKeysSecret).Build()
+	registry := NewTokenRegistry().Build()
+
+	token, err := NewToken(c).For(cluster, "root").WithRegistry(registry).Issue(context.Background())
+	require.NoError(t, err)
+
+	claims := jwtImpl.MapClaims{}
+	_, err = jwtImpl.ParseWithClaims(token, claims, func(*jwtImpl.Token) (any, error) { return &key.PublicKey, nil },
+		jwtImpl.WithValidMethods([]string{jwtImpl.SigningMethodRS256.Alg()}))
+	require.NoError(t, err)
+	assert.Equal(t, "root", claims["email"])
+	assert.Equal(t, "root", claims["sun"])
+	assert.Equal(t, "soperator", claims["iss"])
+
+	signingKeysSecret.Annotations[consts.AnnotationJWTUsernameClaim] = "preferred_username"
+	require.NoError(t, c.Update(context.Background(), signingKeysSecret))
+
+	reclaimedToken, err := NewToken(c).For(cluster, "root").WithRegistry(registry).Issue(context.Background())
+	require.NoError(t, err)
+	assert.NotEqual(t, token, reclaimedToken, "cached token of the previous claim isn't used")
+}
+
+func TestToken_Issue_UsernameClaim_HS256(t *testing.T) {
+	cluster := types.NamespacedName{Namespace: "default", Name: "slurm"}
+	c := fake.NewClientBuilder().WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cluster.Namespace,
+				Name:      naming.BuildSecretSlurmRESTSecretName(cluster.Name),
+			},
+			Data: map[string][]byte{consts.SecretRESTJWTKeyFileName: []byte("hs256-key")},
+		},
+		// Until the first RS256 key is published, the tokens are signed by the HS256 key.
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   cluster.Namespace,
+				Name:        naming.BuildSecretJWTSigningKeysName(cluster.Name),
+				Annotations: map[string]string{consts.AnnotationJWTUsernameClaim: "email"},
+			},
+		},
+	).Build()
+
+	token, err := NewToken(c).For(cluster, "root").Issue(context.Background())
+	require.NoError(t, err)
+
+	// Slurm takes the username from the custom claim of every JWT, so it must be in the HS256 ones as well.
+	claims := jwtImpl.MapClaims{}
+	_, err = jwtImpl.ParseWithClaims(token, claims, func(*jwtImpl.Token) (any, error) { return []byte("hs256-key"), nil },
+		jwtImpl.WithValidMethods([]string{jwtImpl.SigningMethodHS256.Alg()}))
+	require.NoError(t, err)
+	assert.Equal(t, "root", claims["email"])
+	assert.Equal(t, "root", claims["sun"])
+}
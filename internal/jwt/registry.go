@@ -13,6 +13,7 @@ type TokenRegistry struct {
 	expirationPeriod time.Duration
 	evictionPeriod   time.Duration
 	maxEntries       int32
+	renewBefore      time.Duration
 }
 
 // NewTokenRegistry creates a new TokenRegistry with default configuration values.
@@ -42,6 +43,13 @@ func (r *TokenRegistry) WithMaxEntries(maxEntries int32) *TokenRegistry {
 	return r
 }
 
+// WithRenewBefore sets how long before their expiration the issued tokens are evicted from the registry, so that they
+// are reissued while the cached ones are still valid.
+func (r *TokenRegistry) WithRenewBefore(renewBefore time.Duration) *TokenRegistry {
+	r.renewBefore = renewBefore
+	return r
+}
+
 // Build initializes the token registry with the specified configuration.
 func (r *TokenRegistry) Build() *TokenRegistry {
 	r.cache = cache.NewLRU(
@@ -2,6 +2,7 @@ package jwt
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/golang-jwt/jwt/v5"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -53,6 +55,29 @@ type TokenClaims struct {
 	SlurmUsername string `json:"sun,omitempty"`
 }
 
+// usernameClaimTokenClaims are TokenClaims with the Slurm username put into a custom claim as well.
+type usernameClaimTokenClaims struct {
+	TokenClaims
+
+	usernameClaim string
+}
+
+// MarshalJSON implements json.Marshaler interface.
+func (c usernameClaimTokenClaims) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(c.TokenClaims)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := map[string]any{}
+	if err = json.Unmarshal(data, &claims); err != nil {
+		return nil, err
+	}
+	claims[c.usernameClaim] = c.SlurmUsername
+
+	return json.Marshal(claims)
+}
+
 // Token is a builder for issuing JWT tokens for Slurm clusters.
 type Token struct {
 	client client.Client
@@ -144,8 +169,22 @@ func (t *Token) Issue(ctx context.Context) (string, error) {
 		return "", fmt.Errorf("failed to issue token: %w", err)
 	}
 
+	signingKey, err := t.getSigningKey(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get signing key: %w", err)
+	}
+
+	// Tokens are cached per signing key, so that new tokens are issued as soon as the signing key is rotated.
+	registryKey := t.meta.String()
+	if signingKey.id != "" {
+		registryKey = fmt.Sprintf("%s@%s", registryKey, signingKey.id)
+	}
+	if signingKey.usernameClaim != "" {
+		registryKey = fmt.Sprintf("%s#%s", registryKey, signingKey.usernameClaim)
+	}
+
 	if t.registry != nil {
-		token, found := t.registry.Get(t.meta.String())
+		token, found := t.registry.Get(registryKey)
 		if found {
 			return token, nil
 		}
@@ -166,30 +205,69 @@ func (t *Token) Issue(ctx context.Context) (string, error) {
 		claims.ExpiresAt = jwt.NewNumericDate(issuedAt.Add(t.lifetime))
 	}
 
+	var tokenClaims jwt.Claims = claims
+	if signingKey.usernameClaim != "" {
+		tokenClaims = usernameClaimTokenClaims{TokenClaims: claims, usernameClaim: signingKey.usernameClaim}
+	}
+
 	token := jwt.NewWithClaims(
-		jwt.SigningMethodHS256,
-		claims,
+		signingKey.method,
+		tokenClaims,
 	)
-
-	signingKey, err := t.getSigningKey(ctx)
-	if err != nil {
-		return "", fmt.Errorf("failed to get signing key: %w", err)
+	if signingKey.id != "" {
+		token.Header["kid"] = signingKey.id
 	}
 
-	signedToken, err := token.SignedString(signingKey)
+	signedToken, err := token.SignedString(signingKey.key)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign token: %w", err)
 	}
 
-	if t.registry != nil {
-		t.registry.RegisterWithLifetime(t.meta.String(), signedToken, t.lifetime)
+	if t.registry != nil && t.lifetime > t.registry.renewBefore {
+		t.registry.RegisterWithLifetime(registryKey, signedToken, t.lifetime-t.registry.renewBefore)
 	}
 
 	return signedToken, nil
 }
 
+// signingKey is the key the token is signed by.
+type signingKey struct {
+	method jwt.SigningMethod
+	key    any
+	// id is the ID of the key in the JWKS. It's empty for the REST JWT key.
+	id string
+	// usernameClaim is the custom claim Slurm takes the username from. It's empty if Slurm's default one is used.
+	usernameClaim string
+}
+
 // getSigningKey retrieves the signing key from a Kubernetes secret.
-func (t *Token) getSigningKey(ctx context.Context) ([]byte, error) {
+// The active key of the JWT signing keys Secret is used if there is one, the REST JWT key otherwise.
+// The username claim configured in the JWT signing keys Secret applies to both of them, as Slurm checks it in all JWTs.
+func (t *Token) getSigningKey(ctx context.Context) (signingKey, error) {
+	signingKeysSecret := corev1.Secret{}
+	err := t.client.Get(
+		ctx,
+		types.NamespacedName{
+			Namespace: t.meta.cluster.Namespace,
+			Name:      naming.BuildSecretJWTSigningKeysName(t.meta.cluster.Name),
+		},
+		&signingKeysSecret,
+	)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return signingKey{}, fmt.Errorf("failed to get signing keys secret: %w", err)
+	}
+	usernameClaim := signingKeysSecret.Annotations[consts.AnnotationJWTUsernameClaim]
+	if usernameClaim == consts.JWTDefaultUsernameClaim {
+		usernameClaim = ""
+	}
+	if keyID := signingKeysSecret.Annotations[consts.AnnotationActiveSigningKeyID]; err == nil && keyID != "" {
+		key, err := ParseRSASigningKey(signingKeysSecret.Data[keyID])
+		if err != nil {
+			return signingKey{}, fmt.Errorf("failed to parse active signing key %s: %w", keyID, err)
+		}
+		return signingKey{method: jwt.SigningMethodRS256, key: key, id: keyID, usernameClaim: usernameClaim}, nil
+	}
+
 	signingKeySecret := corev1.Secret{}
 	if err := t.client.Get(
 		ctx,
@@ -199,8 +277,12 @@ func (t *Token) getSigningKey(ctx context.Context) ([]byte, error) {
 		},
 		&signingKeySecret,
 	); err != nil {
-		return nil, fmt.Errorf("failed to get signing secret: %w", err)
+		return signingKey{}, fmt.Errorf("failed to get signing secret: %w", err)
 	}
 
-	return signingKeySecret.Data[consts.SecretRESTJWTKeyFileName], nil
+	return signingKey{
+		method:        jwt.SigningMethodHS256,
+		key:           signingKeySecret.Data[consts.SecretRESTJWTKeyFileName],
+		usernameClaim: usernameClaim,
+	}, nil
 }
@@ -124,6 +124,35 @@ func TestToken_Issue_WithRegistry(t *testing.T) {
 	assert.Equal(t, token, cachedToken, "Cached token should match the previously issued token")
 }
 
+func TestToken_Issue_WithRegistryRenewal(t *testing.T) {
+	client := fake.NewClientBuilder().WithObjects(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: clusterNamespace,
+			Name:      secretName,
+		},
+		Data: map[string][]byte{
+			consts.SecretRESTJWTKeyFileName: signingKey,
+		},
+	}).Build()
+	registry := jwt.NewTokenRegistry().WithRenewBefore(time.Hour).Build()
+
+	token, err := jwt.NewToken(client).
+		For(cluster, username).
+		WithLifetime(time.Hour).
+		WithRegistry(registry).
+		Issue(context.TODO())
+	assert.NoError(t, err)
+
+	renewedToken, err := jwt.NewToken(client).
+		For(cluster, username).
+		WithLifetime(time.Hour).
+		WithRegistry(registry).
+		Issue(context.TODO())
+	assert.NoError(t, err)
+
+	assert.NotEqual(t, token, renewedToken, "Token due to renewal shouldn't be cached")
+}
+
 func TestToken_Issue_SecretNotFound(t *testing.T) {
 	client := fake.NewClientBuilder().Build()
 
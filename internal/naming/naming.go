@@ -65,6 +65,16 @@ func BuildNodeSetServiceName(clusterName string, nodeSetName string) string {
 	}.String()
 }
 
+// BuildControllerReplicaServiceName builds the name of the Service selecting the single controller pod with the ordinal.
+func BuildControllerReplicaServiceName(clusterName string, ordinal int32) string {
+	return namedEntity{
+		clusterName:        clusterName,
+		componentType:      &consts.ComponentTypeController,
+		componentSpecifier: fmt.Sprint(ordinal),
+		entity:             entityService,
+	}.String()
+}
+
 func BuildServiceFQDN(svcName, namespace string) string {
 	return fmt.Sprintf("%s.%s.svc.cluster.local", svcName, namespace)
 }
@@ -284,6 +294,20 @@ func BuildSecretSlurmRESTSecretName(clusterName string) string {
 	}.String()
 }
 
+func BuildSecretJWTSigningKeysName(clusterName string) string {
+	return namedEntity{
+		clusterName: clusterName,
+		entity:      consts.SecretJWTSigningKeys,
+	}.String()
+}
+
+func BuildSecretExternalJWKSName(clusterName string) string {
+	return namedEntity{
+		clusterName: clusterName,
+		entity:      consts.SecretExternalJWKS,
+	}.String()
+}
+
 func BuildMariaDbName(clusterName string) string {
 	return namedEntity{
 		clusterName: clusterName,
@@ -0,0 +1,249 @@
+package rebooter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/utils/timewindow"
+)
+
+//+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;create;update
+
+// rebootLockTTL is how long a reboot slot is held without being renewed.
+// A rebooter renews its slot on every reconcile until the node is back, so only the slots of nodes that never came
+// back from a reboot go stale.
+const rebootLockTTL = 30 * time.Minute
+
+// rebootLockEntry is a reboot slot held by a node in the lock ConfigMap.
+type rebootLockEntry struct {
+	// NodeSet is the NodeSet of the worker the node ran when the slot was taken.
+	// The worker is evicted during the drain, so it's kept for the whole reboot.
+	NodeSet   string      `json:"nodeSet,omitempty"`
+	RenewTime metav1.Time `json:"renewTime"`
+}
+
+// rebootCoordinator limits the reboots rebooters on different nodes start.
+// The slots are kept in a ConfigMap, one key per node, and updated with optimistic concurrency.
+type rebootCoordinator struct {
+	client    client.Client
+	apiReader client.Reader
+	namespace string
+
+	maxConcurrentReboots           int32
+	maxConcurrentRebootsPerNodeSet int32
+	maintenanceWindows             []timewindow.Window
+
+	now func() time.Time
+	// mayHoldSlot is whether the node may hold a slot, e.g. taken before the rebooter restarted.
+	mayHoldSlot bool
+}
+
+// newRebootCoordinator returns nil if reboots don't need to be coordinated.
+func newRebootCoordinator(c client.Client, apiReader client.Reader, params RebooterParams) *rebootCoordinator {
+	if params.MaxConcurrentReboots == 0 && params.MaxConcurrentRebootsPerNodeSet == 0 && len(params.MaintenanceWindows) == 0 {
+		return nil
+	}
+	return &rebootCoordinator{
+		client:                         c,
+		apiReader:                      apiReader,
+		namespace:                      params.Namespace,
+		maxConcurrentReboots:           params.MaxConcurrentReboots,
+		maxConcurrentRebootsPerNodeSet: params.MaxConcurrentRebootsPerNodeSet,
+		maintenanceWindows:             params.MaintenanceWindows,
+		now:                            time.Now,
+		mayHoldSlot:                    true,
+	}
+}
+
+// ParseMaintenanceWindows parses the maintenance windows rendered into the rebooter environment.
+func ParseMaintenanceWindows(value string) ([]timewindow.Window, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var maintenanceWindows []slurmv1alpha1.MaintenanceWindow
+	if err := json.Unmarshal([]byte(value), &maintenanceWindows); err != nil {
+		return nil, fmt.Errorf("unmarshalling maintenance windows: %w", err)
+	}
+
+	res := make([]timewindow.Window, 0, len(maintenanceWindows))
+	for _, maintenanceWindow := range maintenanceWindows {
+		window := timewindow.Window{
+			Start:    maintenanceWindow.Start,
+			End:      maintenanceWindow.End,
+			TimeZone: maintenanceWindow.TimeZone,
+		}
+		for _, day := range maintenanceWindow.Days {
+			window.Days = append(window.Days, string(day))
+		}
+		if _, _, err := window.Evaluate(time.Now()); err != nil {
+			return nil, fmt.Errorf("invalid maintenance window %s-%s: %w", window.Start, window.End, err)
+		}
+		res = append(res, window)
+	}
+	return res, nil
+}
+
+// acquire takes or renews the reboot slot of the node and reports whether the node may reboot.
+// A new slot is only taken within a maintenance window and below the concurrency limits, while a held slot is kept
+// until it's released.
+func (c *rebootCoordinator) acquire(ctx context.Context, nodeName, nodeSet string) (bool, error) {
+	logger := log.FromContext(ctx).WithName("rebootCoordinator").WithValues("nodeName", nodeName)
+
+	acquired := false
+	err := c.updateLock(ctx, func(entries map[string]rebootLockEntry, now time.Time) (bool, error) {
+		entry, held := entries[nodeName]
+		if !held {
+			reason, err := c.blockReason(now, nodeSet, entries)
+			if err != nil {
+				return false, err
+			}
+			if reason != "" {
+				logger.Info("Postponing reboot", "reason", reason)
+				acquired = false
+				return false, nil
+			}
+			entry = rebootLockEntry{NodeSet: nodeSet}
+			logger.Info("Acquired reboot slot", "nodeSet", nodeSet)
+		}
+		entry.RenewTime = metav1.NewTime(now)
+		entries[nodeName] = entry
+		acquired = true
+		return true, nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("acquiring reboot slot: %w", err)
+	}
+	if acquired {
+		c.mayHoldSlot = true
+	}
+	return acquired, nil
+}
+
+// release frees the reboot slot of the node, if held.
+func (c *rebootCoordinator) release(ctx context.Context, nodeName string) error {
+	if !c.mayHoldSlot {
+		return nil
+	}
+	err := c.updateLock(ctx, func(entries map[string]rebootLockEntry, _ time.Time) (bool, error) {
+		if _, held := entries[nodeName]; !held {
+			return false, nil
+		}
+		delete(entries, nodeName)
+		log.FromContext(ctx).WithName("rebootCoordinator").Info("Released reboot slot", "nodeName", nodeName)
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("releasing reboot slot: %w", err)
+	}
+	c.mayHoldSlot = false
+	return nil
+}
+
+// blockReason returns why a new reboot can't start now, or an empty string if it can.
+func (c *rebootCoordinator) blockReason(now time.Time, nodeSet string, entries map[string]rebootLockEntry) (string, error) {
+	if len(c.maintenanceWindows) > 0 {
+		inWindow := false
+		for _, window := range c.maintenanceWindows {
+			active, _, err := window.Evaluate(now)
+			if err != nil {
+				return "", fmt.Errorf("evaluating maintenance window: %w", err)
+			}
+			if active {
+				inWindow = true
+				break
+			}
+		}
+		if !inWindow {
+			return "outside of maintenance windows", nil
+		}
+	}
+
+	if c.maxConcurrentReboots > 0 && len(entries) >= int(c.maxConcurrentReboots) {
+		return fmt.Sprintf("%d nodes are already rebooting", len(entries)), nil
+	}
+
+	if c.maxConcurrentRebootsPerNodeSet > 0 && nodeSet != "" {
+		rebooting := 0
+		for _, entry := range entries {
+			if entry.NodeSet == nodeSet {
+				rebooting++
+			}
+		}
+		if rebooting >= int(c.maxConcurrentRebootsPerNodeSet) {
+			return fmt.Sprintf("%d nodes of NodeSet %s are already rebooting", rebooting, nodeSet), nil
+		}
+	}
+
+	return "", nil
+}
+
+// updateLock applies mutate to the live slots, with the stale ones dropped, and writes them back if mutate reports
+// a change.
+// The lock is read through the API reader, so concurrent updates of other rebooters are retried on conflict.
+func (c *rebootCoordinator) updateLock(
+	ctx context.Context,
+	mutate func(entries map[string]rebootLockEntry, now time.Time) (bool, error),
+) error {
+	return retry.OnError(retry.DefaultRetry, func(err error) bool {
+		return apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err)
+	}, func() error {
+		now := c.now()
+
+		lock := &corev1.ConfigMap{}
+		exists := true
+		if err := c.apiReader.Get(ctx, client.ObjectKey{Namespace: c.namespace, Name: consts.RebooterLockConfigMapName}, lock); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return fmt.Errorf("getting lock ConfigMap: %w", err)
+			}
+			exists = false
+			lock = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: c.namespace,
+					Name:      consts.RebooterLockConfigMapName,
+				},
+			}
+		}
+
+		entries := make(map[string]rebootLockEntry, len(lock.Data))
+		for nodeName, value := range lock.Data {
+			var entry rebootLockEntry
+			if err := json.Unmarshal([]byte(value), &entry); err != nil {
+				// Not a slot written by a rebooter, so it's dropped like a stale one.
+				continue
+			}
+			if now.Sub(entry.RenewTime.Time) > rebootLockTTL {
+				continue
+			}
+			entries[nodeName] = entry
+		}
+
+		changed, err := mutate(entries, now)
+		if err != nil || !changed {
+			return err
+		}
+
+		lock.Data = make(map[string]string, len(entries))
+		for nodeName, entry := range entries {
+			value, err := json.Marshal(entry)
+			if err != nil {
+				return fmt.Errorf("marshalling reboot slot: %w", err)
+			}
+			lock.Data[nodeName] = string(value)
+		}
+		if !exists {
+			return c.client.Create(ctx, lock)
+		}
+		return c.client.Update(ctx, lock)
+	})
+}
@@ -0,0 +1,202 @@
+package rebooter
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/utils/timewindow"
+)
+
+type staticNodePodsFetcher corev1.PodList
+
+func (f staticNodePodsFetcher) GetPodsOnNode(context.Context, string) (*corev1.PodList, error) {
+	podList := corev1.PodList(f)
+	return &podList, nil
+}
+
+func testRebootCoordinator(t *testing.T, params RebooterParams, objects ...client.Object) (*rebootCoordinator, client.Client) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+	params.Namespace = "soperator"
+	coordinator := newRebootCoordinator(fakeClient, fakeClient, params)
+	require.NotNil(t, coordinator)
+	// 2026-03-04 is a Wednesday.
+	coordinator.now = func() time.Time { return time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC) }
+	return coordinator, fakeClient
+}
+
+func testRebootLock(t *testing.T, entries map[string]rebootLockEntry) *corev1.ConfigMap {
+	t.Helper()
+	lock := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "soperator", Name: consts.RebooterLockConfigMapName},
+		Data:       map[string]string{},
+	}
+	for nodeName, entry := range entries {
+		value, err := json.Marshal(entry)
+		require.NoError(t, err)
+		lock.Data[nodeName] = string(value)
+	}
+	return lock
+}
+
+func getRebootLockNodes(t *testing.T, c client.Client) []string {
+	t.Helper()
+	lock := &corev1.ConfigMap{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "soperator", Name: consts.RebooterLockConfigMapName}, lock))
+	var nodeNames []string
+	for nodeName := range lock.Data {
+		nodeNames = append(nodeNames, nodeName)
+	}
+	return nodeNames
+}
+
+func TestNewRebootCoordinatorDisabledWithoutLimits(t *testing.T) {
+	assert.Nil(t, newRebootCoordinator(nil, nil, RebooterParams{}))
+}
+
+func TestRebootCoordinatorAcquire(t *testing.T) {
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		params       RebooterParams
+		entries      map[string]rebootLockEntry
+		nodeSet      string
+		wantAcquired bool
+	}{
+		{
+			name:         "below the limit",
+			params:       RebooterParams{MaxConcurrentReboots: 2},
+			entries:      map[string]rebootLockEntry{"node-1": {RenewTime: metav1.NewTime(now)}},
+			wantAcquired: true,
+		},
+		{
+			name:    "at the limit",
+			params:  RebooterParams{MaxConcurrentReboots: 1},
+			entries: map[string]rebootLockEntry{"node-1": {RenewTime: metav1.NewTime(now)}},
+		},
+		{
+			name:         "stale slots are freed",
+			params:       RebooterParams{MaxConcurrentReboots: 1},
+			entries:      map[string]rebootLockEntry{"node-1": {RenewTime: metav1.NewTime(now.Add(-time.Hour))}},
+			wantAcquired: true,
+		},
+		{
+			name:         "held slot is renewed",
+			params:       RebooterParams{MaxConcurrentReboots: 1},
+			entries:      map[string]rebootLockEntry{"node-0": {RenewTime: metav1.NewTime(now.Add(-time.Minute))}},
+			wantAcquired: true,
+		},
+		{
+			name:   "at the NodeSet limit",
+			params: RebooterParams{MaxConcurrentRebootsPerNodeSet: 1},
+			entries: map[string]rebootLockEntry{
+				"node-1": {NodeSet: "gpu", RenewTime: metav1.NewTime(now)},
+			},
+			nodeSet: "gpu",
+		},
+		{
+			name:   "other NodeSets don't count towards the NodeSet limit",
+			params: RebooterParams{MaxConcurrentRebootsPerNodeSet: 1},
+			entries: map[string]rebootLockEntry{
+				"node-1": {NodeSet: "cpu", RenewTime: metav1.NewTime(now)},
+			},
+			nodeSet:      "gpu",
+			wantAcquired: true,
+		},
+		{
+			name: "outside of maintenance windows",
+			params: RebooterParams{MaintenanceWindows: []timewindow.Window{
+				{Days: []string{"Sat", "Sun"}, Start: "00:00", End: "23:59"},
+			}},
+		},
+		{
+			name: "within a maintenance window",
+			params: RebooterParams{MaintenanceWindows: []timewindow.Window{
+				{Days: []string{"Sat", "Sun"}, Start: "00:00", End: "23:59"},
+				{Start: "11:00", End: "13:00"},
+			}},
+			wantAcquired: true,
+		},
+		{
+			name: "held slot is kept outside of maintenance windows",
+			params: RebooterParams{MaintenanceWindows: []timewindow.Window{
+				{Days: []string{"Sat"}, Start: "00:00", End: "23:59"},
+			}},
+			entries:      map[string]rebootLockEntry{"node-0": {RenewTime: metav1.NewTime(now.Add(-time.Minute))}},
+			wantAcquired: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var objects []client.Object
+			if tt.entries != nil {
+				objects = append(objects, testRebootLock(t, tt.entries))
+			}
+			coordinator, c := testRebootCoordinator(t, tt.params, objects...)
+
+			acquired, err := coordinator.acquire(context.Background(), "node-0", tt.nodeSet)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAcquired, acquired)
+			if tt.wantAcquired {
+				assert.Contains(t, getRebootLockNodes(t, c), "node-0")
+			}
+		})
+	}
+}
+
+func TestRebootCoordinatorRelease(t *testing.T) {
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	coordinator, c := testRebootCoordinator(t, RebooterParams{MaxConcurrentReboots: 1}, testRebootLock(t, map[string]rebootLockEntry{
+		"node-0": {RenewTime: metav1.NewTime(now)},
+		"node-1": {RenewTime: metav1.NewTime(now)},
+	}))
+
+	require.NoError(t, coordinator.release(context.Background(), "node-0"))
+	assert.Equal(t, []string{"node-1"}, getRebootLockNodes(t, c))
+	assert.False(t, coordinator.mayHoldSlot)
+
+	acquired, err := coordinator.acquire(context.Background(), "node-0", "")
+	require.NoError(t, err)
+	assert.False(t, acquired, "node-1 holds the only slot")
+}
+
+func TestCoordinateRebootPostponesDrainWithTheReboot(t *testing.T) {
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	coordinator, c := testRebootCoordinator(t, RebooterParams{MaxConcurrentRebootsPerNodeSet: 1}, testRebootLock(t, map[string]rebootLockEntry{
+		"node-1": {NodeSet: "gpu", RenewTime: metav1.NewTime(now)},
+	}))
+	r := &RebooterReconciler{
+		nodeName: "node-0",
+		NodePodsFetcher: staticNodePodsFetcher{Items: []corev1.Pod{{
+			ObjectMeta: metav1.ObjectMeta{Name: "gpu-0", Labels: map[string]string{consts.LabelNodeSetKey: "gpu"}},
+		}}},
+		coordinator: coordinator,
+	}
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-0"}}
+
+	actions, err := r.coordinateReboot(context.Background(), node, NodeActions{Reboot: true, Drain: true})
+	require.NoError(t, err)
+	assert.Equal(t, NodeActions{}, actions)
+
+	node.Status.Conditions = []corev1.NodeCondition{{Type: consts.SlurmNodeDrain, Status: corev1.ConditionTrue}}
+	actions, err = r.coordinateReboot(context.Background(), node, NodeActions{Reboot: true, Drain: true})
+	require.NoError(t, err)
+	assert.Equal(t, NodeActions{Drain: true}, actions, "drain requested on its own")
+	assert.Equal(t, []string{"node-1"}, getRebootLockNodes(t, c))
+}
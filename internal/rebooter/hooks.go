@@ -0,0 +1,175 @@
+package rebooter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/consts"
+)
+
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+
+const (
+	defaultHookTimeout = time.Minute
+	// hookOutputLimit is how much of the end of a failed hook output is reported.
+	hookOutputLimit = 512
+
+	// postRebootCheckInitialBackoff is how long failed post-reboot checks wait before the first retry.
+	// The wait doubles with every failure, up to postRebootCheckMaxBackoff.
+	postRebootCheckInitialBackoff = time.Minute
+	postRebootCheckMaxBackoff     = 30 * time.Minute
+)
+
+// Hook is a command run in the host namespaces around a reboot.
+type Hook struct {
+	Name    string
+	Command []string
+	Timeout time.Duration
+}
+
+// HostCommandRunner runs commands in the host namespaces.
+type HostCommandRunner interface {
+	Run(ctx context.Context, command []string) ([]byte, error)
+}
+
+// nsenterCommandRunner enters the namespaces of the host init process, the same way the reboot command does.
+// This relies on hostPID:true and privileged:true of the rebooter pod.
+type nsenterCommandRunner struct{}
+
+func (nsenterCommandRunner) Run(ctx context.Context, command []string) ([]byte, error) {
+	args := append([]string{"--target=1", "--mount", "--uts", "--ipc", "--net", "--pid", "--"}, command...)
+	return exec.CommandContext(ctx, "/usr/bin/nsenter", args...).CombinedOutput()
+}
+
+// ParseHooks parses the hooks rendered into the rebooter environment.
+func ParseHooks(value string) ([]Hook, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var rebooterHooks []slurmv1alpha1.RebooterHook
+	if err := json.Unmarshal([]byte(value), &rebooterHooks); err != nil {
+		return nil, fmt.Errorf("unmarshalling hooks: %w", err)
+	}
+
+	res := make([]Hook, 0, len(rebooterHooks))
+	for _, rebooterHook := range rebooterHooks {
+		if len(rebooterHook.Command) == 0 {
+			return nil, fmt.Errorf("hook %q has no command", rebooterHook.Name)
+		}
+		hook := Hook{
+			Name:    rebooterHook.Name,
+			Command: rebooterHook.Command,
+			Timeout: rebooterHook.Timeout.Duration,
+		}
+		if hook.Timeout <= 0 {
+			hook.Timeout = defaultHookTimeout
+		}
+		res = append(res, hook)
+	}
+	return res, nil
+}
+
+// runPreRebootHooks runs the pre-reboot hooks of the node.
+// The reboot was asked for, so failed hooks are only reported.
+func (r *RebooterReconciler) runPreRebootHooks(ctx context.Context, node *corev1.Node) {
+	logger := log.FromContext(ctx).WithName("runPreRebootHooks").WithValues("nodeName", node.Name)
+	for _, hook := range r.preRebootHooks {
+		if err := r.runHook(ctx, hook); err != nil {
+			logger.Error(err, "Pre-reboot hook failed, rebooting anyway", "hook", hook.Name)
+			r.Recorder.Event(node, corev1.EventTypeWarning, consts.RebooterEventPreRebootHookFailed, err.Error())
+		}
+	}
+}
+
+// postRebootCheckFailure is the state of the failing post-reboot checks of the node.
+type postRebootCheckFailure struct {
+	// check is the name of the failed check.
+	check    string
+	err      error
+	failures int
+	retryAt  time.Time
+}
+
+// runPostRebootChecks runs the post-reboot checks of the node and returns the error of the first failed one.
+// Failed checks are retried with an exponential backoff, and the error of the last run is returned until then.
+// An event is only recorded when the failed check changes, or once all of them pass after a failure.
+func (r *RebooterReconciler) runPostRebootChecks(ctx context.Context, node *corev1.Node) error {
+	if len(r.postRebootChecks) == 0 {
+		return nil
+	}
+
+	previous := r.postRebootCheckFailure
+	if previous != nil && r.now().Before(previous.retryAt) {
+		return fmt.Errorf("retrying after %s: %w", previous.retryAt.Format(time.RFC3339), previous.err)
+	}
+
+	for _, check := range r.postRebootChecks {
+		err := r.runHook(ctx, check)
+		if err == nil {
+			continue
+		}
+
+		failure := &postRebootCheckFailure{check: check.Name, err: err, failures: 1}
+		if previous != nil {
+			failure.failures = previous.failures + 1
+		}
+		failure.retryAt = r.now().Add(postRebootCheckBackoff(failure.failures))
+		r.postRebootCheckFailure = failure
+
+		if previous == nil || previous.check != check.Name {
+			r.Recorder.Event(node, corev1.EventTypeWarning, consts.RebooterEventPostRebootCheckFailed, err.Error())
+		}
+		return err
+	}
+
+	if previous != nil {
+		r.postRebootCheckFailure = nil
+		r.Recorder.Event(node, corev1.EventTypeNormal, consts.RebooterEventPostRebootChecksPassed,
+			fmt.Sprintf("Post-reboot checks passed after %d failed attempts", previous.failures))
+	}
+	return nil
+}
+
+// postRebootCheckBackoff returns how long to wait before retrying the post-reboot checks after the given number of
+// consecutive failures.
+func postRebootCheckBackoff(failures int) time.Duration {
+	backoff := postRebootCheckInitialBackoff
+	for i := 1; i < failures && backoff < postRebootCheckMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, postRebootCheckMaxBackoff)
+}
+
+func (r *RebooterReconciler) runHook(ctx context.Context, hook Hook) error {
+	logger := log.FromContext(ctx).WithName("runHook").WithValues("hook", hook.Name).V(1)
+
+	hookCtx, cancel := context.WithTimeout(ctx, hook.Timeout)
+	defer cancel()
+
+	logger.Info("Running hook", "command", hook.Command)
+	output, err := r.hostCommandRunner.Run(hookCtx, hook.Command)
+	if hookCtx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("hook %s timed out after %s", hook.Name, hook.Timeout)
+	}
+	if err != nil {
+		return fmt.Errorf("hook %s failed: %w: %s", hook.Name, err, outputTail(output))
+	}
+	logger.Info("Hook succeeded", "output", outputTail(output))
+	return nil
+}
+
+func outputTail(output []byte) string {
+	res := strings.TrimSpace(string(output))
+	if len(res) > hookOutputLimit {
+		res = "..." + res[len(res)-hookOutputLimit:]
+	}
+	return res
+}
@@ -0,0 +1,168 @@
+package rebooter
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/controller/reconciler"
+)
+
+// fakeHostCommandRunner fails the commands named in failures and records the commands it ran.
+type fakeHostCommandRunner struct {
+	failures map[string]string
+	ran      []string
+}
+
+func (f *fakeHostCommandRunner) Run(ctx context.Context, command []string) ([]byte, error) {
+	f.ran = append(f.ran, command[0])
+	if command[0] == "sleep" {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if output, failed := f.failures[command[0]]; failed {
+		return []byte(output), errors.New("exit status 1")
+	}
+	return []byte("ok"), nil
+}
+
+func testHooksReconciler(runner HostCommandRunner, preRebootHooks, postRebootChecks []Hook) (*RebooterReconciler, *record.FakeRecorder) {
+	recorder := record.NewFakeRecorder(10)
+	return &RebooterReconciler{
+		Reconciler:        &reconciler.Reconciler{Recorder: recorder},
+		preRebootHooks:    preRebootHooks,
+		postRebootChecks:  postRebootChecks,
+		hostCommandRunner: runner,
+		now:               time.Now,
+	}, recorder
+}
+
+func TestParseHooks(t *testing.T) {
+	hooks, err := ParseHooks(`[{"name":"dmesg","command":["dmesg"]},{"name":"gpus","command":["nvidia-smi","-L"],"timeout":"2m0s"}]`)
+	require.NoError(t, err)
+	assert.Equal(t, []Hook{
+		{Name: "dmesg", Command: []string{"dmesg"}, Timeout: time.Minute},
+		{Name: "gpus", Command: []string{"nvidia-smi", "-L"}, Timeout: 2 * time.Minute},
+	}, hooks)
+
+	hooks, err = ParseHooks("")
+	require.NoError(t, err)
+	assert.Empty(t, hooks)
+
+	_, err = ParseHooks(`[{"name":"empty"}]`)
+	assert.Error(t, err)
+}
+
+func TestRunPreRebootHooksContinuesAfterFailure(t *testing.T) {
+	runner := &fakeHostCommandRunner{failures: map[string]string{"dmesg": "permission denied"}}
+	r, recorder := testHooksReconciler(runner, []Hook{
+		{Name: "dmesg", Command: []string{"dmesg"}, Timeout: time.Minute},
+		{Name: "sync", Command: []string{"sync"}, Timeout: time.Minute},
+	}, nil)
+
+	r.runPreRebootHooks(context.Background(), &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-0"}})
+	assert.Equal(t, []string{"dmesg", "sync"}, runner.ran)
+	require.Len(t, recorder.Events, 1)
+	event := <-recorder.Events
+	assert.Contains(t, event, consts.RebooterEventPreRebootHookFailed)
+	assert.Contains(t, event, "permission denied")
+}
+
+func TestRunPostRebootChecks(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-0"}}
+	checks := []Hook{
+		{Name: "gpus", Command: []string{"nvidia-smi"}, Timeout: time.Minute},
+		{Name: "ib", Command: []string{"ibstat"}, Timeout: time.Minute},
+	}
+
+	t.Run("all pass", func(t *testing.T) {
+		runner := &fakeHostCommandRunner{}
+		r, recorder := testHooksReconciler(runner, nil, checks)
+
+		require.NoError(t, r.runPostRebootChecks(context.Background(), node))
+		assert.Equal(t, []string{"nvidia-smi", "ibstat"}, runner.ran)
+		assert.Empty(t, recorder.Events)
+	})
+
+	t.Run("first failure stops the checks", func(t *testing.T) {
+		runner := &fakeHostCommandRunner{failures: map[string]string{"nvidia-smi": strings.Repeat("x", 1000) + "GPU is lost"}}
+		r, recorder := testHooksReconciler(runner, nil, checks)
+
+		err := r.runPostRebootChecks(context.Background(), node)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "GPU is lost")
+		assert.Less(t, len(err.Error()), 600, "output is truncated")
+		assert.Equal(t, []string{"nvidia-smi"}, runner.ran)
+		assert.Contains(t, <-recorder.Events, consts.RebooterEventPostRebootCheckFailed)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		r, _ := testHooksReconciler(&fakeHostCommandRunner{}, nil, []Hook{
+			{Name: "hangs", Command: []string{"sleep"}, Timeout: 10 * time.Millisecond},
+		})
+
+		err := r.runPostRebootChecks(context.Background(), node)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "timed out")
+	})
+}
+
+func TestRunPostRebootChecksBackoff(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-0"}}
+	runner := &fakeHostCommandRunner{failures: map[string]string{"nvidia-smi": "GPU is lost"}}
+	r, recorder := testHooksReconciler(runner, nil, []Hook{
+		{Name: "gpus", Command: []string{"nvidia-smi"}, Timeout: time.Minute},
+		{Name: "ib", Command: []string{"ibstat"}, Timeout: time.Minute},
+	})
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	r.now = func() time.Time { return now }
+
+	require.Error(t, r.runPostRebootChecks(context.Background(), node))
+	assert.Contains(t, <-recorder.Events, consts.RebooterEventPostRebootCheckFailed)
+
+	// The checks aren't run again before the backoff expires.
+	now = now.Add(postRebootCheckInitialBackoff / 2)
+	err := r.runPostRebootChecks(context.Background(), node)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "GPU is lost")
+	assert.Equal(t, []string{"nvidia-smi"}, runner.ran)
+
+	// The same check failing again doesn't record another event, and the backoff doubles.
+	now = now.Add(postRebootCheckInitialBackoff)
+	require.Error(t, r.runPostRebootChecks(context.Background(), node))
+	assert.Equal(t, []string{"nvidia-smi", "nvidia-smi"}, runner.ran)
+	assert.Empty(t, recorder.Events)
+	assert.Equal(t, now.Add(2*postRebootCheckInitialBackoff), r.postRebootCheckFailure.retryAt)
+
+	// Another check failing records an event.
+	runner.failures = map[string]string{"ibstat": "link down"}
+	now = now.Add(2 * postRebootCheckInitialBackoff)
+	require.Error(t, r.runPostRebootChecks(context.Background(), node))
+	event := <-recorder.Events
+	assert.Contains(t, event, consts.RebooterEventPostRebootCheckFailed)
+	assert.Contains(t, event, "link down")
+
+	// Passing checks reset the backoff.
+	runner.failures = nil
+	now = now.Add(postRebootCheckMaxBackoff)
+	require.NoError(t, r.runPostRebootChecks(context.Background(), node))
+	assert.Contains(t, <-recorder.Events, consts.RebooterEventPostRebootChecksPassed)
+	assert.Nil(t, r.postRebootCheckFailure)
+}
+
+func TestPostRebootCheckBackoff(t *testing.T) {
+	assert.Equal(t, time.Minute, postRebootCheckBackoff(1))
+	assert.Equal(t, 2*time.Minute, postRebootCheckBackoff(2))
+	assert.Equal(t, 16*time.Minute, postRebootCheckBackoff(5))
+	assert.Equal(t, postRebootCheckMaxBackoff, postRebootCheckBackoff(6))
+	assert.Equal(t, postRebootCheckMaxBackoff, postRebootCheckBackoff(100))
+}
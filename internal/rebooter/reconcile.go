@@ -26,6 +26,7 @@ import (
 	"nebius.ai/slurm-operator/internal/consts"
 	"nebius.ai/slurm-operator/internal/controller/reconciler"
 	"nebius.ai/slurm-operator/internal/controllerconfig"
+	"nebius.ai/slurm-operator/internal/utils/timewindow"
 )
 
 //+kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch;update;patch
@@ -40,6 +41,21 @@ type RebooterParams struct {
 	ReconcileTimeout time.Duration
 	NodeName         string
 	EvictionMethod   consts.RebooterMethod
+
+	// Namespace is where the lock ConfigMap coordinating reboots is kept.
+	Namespace string
+	// MaxConcurrentReboots is the maximum number of nodes rebooted at the same time. Unlimited if 0.
+	MaxConcurrentReboots int32
+	// MaxConcurrentRebootsPerNodeSet is the maximum number of nodes of the same NodeSet rebooted at the same time.
+	// Unlimited if 0.
+	MaxConcurrentRebootsPerNodeSet int32
+	// MaintenanceWindows are the time windows reboots may start in. Any time if empty.
+	MaintenanceWindows []timewindow.Window
+
+	// PreRebootHooks are run on the host before the node is rebooted.
+	PreRebootHooks []Hook
+	// PostRebootChecks are run on the host once the node is back from a reboot, and must succeed before it's undrained.
+	PostRebootChecks []Hook
 }
 
 type RebooterReconciler struct {
@@ -49,6 +65,15 @@ type RebooterReconciler struct {
 	nodeName         string
 	evictionMethod   consts.RebooterMethod
 	NodePodsFetcher  NodePodsFetcher
+	coordinator      *rebootCoordinator
+
+	preRebootHooks    []Hook
+	postRebootChecks  []Hook
+	hostCommandRunner HostCommandRunner
+	// postRebootCheckFailure is set while the post-reboot checks of the node fail.
+	postRebootCheckFailure *postRebootCheckFailure
+
+	now func() time.Time
 }
 
 func NewRebooterReconciler(
@@ -67,6 +92,13 @@ func NewRebooterReconciler(
 		nodeName:         rebooterParams.NodeName,
 		evictionMethod:   rebooterParams.EvictionMethod,
 		NodePodsFetcher:  nodePodsFetcher,
+		coordinator:      newRebootCoordinator(c, apiReader, rebooterParams),
+
+		preRebootHooks:    rebooterParams.PreRebootHooks,
+		postRebootChecks:  rebooterParams.PostRebootChecks,
+		hostCommandRunner: nsenterCommandRunner{},
+
+		now: time.Now,
 	}
 }
 
@@ -99,6 +131,14 @@ func (r *RebooterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	}
 	logger.V(1).Info("Node actions", "actions", nodeActions)
 
+	if r.coordinator != nil {
+		nodeActions, err = r.coordinateReboot(ctx, node, nodeActions)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		logger.V(1).Info("Coordinated node actions", "actions", nodeActions)
+	}
+
 	logger.V(1).Info("Starting handling node drain")
 	if err := r.handleNodeDrain(ctx, node, nodeActions); err != nil {
 		logger.V(1).Info("Failed to drain node", "error", err)
@@ -147,6 +187,11 @@ func (r *RebooterReconciler) GetActions(ctx context.Context, node *corev1.Node)
 			// If node just has been rebooted, it's still has NoExecute taint, so we should undrain it.
 			logger.Info("Checking if node needs to be undrained")
 			if r.checkIfNodeNeedsReboot(ctx, nodeRebootCondition) {
+				// The node is kept drained, and the checks are retried once their backoff expires.
+				if err := r.runPostRebootChecks(ctx, node); err != nil {
+					log.FromContext(ctx).Info("Post-reboot check failed, keeping node drained", "nodeName", node.Name, "error", err.Error())
+					return actions, nil
+				}
 				if err := r.handleNodeUnDrain(ctx, node); err != nil {
 					return NodeActions{}, err
 				}
@@ -167,6 +212,48 @@ func (r *RebooterReconciler) GetActions(ctx context.Context, node *corev1.Node)
 	return actions, nil
 }
 
+// coordinateReboot postpones the reboot of the node until it gets a reboot slot, and frees the slot once the node
+// doesn't need a reboot anymore.
+func (r *RebooterReconciler) coordinateReboot(ctx context.Context, node *corev1.Node, nodeActions NodeActions) (NodeActions, error) {
+	if !nodeActions.Reboot {
+		return nodeActions, r.coordinator.release(ctx, node.Name)
+	}
+
+	nodeSet := ""
+	if r.coordinator.maxConcurrentRebootsPerNodeSet > 0 {
+		var err error
+		if nodeSet, err = r.getNodeSetName(ctx, node); err != nil {
+			return NodeActions{}, err
+		}
+	}
+	acquired, err := r.coordinator.acquire(ctx, node.Name, nodeSet)
+	if err != nil {
+		return NodeActions{}, err
+	}
+	if acquired {
+		return nodeActions, nil
+	}
+
+	// The drain is part of the postponed reboot, unless it was requested on its own.
+	return NodeActions{
+		Drain: r.checkIfNodeNeedsDrain(ctx, r.GetNodeConditions(ctx, node, consts.SlurmNodeDrain)),
+	}, nil
+}
+
+// getNodeSetName returns the NodeSet of the worker running on the node, or an empty string if there is none.
+func (r *RebooterReconciler) getNodeSetName(ctx context.Context, node *corev1.Node) (string, error) {
+	pods, err := r.NodePodsFetcher.GetPodsOnNode(ctx, node.Name)
+	if err != nil {
+		return "", fmt.Errorf("failed to get pods on node %s: %w", node.Name, err)
+	}
+	for _, pod := range pods.Items {
+		if nodeSet := pod.Labels[consts.LabelNodeSetKey]; nodeSet != "" {
+			return nodeSet, nil
+		}
+	}
+	return "", nil
+}
+
 // handleNodeDrain drains the node with the given name if needed.
 func (r *RebooterReconciler) handleNodeDrain(ctx context.Context, node *corev1.Node, nodeActions NodeActions) error {
 	if nodeActions.Drain {
@@ -241,6 +328,7 @@ func (r *RebooterReconciler) handleNodeReboot(ctx context.Context, node *corev1.
 		if err := r.setNodeCondition(ctx, node, consts.SlurmNodeReboot, corev1.ConditionTrue, consts.ReasonNodeRebooting, consts.MessageRebooting); err != nil {
 			return err
 		}
+		r.runPreRebootHooks(ctx, node)
 		if err := r.RebootNode(ctx, node); err != nil {
 			return err
 		}
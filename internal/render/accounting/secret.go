@@ -38,6 +38,7 @@ func RenderSecret(
 	accounting *values.SlurmAccounting,
 	passwordSecret *corev1.Secret,
 	isRestEnabled bool,
+	jwtAuth values.JWTAuth,
 ) (*corev1.Secret, error) {
 	var err error
 	passwordName := make([]byte, 0)
@@ -55,7 +56,7 @@ func RenderSecret(
 	labels := common.RenderLabels(consts.ComponentTypeAccounting, clusterName)
 	data := map[string][]byte{
 		consts.ConfigMapKeySlurmdbdConfig: []byte(common.WithManagedSlurmConfigWarning(generateSlurmdbdConfig(
-			clusterName, accounting, passwordName, isRestEnabled)).Render(),
+			clusterName, accounting, passwordName, isRestEnabled, jwtAuth)).Render(),
 		),
 		consts.SecretSlurmdbdConfigStorageHost: []byte(utils.Ternary(
 			accounting.MariaDb.Enabled,
@@ -134,6 +135,7 @@ func generateSlurmdbdConfig(
 	accounting *values.SlurmAccounting,
 	passwordName []byte,
 	isRestEnabled bool,
+	jwtAuth values.JWTAuth,
 ) renderutils.ConfigFile {
 	res := &renderutils.PropertiesConfig{}
 	// Unmodifiable parameters
@@ -171,7 +173,7 @@ func generateSlurmdbdConfig(
 		res.AddComment("")
 		res.AddComment("REST API settings")
 		res.AddProperty("AuthAltTypes", "auth/jwt")
-		res.AddProperty("AuthAltParameters", "jwt_key="+consts.SlurmdbdRESTJWTKeyPath)
+		res.AddProperty("AuthAltParameters", common.RenderJWTAuthAltParameters(consts.SlurmdbdRESTJWTKeyPath, jwtAuth))
 	}
 
 	// Modifiable parameters
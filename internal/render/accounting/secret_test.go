@@ -10,11 +10,12 @@ import (
 	"nebius.ai/slurm-operator/internal/naming"
 	"nebius.ai/slurm-operator/internal/render/accounting"
 	"nebius.ai/slurm-operator/internal/render/common"
+	"nebius.ai/slurm-operator/internal/values"
 )
 
 func Test_RenderSecret(t *testing.T) {
 
-	secret, err := accounting.RenderSecret(defaultNamespace, defaultNameCluster, acc, defaultSecret, false)
+	secret, err := accounting.RenderSecret(defaultNamespace, defaultNameCluster, acc, defaultSecret, false, values.JWTAuth{})
 	assert.NoError(t, err)
 	assert.NotNil(t, secret)
 	assert.Equal(t, naming.BuildSecretSlurmdbdConfigsName(defaultNameCluster), secret.Name)
@@ -26,32 +27,43 @@ func Test_RenderSecret(t *testing.T) {
 	assert.Contains(t, string(data), common.ManagedSlurmConfigWarning())
 }
 
+func Test_RenderSecret_JWKS(t *testing.T) {
+	secret, err := accounting.RenderSecret(defaultNamespace, defaultNameCluster, acc, defaultSecret, true, values.JWTAuth{})
+	assert.NoError(t, err)
+	assert.Contains(t, string(secret.Data[consts.ConfigMapKeySlurmdbdConfig]), "AuthAltParameters=jwt_key="+consts.SlurmdbdRESTJWTKeyPath+"\n")
+
+	secret, err = accounting.RenderSecret(defaultNamespace, defaultNameCluster, acc, defaultSecret, true, values.JWTAuth{JWKS: `{"keys":[]}`, UsernameClaim: "email"})
+	assert.NoError(t, err)
+	assert.Contains(t, string(secret.Data[consts.ConfigMapKeySlurmdbdConfig]),
+		"AuthAltParameters=jwt_key="+consts.SlurmdbdRESTJWTKeyPath+",jwks="+consts.JWKSPath+",userclaimfield=email\n")
+}
+
 func Test_RenderSecret_Errors(t *testing.T) {
 	testAcc := *acc
 	// Test with nil accounting
-	_, err := accounting.RenderSecret(defaultNamespace, defaultNameCluster, nil, nil, false)
+	_, err := accounting.RenderSecret(defaultNamespace, defaultNameCluster, nil, nil, false, values.JWTAuth{})
 	assert.Equal(t, accounting.ErrAccountingNil, err.Error())
 
 	// // Test with empty secret data
 	testSecret := &corev1.Secret{}
-	_, err = accounting.RenderSecret(defaultNamespace, defaultNameCluster, acc, testSecret, false)
+	_, err = accounting.RenderSecret(defaultNamespace, defaultNameCluster, acc, testSecret, false, values.JWTAuth{})
 	assert.Equal(t, accounting.ErrSecretDataEmpty, err.Error())
 
 	// // Test with empty external DB user
 	testAcc.ExternalDB.User = ""
-	_, err = accounting.RenderSecret(defaultNamespace, defaultNameCluster, &testAcc, defaultSecret, false)
+	_, err = accounting.RenderSecret(defaultNamespace, defaultNameCluster, &testAcc, defaultSecret, false, values.JWTAuth{})
 	assert.Equal(t, accounting.ErrDBUserEmpty, err.Error())
 
 	// // Test with empty external DB host
 	testAcc = *acc
 	testAcc.ExternalDB.Host = ""
-	_, err = accounting.RenderSecret(defaultNamespace, defaultNameCluster, &testAcc, defaultSecret, false)
+	_, err = accounting.RenderSecret(defaultNamespace, defaultNameCluster, &testAcc, defaultSecret, false, values.JWTAuth{})
 	assert.Equal(t, accounting.ErrDBHostEmpty, err.Error())
 
 	// // Test with missing password key
 	testAcc = *acc
 	testAcc.ExternalDB.PasswordSecretKeyRef.Key = "missing-key"
-	_, err = accounting.RenderSecret(defaultNamespace, defaultNameCluster, &testAcc, defaultSecret, false)
+	_, err = accounting.RenderSecret(defaultNamespace, defaultNameCluster, &testAcc, defaultSecret, false, values.JWTAuth{})
 	assert.Equal(t, accounting.ErrPasswordKeyMissing, err.Error())
 
 	// // Test with empty password
@@ -59,6 +71,6 @@ func Test_RenderSecret_Errors(t *testing.T) {
 	testSecret.Data = map[string][]byte{
 		passwordKey: []byte(""),
 	}
-	_, err = accounting.RenderSecret(defaultNamespace, defaultNameCluster, acc, testSecret, false)
+	_, err = accounting.RenderSecret(defaultNamespace, defaultNameCluster, acc, testSecret, false, values.JWTAuth{})
 	assert.Equal(t, accounting.ErrPasswordEmpty, err.Error())
 }
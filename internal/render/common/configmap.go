@@ -11,6 +11,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"k8s.io/utils/strings/slices"
 
 	slurmv1 "nebius.ai/slurm-operator/api/v1"
@@ -30,8 +31,9 @@ import (
 // [consts.ConfigMapKeySpankConfig] - SPANK plugins config
 // [consts.ConfigMapKeyGresConfig] - GRES config
 // [consts.ConfigMapKeyMPIConfig] - PMIx config
+// [consts.ConfigMapKeyJWKS] - Public keys of the JWT signing keys, if they are published
 func RenderConfigMapSlurmConfigs(cluster *values.SlurmCluster) corev1.ConfigMap {
-	return corev1.ConfigMap{
+	res := corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      naming.BuildConfigMapSlurmConfigsName(cluster.Name),
 			Namespace: cluster.Namespace,
@@ -48,6 +50,10 @@ func RenderConfigMapSlurmConfigs(cluster *values.SlurmCluster) corev1.ConfigMap
 			consts.ConfigMapKeyMPIConfig:           WithManagedSlurmConfigWarning(generateMPIConfig(cluster)).Render(),
 		},
 	}
+	if cluster.JWTAuth.JWKS != "" {
+		res.Data[consts.ConfigMapKeyJWKS] = cluster.JWTAuth.JWKS
+	}
+	return res
 }
 
 // RenderJailedConfigSlurmConfigs renders new [slurmv1alpha1.JailedConfig] for every config in `RenderConfigMapSlurmConfigs` result
@@ -58,7 +64,7 @@ func RenderJailedConfigSlurmConfigs(cluster *values.SlurmCluster) slurmv1alpha1.
 	labels := RenderLabels(consts.ComponentTypeController, cluster.Name)
 	labels[consts.LabelJailedAggregationKey] = consts.LabelJailedAggregationCommonValue
 
-	return slurmv1alpha1.JailedConfig{
+	res := slurmv1alpha1.JailedConfig{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      naming.BuildConfigMapSlurmConfigsName(cluster.Name),
 			Namespace: cluster.Namespace,
@@ -81,6 +87,19 @@ func RenderJailedConfigSlurmConfigs(cluster *values.SlurmCluster) slurmv1alpha1.
 			UpdateActions: []slurmv1alpha1.UpdateAction{slurmv1alpha1.UpdateActionReconfigure},
 		},
 	}
+	if cluster.JWTAuth.JWKS != "" {
+		res.Spec.Items = append(res.Spec.Items, corev1.KeyToPath{Key: consts.ConfigMapKeyJWKS, Path: consts.JWKSPath})
+	}
+	return res
+}
+
+// RenderNodeSetNodeList renders the hostlist expression of the Slurm nodes of a NodeSet with a positive number of
+// replicas, e.g. gb200-0-[0-17]. The Slurm node names are the names of the NodeSet pods.
+func RenderNodeSetNodeList(nodeSetName string, replicas int32) string {
+	if replicas == 1 {
+		return fmt.Sprintf("%s-0", nodeSetName)
+	}
+	return fmt.Sprintf("%s-[0-%d]", nodeSetName, replicas-1)
 }
 
 // AddNodeSetsToSlurmConfig adds nodeset configuration to the slurm config
@@ -95,14 +114,11 @@ func AddNodeSetsToSlurmConfig(res *renderutils.PropertiesConfig, cluster *values
 	}
 
 	for _, nodeSet := range cluster.NodeSets {
-		switch {
-		case nodeSet.Spec.Replicas == 1:
-			res.AddProperty("NodeSet", fmt.Sprintf("%s Nodes=%s-0", nodeSet.Name, nodeSet.Name))
-		case nodeSet.Spec.Replicas > 1:
-			res.AddProperty("NodeSet", fmt.Sprintf("%s Nodes=%s-[0-%d]", nodeSet.Name, nodeSet.Name, nodeSet.Spec.Replicas-1))
-		default:
+		if nodeSet.Spec.Replicas <= 0 {
 			res.AddComment(fmt.Sprintf("WARNING: NodeSet %s has 0 replicas, skipping", nodeSet.Name))
+			continue
 		}
+		res.AddProperty("NodeSet", fmt.Sprintf("%s Nodes=%s", nodeSet.Name, RenderNodeSetNodeList(nodeSet.Name, nodeSet.Spec.Replicas)))
 	}
 }
 
@@ -132,12 +148,7 @@ func AddNodesToSlurmConfig(res *renderutils.PropertiesConfig, cluster *values.Sl
 			continue
 		}
 
-		var nodeRange string
-		if nodeSet.Spec.Replicas == 1 {
-			nodeRange = fmt.Sprintf("%s-0", nodeSet.Name)
-		} else {
-			nodeRange = fmt.Sprintf("%s-[0-%d]", nodeSet.Name, nodeSet.Spec.Replicas-1)
-		}
+		nodeRange := RenderNodeSetNodeList(nodeSet.Name, nodeSet.Spec.Replicas)
 
 		nodeAddr := fmt.Sprintf(
 			"%s.%s",
@@ -284,10 +295,8 @@ func generateSlurmConfig(cluster *values.SlurmCluster) renderutils.ConfigFile {
 	res.AddProperty("ClusterName", cluster.Name)
 	res.AddComment("")
 
-	{
-		svcName := cluster.NodeController.Service.Name
-		controllerHostname := cluster.NodeController.StatefulSet.Name + "-0"
-		res.AddProperty("SlurmctldHost", fmt.Sprintf("%s(%s)", controllerHostname, svcName))
+	for _, host := range cluster.NodeController.SlurmctldHosts() {
+		res.AddProperty("SlurmctldHost", fmt.Sprintf("%s(%s)", host.Hostname, host.Address))
 	}
 	res.AddComment("")
 
@@ -483,18 +492,51 @@ func generateSlurmConfig(cluster *values.SlurmCluster) renderutils.ConfigFile {
 		res.AddProperty("AccountingStoragePort", consts.DefaultAccountingPort)
 		res.AddProperty("JobCompType", "jobcomp/none")
 
+		accountingSlurmConfig := cluster.NodeAccounting.SlurmConfig
+		if cluster.StoreJobComments {
+			accountingSlurmConfig.AccountingStoreFlags = ptr.To(
+				addAccountingStoreFlag(ptr.Deref(accountingSlurmConfig.AccountingStoreFlags, ""), "job_comment"),
+			)
+		}
 		// In the generated Slurm config, the accounting section has many optional values
 		// that can be added or removed, and to avoid writing many if statements, we decided to use a reflector.
-		addSlurmConfigProperties(res, cluster.NodeAccounting.SlurmConfig)
+		addSlurmConfigProperties(res, accountingSlurmConfig)
 
 		if cluster.NodeRest.Enabled {
 			res.AddComment("")
 			res.AddComment("REST API")
 			res.AddProperty("AuthAltTypes", "auth/jwt")
-			res.AddProperty("AuthAltParameters", "jwt_key="+consts.RESTJWTKeyPath)
+			res.AddProperty("AuthAltParameters", RenderJWTAuthAltParameters(consts.RESTJWTKeyPath, cluster.JWTAuth))
+		}
+	}
+
+	return res
+}
+
+// addAccountingStoreFlag adds the flag to the comma-separated AccountingStoreFlags, unless it's already there.
+func addAccountingStoreFlag(flags, flag string) string {
+	if strings.TrimSpace(flags) == "" {
+		return flag
+	}
+	for _, f := range strings.Split(flags, ",") {
+		if strings.TrimSpace(f) == flag {
+			return flags
 		}
 	}
+	return flags + "," + flag
+}
 
+// RenderJWTAuthAltParameters renders the parameters of the auth/jwt plugin.
+// The HS256 key verifies the tokens issued by `scontrol token`, while the JWKS verifies the tokens signed with the rotated RS256 keys
+// and the external ones.
+func RenderJWTAuthAltParameters(jwtKeyPath string, jwtAuth values.JWTAuth) string {
+	res := "jwt_key=" + jwtKeyPath
+	if jwtAuth.JWKS != "" {
+		res += ",jwks=" + consts.JWKSPath
+	}
+	if jwtAuth.UsernameClaim != "" && jwtAuth.UsernameClaim != consts.JWTDefaultUsernameClaim {
+		res += ",userclaimfield=" + jwtAuth.UsernameClaim
+	}
 	return res
 }
 
@@ -522,11 +564,7 @@ func buildSuspendExcNodes(cluster *values.SlurmCluster) string {
 			continue
 		}
 
-		if nodeSet.Spec.Replicas == 1 {
-			staticNodeSets = append(staticNodeSets, fmt.Sprintf("%s-0", nodeSet.Name))
-		} else {
-			staticNodeSets = append(staticNodeSets, fmt.Sprintf("%s-[0-%d]", nodeSet.Name, nodeSet.Spec.Replicas-1))
-		}
+		staticNodeSets = append(staticNodeSets, RenderNodeSetNodeList(nodeSet.Name, nodeSet.Spec.Replicas))
 	}
 
 	return strings.Join(staticNodeSets, ",")
@@ -387,6 +387,99 @@ func TestRenderSlurmConfigMapRebootPrograms(t *testing.T) {
 		"PowerAction=soperator-worker-handoff Location=slurmd Program=/opt/bin/slurm/worker_handoff.py")
 }
 
+func TestRenderSlurmConfigMapSlurmctldHosts(t *testing.T) {
+	t.Run("single controller", func(t *testing.T) {
+		result := RenderConfigMapSlurmConfigs(&values.SlurmCluster{
+			NodeController: values.SlurmController{
+				StatefulSet: values.StatefulSet{Name: "controller"},
+				Service:     values.Service{Name: "slurm1-controller-svc"},
+			},
+		})
+
+		slurmConfig := result.Data[consts.ConfigMapKeySlurmBaseConfig]
+		assert.Contains(t, slurmConfig, "SlurmctldHost=controller-0(slurm1-controller-svc)")
+		assert.Equal(t, 1, strings.Count(slurmConfig, "SlurmctldHost="))
+	})
+
+	t.Run("high availability", func(t *testing.T) {
+		result := RenderConfigMapSlurmConfigs(&values.SlurmCluster{
+			NodeController: values.SlurmController{
+				StatefulSet:      values.StatefulSet{Name: "controller"},
+				Service:          values.Service{Name: "slurm1-controller-svc"},
+				HighAvailability: true,
+				ReplicaServices: []values.Service{
+					{Name: "slurm1-controller-0-svc"},
+					{Name: "slurm1-controller-1-svc"},
+				},
+			},
+		})
+
+		slurmConfig := result.Data[consts.ConfigMapKeySlurmBaseConfig]
+		assert.Contains(t, slurmConfig,
+			"SlurmctldHost=controller-0(slurm1-controller-0-svc)\nSlurmctldHost=controller-1(slurm1-controller-1-svc)\n",
+			"the primary controller is listed first")
+	})
+}
+
+func TestRenderSlurmConfigMapJWKS(t *testing.T) {
+	cluster := &values.SlurmCluster{
+		NodeAccounting: values.SlurmAccounting{Enabled: true},
+		NodeRest:       values.SlurmREST{Enabled: true},
+	}
+
+	t.Run("no signing keys", func(t *testing.T) {
+		result := RenderConfigMapSlurmConfigs(cluster)
+		assert.NotContains(t, result.Data, consts.ConfigMapKeyJWKS)
+		assert.Contains(t, result.Data[consts.ConfigMapKeySlurmBaseConfig], "AuthAltParameters=jwt_key="+consts.RESTJWTKeyPath)
+		assert.NotContains(t, result.Data[consts.ConfigMapKeySlurmBaseConfig], "jwks=")
+		assert.Len(t, RenderJailedConfigSlurmConfigs(cluster).Spec.Items, 8)
+	})
+
+	t.Run("published signing keys", func(t *testing.T) {
+		clusterWithJWKS := *cluster
+		clusterWithJWKS.JWTAuth.JWKS = `{"keys":[]}`
+
+		result := RenderConfigMapSlurmConfigs(&clusterWithJWKS)
+		assert.Equal(t, `{"keys":[]}`, result.Data[consts.ConfigMapKeyJWKS])
+		assert.Contains(t, result.Data[consts.ConfigMapKeySlurmBaseConfig],
+			"AuthAltParameters=jwt_key="+consts.RESTJWTKeyPath+",jwks=/etc/slurm/jwks.json")
+		assert.Contains(t, RenderJailedConfigSlurmConfigs(&clusterWithJWKS).Spec.Items,
+			corev1.KeyToPath{Key: consts.ConfigMapKeyJWKS, Path: "/etc/slurm/jwks.json"})
+	})
+
+	t.Run("external username claim", func(t *testing.T) {
+		clusterWithClaim := *cluster
+		clusterWithClaim.JWTAuth = values.JWTAuth{JWKS: `{"keys":[]}`, UsernameClaim: "email"}
+
+		result := RenderConfigMapSlurmConfigs(&clusterWithClaim)
+		assert.Contains(t, result.Data[consts.ConfigMapKeySlurmBaseConfig],
+			"AuthAltParameters=jwt_key="+consts.RESTJWTKeyPath+",jwks=/etc/slurm/jwks.json,userclaimfield=email")
+
+		clusterWithClaim.JWTAuth.UsernameClaim = consts.JWTDefaultUsernameClaim
+		result = RenderConfigMapSlurmConfigs(&clusterWithClaim)
+		assert.NotContains(t, result.Data[consts.ConfigMapKeySlurmBaseConfig], "userclaimfield=")
+	})
+}
+
+func TestRenderSlurmConfigMapStoreJobComments(t *testing.T) {
+	render := func(storeJobComments bool, flags *string) []string {
+		result := RenderConfigMapSlurmConfigs(&values.SlurmCluster{
+			NodeAccounting: values.SlurmAccounting{
+				Enabled:     true,
+				SlurmConfig: slurmv1.AccountingSlurmConf{AccountingStoreFlags: flags},
+			},
+			StoreJobComments: storeJobComments,
+		})
+		return strings.Split(result.Data[consts.ConfigMapKeySlurmBaseConfig], "\n")
+	}
+
+	assert.NotContains(t, render(false, nil), "AccountingStoreFlags=job_comment")
+	assert.Contains(t, render(false, ptr.To("job_script")), "AccountingStoreFlags=job_script")
+	assert.Contains(t, render(true, nil), "AccountingStoreFlags=job_comment")
+	assert.Contains(t, render(true, ptr.To("job_script")), "AccountingStoreFlags=job_script,job_comment")
+	assert.Contains(t, render(true, ptr.To("job_comment,job_env")), "AccountingStoreFlags=job_comment,job_env")
+}
+
 func TestRenderConfigMapSlurmConfigs_FileNamesAndWarnings(t *testing.T) {
 	result := RenderConfigMapSlurmConfigs(&values.SlurmCluster{})
 
@@ -862,6 +955,11 @@ func TestAddNodesToSlurmConfig(t *testing.T) {
 	}
 }
 
+func TestRenderNodeSetNodeList(t *testing.T) {
+	assert.Equal(t, "gb200-0-0", RenderNodeSetNodeList("gb200-0", 1))
+	assert.Equal(t, "gb200-0-[0-17]", RenderNodeSetNodeList("gb200-0", 18))
+}
+
 func TestAddNodeSetsToSlurmConfig(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -3,6 +3,8 @@ package common
 import (
 	"fmt"
 	"path"
+	"strconv"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 
@@ -65,6 +67,61 @@ func RenderContainerMunge(container *values.Container, opts ...RenderOption) cor
 	}
 }
 
+// MungeContainerReloadsKey checks whether the munge container switches the munge daemon to the key rotated in the
+// mounted Secret. That's declared by the ContainerEnvMungeKeyReload env of the container if set. Otherwise, it's done by
+// the entrypoint of the munge images since MungeKeyReloadMinImageVersion, unless the container has a custom command.
+// Images whose version can't be told from the tag, e.g. pinned by digest, are assumed to keep the key they're started
+// with.
+func MungeContainerReloadsKey(container *values.Container) bool {
+	for _, env := range container.CustomEnv {
+		if env.Name == consts.ContainerEnvMungeKeyReload {
+			reloads, err := strconv.ParseBool(env.Value)
+			return err == nil && reloads
+		}
+	}
+	if len(container.Command) != 0 {
+		return false
+	}
+	version, ok := imageVersion(container.Image)
+	if !ok {
+		return false
+	}
+	minVersion, _ := parseVersion(consts.MungeKeyReloadMinImageVersion)
+	for i := range version {
+		if version[i] != minVersion[i] {
+			return version[i] > minVersion[i]
+		}
+	}
+	return true
+}
+
+// imageVersion returns the version the tag of the image starts with, e.g. 5.0.0 of "munge:5.0.0-slurm26.05.3".
+func imageVersion(image string) ([3]int, bool) {
+	image, _, _ = strings.Cut(image, "@")
+	i := strings.LastIndex(image, ":")
+	if i < 0 || i < strings.LastIndex(image, "/") {
+		return [3]int{}, false
+	}
+	version, _, _ := strings.Cut(image[i+1:], "-")
+	return parseVersion(version)
+}
+
+func parseVersion(version string) ([3]int, bool) {
+	var res [3]int
+	parts := strings.Split(version, ".")
+	if len(parts) != len(res) {
+		return res, false
+	}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return res, false
+		}
+		res[i] = n
+	}
+	return res, true
+}
+
 // RenderPlaceholderContainerMunge renders [corev1.Container] for munge in sleep mode for DaemonSet
 func RenderPlaceholderContainerMunge(container *values.Container) corev1.Container {
 	// Since Kubernetes 1.29 has native sidecar support, we can use the native restart policy
@@ -153,3 +153,66 @@ func TestRenderContainerMunge_SecurityContext(t *testing.T) {
 			result.SecurityContext.AppArmorProfile.Type)
 	}
 }
+
+func TestMungeContainerReloadsKey(t *testing.T) {
+	tests := []struct {
+		name      string
+		container slurmv1.NodeContainer
+		want      bool
+	}{
+		{
+			name:      "image entrypoint",
+			container: slurmv1.NodeContainer{Image: "cr.eu-north1.nebius.cloud/soperator/munge:5.0.0-slurm26.05.3-nebius-2"},
+			want:      true,
+		},
+		{
+			name:      "newer image",
+			container: slurmv1.NodeContainer{Image: "registry:5000/soperator/munge:5.1.10-slurm26.05.3"},
+			want:      true,
+		},
+		{
+			name:      "older image",
+			container: slurmv1.NodeContainer{Image: "cr.eu-north1.nebius.cloud/soperator/munge:4.9.1-slurm25.05.4"},
+			want:      false,
+		},
+		{
+			name:      "image version unknown",
+			container: slurmv1.NodeContainer{Image: "registry:5000/custom/munge@sha256:0123456789abcdef"},
+			want:      false,
+		},
+		{
+			name: "custom command",
+			container: slurmv1.NodeContainer{
+				Image:   "cr.eu-north1.nebius.cloud/soperator/munge:5.0.0-slurm26.05.3-nebius-2",
+				Command: []string{"/usr/sbin/munged", "-F"},
+			},
+			want: false,
+		},
+		{
+			name: "declared by env",
+			container: slurmv1.NodeContainer{
+				Image:     "registry:5000/custom/munge:latest",
+				Command:   []string{"/custom-entrypoint.sh"},
+				CustomEnv: []corev1.EnvVar{{Name: consts.ContainerEnvMungeKeyReload, Value: "true"}},
+			},
+			want: true,
+		},
+		{
+			name: "disabled by env",
+			container: slurmv1.NodeContainer{
+				Image:     "cr.eu-north1.nebius.cloud/soperator/munge:5.0.0-slurm26.05.3-nebius-2",
+				CustomEnv: []corev1.EnvVar{{Name: consts.ContainerEnvMungeKeyReload, Value: "false"}},
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			container := &values.Container{NodeContainer: tt.container}
+			if got := MungeContainerReloadsKey(container); got != tt.want {
+				t.Errorf("MungeContainerReloadsKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
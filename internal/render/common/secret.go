@@ -30,11 +30,20 @@ func generateRandBytes(size int) ([]byte, error) {
 	return randBytes, nil
 }
 
+// GenerateMungeKey generates new munge key
+func GenerateMungeKey() ([]byte, error) {
+	mungeKey, err := generateRandBytes(1024)
+	if err != nil {
+		return nil, fmt.Errorf("error generating munge key: %w", err)
+	}
+	return mungeKey, nil
+}
+
 // RenderMungeKeySecret renders new [corev1.Secret] containing munge key
 func RenderMungeKeySecret(clusterName string, namespace string) (corev1.Secret, error) {
-	mungeKey, err := generateRandBytes(1024)
+	mungeKey, err := GenerateMungeKey()
 	if err != nil {
-		return corev1.Secret{}, fmt.Errorf("error generating munge key: %w", err)
+		return corev1.Secret{}, err
 	}
 	return corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -203,21 +203,17 @@ func RenderVolumeMountMungeSocket() corev1.VolumeMount {
 	}
 }
 
-// RenderVolumeMungeKey renders [corev1.Volume] containing munge key file
+// RenderVolumeMungeKey renders [corev1.Volume] containing munge key file.
+// The whole Secret is mounted, so that the next key staged during a rotation reaches the munge containers, which
+// reload it without restarting the pods. That's why only the munge containers that don't reload it make the active
+// key a dependency of the workloads, see MungeContainerReloadsKey.
 func RenderVolumeMungeKey(clusterName string) corev1.Volume {
 	return corev1.Volume{
 		Name: consts.VolumeNameMungeKey,
 		VolumeSource: corev1.VolumeSource{
 			Secret: &corev1.SecretVolumeSource{
-				SecretName: naming.BuildSecretMungeKeyName(clusterName),
-				Items: []corev1.KeyToPath{
-					{
-						Key:  consts.SecretMungeKeyFileName,
-						Path: consts.SecretMungeKeyFileName,
-						Mode: ptr.To(consts.SecretMungeKeyFileMode),
-					},
-				},
-				DefaultMode: ptr.To(DefaultFileMode),
+				SecretName:  naming.BuildSecretMungeKeyName(clusterName),
+				DefaultMode: ptr.To(consts.SecretMungeKeyFileMode),
 			},
 		},
 	}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"fmt"
+	"maps"
 	"slices"
 
 	appspub "github.com/openkruise/kruise-api/apps/pub"
@@ -47,12 +48,16 @@ func RenderStatefulSet(
 		return kruisev1b1.StatefulSet{}, fmt.Errorf("rendering volumes and claim template specs: %w", err)
 	}
 
-	// Controller always has 1 replica
-	replicas := ptr.To(consts.SingleReplicas)
+	replicas := ptr.To(controller.StatefulSet.Replicas)
 	if check.IsMaintenanceActive(controller.Maintenance) {
 		replicas = ptr.To(consts.ZeroReplicas)
 	}
 
+	affinity := nodeFilter.Affinity
+	if controller.HighAvailability {
+		affinity = renderHighAvailabilityAffinity(affinity, matchLabels)
+	}
+
 	initContainers := slices.Clone(controller.CustomInitContainers)
 	initContainers = append(initContainers, common.RenderContainerMunge(&controller.ContainerMunge))
 	if controller.ContainerSSSD != nil {
@@ -113,7 +118,7 @@ func RenderStatefulSet(
 					},
 					HostUsers:                     controller.HostUsers,
 					ImagePullSecrets:              controller.ContainerSlurmctld.ImagePullSecrets,
-					Affinity:                      nodeFilter.Affinity,
+					Affinity:                      affinity,
 					NodeSelector:                  nodeFilter.NodeSelector,
 					Tolerations:                   nodeFilter.Tolerations,
 					InitContainers:                initContainers,
@@ -133,3 +138,26 @@ func RenderStatefulSet(
 
 	return res, nil
 }
+
+// renderHighAvailabilityAffinity adds the preference for scheduling the primary and the backup controllers on different
+// K8s nodes to the affinity of the node filter
+func renderHighAvailabilityAffinity(affinity *corev1.Affinity, matchLabels map[string]string) *corev1.Affinity {
+	res := affinity.DeepCopy()
+	if res == nil {
+		res = &corev1.Affinity{}
+	}
+	if res.PodAntiAffinity == nil {
+		res.PodAntiAffinity = &corev1.PodAntiAffinity{}
+	}
+	res.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(
+		res.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+		corev1.WeightedPodAffinityTerm{
+			Weight: 100,
+			PodAffinityTerm: corev1.PodAffinityTerm{
+				LabelSelector: &metav1.LabelSelector{MatchLabels: maps.Clone(matchLabels)},
+				TopologyKey:   corev1.LabelHostname,
+			},
+		},
+	)
+	return res
+}
@@ -537,3 +537,86 @@ func TestRenderStatefulSetHostUsers(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderStatefulSetHighAvailability(t *testing.T) {
+	controller := &values.SlurmController{
+		K8sNodeFilterName: "test-filter",
+		StatefulSet: values.StatefulSet{
+			Name:           "test-controller-sts",
+			Replicas:       consts.ControllerHighAvailabilityReplicas,
+			MaxUnavailable: intstr.FromInt32(1),
+		},
+		Service: values.Service{
+			Name: "test-controller-svc",
+		},
+		ContainerSlurmctld: values.Container{
+			NodeContainer: slurmv1.NodeContainer{
+				Image:           "test-image:latest",
+				Port:            6817,
+				AppArmorProfile: consts.AppArmorProfileUnconfined,
+			},
+			Name: "slurmctld",
+		},
+		ContainerMunge: values.Container{
+			NodeContainer: slurmv1.NodeContainer{
+				Image:           "munge-image:latest",
+				AppArmorProfile: consts.AppArmorProfileUnconfined,
+			},
+		},
+		VolumeSpool: slurmv1.NodeVolume{
+			VolumeSourceName: ptr.To("test-volume"),
+		},
+		VolumeJail: slurmv1.NodeVolume{
+			VolumeSourceName: ptr.To("test-volume"),
+		},
+		HighAvailability: true,
+	}
+
+	nodeAffinity := &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+				MatchExpressions: []corev1.NodeSelectorRequirement{{
+					Key:      "node-type",
+					Operator: corev1.NodeSelectorOpIn,
+					Values:   []string{"controller"},
+				}},
+			}},
+		},
+	}
+	nodeFilters := []slurmv1.K8sNodeFilter{
+		{
+			Name:     "test-filter",
+			Affinity: &corev1.Affinity{NodeAffinity: nodeAffinity},
+		},
+	}
+
+	result, err := RenderStatefulSet(
+		"test-namespace",
+		"test-cluster",
+		nodeFilters,
+		[]slurmv1.VolumeSource{
+			{
+				Name: "test-volume",
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				},
+			},
+		},
+		controller,
+		true,
+	)
+	assert.NoError(t, err)
+
+	assert.Equal(t, consts.ControllerHighAvailabilityReplicas, *result.Spec.Replicas)
+
+	affinity := result.Spec.Template.Spec.Affinity
+	if assert.NotNil(t, affinity) && assert.NotNil(t, affinity.PodAntiAffinity) {
+		assert.Equal(t, nodeAffinity, affinity.NodeAffinity)
+		terms := affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+		if assert.Len(t, terms, 1) {
+			assert.Equal(t, corev1.LabelHostname, terms[0].PodAffinityTerm.TopologyKey)
+			assert.Equal(t, result.Spec.Selector.MatchLabels, terms[0].PodAffinityTerm.LabelSelector.MatchLabels)
+		}
+	}
+	assert.Nil(t, nodeFilters[0].Affinity.PodAntiAffinity, "node filter is not modified")
+}
@@ -26,6 +26,9 @@ func renderContainerExporter(clusterValues *values.SlurmCluster) corev1.Containe
 	if clusterValues.SlurmExporter.JobSource != "" {
 		env = append(env, corev1.EnvVar{Name: "SLURM_EXPORTER_JOB_SOURCE", Value: clusterValues.SlurmExporter.JobSource})
 	}
+	if clusterValues.NodeAccounting.Enabled {
+		env = append(env, corev1.EnvVar{Name: "SLURM_EXPORTER_ACCOUNTING_COLLECTORS", Value: "true"})
+	}
 	if clusterValues.SlurmExporter.AccountingJobsLookback != "" {
 		env = append(env, corev1.EnvVar{Name: "SLURM_EXPORTER_ACCOUNTING_JOBS_LOOKBACK", Value: string(clusterValues.SlurmExporter.AccountingJobsLookback)})
 	}
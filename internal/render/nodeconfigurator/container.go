@@ -1,6 +1,9 @@
 package nodeconfigurator
 
 import (
+	"encoding/json"
+	"strconv"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/utils/ptr"
@@ -34,8 +37,43 @@ func renderContainerRebooter(rebooter slurmv1alpha1.Rebooter) corev1.Container {
 					FieldPath: "spec.nodeName",
 				},
 			},
+		}, corev1.EnvVar{
+			Name: consts.RebooterNamespaceEnv,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "metadata.namespace",
+				},
+			},
+		}, corev1.EnvVar{
+			Name:  consts.RebooterMaxConcurrentRebootsEnv,
+			Value: strconv.Itoa(int(rebooter.MaxConcurrentReboots)),
+		}, corev1.EnvVar{
+			Name:  consts.RebooterMaxConcurrentRebootsPerNodeSetEnv,
+			Value: strconv.Itoa(int(rebooter.MaxConcurrentRebootsPerNodeSet)),
 		},
 	)
+	// Marshalling plain strings and durations can't fail.
+	if len(rebooter.MaintenanceWindows) > 0 {
+		maintenanceWindows, _ := json.Marshal(rebooter.MaintenanceWindows)
+		rebooter.Env = append(rebooter.Env, corev1.EnvVar{
+			Name:  consts.RebooterMaintenanceWindowsEnv,
+			Value: string(maintenanceWindows),
+		})
+	}
+	if len(rebooter.PreRebootHooks) > 0 {
+		preRebootHooks, _ := json.Marshal(rebooter.PreRebootHooks)
+		rebooter.Env = append(rebooter.Env, corev1.EnvVar{
+			Name:  consts.RebooterPreRebootHooksEnv,
+			Value: string(preRebootHooks),
+		})
+	}
+	if len(rebooter.PostRebootChecks) > 0 {
+		postRebootChecks, _ := json.Marshal(rebooter.PostRebootChecks)
+		rebooter.Env = append(rebooter.Env, corev1.EnvVar{
+			Name:  consts.RebooterPostRebootChecksEnv,
+			Value: string(postRebootChecks),
+		})
+	}
 
 	return corev1.Container{
 		Name:            consts.ContainerNameRebooter,
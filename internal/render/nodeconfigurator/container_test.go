@@ -0,0 +1,76 @@
+package nodeconfigurator
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/consts"
+)
+
+func TestRenderContainerRebooterCoordinationEnv(t *testing.T) {
+	container := renderContainerRebooter(slurmv1alpha1.Rebooter{
+		Enabled:                        true,
+		MaxConcurrentReboots:           3,
+		MaxConcurrentRebootsPerNodeSet: 1,
+		MaintenanceWindows: []slurmv1alpha1.MaintenanceWindow{{
+			Days:     []slurmv1alpha1.Weekday{slurmv1alpha1.WeekdaySaturday},
+			Start:    "22:00",
+			End:      "04:00",
+			TimeZone: "UTC",
+		}},
+	})
+
+	env := map[string]corev1.EnvVar{}
+	for _, envVar := range container.Env {
+		env[envVar.Name] = envVar
+	}
+
+	for name, want := range map[string]string{
+		consts.RebooterMaxConcurrentRebootsEnv:           "3",
+		consts.RebooterMaxConcurrentRebootsPerNodeSetEnv: "1",
+		consts.RebooterMaintenanceWindowsEnv:             `[{"days":["Sat"],"start":"22:00","end":"04:00","timeZone":"UTC"}]`,
+	} {
+		if got := env[name].Value; got != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+	if namespace := env[consts.RebooterNamespaceEnv].ValueFrom; namespace == nil || namespace.FieldRef.FieldPath != "metadata.namespace" {
+		t.Errorf("%s is not taken from the pod namespace", consts.RebooterNamespaceEnv)
+	}
+}
+
+func TestRenderContainerRebooterWithoutMaintenanceWindows(t *testing.T) {
+	container := renderContainerRebooter(slurmv1alpha1.Rebooter{Enabled: true})
+
+	for _, envVar := range container.Env {
+		if envVar.Name == consts.RebooterMaintenanceWindowsEnv {
+			t.Fatalf("unexpected %s env", consts.RebooterMaintenanceWindowsEnv)
+		}
+	}
+}
+
+func TestRenderContainerRebooterHooksEnv(t *testing.T) {
+	container := renderContainerRebooter(slurmv1alpha1.Rebooter{
+		Enabled: true,
+		PostRebootChecks: []slurmv1alpha1.RebooterHook{{
+			Name:    "gpus",
+			Command: []string{"nvidia-smi"},
+			Timeout: metav1.Duration{Duration: 2 * time.Minute},
+		}},
+	})
+
+	env := map[string]string{}
+	for _, envVar := range container.Env {
+		env[envVar.Name] = envVar.Value
+	}
+	if got, want := env[consts.RebooterPostRebootChecksEnv], `[{"name":"gpus","command":["nvidia-smi"],"timeout":"2m0s"}]`; got != want {
+		t.Errorf("%s = %q, want %q", consts.RebooterPostRebootChecksEnv, got, want)
+	}
+	if _, found := env[consts.RebooterPreRebootHooksEnv]; found {
+		t.Errorf("unexpected %s env", consts.RebooterPreRebootHooksEnv)
+	}
+}
@@ -29,3 +29,16 @@ func RenderSecret(namespace, clusterName string) (corev1.Secret, error) {
 		Data: data,
 	}, nil
 }
+
+// RenderJWTSigningKeysSecret renders new [corev1.Secret] for the JWT signing keys.
+// The keys are added by the rotations, the first one included.
+func RenderJWTSigningKeysSecret(namespace, clusterName string) corev1.Secret {
+	return corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      naming.BuildSecretJWTSigningKeysName(clusterName),
+			Namespace: namespace,
+			Labels:    common.RenderLabels(consts.ComponentTypeREST, clusterName),
+		},
+		Data: map[string][]byte{},
+	}
+}
@@ -10,7 +10,7 @@ import (
 	"nebius.ai/slurm-operator/internal/render/common"
 )
 
-func renderContainerK8sCronjob(check *slurmv1alpha1.ActiveCheck) corev1.Container {
+func renderContainerK8sCronjob(check *slurmv1alpha1.ActiveCheck, targetNodeList string) corev1.Container {
 	var container corev1.Container
 
 	if check.Spec.CheckType == "k8sJob" {
@@ -89,6 +89,12 @@ func renderContainerK8sCronjob(check *slurmv1alpha1.ActiveCheck) corev1.Containe
 			Value: fmt.Sprint(*check.Spec.SlurmJobSpec.MaxNumberOfJobs),
 		})
 	}
+	if targetNodeList != "" {
+		slurmEnvVars = append(slurmEnvVars, corev1.EnvVar{
+			Name:  consts.ActiveCheckNodesEnv,
+			Value: targetNodeList,
+		})
+	}
 	container = corev1.Container{
 		Name:            check.Spec.Name,
 		Image:           check.Spec.SlurmJobSpec.JobContainer.Image,
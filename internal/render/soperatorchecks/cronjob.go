@@ -11,12 +11,18 @@ import (
 	"nebius.ai/slurm-operator/internal/render/common"
 )
 
-func RenderK8sCronJob(check *slurmv1alpha1.ActiveCheck, foundPodTemplate *corev1.PodTemplate) (batchv1.CronJob, error) {
+// RenderK8sCronJob renders the CronJob of the check. targetNodeList is the hostlist expression of the Slurm nodes
+// the check targets, or empty if the check runs on all nodes.
+func RenderK8sCronJob(
+	check *slurmv1alpha1.ActiveCheck,
+	foundPodTemplate *corev1.PodTemplate,
+	targetNodeList string,
+) (batchv1.CronJob, error) {
 	labels := common.RenderLabels(consts.ComponentTypeSoperatorChecks, check.Spec.SlurmClusterRefName)
 
 	var podTemplateSpec corev1.PodTemplateSpec
 
-	basePodTemplateSpec := renderPodTemplateSpec(check, labels)
+	basePodTemplateSpec := renderPodTemplateSpec(check, labels, targetNodeList)
 
 	if foundPodTemplate != nil {
 		var err error
@@ -2,8 +2,11 @@ package soperatorchecks
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 
@@ -39,3 +42,62 @@ func RenderK8sJob(check *slurmv1alpha1.ActiveCheck, cronJob *batchv1.CronJob) *b
 func RenderK8sJobName(check *slurmv1alpha1.ActiveCheck) string {
 	return fmt.Sprintf("%s-initial-run", check.Spec.Name)
 }
+
+// RenderActiveCheckRunJob renders the K8s Job of an on-demand ActiveCheckRun from the job template of the check's
+// CronJob. The Job is owned by the run, and is limited to the run's nodes through the ACTIVE_CHECK_NODES variable,
+// which replaces the targets of the check, and to the run's reservation through the ACTIVE_CHECK_RESERVATION variable.
+func RenderActiveCheckRunJob(
+	run *slurmv1alpha1.ActiveCheckRun,
+	check *slurmv1alpha1.ActiveCheck,
+	cronJob *batchv1.CronJob,
+) *batchv1.Job {
+	labels := common.RenderLabels(consts.ComponentTypeSoperatorChecks, check.Spec.SlurmClusterRefName)
+	labels[consts.LabelActiveCheckRunKey] = run.Name
+
+	spec := cronJob.Spec.JobTemplate.Spec.DeepCopy()
+	for i := range spec.Template.Spec.Containers {
+		container := &spec.Template.Spec.Containers[i]
+		if container.Name != check.Spec.Name {
+			continue
+		}
+		if len(run.Spec.Nodes) != 0 {
+			container.Env = slices.DeleteFunc(container.Env, func(env corev1.EnvVar) bool {
+				return env.Name == consts.ActiveCheckNodesEnv
+			})
+			container.Env = append(container.Env, corev1.EnvVar{
+				Name:  consts.ActiveCheckNodesEnv,
+				Value: strings.Join(run.Spec.Nodes, ","),
+			})
+		}
+		if run.Spec.Reservation != "" {
+			container.Env = append(container.Env, corev1.EnvVar{
+				Name:  consts.ActiveCheckReservationEnv,
+				Value: run.Spec.Reservation,
+			})
+		}
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      RenderActiveCheckRunJobName(run),
+			Namespace: run.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         slurmv1alpha1.GroupVersion.String(),
+					Kind:               slurmv1alpha1.KindActiveCheckRun,
+					Name:               run.Name,
+					UID:                run.UID,
+					Controller:         ptr.To(true),
+					BlockOwnerDeletion: ptr.To(true),
+				},
+			},
+			Labels:      labels,
+			Annotations: cronJob.Spec.JobTemplate.Annotations,
+		},
+		Spec: *spec,
+	}
+}
+
+func RenderActiveCheckRunJobName(run *slurmv1alpha1.ActiveCheckRun) string {
+	return fmt.Sprintf("%s-run", run.Name)
+}
@@ -13,7 +13,11 @@ import (
 	"nebius.ai/slurm-operator/internal/values"
 )
 
-func renderPodTemplateSpec(check *slurmv1alpha1.ActiveCheck, labels map[string]string) corev1.PodTemplateSpec {
+func renderPodTemplateSpec(
+	check *slurmv1alpha1.ActiveCheck,
+	labels map[string]string,
+	targetNodeList string,
+) corev1.PodTemplateSpec {
 	var initContainers []corev1.Container
 	var annotations map[string]string
 
@@ -60,7 +64,7 @@ func renderPodTemplateSpec(check *slurmv1alpha1.ActiveCheck, labels map[string]s
 			ActiveDeadlineSeconds: ptr.To(check.Spec.ActiveDeadlineSeconds),
 			RestartPolicy:         corev1.RestartPolicyNever,
 			Volumes:               renderVolumes(check),
-			Containers:            []corev1.Container{renderContainerK8sCronjob(check)},
+			Containers:            []corev1.Container{renderContainerK8sCronjob(check, targetNodeList)},
 			InitContainers:        initContainers,
 			ServiceAccountName:    naming.BuildServiceAccountActiveCheckName(check.Spec.SlurmClusterRefName),
 		},
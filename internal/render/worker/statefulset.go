@@ -152,6 +152,37 @@ func RenderNodeSetStatefulSet(
 		"kruise.io/auto-generate-persistent-pod-state": "true",
 		"kruise.io/preferred-persistent-topology":      "kubernetes.io/hostname",
 	}
+	// The annotations of the slurm-aware rolling update are rendered even if they aren't set in the NodeSet,
+	// so that unsetting them clears them on existing StatefulSets.
+	annotations[consts.AnnotationUpdateVerificationCheck] = ""
+	annotations[consts.AnnotationUpdateVerificationMaxFailedNodes] = "0"
+	if nodeSet.UpdateVerification != nil {
+		annotations[consts.AnnotationUpdateVerificationCheck] = nodeSet.UpdateVerification.ActiveCheckName
+		annotations[consts.AnnotationUpdateVerificationMaxFailedNodes] =
+			strconv.Itoa(int(nodeSet.UpdateVerification.MaxFailedNodes))
+	}
+	annotations[consts.AnnotationRolloutPaused] = "false"
+	annotations[consts.AnnotationRolloutPartition] = "0"
+	annotations[consts.AnnotationRolloutMode] = string(consts.RolloutModeReboot)
+	annotations[consts.AnnotationRolloutDrainReservationDuration] = ""
+	annotations[consts.AnnotationRolloutMaxDrainedPerPartition] = ""
+	if nodeSet.Rollout != nil {
+		annotations[consts.AnnotationRolloutPaused] = strconv.FormatBool(nodeSet.Rollout.Paused)
+		annotations[consts.AnnotationRolloutPartition] = strconv.Itoa(int(nodeSet.Rollout.Partition))
+		if nodeSet.Rollout.Mode != "" {
+			annotations[consts.AnnotationRolloutMode] = string(nodeSet.Rollout.Mode)
+		}
+		if drainAndWait := nodeSet.Rollout.DrainAndWait; drainAndWait != nil {
+			if drainAndWait.ReservationDuration.Duration > 0 {
+				annotations[consts.AnnotationRolloutDrainReservationDuration] =
+					drainAndWait.ReservationDuration.Duration.String()
+			}
+			if drainAndWait.MaxDrainedPerPartition != nil {
+				annotations[consts.AnnotationRolloutMaxDrainedPerPartition] =
+					drainAndWait.MaxDrainedPerPartition.String()
+			}
+		}
+	}
 
 	pvcRetentionPolicy := nodeSet.PersistentVolumeClaimRetentionPolicy
 	if pvcRetentionPolicy == nil {
@@ -3,12 +3,14 @@ package worker_test
 import (
 	"strconv"
 	"testing"
+	"time"
 
 	kruisev1b1 "github.com/openkruise/kruise-api/apps/v1beta1"
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -1151,6 +1153,53 @@ func TestRenderNodeSetStatefulSet_ScaleStrategy(t *testing.T) {
 		assert.Equal(t, intstr.FromString("20%"), *result.Spec.ScaleStrategy.MaxUnavailable)
 	})
 
+	t.Run("update verification is rendered as annotations", func(t *testing.T) {
+		nodeSet := makeNodeSet(intstr.FromString("20%"))
+		nodeSet.UpdateStrategy = consts.UpdateStrategySlurmAwareRollingUpdate
+
+		result, err := worker.RenderNodeSetStatefulSet("test-cluster", nodeSet, &slurmv1.Secrets{}, consts.CGroupV2, true, false, "")
+		assert.NoError(t, err)
+		assert.Equal(t, "", result.Annotations[consts.AnnotationUpdateVerificationCheck])
+
+		nodeSet.UpdateVerification = &slurmv1alpha1.UpdateVerificationSpec{ActiveCheckName: "gpu-checks", MaxFailedNodes: 2}
+		result, err = worker.RenderNodeSetStatefulSet("test-cluster", nodeSet, &slurmv1.Secrets{}, consts.CGroupV2, true, false, "")
+		assert.NoError(t, err)
+		assert.Equal(t, "gpu-checks", result.Annotations[consts.AnnotationUpdateVerificationCheck])
+		assert.Equal(t, "2", result.Annotations[consts.AnnotationUpdateVerificationMaxFailedNodes])
+	})
+
+	t.Run("rollout controls are rendered as annotations", func(t *testing.T) {
+		nodeSet := makeNodeSet(intstr.FromString("20%"))
+		nodeSet.UpdateStrategy = consts.UpdateStrategySlurmAwareRollingUpdate
+
+		result, err := worker.RenderNodeSetStatefulSet("test-cluster", nodeSet, &slurmv1.Secrets{}, consts.CGroupV2, true, false, "")
+		assert.NoError(t, err)
+		assert.Equal(t, "false", result.Annotations[consts.AnnotationRolloutPaused])
+		assert.Equal(t, "0", result.Annotations[consts.AnnotationRolloutPartition])
+
+		nodeSet.Rollout = &slurmv1alpha1.RolloutSpec{Paused: true, Partition: 6}
+		result, err = worker.RenderNodeSetStatefulSet("test-cluster", nodeSet, &slurmv1.Secrets{}, consts.CGroupV2, true, false, "")
+		assert.NoError(t, err)
+		assert.Equal(t, "true", result.Annotations[consts.AnnotationRolloutPaused])
+		assert.Equal(t, "6", result.Annotations[consts.AnnotationRolloutPartition])
+		assert.Equal(t, "reboot", result.Annotations[consts.AnnotationRolloutMode])
+		assert.Equal(t, "", result.Annotations[consts.AnnotationRolloutDrainReservationDuration])
+		assert.Equal(t, "", result.Annotations[consts.AnnotationRolloutMaxDrainedPerPartition])
+
+		nodeSet.Rollout = &slurmv1alpha1.RolloutSpec{
+			Mode: consts.RolloutModeDrainAndWait,
+			DrainAndWait: &slurmv1alpha1.DrainAndWaitSpec{
+				ReservationDuration:    metav1.Duration{Duration: 30 * time.Minute},
+				MaxDrainedPerPartition: ptr.To(intstr.FromString("5%")),
+			},
+		}
+		result, err = worker.RenderNodeSetStatefulSet("test-cluster", nodeSet, &slurmv1.Secrets{}, consts.CGroupV2, true, false, "")
+		assert.NoError(t, err)
+		assert.Equal(t, "drainAndWait", result.Annotations[consts.AnnotationRolloutMode])
+		assert.Equal(t, "30m0s", result.Annotations[consts.AnnotationRolloutDrainReservationDuration])
+		assert.Equal(t, "5%", result.Annotations[consts.AnnotationRolloutMaxDrainedPerPartition])
+	})
+
 	t.Run("unsupported strategy returns an error", func(t *testing.T) {
 		nodeSet := makeNodeSet(intstr.FromString("20%"))
 		nodeSet.UpdateStrategy = consts.UpdateStrategy("unsupported")
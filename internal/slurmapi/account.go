@@ -0,0 +1,111 @@
+package slurmapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+)
+
+// Account is a slurmdbd account. Limits, QOS and fairshare of an account are set on its association,
+// see Association.
+type Account struct {
+	Name         string
+	Description  string
+	Organization string
+}
+
+func validateAPIAccount(account api.V0044Account) error {
+	var errs []error
+
+	if account.Name == "" {
+		errs = append(errs, errors.New("account doesn't have name"))
+	}
+
+	return errors.Join(errs...)
+}
+
+func AccountFromAPI(account api.V0044Account) (Account, error) {
+	if err := validateAPIAccount(account); err != nil {
+		return Account{}, err
+	}
+
+	return Account{
+		Name:         account.Name,
+		Description:  account.Description,
+		Organization: account.Organization,
+	}, nil
+}
+
+// validateAccount checks that the account can be submitted to slurmdbd.
+func validateAccount(account Account) error {
+	var errs []error
+
+	if account.Name == "" {
+		errs = append(errs, errors.New("account doesn't have name"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// accountToAPI converts the account for submission. As with sacctmgr, the description and the
+// organization default to the account name.
+func accountToAPI(account Account) api.V0044Account {
+	res := api.V0044Account{
+		Name:         account.Name,
+		Description:  account.Description,
+		Organization: account.Organization,
+	}
+
+	if res.Description == "" {
+		res.Description = account.Name
+	}
+	if res.Organization == "" {
+		res.Organization = account.Name
+	}
+
+	return res
+}
+
+// CreateAccount creates an account in slurmdbd. The account can't be used until an association is
+// created for it in a cluster.
+func (c *client) CreateAccount(ctx context.Context, account Account) error {
+	if err := validateAccount(account); err != nil {
+		return fmt.Errorf("create account: %w", err)
+	}
+	return c.postAccount(ctx, "create account "+account.Name, account)
+}
+
+// UpdateAccount updates the description and the organization of an existing account in slurmdbd.
+// Slurmdbd matches accounts by name, so creating and updating share the same request.
+func (c *client) UpdateAccount(ctx context.Context, account Account) error {
+	if err := validateAccount(account); err != nil {
+		return fmt.Errorf("update account: %w", err)
+	}
+	return c.postAccount(ctx, "update account "+account.Name, account)
+}
+
+func (c *client) postAccount(ctx context.Context, action string, account Account) error {
+	response, err := c.SlurmdbV0044PostAccountsWithResponse(ctx, api.V0044OpenapiAccountsResp{
+		Accounts: api.V0044AccountList{accountToAPI(account)},
+	})
+	if err != nil {
+		return fmt.Errorf("post %s request: %w", action, err)
+	}
+	return checkSlurmRESTMutation(action, response.StatusCode(), response.Body)
+}
+
+// DeleteAccount deletes an account from slurmdbd. Slurmdbd refuses to delete accounts which still
+// have associations.
+func (c *client) DeleteAccount(ctx context.Context, accountName string) error {
+	if accountName == "" {
+		return errors.New("delete account: account name is required")
+	}
+
+	response, err := c.SlurmdbV0044DeleteAccountWithResponse(ctx, accountName)
+	if err != nil {
+		return fmt.Errorf("delete account %s request: %w", accountName, err)
+	}
+	return checkSlurmRESTMutation("delete account "+accountName, response.StatusCode(), response.Body)
+}
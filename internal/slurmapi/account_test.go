@@ -0,0 +1,72 @@
+package slurmapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountFromAPI_MissingName(t *testing.T) {
+	_, err := AccountFromAPI(api.V0044Account{Description: "no name"})
+	require.Error(t, err)
+}
+
+func TestCreateAccountDefaultsDescriptionAndOrganization(t *testing.T) {
+	var gotBody api.V0044OpenapiAccountsResp
+	httpClient := &http.Client{Transport: roundTripFunc(func(request *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPost, request.Method)
+		assert.Equal(t, "/slurmdb/v0.0.44/accounts/", request.URL.Path)
+
+		require.NoError(t, json.NewDecoder(request.Body).Decode(&gotBody))
+		return undrainJSONResponse(http.StatusOK, `{"errors":[]}`), nil
+	})}
+
+	client, err := NewClient("http://slurmrestd/", nil, httpClient)
+	require.NoError(t, err)
+
+	require.NoError(t, client.CreateAccount(context.Background(), Account{Name: "team-a"}))
+	require.Len(t, gotBody.Accounts, 1)
+	assert.Equal(t, api.V0044Account{Name: "team-a", Description: "team-a", Organization: "team-a"}, gotBody.Accounts[0])
+}
+
+func TestDeleteAccountStatusErrorSummarizesSlurmEnvelope(t *testing.T) {
+	httpClient := &http.Client{Transport: roundTripFunc(func(request *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodDelete, request.Method)
+		assert.Equal(t, "/slurmdb/v0.0.44/account/team-a", request.URL.Path)
+		return undrainJSONResponse(http.StatusInternalServerError, `{"errors":[{"description":"Account has associations"}]}`), nil
+	})}
+
+	client, err := NewClient("http://slurmrestd", nil, httpClient)
+	require.NoError(t, err)
+
+	err = client.DeleteAccount(context.Background(), "team-a")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status=500")
+	assert.Contains(t, err.Error(), "Account has associations")
+}
+
+func TestCreateUserPostsDefaultAccount(t *testing.T) {
+	var gotBody api.V0044OpenapiUsersResp
+	httpClient := &http.Client{Transport: roundTripFunc(func(request *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPost, request.Method)
+		assert.Equal(t, "/slurmdb/v0.0.44/users/", request.URL.Path)
+
+		require.NoError(t, json.NewDecoder(request.Body).Decode(&gotBody))
+		return undrainJSONResponse(http.StatusOK, `{"errors":[]}`), nil
+	})}
+
+	client, err := NewClient("http://slurmrestd/", nil, httpClient)
+	require.NoError(t, err)
+
+	require.NoError(t, client.CreateUser(context.Background(), User{Name: "alice", DefaultAccount: "team-a"}))
+	require.Len(t, gotBody.Users, 1)
+
+	user, err := UserFromAPI(gotBody.Users[0])
+	require.NoError(t, err)
+	assert.Equal(t, User{Name: "alice", DefaultAccount: "team-a"}, user)
+}
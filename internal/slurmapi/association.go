@@ -0,0 +1,254 @@
+package slurmapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"k8s.io/utils/ptr"
+)
+
+// AssociationKey identifies an association in slurmdbd. User is empty for account associations and
+// Partition is empty for associations that aren't partition-specific.
+type AssociationKey struct {
+	Cluster   string
+	Account   string
+	User      string
+	Partition string
+}
+
+func (k AssociationKey) String() string {
+	return fmt.Sprintf("cluster=%s account=%s user=%s partition=%s", k.Cluster, k.Account, k.User, k.Partition)
+}
+
+// Association is a slurmdbd association of an account, or of a user within an account. Account
+// associations have an empty User. On update, limits that are nil or missing from the maps are left
+// unchanged by slurmdbd.
+type Association struct {
+	ID            *int32
+	Cluster       string
+	Account       string
+	User          string
+	Partition     string
+	ParentAccount string
+	IsDefault     bool
+	SharesRaw     *int32
+	Priority      *int32
+	DefaultQOS    string
+	QOS           []string
+
+	// GrpTRES is the total amount of TRES running jobs of the association may use, keyed by TRES
+	// name (e.g. "cpu", "mem", "gres/gpu").
+	GrpTRES map[string]int64
+	// GrpTRESMinutes is the total amount of TRES-minutes jobs of the association may consume.
+	GrpTRESMinutes map[string]int64
+	// GrpJobs is the maximum number of running jobs of the association.
+	GrpJobs *int32
+	// MaxJobs is the maximum number of running jobs of each user of the association.
+	MaxJobs *int32
+	// MaxTRESPerJob is the amount of TRES a single job of the association may use.
+	MaxTRESPerJob map[string]int64
+	// MaxWallPerJobMinutes is the maximum wall clock time a job of the association may run for.
+	MaxWallPerJobMinutes *int32
+}
+
+func validateAPIAssociation(association api.V0044Assoc) error {
+	var errs []error
+
+	if association.Account == nil || *association.Account == "" {
+		errs = append(errs, errors.New("association doesn't have account"))
+	}
+
+	return errors.Join(errs...)
+}
+
+func AssociationFromAPI(association api.V0044Assoc) (Association, error) {
+	if err := validateAPIAssociation(association); err != nil {
+		return Association{}, err
+	}
+
+	res := Association{
+		ID:        association.Id,
+		Account:   *association.Account,
+		User:      association.User,
+		SharesRaw: association.SharesRaw,
+		Priority:  convertToInt(association.Priority),
+	}
+
+	if association.Cluster != nil {
+		res.Cluster = *association.Cluster
+	}
+	if association.Partition != nil {
+		res.Partition = *association.Partition
+	}
+	if association.ParentAccount != nil {
+		res.ParentAccount = *association.ParentAccount
+	}
+	if association.IsDefault != nil {
+		res.IsDefault = *association.IsDefault
+	}
+	if association.Default != nil && association.Default.Qos != nil {
+		res.DefaultQOS = *association.Default.Qos
+	}
+	if association.Qos != nil {
+		res.QOS = *association.Qos
+	}
+
+	if maxLimits := association.Max; maxLimits != nil {
+		if maxLimits.Tres != nil {
+			res.GrpTRES = tresListToMap(maxLimits.Tres.Total)
+			if maxLimits.Tres.Group != nil {
+				res.GrpTRESMinutes = tresListToMap(maxLimits.Tres.Group.Minutes)
+			}
+			if maxLimits.Tres.Per != nil {
+				res.MaxTRESPerJob = tresListToMap(maxLimits.Tres.Per.Job)
+			}
+		}
+		if maxLimits.Jobs != nil {
+			res.MaxJobs = convertToInt(maxLimits.Jobs.Active)
+			if maxLimits.Jobs.Per != nil {
+				res.GrpJobs = convertToInt(maxLimits.Jobs.Per.Count)
+				res.MaxWallPerJobMinutes = convertToInt(maxLimits.Jobs.Per.WallClock)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// IsUserAssociation reports whether the association belongs to a user rather than an account.
+func (a *Association) IsUserAssociation() bool {
+	return a.User != ""
+}
+
+func (a *Association) Key() AssociationKey {
+	return AssociationKey{
+		Cluster:   a.Cluster,
+		Account:   a.Account,
+		User:      a.User,
+		Partition: a.Partition,
+	}
+}
+
+// validateAssociation checks that the association can be submitted to slurmdbd.
+func validateAssociation(association Association) error {
+	var errs []error
+
+	if association.Account == "" {
+		errs = append(errs, errors.New("association doesn't have account"))
+	}
+	if association.Cluster == "" {
+		errs = append(errs, errors.New("association doesn't have cluster"))
+	}
+	if association.SharesRaw != nil && *association.SharesRaw < 0 {
+		errs = append(errs, errors.New("association shares must not be negative"))
+	}
+	if association.DefaultQOS != "" && len(association.QOS) > 0 && !slices.Contains(association.QOS, association.DefaultQOS) {
+		errs = append(errs, fmt.Errorf("association default qos %s is not in the qos list", association.DefaultQOS))
+	}
+
+	return errors.Join(errs...)
+}
+
+func associationToAPI(association Association) api.V0044Assoc {
+	res := api.V0044Assoc{
+		Account:   ptr.To(association.Account),
+		Cluster:   ptr.To(association.Cluster),
+		User:      association.User,
+		SharesRaw: association.SharesRaw,
+		Priority:  uint32NoVal(association.Priority),
+	}
+
+	if association.Partition != "" {
+		res.Partition = ptr.To(association.Partition)
+	}
+	if association.ParentAccount != "" {
+		res.ParentAccount = ptr.To(association.ParentAccount)
+	}
+	if association.IsDefault {
+		res.IsDefault = ptr.To(true)
+	}
+	if association.DefaultQOS != "" {
+		ensure(&res.Default).Qos = ptr.To(association.DefaultQOS)
+	}
+	if len(association.QOS) > 0 {
+		res.Qos = ptr.To(api.V0044QosStringIdList(association.QOS))
+	}
+
+	if len(association.GrpTRES) > 0 {
+		ensure(&ensure(&res.Max).Tres).Total = tresMapToList(association.GrpTRES)
+	}
+	if len(association.GrpTRESMinutes) > 0 {
+		ensure(&ensure(&ensure(&res.Max).Tres).Group).Minutes = tresMapToList(association.GrpTRESMinutes)
+	}
+	if len(association.MaxTRESPerJob) > 0 {
+		ensure(&ensure(&ensure(&res.Max).Tres).Per).Job = tresMapToList(association.MaxTRESPerJob)
+	}
+	if association.GrpJobs != nil {
+		ensure(&ensure(&ensure(&res.Max).Jobs).Per).Count = uint32NoVal(association.GrpJobs)
+	}
+	if association.MaxJobs != nil {
+		ensure(&ensure(&res.Max).Jobs).Active = uint32NoVal(association.MaxJobs)
+	}
+	if association.MaxWallPerJobMinutes != nil {
+		ensure(&ensure(&ensure(&res.Max).Jobs).Per).WallClock = uint32NoVal(association.MaxWallPerJobMinutes)
+	}
+
+	return res
+}
+
+// CreateAssociation creates an association in slurmdbd. The account, and the user for user
+// associations, must already exist.
+func (c *client) CreateAssociation(ctx context.Context, association Association) error {
+	if err := validateAssociation(association); err != nil {
+		return fmt.Errorf("create association: %w", err)
+	}
+	return c.postAssociation(ctx, fmt.Sprintf("create association %s", association.Key()), association)
+}
+
+// UpdateAssociation updates an existing association in slurmdbd. Slurmdbd matches associations by
+// cluster, account, user and partition, so creating and updating share the same request.
+func (c *client) UpdateAssociation(ctx context.Context, association Association) error {
+	if err := validateAssociation(association); err != nil {
+		return fmt.Errorf("update association: %w", err)
+	}
+	return c.postAssociation(ctx, fmt.Sprintf("update association %s", association.Key()), association)
+}
+
+func (c *client) postAssociation(ctx context.Context, action string, association Association) error {
+	response, err := c.SlurmdbV0044PostAssociationsWithResponse(ctx, api.V0044OpenapiAssocsResp{
+		Associations: api.V0044AssocList{associationToAPI(association)},
+	})
+	if err != nil {
+		return fmt.Errorf("post %s request: %w", action, err)
+	}
+	return checkSlurmRESTMutation(action, response.StatusCode(), response.Body)
+}
+
+// DeleteAssociation deletes an association from slurmdbd. As with sacctmgr, deleting an account
+// association also deletes the associations of its users.
+func (c *client) DeleteAssociation(ctx context.Context, key AssociationKey) error {
+	if key.Account == "" || key.Cluster == "" {
+		return fmt.Errorf("delete association %s: account and cluster are required", key)
+	}
+
+	params := &api.SlurmdbV0044DeleteAssociationParams{
+		Account: &key.Account,
+		Cluster: &key.Cluster,
+	}
+	if key.User != "" {
+		params.User = &key.User
+	}
+	if key.Partition != "" {
+		params.Partition = &key.Partition
+	}
+
+	action := fmt.Sprintf("delete association %s", key)
+	response, err := c.SlurmdbV0044DeleteAssociationWithResponse(ctx, params)
+	if err != nil {
+		return fmt.Errorf("%s request: %w", action, err)
+	}
+	return checkSlurmRESTMutation(action, response.StatusCode(), response.Body)
+}
@@ -0,0 +1,161 @@
+package slurmapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+)
+
+func TestAssociationFromAPI(t *testing.T) {
+	data, err := os.ReadFile("testdata/association_rest.json")
+	require.NoError(t, err)
+
+	var apiAssociation api.V0044Assoc
+	require.NoError(t, json.Unmarshal(data, &apiAssociation))
+
+	got, err := AssociationFromAPI(apiAssociation)
+	require.NoError(t, err)
+	// max.jobs.per.count is GrpJobs and max.jobs.active is MaxJobs
+	assert.Equal(t, Association{
+		ID:                   ptr.To(int32(3)),
+		Cluster:              "soperator",
+		Account:              "team-a",
+		ParentAccount:        "root",
+		SharesRaw:            ptr.To(int32(10)),
+		DefaultQOS:           "normal",
+		QOS:                  []string{"normal", "high"},
+		GrpTRES:              map[string]int64{"gres/gpu": 16},
+		GrpTRESMinutes:       map[string]int64{"cpu": 1000000},
+		GrpJobs:              ptr.To(int32(100)),
+		MaxJobs:              ptr.To(int32(10)),
+		MaxTRESPerJob:        map[string]int64{"gres/gpu": 8},
+		MaxWallPerJobMinutes: ptr.To(int32(1440)),
+	}, got)
+	assert.False(t, got.IsUserAssociation())
+}
+
+func TestAssociationFromAPI_MissingAccount(t *testing.T) {
+	_, err := AssociationFromAPI(api.V0044Assoc{User: "alice"})
+	assert.Error(t, err)
+}
+
+func TestAssociationToAPIRoundTrip(t *testing.T) {
+	association := Association{
+		Cluster:              "soperator",
+		Account:              "team-a",
+		User:                 "alice",
+		ParentAccount:        "root",
+		IsDefault:            true,
+		SharesRaw:            ptr.To(int32(10)),
+		Priority:             ptr.To(int32(5)),
+		DefaultQOS:           "normal",
+		QOS:                  []string{"normal", "high"},
+		GrpTRES:              map[string]int64{"gres/gpu": 16},
+		GrpTRESMinutes:       map[string]int64{"cpu": 1000000},
+		GrpJobs:              ptr.To(int32(100)),
+		MaxJobs:              ptr.To(int32(10)),
+		MaxTRESPerJob:        map[string]int64{"gres/gpu": 8},
+		MaxWallPerJobMinutes: ptr.To(int32(1440)),
+	}
+
+	got, err := AssociationFromAPI(associationToAPI(association))
+	require.NoError(t, err)
+	assert.Equal(t, association, got)
+}
+
+func TestAssociationToAPIJobLimits(t *testing.T) {
+	data, err := json.Marshal(associationToAPI(Association{
+		Cluster: "soperator",
+		Account: "team-a",
+		GrpJobs: ptr.To(int32(100)),
+		MaxJobs: ptr.To(int32(10)),
+	}))
+	require.NoError(t, err)
+
+	var raw struct {
+		Max struct {
+			Jobs struct {
+				Active struct {
+					Number int `json:"number"`
+				} `json:"active"`
+				Per struct {
+					Count struct {
+						Number int `json:"number"`
+					} `json:"count"`
+				} `json:"per"`
+			} `json:"jobs"`
+		} `json:"max"`
+	}
+	require.NoError(t, json.Unmarshal(data, &raw))
+	assert.Equal(t, 100, raw.Max.Jobs.Per.Count.Number, "max.jobs.per.count is GrpJobs")
+	assert.Equal(t, 10, raw.Max.Jobs.Active.Number, "max.jobs.active is MaxJobs")
+}
+
+func TestValidateAssociation(t *testing.T) {
+	err := validateAssociation(Association{DefaultQOS: "high", QOS: []string{"normal"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "doesn't have account")
+	assert.Contains(t, err.Error(), "doesn't have cluster")
+	assert.Contains(t, err.Error(), "default qos high is not in the qos list")
+}
+
+func TestGetAssociationPicksAccountAssociation(t *testing.T) {
+	httpClient := &http.Client{Transport: roundTripFunc(func(request *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodGet, request.Method)
+		assert.Equal(t, "/slurmdb/v0.0.44/association/", request.URL.Path)
+		assert.Equal(t, "team-a", request.URL.Query().Get("account"))
+		assert.False(t, request.URL.Query().Has("user"))
+		return undrainJSONResponse(http.StatusOK, `{"associations":[
+			{"account":"team-a","cluster":"soperator","user":"alice"},
+			{"account":"team-a","cluster":"soperator","user":"","shares_raw":10}
+		]}`), nil
+	})}
+
+	client, err := NewClient("http://slurmrestd", nil, httpClient)
+	require.NoError(t, err)
+
+	got, err := client.GetAssociation(context.Background(), AssociationKey{Cluster: "soperator", Account: "team-a"})
+	require.NoError(t, err)
+	assert.Equal(t, "", got.User)
+	assert.Equal(t, ptr.To(int32(10)), got.SharesRaw)
+}
+
+func TestCreateAssociationPostsV0044Payload(t *testing.T) {
+	var gotBody api.V0044OpenapiAssocsResp
+	httpClient := &http.Client{Transport: roundTripFunc(func(request *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPost, request.Method)
+		assert.Equal(t, "/slurmdb/v0.0.44/associations/", request.URL.Path)
+
+		require.NoError(t, json.NewDecoder(request.Body).Decode(&gotBody))
+		return undrainJSONResponse(http.StatusOK, `{"errors":[]}`), nil
+	})}
+
+	client, err := NewClient("http://slurmrestd/", nil, httpClient)
+	require.NoError(t, err)
+
+	require.NoError(t, client.CreateAssociation(context.Background(), Association{
+		Cluster: "soperator",
+		Account: "team-a",
+		User:    "alice",
+		GrpTRES: map[string]int64{"gres/gpu": 8},
+	}))
+	require.Len(t, gotBody.Associations, 1)
+	assert.Equal(t, "alice", gotBody.Associations[0].User)
+	assert.Equal(t, map[string]int64{"gres/gpu": 8}, tresListToMap(gotBody.Associations[0].Max.Tres.Total))
+}
+
+func TestDeleteAssociationRequiresAccountAndCluster(t *testing.T) {
+	client, err := NewClient("http://slurmrestd", nil, nil)
+	require.NoError(t, err)
+
+	err = client.DeleteAssociation(context.Background(), AssociationKey{Account: "team-a"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "account and cluster are required")
+}
@@ -1,6 +1,7 @@
 package slurmapi
 
 import (
+	"bytes"
 	"cmp"
 	"context"
 	"encoding/json"
@@ -54,6 +55,28 @@ func summarizeSlurmRESTBody(body []byte) string {
 	return fmt.Sprintf("body=%s", string(body))
 }
 
+// checkSlurmRESTMutation verifies the response of a create, update or delete request. Slurm REST
+// API may report failures with a successful status code, so the errors of the envelope are checked
+// as well.
+func checkSlurmRESTMutation(action string, statusCode int, body []byte) error {
+	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%s request failed: status=%d %s", action, statusCode, summarizeSlurmRESTBody(body))
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	var responseEnvelope api.V0044OpenapiResp
+	if err := json.Unmarshal(body, &responseEnvelope); err != nil {
+		return fmt.Errorf("decode %s response: %w", action, err)
+	}
+	if responseEnvelope.Errors != nil && len(*responseEnvelope.Errors) > 0 {
+		return fmt.Errorf("%s responded with errors: %s", action, summarizeSlurmRESTBody(body))
+	}
+
+	return nil
+}
+
 const (
 	headerSlurmUserToken = "X-SLURM-USER-TOKEN"
 
@@ -373,3 +396,301 @@ func (c *client) GetDiag(ctx context.Context) (*api.V0044OpenapiDiagResp, error)
 
 	return getDiagResp.JSON200, nil
 }
+
+func (c *client) ListPartitions(ctx context.Context) ([]Partition, error) {
+	getPartitionsResp, err := c.SlurmV0044GetPartitionsWithResponse(ctx, &api.SlurmV0044GetPartitionsParams{})
+	if err != nil {
+		return nil, fmt.Errorf("list partitions: %w", err)
+	}
+	if getPartitionsResp.JSON200 == nil {
+		return nil, fmt.Errorf("list partitions: status=%d %s", getPartitionsResp.StatusCode(), summarizeSlurmRESTBody(getPartitionsResp.Body))
+	}
+	if getPartitionsResp.JSON200.Errors != nil && len(*getPartitionsResp.JSON200.Errors) != 0 {
+		return nil, fmt.Errorf("list partitions responded with errors: %v", *getPartitionsResp.JSON200.Errors)
+	}
+
+	partitions := make([]Partition, 0, len(getPartitionsResp.JSON200.Partitions))
+	for _, p := range getPartitionsResp.JSON200.Partitions {
+		partition, err := PartitionFromAPI(p)
+		if err != nil {
+			return nil, fmt.Errorf("convert partition from api response: %w", err)
+		}
+
+		partitions = append(partitions, partition)
+	}
+
+	return partitions, nil
+}
+
+func (c *client) ListReservations(ctx context.Context) ([]Reservation, error) {
+	getReservationsResp, err := c.SlurmV0044GetReservationsWithResponse(ctx, &api.SlurmV0044GetReservationsParams{})
+	if err != nil {
+		return nil, fmt.Errorf("list reservations: %w", err)
+	}
+	if getReservationsResp.JSON200 == nil {
+		return nil, fmt.Errorf("list reservations: status=%d %s", getReservationsResp.StatusCode(), summarizeSlurmRESTBody(getReservationsResp.Body))
+	}
+	if getReservationsResp.JSON200.Errors != nil && len(*getReservationsResp.JSON200.Errors) != 0 {
+		return nil, fmt.Errorf("list reservations responded with errors: %v", *getReservationsResp.JSON200.Errors)
+	}
+
+	reservations := make([]Reservation, 0, len(getReservationsResp.JSON200.Reservations))
+	for _, r := range getReservationsResp.JSON200.Reservations {
+		reservation, err := ReservationFromAPI(r)
+		if err != nil {
+			return nil, fmt.Errorf("convert reservation from api response: %w", err)
+		}
+
+		reservations = append(reservations, reservation)
+	}
+
+	return reservations, nil
+}
+
+// ListQOS reads QOS definitions from slurmdbd. It fails on clusters without accounting.
+func (c *client) ListQOS(ctx context.Context) ([]QOS, error) {
+	getQOSResp, err := c.SlurmdbV0044GetQosWithResponse(ctx, &api.SlurmdbV0044GetQosParams{})
+	if err != nil {
+		return nil, fmt.Errorf("list qos: %w", err)
+	}
+	if getQOSResp.JSON200 == nil {
+		return nil, fmt.Errorf("list qos: status=%d %s", getQOSResp.StatusCode(), summarizeSlurmRESTBody(getQOSResp.Body))
+	}
+	if getQOSResp.JSON200.Errors != nil && len(*getQOSResp.JSON200.Errors) != 0 {
+		return nil, fmt.Errorf("list qos responded with errors: %v", *getQOSResp.JSON200.Errors)
+	}
+
+	qosList := make([]QOS, 0, len(getQOSResp.JSON200.Qos))
+	for _, q := range getQOSResp.JSON200.Qos {
+		qos, err := QOSFromAPI(q)
+		if err != nil {
+			return nil, fmt.Errorf("convert qos from api response: %w", err)
+		}
+
+		qosList = append(qosList, qos)
+	}
+
+	return qosList, nil
+}
+
+func (c *client) ListShares(ctx context.Context) ([]Share, error) {
+	getSharesResp, err := c.SlurmV0044GetSharesWithResponse(ctx, &api.SlurmV0044GetSharesParams{})
+	if err != nil {
+		return nil, fmt.Errorf("list shares: %w", err)
+	}
+	if getSharesResp.JSON200 == nil {
+		return nil, fmt.Errorf("list shares: status=%d %s", getSharesResp.StatusCode(), summarizeSlurmRESTBody(getSharesResp.Body))
+	}
+	if getSharesResp.JSON200.Errors != nil && len(*getSharesResp.JSON200.Errors) != 0 {
+		return nil, fmt.Errorf("list shares responded with errors: %v", *getSharesResp.JSON200.Errors)
+	}
+	if getSharesResp.JSON200.Shares.Shares == nil {
+		return nil, nil
+	}
+
+	shares := make([]Share, 0, len(*getSharesResp.JSON200.Shares.Shares))
+	for _, s := range *getSharesResp.JSON200.Shares.Shares {
+		share, err := ShareFromAPI(s)
+		if err != nil {
+			return nil, fmt.Errorf("convert share from api response: %w", err)
+		}
+
+		shares = append(shares, share)
+	}
+
+	return shares, nil
+}
+
+func (c *client) ListAssociations(ctx context.Context) ([]Association, error) {
+	getAssociationsResp, err := c.SlurmdbV0044GetAssociationsWithResponse(ctx, &api.SlurmdbV0044GetAssociationsParams{})
+	if err != nil {
+		return nil, fmt.Errorf("list associations: %w", err)
+	}
+	if getAssociationsResp.JSON200 == nil {
+		return nil, fmt.Errorf("list associations: status=%d %s", getAssociationsResp.StatusCode(), summarizeSlurmRESTBody(getAssociationsResp.Body))
+	}
+	if getAssociationsResp.JSON200.Errors != nil && len(*getAssociationsResp.JSON200.Errors) != 0 {
+		return nil, fmt.Errorf("list associations responded with errors: %v", *getAssociationsResp.JSON200.Errors)
+	}
+
+	associations := make([]Association, 0, len(getAssociationsResp.JSON200.Associations))
+	for _, a := range getAssociationsResp.JSON200.Associations {
+		association, err := AssociationFromAPI(a)
+		if err != nil {
+			return nil, fmt.Errorf("convert association from api response: %w", err)
+		}
+
+		associations = append(associations, association)
+	}
+
+	return associations, nil
+}
+
+func (c *client) ListAccounts(ctx context.Context) ([]Account, error) {
+	getAccountsResp, err := c.SlurmdbV0044GetAccountsWithResponse(ctx, &api.SlurmdbV0044GetAccountsParams{})
+	if err != nil {
+		return nil, fmt.Errorf("list accounts: %w", err)
+	}
+	if getAccountsResp.JSON200 == nil {
+		return nil, fmt.Errorf("list accounts: status=%d %s", getAccountsResp.StatusCode(), summarizeSlurmRESTBody(getAccountsResp.Body))
+	}
+	if getAccountsResp.JSON200.Errors != nil && len(*getAccountsResp.JSON200.Errors) != 0 {
+		return nil, fmt.Errorf("list accounts responded with errors: %v", *getAccountsResp.JSON200.Errors)
+	}
+
+	accounts := make([]Account, 0, len(getAccountsResp.JSON200.Accounts))
+	for _, a := range getAccountsResp.JSON200.Accounts {
+		account, err := AccountFromAPI(a)
+		if err != nil {
+			return nil, fmt.Errorf("convert account from api response: %w", err)
+		}
+
+		accounts = append(accounts, account)
+	}
+
+	return accounts, nil
+}
+
+func (c *client) ListUsers(ctx context.Context) ([]User, error) {
+	getUsersResp, err := c.SlurmdbV0044GetUsersWithResponse(ctx, &api.SlurmdbV0044GetUsersParams{})
+	if err != nil {
+		return nil, fmt.Errorf("list users: %w", err)
+	}
+	if getUsersResp.JSON200 == nil {
+		return nil, fmt.Errorf("list users: status=%d %s", getUsersResp.StatusCode(), summarizeSlurmRESTBody(getUsersResp.Body))
+	}
+	if getUsersResp.JSON200.Errors != nil && len(*getUsersResp.JSON200.Errors) != 0 {
+		return nil, fmt.Errorf("list users responded with errors: %v", *getUsersResp.JSON200.Errors)
+	}
+
+	users := make([]User, 0, len(getUsersResp.JSON200.Users))
+	for _, u := range getUsersResp.JSON200.Users {
+		user, err := UserFromAPI(u)
+		if err != nil {
+			return nil, fmt.Errorf("convert user from api response: %w", err)
+		}
+
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+// GetPartition returns a single partition. Partitions can't be created or modified through
+// slurmrestd; they are defined in slurm.conf, which is rendered from the SlurmCluster spec.
+func (c *client) GetPartition(ctx context.Context, partitionName string) (Partition, error) {
+	getPartitionResp, err := c.SlurmV0044GetPartitionWithResponse(ctx, partitionName, &api.SlurmV0044GetPartitionParams{})
+	if err != nil {
+		return Partition{}, fmt.Errorf("get partition %s: %w", partitionName, err)
+	}
+	if getPartitionResp.JSON200 == nil {
+		return Partition{}, fmt.Errorf("get partition %s: status=%d %s", partitionName, getPartitionResp.StatusCode(), summarizeSlurmRESTBody(getPartitionResp.Body))
+	}
+	if getPartitionResp.JSON200.Errors != nil && len(*getPartitionResp.JSON200.Errors) != 0 {
+		return Partition{}, fmt.Errorf("get partition %s responded with errors: %v", partitionName, *getPartitionResp.JSON200.Errors)
+	}
+
+	if partitionLength := len(getPartitionResp.JSON200.Partitions); partitionLength != 1 {
+		return Partition{}, fmt.Errorf("expected only one partition in response for get %s request, got %d", partitionName, partitionLength)
+	}
+
+	partition, err := PartitionFromAPI(getPartitionResp.JSON200.Partitions[0])
+	if err != nil {
+		return Partition{}, fmt.Errorf("convert partition from api response: %w", err)
+	}
+
+	return partition, nil
+}
+
+func (c *client) GetReservation(ctx context.Context, reservationName string) (Reservation, error) {
+	getReservationResp, err := c.SlurmV0044GetReservationWithResponse(ctx, reservationName, &api.SlurmV0044GetReservationParams{})
+	if err != nil {
+		return Reservation{}, fmt.Errorf("get reservation %s: %w", reservationName, err)
+	}
+	if getReservationResp.JSON200 == nil {
+		return Reservation{}, fmt.Errorf("get reservation %s: status=%d %s", reservationName, getReservationResp.StatusCode(), summarizeSlurmRESTBody(getReservationResp.Body))
+	}
+	if getReservationResp.JSON200.Errors != nil && len(*getReservationResp.JSON200.Errors) != 0 {
+		return Reservation{}, fmt.Errorf("get reservation %s responded with errors: %v", reservationName, *getReservationResp.JSON200.Errors)
+	}
+
+	if reservationLength := len(getReservationResp.JSON200.Reservations); reservationLength != 1 {
+		return Reservation{}, fmt.Errorf("expected only one reservation in response for get %s request, got %d", reservationName, reservationLength)
+	}
+
+	reservation, err := ReservationFromAPI(getReservationResp.JSON200.Reservations[0])
+	if err != nil {
+		return Reservation{}, fmt.Errorf("convert reservation from api response: %w", err)
+	}
+
+	return reservation, nil
+}
+
+func (c *client) GetQOS(ctx context.Context, qosName string) (QOS, error) {
+	getQOSResp, err := c.SlurmdbV0044GetSingleQosWithResponse(ctx, qosName, &api.SlurmdbV0044GetSingleQosParams{})
+	if err != nil {
+		return QOS{}, fmt.Errorf("get qos %s: %w", qosName, err)
+	}
+	if getQOSResp.JSON200 == nil {
+		return QOS{}, fmt.Errorf("get qos %s: status=%d %s", qosName, getQOSResp.StatusCode(), summarizeSlurmRESTBody(getQOSResp.Body))
+	}
+	if getQOSResp.JSON200.Errors != nil && len(*getQOSResp.JSON200.Errors) != 0 {
+		return QOS{}, fmt.Errorf("get qos %s responded with errors: %v", qosName, *getQOSResp.JSON200.Errors)
+	}
+
+	if qosLength := len(getQOSResp.JSON200.Qos); qosLength != 1 {
+		return QOS{}, fmt.Errorf("expected only one qos in response for get %s request, got %d", qosName, qosLength)
+	}
+
+	qos, err := QOSFromAPI(getQOSResp.JSON200.Qos[0])
+	if err != nil {
+		return QOS{}, fmt.Errorf("convert qos from api response: %w", err)
+	}
+
+	return qos, nil
+}
+
+func (c *client) GetAssociation(ctx context.Context, key AssociationKey) (Association, error) {
+	params := &api.SlurmdbV0044GetAssociationParams{
+		Account: &key.Account,
+	}
+	if key.User != "" {
+		params.User = &key.User
+	}
+	if key.Cluster != "" {
+		params.Cluster = &key.Cluster
+	}
+	if key.Partition != "" {
+		params.Partition = &key.Partition
+	}
+
+	getAssociationResp, err := c.SlurmdbV0044GetAssociationWithResponse(ctx, params)
+	if err != nil {
+		return Association{}, fmt.Errorf("get association %s: %w", key, err)
+	}
+	if getAssociationResp.JSON200 == nil {
+		return Association{}, fmt.Errorf("get association %s: status=%d %s", key, getAssociationResp.StatusCode(), summarizeSlurmRESTBody(getAssociationResp.Body))
+	}
+	if getAssociationResp.JSON200.Errors != nil && len(*getAssociationResp.JSON200.Errors) != 0 {
+		return Association{}, fmt.Errorf("get association %s responded with errors: %v", key, *getAssociationResp.JSON200.Errors)
+	}
+
+	// Slurmdbd treats an empty user filter as "any user", so the account association is picked out
+	// of the response explicitly.
+	var matched []api.V0044Assoc
+	for _, a := range getAssociationResp.JSON200.Associations {
+		if a.User == key.User && ptr.Deref(a.Partition, "") == key.Partition {
+			matched = append(matched, a)
+		}
+	}
+	if len(matched) != 1 {
+		return Association{}, fmt.Errorf("expected only one association in response for get %s request, got %d", key, len(matched))
+	}
+
+	association, err := AssociationFromAPI(matched[0])
+	if err != nil {
+		return Association{}, fmt.Errorf("convert association from api response: %w", err)
+	}
+
+	return association, nil
+}
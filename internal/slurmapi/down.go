@@ -0,0 +1,30 @@
+package slurmapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"k8s.io/utils/ptr"
+)
+
+// DownNode moves a Slurm node to DOWN state with the given reason through slurmrestd.
+func (c *client) DownNode(ctx context.Context, nodeName, reason string) error {
+	if nodeName == "" {
+		return errors.New("down node: node name is required")
+	}
+	if reason == "" {
+		return errors.New("down node: reason is required")
+	}
+
+	states := []api.V0044UpdateNodeMsgState{api.V0044UpdateNodeMsgStateDOWN}
+	response, err := c.SlurmV0044PostNodeWithResponse(ctx, nodeName, api.V0044UpdateNodeMsg{
+		State:  &states,
+		Reason: ptr.To(reason),
+	})
+	if err != nil {
+		return fmt.Errorf("post down node %s request: %w", nodeName, err)
+	}
+	return checkSlurmRESTMutation("down node "+nodeName, response.StatusCode(), response.Body)
+}
@@ -0,0 +1,50 @@
+package slurmapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownNodePostsStateAndReason(t *testing.T) {
+	var gotBody map[string]any
+	httpClient := &http.Client{Transport: roundTripFunc(func(request *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPost, request.Method)
+		assert.Equal(t, "/slurm/v0.0.44/node/worker-1", request.URL.Path)
+
+		require.NoError(t, json.NewDecoder(request.Body).Decode(&gotBody))
+		return undrainJSONResponse(http.StatusOK, `{"errors":[]}`), nil
+	})}
+
+	client, err := NewClient("http://slurmrestd", staticTokenIssuer("token-value"), httpClient)
+	require.NoError(t, err)
+
+	require.NoError(t, client.DownNode(context.Background(), "worker-1", "pod worker-1 is unschedulable"))
+	assert.Equal(t, []any{"DOWN"}, gotBody["state"])
+	assert.Equal(t, "pod worker-1 is unschedulable", gotBody["reason"])
+}
+
+func TestDownNodeRequiresNodeNameAndReason(t *testing.T) {
+	client, err := NewClient("http://slurmrestd", nil, nil)
+	require.NoError(t, err)
+
+	require.ErrorContains(t, client.DownNode(context.Background(), "", "reason"), "node name is required")
+	require.ErrorContains(t, client.DownNode(context.Background(), "worker-1", ""), "reason is required")
+}
+
+func TestDownNodeReportsEnvelopeErrors(t *testing.T) {
+	httpClient := &http.Client{Transport: roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return undrainJSONResponse(http.StatusOK, `{"errors":[{"description":"Invalid node name"}]}`), nil
+	})}
+
+	client, err := NewClient("http://slurmrestd", nil, httpClient)
+	require.NoError(t, err)
+
+	err = client.DownNode(context.Background(), "worker-1", "reason")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Invalid node name")
+}
@@ -24,24 +24,1130 @@ func (_m *MockClient) EXPECT() *MockClient_Expecter {
 	return &MockClient_Expecter{mock: &_m.Mock}
 }
 
+// CreateAccount provides a mock function with given fields: ctx, account
+func (_m *MockClient) CreateAccount(ctx context.Context, account slurmapi.Account) error {
+	ret := _m.Called(ctx, account)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAccount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, slurmapi.Account) error); ok {
+		r0 = rf(ctx, account)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_CreateAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAccount'
+type MockClient_CreateAccount_Call struct {
+	*mock.Call
+}
+
+// CreateAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - account slurmapi.Account
+func (_e *MockClient_Expecter) CreateAccount(ctx interface{}, account interface{}) *MockClient_CreateAccount_Call {
+	return &MockClient_CreateAccount_Call{Call: _e.mock.On("CreateAccount", ctx, account)}
+}
+
+func (_c *MockClient_CreateAccount_Call) Run(run func(ctx context.Context, account slurmapi.Account)) *MockClient_CreateAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(slurmapi.Account))
+	})
+	return _c
+}
+
+func (_c *MockClient_CreateAccount_Call) Return(_a0 error) *MockClient_CreateAccount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_CreateAccount_Call) RunAndReturn(run func(context.Context, slurmapi.Account) error) *MockClient_CreateAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateAssociation provides a mock function with given fields: ctx, association
+func (_m *MockClient) CreateAssociation(ctx context.Context, association slurmapi.Association) error {
+	ret := _m.Called(ctx, association)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAssociation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, slurmapi.Association) error); ok {
+		r0 = rf(ctx, association)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_CreateAssociation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAssociation'
+type MockClient_CreateAssociation_Call struct {
+	*mock.Call
+}
+
+// CreateAssociation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - association slurmapi.Association
+func (_e *MockClient_Expecter) CreateAssociation(ctx interface{}, association interface{}) *MockClient_CreateAssociation_Call {
+	return &MockClient_CreateAssociation_Call{Call: _e.mock.On("CreateAssociation", ctx, association)}
+}
+
+func (_c *MockClient_CreateAssociation_Call) Run(run func(ctx context.Context, association slurmapi.Association)) *MockClient_CreateAssociation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(slurmapi.Association))
+	})
+	return _c
+}
+
+func (_c *MockClient_CreateAssociation_Call) Return(_a0 error) *MockClient_CreateAssociation_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_CreateAssociation_Call) RunAndReturn(run func(context.Context, slurmapi.Association) error) *MockClient_CreateAssociation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateQOS provides a mock function with given fields: ctx, qos
+func (_m *MockClient) CreateQOS(ctx context.Context, qos slurmapi.QOS) error {
+	ret := _m.Called(ctx, qos)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateQOS")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, slurmapi.QOS) error); ok {
+		r0 = rf(ctx, qos)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_CreateQOS_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateQOS'
+type MockClient_CreateQOS_Call struct {
+	*mock.Call
+}
+
+// CreateQOS is a helper method to define mock.On call
+//   - ctx context.Context
+//   - qos slurmapi.QOS
+func (_e *MockClient_Expecter) CreateQOS(ctx interface{}, qos interface{}) *MockClient_CreateQOS_Call {
+	return &MockClient_CreateQOS_Call{Call: _e.mock.On("CreateQOS", ctx, qos)}
+}
+
+func (_c *MockClient_CreateQOS_Call) Run(run func(ctx context.Context, qos slurmapi.QOS)) *MockClient_CreateQOS_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(slurmapi.QOS))
+	})
+	return _c
+}
+
+func (_c *MockClient_CreateQOS_Call) Return(_a0 error) *MockClient_CreateQOS_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_CreateQOS_Call) RunAndReturn(run func(context.Context, slurmapi.QOS) error) *MockClient_CreateQOS_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateReservation provides a mock function with given fields: ctx, reservation
+func (_m *MockClient) CreateReservation(ctx context.Context, reservation slurmapi.Reservation) error {
+	ret := _m.Called(ctx, reservation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateReservation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, slurmapi.Reservation) error); ok {
+		r0 = rf(ctx, reservation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_CreateReservation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateReservation'
+type MockClient_CreateReservation_Call struct {
+	*mock.Call
+}
+
+// CreateReservation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reservation slurmapi.Reservation
+func (_e *MockClient_Expecter) CreateReservation(ctx interface{}, reservation interface{}) *MockClient_CreateReservation_Call {
+	return &MockClient_CreateReservation_Call{Call: _e.mock.On("CreateReservation", ctx, reservation)}
+}
+
+func (_c *MockClient_CreateReservation_Call) Run(run func(ctx context.Context, reservation slurmapi.Reservation)) *MockClient_CreateReservation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(slurmapi.Reservation))
+	})
+	return _c
+}
+
+func (_c *MockClient_CreateReservation_Call) Return(_a0 error) *MockClient_CreateReservation_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_CreateReservation_Call) RunAndReturn(run func(context.Context, slurmapi.Reservation) error) *MockClient_CreateReservation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateUser provides a mock function with given fields: ctx, user
+func (_m *MockClient) CreateUser(ctx context.Context, user slurmapi.User) error {
+	ret := _m.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, slurmapi.User) error); ok {
+		r0 = rf(ctx, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
+type MockClient_CreateUser_Call struct {
+	*mock.Call
+}
+
+// CreateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user slurmapi.User
+func (_e *MockClient_Expecter) CreateUser(ctx interface{}, user interface{}) *MockClient_CreateUser_Call {
+	return &MockClient_CreateUser_Call{Call: _e.mock.On("CreateUser", ctx, user)}
+}
+
+func (_c *MockClient_CreateUser_Call) Run(run func(ctx context.Context, user slurmapi.User)) *MockClient_CreateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(slurmapi.User))
+	})
+	return _c
+}
+
+func (_c *MockClient_CreateUser_Call) Return(_a0 error) *MockClient_CreateUser_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_CreateUser_Call) RunAndReturn(run func(context.Context, slurmapi.User) error) *MockClient_CreateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAccount provides a mock function with given fields: ctx, accountName
+func (_m *MockClient) DeleteAccount(ctx context.Context, accountName string) error {
+	ret := _m.Called(ctx, accountName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAccount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, accountName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_DeleteAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAccount'
+type MockClient_DeleteAccount_Call struct {
+	*mock.Call
+}
+
+// DeleteAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - accountName string
+func (_e *MockClient_Expecter) DeleteAccount(ctx interface{}, accountName interface{}) *MockClient_DeleteAccount_Call {
+	return &MockClient_DeleteAccount_Call{Call: _e.mock.On("DeleteAccount", ctx, accountName)}
+}
+
+func (_c *MockClient_DeleteAccount_Call) Run(run func(ctx context.Context, accountName string)) *MockClient_DeleteAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_DeleteAccount_Call) Return(_a0 error) *MockClient_DeleteAccount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_DeleteAccount_Call) RunAndReturn(run func(context.Context, string) error) *MockClient_DeleteAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAssociation provides a mock function with given fields: ctx, key
+func (_m *MockClient) DeleteAssociation(ctx context.Context, key slurmapi.AssociationKey) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAssociation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, slurmapi.AssociationKey) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_DeleteAssociation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAssociation'
+type MockClient_DeleteAssociation_Call struct {
+	*mock.Call
+}
+
+// DeleteAssociation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key slurmapi.AssociationKey
+func (_e *MockClient_Expecter) DeleteAssociation(ctx interface{}, key interface{}) *MockClient_DeleteAssociation_Call {
+	return &MockClient_DeleteAssociation_Call{Call: _e.mock.On("DeleteAssociation", ctx, key)}
+}
+
+func (_c *MockClient_DeleteAssociation_Call) Run(run func(ctx context.Context, key slurmapi.AssociationKey)) *MockClient_DeleteAssociation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(slurmapi.AssociationKey))
+	})
+	return _c
+}
+
+func (_c *MockClient_DeleteAssociation_Call) Return(_a0 error) *MockClient_DeleteAssociation_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_DeleteAssociation_Call) RunAndReturn(run func(context.Context, slurmapi.AssociationKey) error) *MockClient_DeleteAssociation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteQOS provides a mock function with given fields: ctx, qosName
+func (_m *MockClient) DeleteQOS(ctx context.Context, qosName string) error {
+	ret := _m.Called(ctx, qosName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteQOS")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, qosName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_DeleteQOS_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteQOS'
+type MockClient_DeleteQOS_Call struct {
+	*mock.Call
+}
+
+// DeleteQOS is a helper method to define mock.On call
+//   - ctx context.Context
+//   - qosName string
+func (_e *MockClient_Expecter) DeleteQOS(ctx interface{}, qosName interface{}) *MockClient_DeleteQOS_Call {
+	return &MockClient_DeleteQOS_Call{Call: _e.mock.On("DeleteQOS", ctx, qosName)}
+}
+
+func (_c *MockClient_DeleteQOS_Call) Run(run func(ctx context.Context, qosName string)) *MockClient_DeleteQOS_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_DeleteQOS_Call) Return(_a0 error) *MockClient_DeleteQOS_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_DeleteQOS_Call) RunAndReturn(run func(context.Context, string) error) *MockClient_DeleteQOS_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteReservation provides a mock function with given fields: ctx, reservationName
+func (_m *MockClient) DeleteReservation(ctx context.Context, reservationName string) error {
+	ret := _m.Called(ctx, reservationName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteReservation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, reservationName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_DeleteReservation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteReservation'
+type MockClient_DeleteReservation_Call struct {
+	*mock.Call
+}
+
+// DeleteReservation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reservationName string
+func (_e *MockClient_Expecter) DeleteReservation(ctx interface{}, reservationName interface{}) *MockClient_DeleteReservation_Call {
+	return &MockClient_DeleteReservation_Call{Call: _e.mock.On("DeleteReservation", ctx, reservationName)}
+}
+
+func (_c *MockClient_DeleteReservation_Call) Run(run func(ctx context.Context, reservationName string)) *MockClient_DeleteReservation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_DeleteReservation_Call) Return(_a0 error) *MockClient_DeleteReservation_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_DeleteReservation_Call) RunAndReturn(run func(context.Context, string) error) *MockClient_DeleteReservation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DownNode provides a mock function with given fields: ctx, nodeName, reason
+func (_m *MockClient) DownNode(ctx context.Context, nodeName string, reason string) error {
+	ret := _m.Called(ctx, nodeName, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DownNode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, nodeName, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_DownNode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DownNode'
+type MockClient_DownNode_Call struct {
+	*mock.Call
+}
+
+// DownNode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - nodeName string
+//   - reason string
+func (_e *MockClient_Expecter) DownNode(ctx interface{}, nodeName interface{}, reason interface{}) *MockClient_DownNode_Call {
+	return &MockClient_DownNode_Call{Call: _e.mock.On("DownNode", ctx, nodeName, reason)}
+}
+
+func (_c *MockClient_DownNode_Call) Run(run func(ctx context.Context, nodeName string, reason string)) *MockClient_DownNode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_DownNode_Call) Return(_a0 error) *MockClient_DownNode_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_DownNode_Call) RunAndReturn(run func(context.Context, string, string) error) *MockClient_DownNode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAssociation provides a mock function with given fields: ctx, key
+func (_m *MockClient) GetAssociation(ctx context.Context, key slurmapi.AssociationKey) (slurmapi.Association, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAssociation")
+	}
+
+	var r0 slurmapi.Association
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, slurmapi.AssociationKey) (slurmapi.Association, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, slurmapi.AssociationKey) slurmapi.Association); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Get(0).(slurmapi.Association)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, slurmapi.AssociationKey) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetAssociation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAssociation'
+type MockClient_GetAssociation_Call struct {
+	*mock.Call
+}
+
+// GetAssociation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key slurmapi.AssociationKey
+func (_e *MockClient_Expecter) GetAssociation(ctx interface{}, key interface{}) *MockClient_GetAssociation_Call {
+	return &MockClient_GetAssociation_Call{Call: _e.mock.On("GetAssociation", ctx, key)}
+}
+
+func (_c *MockClient_GetAssociation_Call) Run(run func(ctx context.Context, key slurmapi.AssociationKey)) *MockClient_GetAssociation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(slurmapi.AssociationKey))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetAssociation_Call) Return(_a0 slurmapi.Association, _a1 error) *MockClient_GetAssociation_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetAssociation_Call) RunAndReturn(run func(context.Context, slurmapi.AssociationKey) (slurmapi.Association, error)) *MockClient_GetAssociation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetDiag provides a mock function with given fields: ctx
 func (_m *MockClient) GetDiag(ctx context.Context) (*v0044.V0044OpenapiDiagResp, error) {
 	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetDiag")
+		panic("no return value specified for GetDiag")
+	}
+
+	var r0 *v0044.V0044OpenapiDiagResp
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*v0044.V0044OpenapiDiagResp, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *v0044.V0044OpenapiDiagResp); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v0044.V0044OpenapiDiagResp)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetDiag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDiag'
+type MockClient_GetDiag_Call struct {
+	*mock.Call
+}
+
+// GetDiag is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockClient_Expecter) GetDiag(ctx interface{}) *MockClient_GetDiag_Call {
+	return &MockClient_GetDiag_Call{Call: _e.mock.On("GetDiag", ctx)}
+}
+
+func (_c *MockClient_GetDiag_Call) Run(run func(ctx context.Context)) *MockClient_GetDiag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetDiag_Call) Return(_a0 *v0044.V0044OpenapiDiagResp, _a1 error) *MockClient_GetDiag_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetDiag_Call) RunAndReturn(run func(context.Context) (*v0044.V0044OpenapiDiagResp, error)) *MockClient_GetDiag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetJobsByIDFromAccounting provides a mock function with given fields: ctx, jobID
+func (_m *MockClient) GetJobsByIDFromAccounting(ctx context.Context, jobID string) ([]slurmapi.Job, error) {
+	ret := _m.Called(ctx, jobID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJobsByIDFromAccounting")
+	}
+
+	var r0 []slurmapi.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]slurmapi.Job, error)); ok {
+		return rf(ctx, jobID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []slurmapi.Job); ok {
+		r0 = rf(ctx, jobID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]slurmapi.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, jobID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetJobsByIDFromAccounting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJobsByIDFromAccounting'
+type MockClient_GetJobsByIDFromAccounting_Call struct {
+	*mock.Call
+}
+
+// GetJobsByIDFromAccounting is a helper method to define mock.On call
+//   - ctx context.Context
+//   - jobID string
+func (_e *MockClient_Expecter) GetJobsByIDFromAccounting(ctx interface{}, jobID interface{}) *MockClient_GetJobsByIDFromAccounting_Call {
+	return &MockClient_GetJobsByIDFromAccounting_Call{Call: _e.mock.On("GetJobsByIDFromAccounting", ctx, jobID)}
+}
+
+func (_c *MockClient_GetJobsByIDFromAccounting_Call) Run(run func(ctx context.Context, jobID string)) *MockClient_GetJobsByIDFromAccounting_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetJobsByIDFromAccounting_Call) Return(_a0 []slurmapi.Job, _a1 error) *MockClient_GetJobsByIDFromAccounting_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetJobsByIDFromAccounting_Call) RunAndReturn(run func(context.Context, string) ([]slurmapi.Job, error)) *MockClient_GetJobsByIDFromAccounting_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNode provides a mock function with given fields: ctx, nodeName
+func (_m *MockClient) GetNode(ctx context.Context, nodeName string) (slurmapi.Node, error) {
+	ret := _m.Called(ctx, nodeName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNode")
+	}
+
+	var r0 slurmapi.Node
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (slurmapi.Node, error)); ok {
+		return rf(ctx, nodeName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) slurmapi.Node); ok {
+		r0 = rf(ctx, nodeName)
+	} else {
+		r0 = ret.Get(0).(slurmapi.Node)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, nodeName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetNode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNode'
+type MockClient_GetNode_Call struct {
+	*mock.Call
+}
+
+// GetNode is a helper method to define mock.On call
+//   - ctx context.Context
+//   - nodeName string
+func (_e *MockClient_Expecter) GetNode(ctx interface{}, nodeName interface{}) *MockClient_GetNode_Call {
+	return &MockClient_GetNode_Call{Call: _e.mock.On("GetNode", ctx, nodeName)}
+}
+
+func (_c *MockClient_GetNode_Call) Run(run func(ctx context.Context, nodeName string)) *MockClient_GetNode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetNode_Call) Return(_a0 slurmapi.Node, _a1 error) *MockClient_GetNode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetNode_Call) RunAndReturn(run func(context.Context, string) (slurmapi.Node, error)) *MockClient_GetNode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPartition provides a mock function with given fields: ctx, partitionName
+func (_m *MockClient) GetPartition(ctx context.Context, partitionName string) (slurmapi.Partition, error) {
+	ret := _m.Called(ctx, partitionName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPartition")
+	}
+
+	var r0 slurmapi.Partition
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (slurmapi.Partition, error)); ok {
+		return rf(ctx, partitionName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) slurmapi.Partition); ok {
+		r0 = rf(ctx, partitionName)
+	} else {
+		r0 = ret.Get(0).(slurmapi.Partition)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, partitionName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetPartition_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPartition'
+type MockClient_GetPartition_Call struct {
+	*mock.Call
+}
+
+// GetPartition is a helper method to define mock.On call
+//   - ctx context.Context
+//   - partitionName string
+func (_e *MockClient_Expecter) GetPartition(ctx interface{}, partitionName interface{}) *MockClient_GetPartition_Call {
+	return &MockClient_GetPartition_Call{Call: _e.mock.On("GetPartition", ctx, partitionName)}
+}
+
+func (_c *MockClient_GetPartition_Call) Run(run func(ctx context.Context, partitionName string)) *MockClient_GetPartition_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetPartition_Call) Return(_a0 slurmapi.Partition, _a1 error) *MockClient_GetPartition_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetPartition_Call) RunAndReturn(run func(context.Context, string) (slurmapi.Partition, error)) *MockClient_GetPartition_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetQOS provides a mock function with given fields: ctx, qosName
+func (_m *MockClient) GetQOS(ctx context.Context, qosName string) (slurmapi.QOS, error) {
+	ret := _m.Called(ctx, qosName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQOS")
+	}
+
+	var r0 slurmapi.QOS
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (slurmapi.QOS, error)); ok {
+		return rf(ctx, qosName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) slurmapi.QOS); ok {
+		r0 = rf(ctx, qosName)
+	} else {
+		r0 = ret.Get(0).(slurmapi.QOS)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, qosName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetQOS_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetQOS'
+type MockClient_GetQOS_Call struct {
+	*mock.Call
+}
+
+// GetQOS is a helper method to define mock.On call
+//   - ctx context.Context
+//   - qosName string
+func (_e *MockClient_Expecter) GetQOS(ctx interface{}, qosName interface{}) *MockClient_GetQOS_Call {
+	return &MockClient_GetQOS_Call{Call: _e.mock.On("GetQOS", ctx, qosName)}
+}
+
+func (_c *MockClient_GetQOS_Call) Run(run func(ctx context.Context, qosName string)) *MockClient_GetQOS_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetQOS_Call) Return(_a0 slurmapi.QOS, _a1 error) *MockClient_GetQOS_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetQOS_Call) RunAndReturn(run func(context.Context, string) (slurmapi.QOS, error)) *MockClient_GetQOS_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReservation provides a mock function with given fields: ctx, reservationName
+func (_m *MockClient) GetReservation(ctx context.Context, reservationName string) (slurmapi.Reservation, error) {
+	ret := _m.Called(ctx, reservationName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReservation")
+	}
+
+	var r0 slurmapi.Reservation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (slurmapi.Reservation, error)); ok {
+		return rf(ctx, reservationName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) slurmapi.Reservation); ok {
+		r0 = rf(ctx, reservationName)
+	} else {
+		r0 = ret.Get(0).(slurmapi.Reservation)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, reservationName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_GetReservation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReservation'
+type MockClient_GetReservation_Call struct {
+	*mock.Call
+}
+
+// GetReservation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reservationName string
+func (_e *MockClient_Expecter) GetReservation(ctx interface{}, reservationName interface{}) *MockClient_GetReservation_Call {
+	return &MockClient_GetReservation_Call{Call: _e.mock.On("GetReservation", ctx, reservationName)}
+}
+
+func (_c *MockClient_GetReservation_Call) Run(run func(ctx context.Context, reservationName string)) *MockClient_GetReservation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockClient_GetReservation_Call) Return(_a0 slurmapi.Reservation, _a1 error) *MockClient_GetReservation_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_GetReservation_Call) RunAndReturn(run func(context.Context, string) (slurmapi.Reservation, error)) *MockClient_GetReservation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAccounts provides a mock function with given fields: ctx
+func (_m *MockClient) ListAccounts(ctx context.Context) ([]slurmapi.Account, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAccounts")
 	}
 
-	var r0 *v0044.V0044OpenapiDiagResp
+	var r0 []slurmapi.Account
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (*v0044.V0044OpenapiDiagResp, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) ([]slurmapi.Account, error)); ok {
 		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) *v0044.V0044OpenapiDiagResp); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) []slurmapi.Account); ok {
 		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*v0044.V0044OpenapiDiagResp)
+			r0 = ret.Get(0).([]slurmapi.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_ListAccounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAccounts'
+type MockClient_ListAccounts_Call struct {
+	*mock.Call
+}
+
+// ListAccounts is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockClient_Expecter) ListAccounts(ctx interface{}) *MockClient_ListAccounts_Call {
+	return &MockClient_ListAccounts_Call{Call: _e.mock.On("ListAccounts", ctx)}
+}
+
+func (_c *MockClient_ListAccounts_Call) Run(run func(ctx context.Context)) *MockClient_ListAccounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockClient_ListAccounts_Call) Return(_a0 []slurmapi.Account, _a1 error) *MockClient_ListAccounts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_ListAccounts_Call) RunAndReturn(run func(context.Context) ([]slurmapi.Account, error)) *MockClient_ListAccounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAssociations provides a mock function with given fields: ctx
+func (_m *MockClient) ListAssociations(ctx context.Context) ([]slurmapi.Association, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAssociations")
+	}
+
+	var r0 []slurmapi.Association
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]slurmapi.Association, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []slurmapi.Association); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]slurmapi.Association)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_ListAssociations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAssociations'
+type MockClient_ListAssociations_Call struct {
+	*mock.Call
+}
+
+// ListAssociations is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockClient_Expecter) ListAssociations(ctx interface{}) *MockClient_ListAssociations_Call {
+	return &MockClient_ListAssociations_Call{Call: _e.mock.On("ListAssociations", ctx)}
+}
+
+func (_c *MockClient_ListAssociations_Call) Run(run func(ctx context.Context)) *MockClient_ListAssociations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockClient_ListAssociations_Call) Return(_a0 []slurmapi.Association, _a1 error) *MockClient_ListAssociations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_ListAssociations_Call) RunAndReturn(run func(context.Context) ([]slurmapi.Association, error)) *MockClient_ListAssociations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListJobs provides a mock function with given fields: ctx
+func (_m *MockClient) ListJobs(ctx context.Context) ([]slurmapi.Job, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListJobs")
+	}
+
+	var r0 []slurmapi.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]slurmapi.Job, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []slurmapi.Job); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]slurmapi.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_ListJobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListJobs'
+type MockClient_ListJobs_Call struct {
+	*mock.Call
+}
+
+// ListJobs is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockClient_Expecter) ListJobs(ctx interface{}) *MockClient_ListJobs_Call {
+	return &MockClient_ListJobs_Call{Call: _e.mock.On("ListJobs", ctx)}
+}
+
+func (_c *MockClient_ListJobs_Call) Run(run func(ctx context.Context)) *MockClient_ListJobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockClient_ListJobs_Call) Return(_a0 []slurmapi.Job, _a1 error) *MockClient_ListJobs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_ListJobs_Call) RunAndReturn(run func(context.Context) ([]slurmapi.Job, error)) *MockClient_ListJobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListJobsWithParams provides a mock function with given fields: ctx, params
+func (_m *MockClient) ListJobsWithParams(ctx context.Context, params slurmapi.ListJobsParams) ([]slurmapi.Job, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListJobsWithParams")
+	}
+
+	var r0 []slurmapi.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, slurmapi.ListJobsParams) ([]slurmapi.Job, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, slurmapi.ListJobsParams) []slurmapi.Job); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]slurmapi.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, slurmapi.ListJobsParams) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockClient_ListJobsWithParams_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListJobsWithParams'
+type MockClient_ListJobsWithParams_Call struct {
+	*mock.Call
+}
+
+// ListJobsWithParams is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params slurmapi.ListJobsParams
+func (_e *MockClient_Expecter) ListJobsWithParams(ctx interface{}, params interface{}) *MockClient_ListJobsWithParams_Call {
+	return &MockClient_ListJobsWithParams_Call{Call: _e.mock.On("ListJobsWithParams", ctx, params)}
+}
+
+func (_c *MockClient_ListJobsWithParams_Call) Run(run func(ctx context.Context, params slurmapi.ListJobsParams)) *MockClient_ListJobsWithParams_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(slurmapi.ListJobsParams))
+	})
+	return _c
+}
+
+func (_c *MockClient_ListJobsWithParams_Call) Return(_a0 []slurmapi.Job, _a1 error) *MockClient_ListJobsWithParams_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockClient_ListJobsWithParams_Call) RunAndReturn(run func(context.Context, slurmapi.ListJobsParams) ([]slurmapi.Job, error)) *MockClient_ListJobsWithParams_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListNodes provides a mock function with given fields: ctx
+func (_m *MockClient) ListNodes(ctx context.Context) ([]slurmapi.Node, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListNodes")
+	}
+
+	var r0 []slurmapi.Node
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]slurmapi.Node, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []slurmapi.Node); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]slurmapi.Node)
 		}
 	}
 
@@ -54,57 +1160,57 @@ func (_m *MockClient) GetDiag(ctx context.Context) (*v0044.V0044OpenapiDiagResp,
 	return r0, r1
 }
 
-// MockClient_GetDiag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDiag'
-type MockClient_GetDiag_Call struct {
+// MockClient_ListNodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNodes'
+type MockClient_ListNodes_Call struct {
 	*mock.Call
 }
 
-// GetDiag is a helper method to define mock.On call
+// ListNodes is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockClient_Expecter) GetDiag(ctx interface{}) *MockClient_GetDiag_Call {
-	return &MockClient_GetDiag_Call{Call: _e.mock.On("GetDiag", ctx)}
+func (_e *MockClient_Expecter) ListNodes(ctx interface{}) *MockClient_ListNodes_Call {
+	return &MockClient_ListNodes_Call{Call: _e.mock.On("ListNodes", ctx)}
 }
 
-func (_c *MockClient_GetDiag_Call) Run(run func(ctx context.Context)) *MockClient_GetDiag_Call {
+func (_c *MockClient_ListNodes_Call) Run(run func(ctx context.Context)) *MockClient_ListNodes_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockClient_GetDiag_Call) Return(_a0 *v0044.V0044OpenapiDiagResp, _a1 error) *MockClient_GetDiag_Call {
+func (_c *MockClient_ListNodes_Call) Return(_a0 []slurmapi.Node, _a1 error) *MockClient_ListNodes_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockClient_GetDiag_Call) RunAndReturn(run func(context.Context) (*v0044.V0044OpenapiDiagResp, error)) *MockClient_GetDiag_Call {
+func (_c *MockClient_ListNodes_Call) RunAndReturn(run func(context.Context) ([]slurmapi.Node, error)) *MockClient_ListNodes_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetJobsByIDFromAccounting provides a mock function with given fields: ctx, jobID
-func (_m *MockClient) GetJobsByIDFromAccounting(ctx context.Context, jobID string) ([]slurmapi.Job, error) {
-	ret := _m.Called(ctx, jobID)
+// ListPartitions provides a mock function with given fields: ctx
+func (_m *MockClient) ListPartitions(ctx context.Context) ([]slurmapi.Partition, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetJobsByIDFromAccounting")
+		panic("no return value specified for ListPartitions")
 	}
 
-	var r0 []slurmapi.Job
+	var r0 []slurmapi.Partition
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]slurmapi.Job, error)); ok {
-		return rf(ctx, jobID)
+	if rf, ok := ret.Get(0).(func(context.Context) ([]slurmapi.Partition, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []slurmapi.Job); ok {
-		r0 = rf(ctx, jobID)
+	if rf, ok := ret.Get(0).(func(context.Context) []slurmapi.Partition); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]slurmapi.Job)
+			r0 = ret.Get(0).([]slurmapi.Partition)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, jobID)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -112,56 +1218,57 @@ func (_m *MockClient) GetJobsByIDFromAccounting(ctx context.Context, jobID strin
 	return r0, r1
 }
 
-// MockClient_GetJobsByIDFromAccounting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetJobsByIDFromAccounting'
-type MockClient_GetJobsByIDFromAccounting_Call struct {
+// MockClient_ListPartitions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPartitions'
+type MockClient_ListPartitions_Call struct {
 	*mock.Call
 }
 
-// GetJobsByIDFromAccounting is a helper method to define mock.On call
+// ListPartitions is a helper method to define mock.On call
 //   - ctx context.Context
-//   - jobID string
-func (_e *MockClient_Expecter) GetJobsByIDFromAccounting(ctx interface{}, jobID interface{}) *MockClient_GetJobsByIDFromAccounting_Call {
-	return &MockClient_GetJobsByIDFromAccounting_Call{Call: _e.mock.On("GetJobsByIDFromAccounting", ctx, jobID)}
+func (_e *MockClient_Expecter) ListPartitions(ctx interface{}) *MockClient_ListPartitions_Call {
+	return &MockClient_ListPartitions_Call{Call: _e.mock.On("ListPartitions", ctx)}
 }
 
-func (_c *MockClient_GetJobsByIDFromAccounting_Call) Run(run func(ctx context.Context, jobID string)) *MockClient_GetJobsByIDFromAccounting_Call {
+func (_c *MockClient_ListPartitions_Call) Run(run func(ctx context.Context)) *MockClient_ListPartitions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockClient_GetJobsByIDFromAccounting_Call) Return(_a0 []slurmapi.Job, _a1 error) *MockClient_GetJobsByIDFromAccounting_Call {
+func (_c *MockClient_ListPartitions_Call) Return(_a0 []slurmapi.Partition, _a1 error) *MockClient_ListPartitions_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockClient_GetJobsByIDFromAccounting_Call) RunAndReturn(run func(context.Context, string) ([]slurmapi.Job, error)) *MockClient_GetJobsByIDFromAccounting_Call {
+func (_c *MockClient_ListPartitions_Call) RunAndReturn(run func(context.Context) ([]slurmapi.Partition, error)) *MockClient_ListPartitions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetNode provides a mock function with given fields: ctx, nodeName
-func (_m *MockClient) GetNode(ctx context.Context, nodeName string) (slurmapi.Node, error) {
-	ret := _m.Called(ctx, nodeName)
+// ListQOS provides a mock function with given fields: ctx
+func (_m *MockClient) ListQOS(ctx context.Context) ([]slurmapi.QOS, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetNode")
+		panic("no return value specified for ListQOS")
 	}
 
-	var r0 slurmapi.Node
+	var r0 []slurmapi.QOS
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (slurmapi.Node, error)); ok {
-		return rf(ctx, nodeName)
+	if rf, ok := ret.Get(0).(func(context.Context) ([]slurmapi.QOS, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) slurmapi.Node); ok {
-		r0 = rf(ctx, nodeName)
+	if rf, ok := ret.Get(0).(func(context.Context) []slurmapi.QOS); ok {
+		r0 = rf(ctx)
 	} else {
-		r0 = ret.Get(0).(slurmapi.Node)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]slurmapi.QOS)
+		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, nodeName)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -169,53 +1276,52 @@ func (_m *MockClient) GetNode(ctx context.Context, nodeName string) (slurmapi.No
 	return r0, r1
 }
 
-// MockClient_GetNode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNode'
-type MockClient_GetNode_Call struct {
+// MockClient_ListQOS_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListQOS'
+type MockClient_ListQOS_Call struct {
 	*mock.Call
 }
 
-// GetNode is a helper method to define mock.On call
+// ListQOS is a helper method to define mock.On call
 //   - ctx context.Context
-//   - nodeName string
-func (_e *MockClient_Expecter) GetNode(ctx interface{}, nodeName interface{}) *MockClient_GetNode_Call {
-	return &MockClient_GetNode_Call{Call: _e.mock.On("GetNode", ctx, nodeName)}
+func (_e *MockClient_Expecter) ListQOS(ctx interface{}) *MockClient_ListQOS_Call {
+	return &MockClient_ListQOS_Call{Call: _e.mock.On("ListQOS", ctx)}
 }
 
-func (_c *MockClient_GetNode_Call) Run(run func(ctx context.Context, nodeName string)) *MockClient_GetNode_Call {
+func (_c *MockClient_ListQOS_Call) Run(run func(ctx context.Context)) *MockClient_ListQOS_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockClient_GetNode_Call) Return(_a0 slurmapi.Node, _a1 error) *MockClient_GetNode_Call {
+func (_c *MockClient_ListQOS_Call) Return(_a0 []slurmapi.QOS, _a1 error) *MockClient_ListQOS_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockClient_GetNode_Call) RunAndReturn(run func(context.Context, string) (slurmapi.Node, error)) *MockClient_GetNode_Call {
+func (_c *MockClient_ListQOS_Call) RunAndReturn(run func(context.Context) ([]slurmapi.QOS, error)) *MockClient_ListQOS_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListJobs provides a mock function with given fields: ctx
-func (_m *MockClient) ListJobs(ctx context.Context) ([]slurmapi.Job, error) {
+// ListReservations provides a mock function with given fields: ctx
+func (_m *MockClient) ListReservations(ctx context.Context) ([]slurmapi.Reservation, error) {
 	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListJobs")
+		panic("no return value specified for ListReservations")
 	}
 
-	var r0 []slurmapi.Job
+	var r0 []slurmapi.Reservation
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]slurmapi.Job, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) ([]slurmapi.Reservation, error)); ok {
 		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []slurmapi.Job); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) []slurmapi.Reservation); ok {
 		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]slurmapi.Job)
+			r0 = ret.Get(0).([]slurmapi.Reservation)
 		}
 	}
 
@@ -228,57 +1334,57 @@ func (_m *MockClient) ListJobs(ctx context.Context) ([]slurmapi.Job, error) {
 	return r0, r1
 }
 
-// MockClient_ListJobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListJobs'
-type MockClient_ListJobs_Call struct {
+// MockClient_ListReservations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListReservations'
+type MockClient_ListReservations_Call struct {
 	*mock.Call
 }
 
-// ListJobs is a helper method to define mock.On call
+// ListReservations is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockClient_Expecter) ListJobs(ctx interface{}) *MockClient_ListJobs_Call {
-	return &MockClient_ListJobs_Call{Call: _e.mock.On("ListJobs", ctx)}
+func (_e *MockClient_Expecter) ListReservations(ctx interface{}) *MockClient_ListReservations_Call {
+	return &MockClient_ListReservations_Call{Call: _e.mock.On("ListReservations", ctx)}
 }
 
-func (_c *MockClient_ListJobs_Call) Run(run func(ctx context.Context)) *MockClient_ListJobs_Call {
+func (_c *MockClient_ListReservations_Call) Run(run func(ctx context.Context)) *MockClient_ListReservations_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockClient_ListJobs_Call) Return(_a0 []slurmapi.Job, _a1 error) *MockClient_ListJobs_Call {
+func (_c *MockClient_ListReservations_Call) Return(_a0 []slurmapi.Reservation, _a1 error) *MockClient_ListReservations_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockClient_ListJobs_Call) RunAndReturn(run func(context.Context) ([]slurmapi.Job, error)) *MockClient_ListJobs_Call {
+func (_c *MockClient_ListReservations_Call) RunAndReturn(run func(context.Context) ([]slurmapi.Reservation, error)) *MockClient_ListReservations_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListJobsWithParams provides a mock function with given fields: ctx, params
-func (_m *MockClient) ListJobsWithParams(ctx context.Context, params slurmapi.ListJobsParams) ([]slurmapi.Job, error) {
-	ret := _m.Called(ctx, params)
+// ListShares provides a mock function with given fields: ctx
+func (_m *MockClient) ListShares(ctx context.Context) ([]slurmapi.Share, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListJobsWithParams")
+		panic("no return value specified for ListShares")
 	}
 
-	var r0 []slurmapi.Job
+	var r0 []slurmapi.Share
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, slurmapi.ListJobsParams) ([]slurmapi.Job, error)); ok {
-		return rf(ctx, params)
+	if rf, ok := ret.Get(0).(func(context.Context) ([]slurmapi.Share, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, slurmapi.ListJobsParams) []slurmapi.Job); ok {
-		r0 = rf(ctx, params)
+	if rf, ok := ret.Get(0).(func(context.Context) []slurmapi.Share); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]slurmapi.Job)
+			r0 = ret.Get(0).([]slurmapi.Share)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, slurmapi.ListJobsParams) error); ok {
-		r1 = rf(ctx, params)
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -286,53 +1392,52 @@ func (_m *MockClient) ListJobsWithParams(ctx context.Context, params slurmapi.Li
 	return r0, r1
 }
 
-// MockClient_ListJobsWithParams_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListJobsWithParams'
-type MockClient_ListJobsWithParams_Call struct {
+// MockClient_ListShares_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListShares'
+type MockClient_ListShares_Call struct {
 	*mock.Call
 }
 
-// ListJobsWithParams is a helper method to define mock.On call
+// ListShares is a helper method to define mock.On call
 //   - ctx context.Context
-//   - params slurmapi.ListJobsParams
-func (_e *MockClient_Expecter) ListJobsWithParams(ctx interface{}, params interface{}) *MockClient_ListJobsWithParams_Call {
-	return &MockClient_ListJobsWithParams_Call{Call: _e.mock.On("ListJobsWithParams", ctx, params)}
+func (_e *MockClient_Expecter) ListShares(ctx interface{}) *MockClient_ListShares_Call {
+	return &MockClient_ListShares_Call{Call: _e.mock.On("ListShares", ctx)}
 }
 
-func (_c *MockClient_ListJobsWithParams_Call) Run(run func(ctx context.Context, params slurmapi.ListJobsParams)) *MockClient_ListJobsWithParams_Call {
+func (_c *MockClient_ListShares_Call) Run(run func(ctx context.Context)) *MockClient_ListShares_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(slurmapi.ListJobsParams))
+		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockClient_ListJobsWithParams_Call) Return(_a0 []slurmapi.Job, _a1 error) *MockClient_ListJobsWithParams_Call {
+func (_c *MockClient_ListShares_Call) Return(_a0 []slurmapi.Share, _a1 error) *MockClient_ListShares_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockClient_ListJobsWithParams_Call) RunAndReturn(run func(context.Context, slurmapi.ListJobsParams) ([]slurmapi.Job, error)) *MockClient_ListJobsWithParams_Call {
+func (_c *MockClient_ListShares_Call) RunAndReturn(run func(context.Context) ([]slurmapi.Share, error)) *MockClient_ListShares_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListNodes provides a mock function with given fields: ctx
-func (_m *MockClient) ListNodes(ctx context.Context) ([]slurmapi.Node, error) {
+// ListUsers provides a mock function with given fields: ctx
+func (_m *MockClient) ListUsers(ctx context.Context) ([]slurmapi.User, error) {
 	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListNodes")
+		panic("no return value specified for ListUsers")
 	}
 
-	var r0 []slurmapi.Node
+	var r0 []slurmapi.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]slurmapi.Node, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) ([]slurmapi.User, error)); ok {
 		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []slurmapi.Node); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) []slurmapi.User); ok {
 		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]slurmapi.Node)
+			r0 = ret.Get(0).([]slurmapi.User)
 		}
 	}
 
@@ -345,30 +1450,30 @@ func (_m *MockClient) ListNodes(ctx context.Context) ([]slurmapi.Node, error) {
 	return r0, r1
 }
 
-// MockClient_ListNodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListNodes'
-type MockClient_ListNodes_Call struct {
+// MockClient_ListUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUsers'
+type MockClient_ListUsers_Call struct {
 	*mock.Call
 }
 
-// ListNodes is a helper method to define mock.On call
+// ListUsers is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockClient_Expecter) ListNodes(ctx interface{}) *MockClient_ListNodes_Call {
-	return &MockClient_ListNodes_Call{Call: _e.mock.On("ListNodes", ctx)}
+func (_e *MockClient_Expecter) ListUsers(ctx interface{}) *MockClient_ListUsers_Call {
+	return &MockClient_ListUsers_Call{Call: _e.mock.On("ListUsers", ctx)}
 }
 
-func (_c *MockClient_ListNodes_Call) Run(run func(ctx context.Context)) *MockClient_ListNodes_Call {
+func (_c *MockClient_ListUsers_Call) Run(run func(ctx context.Context)) *MockClient_ListUsers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context))
 	})
 	return _c
 }
 
-func (_c *MockClient_ListNodes_Call) Return(_a0 []slurmapi.Node, _a1 error) *MockClient_ListNodes_Call {
+func (_c *MockClient_ListUsers_Call) Return(_a0 []slurmapi.User, _a1 error) *MockClient_ListUsers_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockClient_ListNodes_Call) RunAndReturn(run func(context.Context) ([]slurmapi.Node, error)) *MockClient_ListNodes_Call {
+func (_c *MockClient_ListUsers_Call) RunAndReturn(run func(context.Context) ([]slurmapi.User, error)) *MockClient_ListUsers_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -6942,6 +8047,194 @@ func (_c *MockClient_UndrainNode_Call) RunAndReturn(run func(context.Context, st
 	return _c
 }
 
+// UpdateAccount provides a mock function with given fields: ctx, account
+func (_m *MockClient) UpdateAccount(ctx context.Context, account slurmapi.Account) error {
+	ret := _m.Called(ctx, account)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAccount")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, slurmapi.Account) error); ok {
+		r0 = rf(ctx, account)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_UpdateAccount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAccount'
+type MockClient_UpdateAccount_Call struct {
+	*mock.Call
+}
+
+// UpdateAccount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - account slurmapi.Account
+func (_e *MockClient_Expecter) UpdateAccount(ctx interface{}, account interface{}) *MockClient_UpdateAccount_Call {
+	return &MockClient_UpdateAccount_Call{Call: _e.mock.On("UpdateAccount", ctx, account)}
+}
+
+func (_c *MockClient_UpdateAccount_Call) Run(run func(ctx context.Context, account slurmapi.Account)) *MockClient_UpdateAccount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(slurmapi.Account))
+	})
+	return _c
+}
+
+func (_c *MockClient_UpdateAccount_Call) Return(_a0 error) *MockClient_UpdateAccount_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_UpdateAccount_Call) RunAndReturn(run func(context.Context, slurmapi.Account) error) *MockClient_UpdateAccount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateAssociation provides a mock function with given fields: ctx, association
+func (_m *MockClient) UpdateAssociation(ctx context.Context, association slurmapi.Association) error {
+	ret := _m.Called(ctx, association)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAssociation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, slurmapi.Association) error); ok {
+		r0 = rf(ctx, association)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_UpdateAssociation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAssociation'
+type MockClient_UpdateAssociation_Call struct {
+	*mock.Call
+}
+
+// UpdateAssociation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - association slurmapi.Association
+func (_e *MockClient_Expecter) UpdateAssociation(ctx interface{}, association interface{}) *MockClient_UpdateAssociation_Call {
+	return &MockClient_UpdateAssociation_Call{Call: _e.mock.On("UpdateAssociation", ctx, association)}
+}
+
+func (_c *MockClient_UpdateAssociation_Call) Run(run func(ctx context.Context, association slurmapi.Association)) *MockClient_UpdateAssociation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(slurmapi.Association))
+	})
+	return _c
+}
+
+func (_c *MockClient_UpdateAssociation_Call) Return(_a0 error) *MockClient_UpdateAssociation_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_UpdateAssociation_Call) RunAndReturn(run func(context.Context, slurmapi.Association) error) *MockClient_UpdateAssociation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateQOS provides a mock function with given fields: ctx, qos
+func (_m *MockClient) UpdateQOS(ctx context.Context, qos slurmapi.QOS) error {
+	ret := _m.Called(ctx, qos)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateQOS")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, slurmapi.QOS) error); ok {
+		r0 = rf(ctx, qos)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_UpdateQOS_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateQOS'
+type MockClient_UpdateQOS_Call struct {
+	*mock.Call
+}
+
+// UpdateQOS is a helper method to define mock.On call
+//   - ctx context.Context
+//   - qos slurmapi.QOS
+func (_e *MockClient_Expecter) UpdateQOS(ctx interface{}, qos interface{}) *MockClient_UpdateQOS_Call {
+	return &MockClient_UpdateQOS_Call{Call: _e.mock.On("UpdateQOS", ctx, qos)}
+}
+
+func (_c *MockClient_UpdateQOS_Call) Run(run func(ctx context.Context, qos slurmapi.QOS)) *MockClient_UpdateQOS_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(slurmapi.QOS))
+	})
+	return _c
+}
+
+func (_c *MockClient_UpdateQOS_Call) Return(_a0 error) *MockClient_UpdateQOS_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_UpdateQOS_Call) RunAndReturn(run func(context.Context, slurmapi.QOS) error) *MockClient_UpdateQOS_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateReservation provides a mock function with given fields: ctx, reservation
+func (_m *MockClient) UpdateReservation(ctx context.Context, reservation slurmapi.Reservation) error {
+	ret := _m.Called(ctx, reservation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateReservation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, slurmapi.Reservation) error); ok {
+		r0 = rf(ctx, reservation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockClient_UpdateReservation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateReservation'
+type MockClient_UpdateReservation_Call struct {
+	*mock.Call
+}
+
+// UpdateReservation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reservation slurmapi.Reservation
+func (_e *MockClient_Expecter) UpdateReservation(ctx interface{}, reservation interface{}) *MockClient_UpdateReservation_Call {
+	return &MockClient_UpdateReservation_Call{Call: _e.mock.On("UpdateReservation", ctx, reservation)}
+}
+
+func (_c *MockClient_UpdateReservation_Call) Run(run func(ctx context.Context, reservation slurmapi.Reservation)) *MockClient_UpdateReservation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(slurmapi.Reservation))
+	})
+	return _c
+}
+
+func (_c *MockClient_UpdateReservation_Call) Return(_a0 error) *MockClient_UpdateReservation_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockClient_UpdateReservation_Call) RunAndReturn(run func(context.Context, slurmapi.Reservation) error) *MockClient_UpdateReservation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockClient creates a new instance of MockClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockClient(t interface {
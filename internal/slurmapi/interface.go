@@ -12,8 +12,33 @@ type Client interface {
 	GetNode(ctx context.Context, nodeName string) (Node, error)
 	RebootNodes(ctx context.Context, request RebootNodesRequest) error
 	UndrainNode(ctx context.Context, nodeName string) error
+	DownNode(ctx context.Context, nodeName, reason string) error
 	GetJobsByIDFromAccounting(ctx context.Context, jobID string) ([]Job, error)
 	ListJobs(ctx context.Context) ([]Job, error)
 	ListJobsWithParams(ctx context.Context, params ListJobsParams) ([]Job, error)
 	GetDiag(ctx context.Context) (*api.V0044OpenapiDiagResp, error)
+	ListPartitions(ctx context.Context) ([]Partition, error)
+	GetPartition(ctx context.Context, partitionName string) (Partition, error)
+	ListReservations(ctx context.Context) ([]Reservation, error)
+	GetReservation(ctx context.Context, reservationName string) (Reservation, error)
+	CreateReservation(ctx context.Context, reservation Reservation) error
+	UpdateReservation(ctx context.Context, reservation Reservation) error
+	DeleteReservation(ctx context.Context, reservationName string) error
+	ListQOS(ctx context.Context) ([]QOS, error)
+	GetQOS(ctx context.Context, qosName string) (QOS, error)
+	CreateQOS(ctx context.Context, qos QOS) error
+	UpdateQOS(ctx context.Context, qos QOS) error
+	DeleteQOS(ctx context.Context, qosName string) error
+	ListShares(ctx context.Context) ([]Share, error)
+	ListAssociations(ctx context.Context) ([]Association, error)
+	GetAssociation(ctx context.Context, key AssociationKey) (Association, error)
+	CreateAssociation(ctx context.Context, association Association) error
+	UpdateAssociation(ctx context.Context, association Association) error
+	DeleteAssociation(ctx context.Context, key AssociationKey) error
+	ListAccounts(ctx context.Context) ([]Account, error)
+	CreateAccount(ctx context.Context, account Account) error
+	UpdateAccount(ctx context.Context, account Account) error
+	DeleteAccount(ctx context.Context, accountName string) error
+	ListUsers(ctx context.Context) ([]User, error)
+	CreateUser(ctx context.Context, user User) error
 }
@@ -9,6 +9,7 @@ import (
 
 	api "github.com/SlinkyProject/slurm-client/api/v0044"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 type Job struct {
@@ -17,11 +18,14 @@ type Job struct {
 	State          string
 	StateReason    string
 	Partition      string
+	QOS            string
+	Account        string
 	UserName       string
 	UserID         *int32
 	UserMail       string
 	StandardError  string
 	StandardOutput string
+	Comment        string
 	Nodes          string
 	ScheduledNodes string
 	RequiredNodes  string
@@ -68,6 +72,14 @@ func JobFromAPI(apiJob api.V0044JobInfo) (Job, error) {
 		job.Partition = *apiJob.Partition
 	}
 
+	if apiJob.Qos != nil {
+		job.QOS = *apiJob.Qos
+	}
+
+	if apiJob.Account != nil {
+		job.Account = *apiJob.Account
+	}
+
 	if apiJob.UserId != nil {
 		job.UserID = apiJob.UserId
 	}
@@ -92,6 +104,10 @@ func JobFromAPI(apiJob api.V0044JobInfo) (Job, error) {
 		job.StandardOutput = *apiJob.StandardOutput
 	}
 
+	if apiJob.Comment != nil {
+		job.Comment = *apiJob.Comment
+	}
+
 	if apiJob.Nodes != nil && !isUnallocatedNodeList(*apiJob.Nodes) {
 		job.Nodes = *apiJob.Nodes
 	}
@@ -151,6 +167,16 @@ func JobFromAccountingAPI(apiJob api.V0044Job) (Job, error) {
 		job.Partition = *apiJob.Partition
 	}
 
+	if apiJob.Qos != nil {
+		job.QOS = *apiJob.Qos
+	}
+
+	if apiJob.Account != nil {
+		job.Account = *apiJob.Account
+	} else if apiJob.Association != nil && apiJob.Association.Account != nil {
+		job.Account = *apiJob.Association.Account
+	}
+
 	if apiJob.User != nil {
 		job.UserName = *apiJob.User
 	} else if apiJob.Association != nil {
@@ -169,6 +195,10 @@ func JobFromAccountingAPI(apiJob api.V0044Job) (Job, error) {
 		job.StandardOutput = *apiJob.Stdout
 	}
 
+	if apiJob.Comment != nil && apiJob.Comment.Job != nil {
+		job.Comment = *apiJob.Comment.Job
+	}
+
 	if apiJob.Nodes != nil && !isUnallocatedNodeList(*apiJob.Nodes) {
 		job.Nodes = *apiJob.Nodes
 	}
@@ -295,6 +325,11 @@ func (j Job) IsCancelledState() bool {
 	return j.State == string(api.V0044JobInfoJobStateCANCELLED)
 }
 
+// ParseNodeList expands a Slurm hostlist expression into the names of the nodes.
+func ParseNodeList(nodeList string) ([]string, error) {
+	return parseNodeList(nodeList)
+}
+
 func parseNodeList(nodeString string) ([]string, error) {
 	if nodeString == "" {
 		return nil, nil
@@ -481,6 +516,41 @@ func convertToInt64(input *api.V0044Uint64NoValStruct) *int64 {
 	return input.Number
 }
 
+// uint32NoVal converts an optional number for submission. Negative numbers are submitted as infinite, which is how
+// slurmdbd removes limits, as sacctmgr does for -1.
+func uint32NoVal(input *int32) *api.V0044Uint32NoValStruct {
+	if input == nil {
+		return nil
+	}
+	if *input < 0 {
+		return &api.V0044Uint32NoValStruct{Set: ptr.To(true), Infinite: ptr.To(true)}
+	}
+	return &api.V0044Uint32NoValStruct{Set: ptr.To(true), Number: input}
+}
+
+func uint64NoVal(input *int64) *api.V0044Uint64NoValStruct {
+	if input == nil {
+		return nil
+	}
+	return &api.V0044Uint64NoValStruct{Set: ptr.To(true), Number: input}
+}
+
+func float64NoVal(input *float64) *api.V0044Float64NoValStruct {
+	if input == nil {
+		return nil
+	}
+	return &api.V0044Float64NoValStruct{Set: ptr.To(true), Number: input}
+}
+
+// ensure allocates the value behind a nested optional field of a generated API struct if it isn't
+// set yet and returns it. It saves spelling out the anonymous struct types oapi-codegen generates.
+func ensure[T any](field **T) *T {
+	if *field == nil {
+		*field = new(T)
+	}
+	return *field
+}
+
 func tresListToString(input *api.V0044TresList) string {
 	if input == nil {
 		return ""
@@ -492,10 +562,7 @@ func tresListToString(input *api.V0044TresList) string {
 			continue
 		}
 
-		key := tres.Type
-		if tres.Name != nil && *tres.Name != "" {
-			key = fmt.Sprintf("%s/%s", key, *tres.Name)
-		}
+		key := tresKey(tres)
 
 		// Slurm reports memory TRES counts in MB (matching `sacct -P -o ReqTRES`); the rest are dimensionless.
 		// Append "M" so parseMemoryValue interprets the value correctly - without a suffix
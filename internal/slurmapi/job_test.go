@@ -117,6 +117,13 @@ func TestJobFromAccountingAPI(t *testing.T) {
 				},
 				Stderr: ptr.To("/tmp/stderr"),
 				Stdout: ptr.To("/tmp/stdout"),
+				Comment: &struct {
+					Administrator *string `json:"administrator,omitempty"`
+					Job           *string `json:"job,omitempty"`
+					System        *string `json:"system,omitempty"`
+				}{
+					Job: ptr.To(`{"busbw":180.5}`),
+				},
 				Array: &struct {
 					JobId  *int32 `json:"job_id,omitempty"`
 					Limits *struct {
@@ -183,6 +190,7 @@ func TestJobFromAccountingAPI(t *testing.T) {
 				UserName:       "testuser",
 				StandardError:  "/tmp/stderr",
 				StandardOutput: "/tmp/stdout",
+				Comment:        `{"busbw":180.5}`,
 				Nodes:          "worker-[1,2]",
 				NodeCount:      ptr.To(int32(2)),
 				ArrayJobID:     ptr.To(int32(54000)),
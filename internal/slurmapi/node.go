@@ -15,8 +15,10 @@ type Node struct {
 	Reason      *NodeReason
 	Partitions  []string
 	Tres        string    // Trackable Resources (e.g., CPUs, GPUs) assigned to the node.
+	TresUsed    string    // Trackable Resources currently allocated for jobs on the node.
 	Address     string    // IP Address of the node in the Kubernetes cluster.
 	BootTime    time.Time // The boot time of the node.
+	LastBusy    time.Time // The last time the node ran a job.
 	Comment     string
 	Reservation string
 
@@ -99,6 +101,10 @@ func NodeFromAPI(node api.V0044Node) (Node, error) {
 		res.BootTime = time.Unix(*node.BootTime.Number, 0)
 	}
 
+	if node.LastBusy != nil && node.LastBusy.Number != nil {
+		res.LastBusy = time.Unix(*node.LastBusy.Number, 0)
+	}
+
 	if node.Reason != nil && len(*node.Reason) != 0 {
 		res.Reason = &NodeReason{
 			Reason:    *node.Reason,
@@ -110,6 +116,10 @@ func NodeFromAPI(node api.V0044Node) (Node, error) {
 		res.Reservation = *node.Reservation
 	}
 
+	if node.TresUsed != nil {
+		res.TresUsed = *node.TresUsed
+	}
+
 	return res, nil
 }
 
@@ -0,0 +1,80 @@
+package slurmapi
+
+import (
+	"errors"
+	"slices"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+)
+
+type Partition struct {
+	Name       string
+	States     []api.V0044PartitionInfoPartitionState
+	NodeList   string // Hostlist expression of the nodes configured in the partition.
+	TotalNodes *int32
+	TotalCPUs  *int32
+	Tres       string // Trackable Resources configured in the partition.
+	QOS        string // QOS assigned to all jobs in the partition.
+}
+
+func validateAPIPartition(partition api.V0044PartitionInfo) error {
+	var errs []error
+
+	if partition.Name == nil || *partition.Name == "" {
+		errs = append(errs, errors.New("partition doesn't have name"))
+	}
+
+	return errors.Join(errs...)
+}
+
+func PartitionFromAPI(partition api.V0044PartitionInfo) (Partition, error) {
+	if err := validateAPIPartition(partition); err != nil {
+		return Partition{}, err
+	}
+
+	res := Partition{
+		Name: *partition.Name,
+	}
+
+	if partition.Partition != nil && partition.Partition.State != nil {
+		res.States = *partition.Partition.State
+	}
+
+	if partition.Nodes != nil {
+		if partition.Nodes.Configured != nil {
+			res.NodeList = *partition.Nodes.Configured
+		}
+		res.TotalNodes = partition.Nodes.Total
+	}
+
+	if partition.Cpus != nil {
+		res.TotalCPUs = partition.Cpus.Total
+	}
+
+	if partition.Tres != nil && partition.Tres.Configured != nil {
+		res.Tres = *partition.Tres.Configured
+	}
+
+	if partition.Qos != nil && partition.Qos.Assigned != nil {
+		res.QOS = *partition.Qos.Assigned
+	}
+
+	return res, nil
+}
+
+// State returns the first reported partition state (UP, DOWN, DRAIN, INACTIVE) or UNKNOWN if Slurm
+// didn't report any.
+func (p *Partition) State() api.V0044PartitionInfoPartitionState {
+	if len(p.States) == 0 {
+		return api.V0044PartitionInfoPartitionStateUNKNOWN
+	}
+	return p.States[0]
+}
+
+func (p *Partition) IsUp() bool {
+	return slices.Contains(p.States, api.V0044PartitionInfoPartitionStateUP)
+}
+
+func (p *Partition) GetNodeList() ([]string, error) {
+	return parseNodeList(p.NodeList)
+}
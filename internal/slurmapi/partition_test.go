@@ -0,0 +1,50 @@
+package slurmapi
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+)
+
+func TestPartitionFromAPI(t *testing.T) {
+	data, err := os.ReadFile("testdata/partition_rest.json")
+	require.NoError(t, err)
+
+	var apiPartition api.V0044PartitionInfo
+	require.NoError(t, json.Unmarshal(data, &apiPartition))
+
+	got, err := PartitionFromAPI(apiPartition)
+	require.NoError(t, err)
+	assert.Equal(t, Partition{
+		Name:       "main",
+		States:     []api.V0044PartitionInfoPartitionState{api.V0044PartitionInfoPartitionStateUP},
+		NodeList:   "worker-[0-1]",
+		TotalNodes: ptr.To(int32(2)),
+		TotalCPUs:  ptr.To(int32(32)),
+		Tres:       "cpu=32,mem=382712M,node=2,billing=32,gres/gpu=16",
+		QOS:        "normal",
+	}, got)
+	assert.True(t, got.IsUp())
+
+	nodes, err := got.GetNodeList()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"worker-0", "worker-1"}, nodes)
+}
+
+func TestPartitionFromAPI_MissingName(t *testing.T) {
+	_, err := PartitionFromAPI(api.V0044PartitionInfo{})
+	assert.Error(t, err)
+}
+
+func TestPartition_State(t *testing.T) {
+	assert.Equal(t, api.V0044PartitionInfoPartitionStateUNKNOWN, (&Partition{}).State())
+
+	partition := Partition{States: []api.V0044PartitionInfoPartitionState{api.V0044PartitionInfoPartitionStateDRAIN}}
+	assert.Equal(t, api.V0044PartitionInfoPartitionStateDRAIN, partition.State())
+	assert.False(t, partition.IsUp())
+}
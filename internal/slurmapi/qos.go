@@ -0,0 +1,188 @@
+package slurmapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"k8s.io/utils/ptr"
+)
+
+// QOS is a slurmdbd quality of service. On update, limits that are nil or missing from the maps are
+// left unchanged by slurmdbd.
+type QOS struct {
+	Name        string
+	ID          *int32
+	Description string
+	Flags       []api.V0044QosFlags
+	Priority    *int32
+	UsageFactor *float64
+
+	// GrpTRES is the total amount of TRES jobs running under the QOS may use, keyed by TRES name
+	// (e.g. "cpu", "mem", "gres/gpu"). Memory is reported in MB, as Slurm does.
+	GrpTRES map[string]int64
+	// MaxTRESPerUser is the amount of TRES a single user may use under the QOS.
+	MaxTRESPerUser map[string]int64
+	// MaxJobsPerUser is the maximum number of running jobs per user.
+	MaxJobsPerUser *int32
+	// MaxWallPerJobMinutes is the maximum wall clock time a job may run for.
+	MaxWallPerJobMinutes *int32
+}
+
+func validateAPIQOS(qos api.V0044Qos) error {
+	var errs []error
+
+	if qos.Name == nil || *qos.Name == "" {
+		errs = append(errs, errors.New("qos doesn't have name"))
+	}
+
+	return errors.Join(errs...)
+}
+
+func QOSFromAPI(qos api.V0044Qos) (QOS, error) {
+	if err := validateAPIQOS(qos); err != nil {
+		return QOS{}, err
+	}
+
+	res := QOS{
+		Name:        *qos.Name,
+		ID:          qos.Id,
+		Priority:    convertToInt(qos.Priority),
+		UsageFactor: convertToFloat64(qos.UsageFactor),
+	}
+
+	if qos.Description != nil {
+		res.Description = *qos.Description
+	}
+	if qos.Flags != nil {
+		res.Flags = *qos.Flags
+	}
+
+	if qos.Limits != nil && qos.Limits.Max != nil {
+		maxLimits := qos.Limits.Max
+		if maxLimits.Tres != nil {
+			res.GrpTRES = tresListToMap(maxLimits.Tres.Total)
+			if maxLimits.Tres.Per != nil {
+				res.MaxTRESPerUser = tresListToMap(maxLimits.Tres.Per.User)
+			}
+		}
+		if maxLimits.Jobs != nil && maxLimits.Jobs.ActiveJobs != nil && maxLimits.Jobs.ActiveJobs.Per != nil {
+			res.MaxJobsPerUser = convertToInt(maxLimits.Jobs.ActiveJobs.Per.User)
+		}
+		if maxLimits.WallClock != nil && maxLimits.WallClock.Per != nil {
+			res.MaxWallPerJobMinutes = convertToInt(maxLimits.WallClock.Per.Job)
+		}
+	}
+
+	return res, nil
+}
+
+// validateQOS checks that the QOS can be submitted to slurmdbd.
+func validateQOS(qos QOS) error {
+	var errs []error
+
+	if qos.Name == "" {
+		errs = append(errs, errors.New("qos doesn't have name"))
+	}
+	if qos.UsageFactor != nil && *qos.UsageFactor < 0 {
+		errs = append(errs, errors.New("qos usage factor must not be negative"))
+	}
+	for tres, limit := range qos.GrpTRES {
+		if limit < -1 {
+			errs = append(errs, fmt.Errorf("qos GrpTRES limit for %s must be -1 (unlimited) or non-negative", tres))
+		}
+	}
+	for tres, limit := range qos.MaxTRESPerUser {
+		if limit < -1 {
+			errs = append(errs, fmt.Errorf("qos MaxTRESPerUser limit for %s must be -1 (unlimited) or non-negative", tres))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func qosToAPI(qos QOS) api.V0044Qos {
+	res := api.V0044Qos{
+		Name:        ptr.To(qos.Name),
+		Priority:    uint32NoVal(qos.Priority),
+		UsageFactor: float64NoVal(qos.UsageFactor),
+	}
+
+	if qos.Description != "" {
+		res.Description = ptr.To(qos.Description)
+	}
+	if len(qos.Flags) > 0 {
+		res.Flags = ptr.To(qos.Flags)
+	}
+
+	if len(qos.GrpTRES) > 0 || len(qos.MaxTRESPerUser) > 0 {
+		tres := ensure(&ensure(&ensure(&res.Limits).Max).Tres)
+		tres.Total = tresMapToList(qos.GrpTRES)
+		if len(qos.MaxTRESPerUser) > 0 {
+			ensure(&tres.Per).User = tresMapToList(qos.MaxTRESPerUser)
+		}
+	}
+	if qos.MaxJobsPerUser != nil {
+		activeJobs := ensure(&ensure(&ensure(&ensure(&res.Limits).Max).Jobs).ActiveJobs)
+		ensure(&activeJobs.Per).User = uint32NoVal(qos.MaxJobsPerUser)
+	}
+	if qos.MaxWallPerJobMinutes != nil {
+		wallClock := ensure(&ensure(&ensure(&res.Limits).Max).WallClock)
+		ensure(&wallClock.Per).Job = uint32NoVal(qos.MaxWallPerJobMinutes)
+	}
+
+	return res
+}
+
+// CreateQOS creates a QOS in slurmdbd.
+func (c *client) CreateQOS(ctx context.Context, qos QOS) error {
+	if err := validateQOS(qos); err != nil {
+		return fmt.Errorf("create qos: %w", err)
+	}
+	return c.postQOS(ctx, "create qos "+qos.Name, qos)
+}
+
+// UpdateQOS updates an existing QOS in slurmdbd. Slurmdbd matches QOS by name, so creating and
+// updating share the same request.
+func (c *client) UpdateQOS(ctx context.Context, qos QOS) error {
+	if err := validateQOS(qos); err != nil {
+		return fmt.Errorf("update qos: %w", err)
+	}
+	return c.postQOS(ctx, "update qos "+qos.Name, qos)
+}
+
+func (c *client) postQOS(ctx context.Context, action string, qos QOS) error {
+	response, err := c.SlurmdbV0044PostQosWithResponse(ctx, &api.SlurmdbV0044PostQosParams{}, api.V0044OpenapiSlurmdbdQosResp{
+		Qos: api.V0044QosList{qosToAPI(qos)},
+	})
+	if err != nil {
+		return fmt.Errorf("post %s request: %w", action, err)
+	}
+	return checkSlurmRESTMutation(action, response.StatusCode(), response.Body)
+}
+
+// DeleteQOS deletes a QOS from slurmdbd.
+func (c *client) DeleteQOS(ctx context.Context, qosName string) error {
+	if qosName == "" {
+		return errors.New("delete qos: qos name is required")
+	}
+
+	response, err := c.SlurmdbV0044DeleteSingleQosWithResponse(ctx, qosName)
+	if err != nil {
+		return fmt.Errorf("delete qos %s request: %w", qosName, err)
+	}
+	return checkSlurmRESTMutation("delete qos "+qosName, response.StatusCode(), response.Body)
+}
+
+func convertToFloat64(input *api.V0044Float64NoValStruct) *float64 {
+	if input == nil || input.Set == nil || !*input.Set || input.Number == nil {
+		return nil
+	}
+
+	if input.Infinite != nil && *input.Infinite {
+		return nil
+	}
+
+	return input.Number
+}
@@ -0,0 +1,103 @@
+package slurmapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+)
+
+func TestQOSFromAPI(t *testing.T) {
+	data, err := os.ReadFile("testdata/qos_rest.json")
+	require.NoError(t, err)
+
+	var apiQOS api.V0044Qos
+	require.NoError(t, json.Unmarshal(data, &apiQOS))
+
+	got, err := QOSFromAPI(apiQOS)
+	require.NoError(t, err)
+	assert.Equal(t, QOS{
+		Name:                 "normal",
+		ID:                   ptr.To(int32(1)),
+		Description:          "Normal QOS default",
+		Flags:                []api.V0044QosFlags{api.V0044QosFlagsDENYLIMIT},
+		Priority:             ptr.To(int32(10)),
+		UsageFactor:          ptr.To(1.5),
+		GrpTRES:              map[string]int64{"cpu": 128, "gres/gpu": 16},
+		MaxTRESPerUser:       map[string]int64{"gres/gpu": 8},
+		MaxJobsPerUser:       ptr.To(int32(4)),
+		MaxWallPerJobMinutes: ptr.To(int32(1440)),
+	}, got)
+}
+
+func TestQOSFromAPI_MissingName(t *testing.T) {
+	_, err := QOSFromAPI(api.V0044Qos{})
+	assert.Error(t, err)
+}
+
+func TestQOSToAPIRoundTrip(t *testing.T) {
+	qos := QOS{
+		Name:                 "normal",
+		Description:          "Normal QOS",
+		Flags:                []api.V0044QosFlags{api.V0044QosFlagsDENYLIMIT},
+		Priority:             ptr.To(int32(10)),
+		UsageFactor:          ptr.To(1.5),
+		GrpTRES:              map[string]int64{"cpu": 128, "gres/gpu": 16},
+		MaxTRESPerUser:       map[string]int64{"gres/gpu": 8},
+		MaxJobsPerUser:       ptr.To(int32(4)),
+		MaxWallPerJobMinutes: ptr.To(int32(1440)),
+	}
+
+	got, err := QOSFromAPI(qosToAPI(qos))
+	require.NoError(t, err)
+	assert.Equal(t, qos, got)
+}
+
+func TestValidateQOS(t *testing.T) {
+	err := validateQOS(QOS{UsageFactor: ptr.To(-1.0), GrpTRES: map[string]int64{"cpu": -2}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "doesn't have name")
+	assert.Contains(t, err.Error(), "usage factor")
+	assert.Contains(t, err.Error(), "GrpTRES limit for cpu")
+}
+
+func TestCreateQOSPostsV0044Payload(t *testing.T) {
+	var gotBody api.V0044OpenapiSlurmdbdQosResp
+	httpClient := &http.Client{Transport: roundTripFunc(func(request *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPost, request.Method)
+		assert.Equal(t, "/slurmdb/v0.0.44/qos/", request.URL.Path)
+
+		require.NoError(t, json.NewDecoder(request.Body).Decode(&gotBody))
+		return undrainJSONResponse(http.StatusOK, `{"errors":[]}`), nil
+	})}
+
+	client, err := NewClient("http://slurmrestd/", nil, httpClient)
+	require.NoError(t, err)
+
+	require.NoError(t, client.CreateQOS(context.Background(), QOS{Name: "high", Priority: ptr.To(int32(100))}))
+	require.Len(t, gotBody.Qos, 1)
+	assert.Equal(t, "high", *gotBody.Qos[0].Name)
+	assert.Equal(t, int32(100), *gotBody.Qos[0].Priority.Number)
+}
+
+func TestDeleteQOSStatusErrorSummarizesSlurmEnvelope(t *testing.T) {
+	httpClient := &http.Client{Transport: roundTripFunc(func(request *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodDelete, request.Method)
+		assert.Equal(t, "/slurmdb/v0.0.44/qos/high", request.URL.Path)
+		return undrainJSONResponse(http.StatusInternalServerError, `{"errors":[{"description":"QOS is in use"}]}`), nil
+	})}
+
+	client, err := NewClient("http://slurmrestd", nil, httpClient)
+	require.NoError(t, err)
+
+	err = client.DeleteQOS(context.Background(), "high")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status=500")
+	assert.Contains(t, err.Error(), "QOS is in use")
+}
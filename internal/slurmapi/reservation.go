@@ -0,0 +1,215 @@
+package slurmapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"k8s.io/utils/ptr"
+)
+
+// Reservation is a Slurm reservation. Tres is reported by Slurm but ignored on create and update:
+// reserved resources are requested with NodeList, NodeCount or CoreCount.
+type Reservation struct {
+	Name      string
+	NodeList  string // Hostlist expression of the reserved nodes.
+	NodeCount *int32
+	CoreCount *int32
+	StartTime time.Time
+	EndTime   time.Time
+	Flags     []api.V0044ReservationInfoFlags
+	Users     []string
+	Accounts  []string
+	Partition string
+	Features  string
+	Tres      string
+}
+
+func validateAPIReservation(reservation api.V0044ReservationInfo) error {
+	var errs []error
+
+	if reservation.Name == nil || *reservation.Name == "" {
+		errs = append(errs, errors.New("reservation doesn't have name"))
+	}
+
+	if reservation.StartTime == nil || reservation.StartTime.Number == nil {
+		errs = append(errs, errors.New("reservation doesn't have start time"))
+	}
+
+	return errors.Join(errs...)
+}
+
+func ReservationFromAPI(reservation api.V0044ReservationInfo) (Reservation, error) {
+	if err := validateAPIReservation(reservation); err != nil {
+		return Reservation{}, err
+	}
+
+	res := Reservation{
+		Name:      *reservation.Name,
+		NodeCount: reservation.NodeCount,
+		CoreCount: reservation.CoreCount,
+		StartTime: time.Unix(*reservation.StartTime.Number, 0),
+	}
+
+	if endTime := convertToInt64(reservation.EndTime); endTime != nil {
+		res.EndTime = time.Unix(*endTime, 0)
+	}
+
+	if reservation.NodeList != nil {
+		res.NodeList = *reservation.NodeList
+	}
+	if reservation.Flags != nil {
+		res.Flags = *reservation.Flags
+	}
+	if reservation.Users != nil {
+		res.Users = splitCommaSeparated(*reservation.Users)
+	}
+	if reservation.Accounts != nil {
+		res.Accounts = splitCommaSeparated(*reservation.Accounts)
+	}
+	if reservation.Partition != nil {
+		res.Partition = *reservation.Partition
+	}
+	if reservation.Features != nil {
+		res.Features = *reservation.Features
+	}
+	if reservation.Tres != nil {
+		res.Tres = *reservation.Tres
+	}
+
+	return res, nil
+}
+
+// IsActive reports whether the reservation window contains the given moment. Reservations without
+// an end time are treated as open-ended.
+func (r *Reservation) IsActive(now time.Time) bool {
+	if now.Before(r.StartTime) {
+		return false
+	}
+	return r.EndTime.IsZero() || now.Before(r.EndTime)
+}
+
+func (r *Reservation) HasFlag(flag api.V0044ReservationInfoFlags) bool {
+	return slices.Contains(r.Flags, flag)
+}
+
+func (r *Reservation) GetNodeList() ([]string, error) {
+	return parseNodeList(r.NodeList)
+}
+
+// validateReservation checks that the reservation can be submitted to Slurm.
+func validateReservation(reservation Reservation) error {
+	var errs []error
+
+	if reservation.Name == "" {
+		errs = append(errs, errors.New("reservation doesn't have name"))
+	}
+	if reservation.StartTime.IsZero() {
+		errs = append(errs, errors.New("reservation doesn't have start time"))
+	}
+	if !reservation.EndTime.IsZero() && !reservation.EndTime.After(reservation.StartTime) {
+		errs = append(errs, errors.New("reservation end time must be after start time"))
+	}
+	if reservation.NodeList == "" && reservation.NodeCount == nil && reservation.CoreCount == nil {
+		errs = append(errs, errors.New("reservation must specify node list, node count or core count"))
+	}
+	if len(reservation.Users) == 0 && len(reservation.Accounts) == 0 {
+		errs = append(errs, errors.New("reservation must specify users or accounts"))
+	}
+
+	return errors.Join(errs...)
+}
+
+func reservationToAPI(reservation Reservation) api.V0044ReservationDescMsg {
+	res := api.V0044ReservationDescMsg{
+		Name:      ptr.To(reservation.Name),
+		NodeCount: uint32NoVal(reservation.NodeCount),
+		CoreCount: uint32NoVal(reservation.CoreCount),
+	}
+
+	if !reservation.StartTime.IsZero() {
+		res.StartTime = uint64NoVal(ptr.To(reservation.StartTime.Unix()))
+	}
+	if !reservation.EndTime.IsZero() {
+		res.EndTime = uint64NoVal(ptr.To(reservation.EndTime.Unix()))
+	}
+	if reservation.NodeList != "" {
+		res.NodeList = &api.V0044HostlistString{reservation.NodeList}
+	}
+	if len(reservation.Flags) > 0 {
+		flags := make([]api.V0044ReservationDescMsgFlags, 0, len(reservation.Flags))
+		for _, flag := range reservation.Flags {
+			flags = append(flags, api.V0044ReservationDescMsgFlags(flag))
+		}
+		res.Flags = &flags
+	}
+	if len(reservation.Users) > 0 {
+		res.Users = ptr.To(api.V0044CsvString(reservation.Users))
+	}
+	if len(reservation.Accounts) > 0 {
+		res.Accounts = ptr.To(api.V0044CsvString(reservation.Accounts))
+	}
+	if reservation.Partition != "" {
+		res.Partition = ptr.To(reservation.Partition)
+	}
+	if reservation.Features != "" {
+		res.Features = ptr.To(reservation.Features)
+	}
+
+	return res
+}
+
+// CreateReservation creates a Slurm reservation.
+func (c *client) CreateReservation(ctx context.Context, reservation Reservation) error {
+	if err := validateReservation(reservation); err != nil {
+		return fmt.Errorf("create reservation: %w", err)
+	}
+	return c.postReservation(ctx, "create reservation "+reservation.Name, reservation)
+}
+
+// UpdateReservation updates an existing Slurm reservation. Slurm keeps the fields that aren't set.
+func (c *client) UpdateReservation(ctx context.Context, reservation Reservation) error {
+	if reservation.Name == "" {
+		return errors.New("update reservation: reservation doesn't have name")
+	}
+	return c.postReservation(ctx, "update reservation "+reservation.Name, reservation)
+}
+
+func (c *client) postReservation(ctx context.Context, action string, reservation Reservation) error {
+	response, err := c.SlurmV0044PostReservationWithResponse(ctx, reservationToAPI(reservation))
+	if err != nil {
+		return fmt.Errorf("post %s request: %w", action, err)
+	}
+	return checkSlurmRESTMutation(action, response.StatusCode(), response.Body)
+}
+
+// DeleteReservation deletes a Slurm reservation.
+func (c *client) DeleteReservation(ctx context.Context, reservationName string) error {
+	if reservationName == "" {
+		return errors.New("delete reservation: reservation name is required")
+	}
+
+	response, err := c.SlurmV0044DeleteReservationWithResponse(ctx, reservationName)
+	if err != nil {
+		return fmt.Errorf("delete reservation %s request: %w", reservationName, err)
+	}
+	return checkSlurmRESTMutation("delete reservation "+reservationName, response.StatusCode(), response.Body)
+}
+
+// splitCommaSeparated splits Slurm's comma-separated name lists, dropping empty items.
+func splitCommaSeparated(s string) []string {
+	if s == "" {
+		return nil
+	}
+	var res []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			res = append(res, item)
+		}
+	}
+	return res
+}
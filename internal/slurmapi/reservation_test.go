@@ -0,0 +1,133 @@
+package slurmapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+)
+
+func TestReservationFromAPI(t *testing.T) {
+	data, err := os.ReadFile("testdata/reservation_rest.json")
+	require.NoError(t, err)
+
+	var apiReservation api.V0044ReservationInfo
+	require.NoError(t, json.Unmarshal(data, &apiReservation))
+
+	got, err := ReservationFromAPI(apiReservation)
+	require.NoError(t, err)
+	assert.Equal(t, Reservation{
+		Name:      "maintenance",
+		NodeList:  "worker-[0-1]",
+		NodeCount: ptr.To(int32(2)),
+		CoreCount: ptr.To(int32(32)),
+		StartTime: time.Unix(1747752894, 0),
+		EndTime:   time.Unix(1747756494, 0),
+		Flags:     []api.V0044ReservationInfoFlags{api.V0044ReservationInfoFlagsMAINT, api.V0044ReservationInfoFlagsIGNOREJOBS},
+		Users:     []string{"root", "soperator"},
+		Partition: "main",
+		Tres:      "cpu=32",
+	}, got)
+	assert.True(t, got.HasFlag(api.V0044ReservationInfoFlagsMAINT))
+	assert.False(t, got.HasFlag(api.V0044ReservationInfoFlagsFLEX))
+}
+
+func TestReservationFromAPI_Invalid(t *testing.T) {
+	_, err := ReservationFromAPI(api.V0044ReservationInfo{Name: ptr.To("no-start-time")})
+	assert.Error(t, err)
+}
+
+func TestReservation_IsActive(t *testing.T) {
+	start := time.Unix(1747752894, 0)
+	reservation := Reservation{StartTime: start, EndTime: start.Add(time.Hour)}
+
+	assert.False(t, reservation.IsActive(start.Add(-time.Second)))
+	assert.True(t, reservation.IsActive(start))
+	assert.False(t, reservation.IsActive(start.Add(time.Hour)))
+
+	openEnded := Reservation{StartTime: start}
+	assert.True(t, openEnded.IsActive(start.Add(24*time.Hour)))
+}
+
+func TestValidateReservation(t *testing.T) {
+	start := time.Unix(1747752894, 0)
+	valid := Reservation{
+		Name:      "maintenance",
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+		NodeList:  "worker-[0-1]",
+		Users:     []string{"root"},
+	}
+	require.NoError(t, validateReservation(valid))
+
+	invalid := valid
+	invalid.EndTime = start
+	invalid.NodeList = ""
+	invalid.Users = nil
+	err := validateReservation(invalid)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "end time must be after start time")
+	assert.Contains(t, err.Error(), "node list, node count or core count")
+	assert.Contains(t, err.Error(), "users or accounts")
+}
+
+func TestCreateReservationPostsV0044Payload(t *testing.T) {
+	var gotBody map[string]any
+	httpClient := &http.Client{Transport: roundTripFunc(func(request *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPost, request.Method)
+		assert.Equal(t, "/slurm/v0.0.44/reservation", request.URL.Path)
+
+		require.NoError(t, json.NewDecoder(request.Body).Decode(&gotBody))
+		return undrainJSONResponse(http.StatusOK, `{"errors":[]}`), nil
+	})}
+
+	client, err := NewClient("http://slurmrestd/", nil, httpClient)
+	require.NoError(t, err)
+
+	start := time.Unix(1747752894, 0)
+	require.NoError(t, client.CreateReservation(context.Background(), Reservation{
+		Name:      "maintenance",
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+		NodeList:  "worker-[0-1]",
+		Flags:     []api.V0044ReservationInfoFlags{api.V0044ReservationInfoFlagsMAINT},
+		Users:     []string{"root"},
+	}))
+
+	assert.Equal(t, "maintenance", gotBody["name"])
+	assert.Equal(t, []any{"worker-[0-1]"}, gotBody["node_list"])
+	assert.Equal(t, []any{"MAINT"}, gotBody["flags"])
+	assert.Equal(t, []any{"root"}, gotBody["users"])
+	assert.Equal(t, map[string]any{"set": true, "number": float64(1747752894)}, gotBody["start_time"])
+}
+
+func TestCreateReservationRejectsInvalidReservation(t *testing.T) {
+	client, err := NewClient("http://slurmrestd", nil, nil)
+	require.NoError(t, err)
+
+	err = client.CreateReservation(context.Background(), Reservation{Name: "maintenance"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "start time")
+}
+
+func TestDeleteReservationDetectsSlurmErrorsOnOK(t *testing.T) {
+	httpClient := &http.Client{Transport: roundTripFunc(func(request *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodDelete, request.Method)
+		assert.Equal(t, "/slurm/v0.0.44/reservation/maintenance", request.URL.Path)
+		return undrainJSONResponse(http.StatusOK, `{"errors":[{"error":"ESLURM_RESERVATION_INVALID"}]}`), nil
+	})}
+
+	client, err := NewClient("http://slurmrestd", nil, httpClient)
+	require.NoError(t, err)
+
+	err = client.DeleteReservation(context.Background(), "maintenance")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ESLURM_RESERVATION_INVALID")
+}
@@ -0,0 +1,119 @@
+package slurmapi
+
+import (
+	"errors"
+	"slices"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+)
+
+// Share is a single row of the fairshare tree, as printed by sshare. Account rows have the
+// account in Name and the parent account in Parent; user rows have the user in Name and the
+// account the user association belongs to in Parent.
+type Share struct {
+	ID        *int32
+	Cluster   string
+	Name      string
+	Parent    string
+	Partition string
+	IsUser    bool
+
+	RawShares        *int32
+	NormalizedShares *float64
+	RawUsage         *int64
+	NormalizedUsage  *float64
+	EffectiveUsage   *float64
+	FairshareFactor  *float64
+	FairshareLevel   *float64
+
+	// TRESRunSeconds is the amount of TRES-seconds remaining for running jobs of the association,
+	// keyed by TRES name (e.g. "cpu", "mem", "gres/gpu").
+	TRESRunSeconds map[string]int64
+	// TRESUsage is the decayed TRES usage of the association used for fairshare calculation.
+	TRESUsage map[string]float64
+}
+
+func validateAPIShare(share api.V0044AssocSharesObjWrap) error {
+	var errs []error
+
+	if share.Name == nil || *share.Name == "" {
+		errs = append(errs, errors.New("share doesn't have name"))
+	}
+
+	return errors.Join(errs...)
+}
+
+func ShareFromAPI(share api.V0044AssocSharesObjWrap) (Share, error) {
+	if err := validateAPIShare(share); err != nil {
+		return Share{}, err
+	}
+
+	res := Share{
+		ID:               share.Id,
+		Name:             *share.Name,
+		RawShares:        convertToInt(share.Shares),
+		NormalizedShares: convertToFloat64(share.SharesNormalized),
+		RawUsage:         share.Usage,
+		NormalizedUsage:  convertToFloat64(share.UsageNormalized),
+		EffectiveUsage:   convertToFloat64(share.EffectiveUsage),
+	}
+
+	if share.Cluster != nil {
+		res.Cluster = *share.Cluster
+	}
+	if share.Parent != nil {
+		res.Parent = *share.Parent
+	}
+	if share.Partition != nil {
+		res.Partition = *share.Partition
+	}
+	if share.Type != nil {
+		res.IsUser = slices.Contains(*share.Type, api.USER)
+	}
+
+	if share.Fairshare != nil {
+		res.FairshareFactor = convertToFloat64(share.Fairshare.Factor)
+		res.FairshareLevel = convertToFloat64(share.Fairshare.Level)
+	}
+
+	if share.Tres != nil {
+		if share.Tres.RunSeconds != nil {
+			res.TRESRunSeconds = make(map[string]int64, len(*share.Tres.RunSeconds))
+			for _, tres := range *share.Tres.RunSeconds {
+				if tres.Name == nil {
+					continue
+				}
+				if value := convertToInt64(tres.Value); value != nil {
+					res.TRESRunSeconds[*tres.Name] = *value
+				}
+			}
+		}
+		if share.Tres.Usage != nil {
+			res.TRESUsage = make(map[string]float64, len(*share.Tres.Usage))
+			for _, tres := range *share.Tres.Usage {
+				if tres.Name == nil || tres.Value == nil {
+					continue
+				}
+				res.TRESUsage[*tres.Name] = float64(*tres.Value)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// Account returns the account the share row describes.
+func (s *Share) Account() string {
+	if s.IsUser {
+		return s.Parent
+	}
+	return s.Name
+}
+
+// User returns the user the share row describes, or an empty string for account rows.
+func (s *Share) User() string {
+	if s.IsUser {
+		return s.Name
+	}
+	return ""
+}
@@ -0,0 +1,57 @@
+package slurmapi
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+)
+
+func TestShareFromAPI(t *testing.T) {
+	data, err := os.ReadFile("testdata/share_rest.json")
+	require.NoError(t, err)
+
+	var apiShare api.V0044AssocSharesObjWrap
+	require.NoError(t, json.Unmarshal(data, &apiShare))
+
+	got, err := ShareFromAPI(apiShare)
+	require.NoError(t, err)
+	assert.Equal(t, Share{
+		ID:               ptr.To(int32(3)),
+		Cluster:          "soperator",
+		Name:             "alice",
+		Parent:           "team-a",
+		IsUser:           true,
+		RawShares:        ptr.To(int32(1)),
+		NormalizedShares: ptr.To(0.5),
+		RawUsage:         ptr.To(int64(7200)),
+		NormalizedUsage:  ptr.To(0.25),
+		EffectiveUsage:   ptr.To(0.25),
+		FairshareFactor:  ptr.To(0.75),
+		FairshareLevel:   ptr.To(2.0),
+		TRESRunSeconds:   map[string]int64{"cpu": 3600, "gres/gpu": 1800},
+		TRESUsage:        map[string]float64{"cpu": 1.5},
+	}, got)
+	assert.Equal(t, "team-a", got.Account())
+	assert.Equal(t, "alice", got.User())
+}
+
+func TestShareFromAPI_AccountRow(t *testing.T) {
+	got, err := ShareFromAPI(api.V0044AssocSharesObjWrap{
+		Name:   ptr.To("team-a"),
+		Parent: ptr.To("root"),
+		Type:   &[]api.V0044AssocSharesObjWrapType{api.ASSOCIATION},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "team-a", got.Account())
+	assert.Empty(t, got.User())
+}
+
+func TestShareFromAPI_MissingName(t *testing.T) {
+	_, err := ShareFromAPI(api.V0044AssocSharesObjWrap{})
+	assert.Error(t, err)
+}
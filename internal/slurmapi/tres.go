@@ -2,8 +2,13 @@ package slurmapi
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
 	"strings"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"k8s.io/utils/ptr"
 )
 
 type TrackableResources struct {
@@ -89,3 +94,47 @@ func parseMemoryValue(value string) (int, error) {
 	}
 	return int(num * multiplier), nil
 }
+
+// tresListToMap converts a structured TRES list into a map keyed the same way Slurm prints TRES
+// strings ("cpu", "mem", "gres/gpu"). Entries without a count are skipped.
+func tresListToMap(input *api.V0044TresList) map[string]int64 {
+	if input == nil || len(*input) == 0 {
+		return nil
+	}
+
+	res := make(map[string]int64, len(*input))
+	for _, tres := range *input {
+		if tres.Count == nil {
+			continue
+		}
+		res[tresKey(tres)] = *tres.Count
+	}
+
+	return res
+}
+
+// tresMapToList is the inverse of tresListToMap. Entries are sorted by key to keep requests stable.
+func tresMapToList(input map[string]int64) *api.V0044TresList {
+	if len(input) == 0 {
+		return nil
+	}
+
+	res := make(api.V0044TresList, 0, len(input))
+	for _, key := range slices.Sorted(maps.Keys(input)) {
+		tres := api.V0044Tres{Type: key, Count: ptr.To(input[key])}
+		if tresType, name, ok := strings.Cut(key, "/"); ok {
+			tres.Type = tresType
+			tres.Name = ptr.To(name)
+		}
+		res = append(res, tres)
+	}
+
+	return &res
+}
+
+func tresKey(tres api.V0044Tres) string {
+	if tres.Name != nil && *tres.Name != "" {
+		return fmt.Sprintf("%s/%s", tres.Type, *tres.Name)
+	}
+	return tres.Type
+}
@@ -99,3 +99,14 @@ func TestParseTrackableResources_Failure(t *testing.T) {
 		})
 	}
 }
+
+func TestTresMapToList(t *testing.T) {
+	assert.Nil(t, tresMapToList(nil))
+
+	got := tresMapToList(map[string]int64{"gres/gpu": 8, "cpu": 16})
+	require.NotNil(t, got)
+	assert.Equal(t, api.V0044TresList{
+		{Type: "cpu", Count: ptr.To(int64(16))},
+		{Type: "gres", Name: ptr.To("gpu"), Count: ptr.To(int64(8))},
+	}, *got)
+}
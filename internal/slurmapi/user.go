@@ -0,0 +1,67 @@
+package slurmapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	api "github.com/SlinkyProject/slurm-client/api/v0044"
+	"k8s.io/utils/ptr"
+)
+
+// User is a slurmdbd user. Users are bound to accounts through associations, see Association.
+type User struct {
+	Name           string
+	DefaultAccount string
+}
+
+func validateAPIUser(user api.V0044User) error {
+	var errs []error
+
+	if user.Name == "" {
+		errs = append(errs, errors.New("user doesn't have name"))
+	}
+
+	return errors.Join(errs...)
+}
+
+func UserFromAPI(user api.V0044User) (User, error) {
+	if err := validateAPIUser(user); err != nil {
+		return User{}, err
+	}
+
+	res := User{
+		Name: user.Name,
+	}
+
+	if user.Default != nil && user.Default.Account != nil {
+		res.DefaultAccount = *user.Default.Account
+	}
+
+	return res, nil
+}
+
+// CreateUser creates a user in slurmdbd. The default account must already exist.
+func (c *client) CreateUser(ctx context.Context, user User) error {
+	if user.Name == "" {
+		return errors.New("create user: user doesn't have name")
+	}
+
+	apiUser := api.V0044User{Name: user.Name}
+	if user.DefaultAccount != "" {
+		apiUser.Default = &struct {
+			Account *string `json:"account,omitempty"`
+			Qos     *int32  `json:"qos,omitempty"`
+			Wckey   *string `json:"wckey,omitempty"`
+		}{Account: ptr.To(user.DefaultAccount)}
+	}
+
+	action := "create user " + user.Name
+	response, err := c.SlurmdbV0044PostUsersWithResponse(ctx, api.V0044OpenapiUsersResp{
+		Users: api.V0044UserList{apiUser},
+	})
+	if err != nil {
+		return fmt.Errorf("post %s request: %w", action, err)
+	}
+	return checkSlurmRESTMutation(action, response.StatusCode(), response.Body)
+}
@@ -0,0 +1,110 @@
+package tokenservice
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+)
+
+// Policy maps Kubernetes ServiceAccounts to the Slurm usernames they can get JWTs for.
+type Policy struct {
+	Rules []PolicyRule `json:"rules"`
+}
+
+// PolicyRule allows the ServiceAccounts to get JWTs for the Slurm usernames in the Slurm clusters.
+type PolicyRule struct {
+	// ServiceAccounts the rule applies to.
+	ServiceAccounts []ServiceAccountRef `json:"serviceAccounts"`
+	// Clusters the JWTs can be issued for.
+	Clusters []ClusterRef `json:"clusters"`
+	// Usernames the JWTs can be issued for. The first one is used if the request doesn't specify a username.
+	Usernames []string `json:"usernames"`
+}
+
+// ServiceAccountRef refers to a ServiceAccount, or to all ServiceAccounts of the namespace if the name is empty.
+type ServiceAccountRef struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name,omitempty"`
+}
+
+// ClusterRef refers to a SlurmCluster.
+type ClusterRef struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// LoadPolicy reads the policy from the YAML file.
+func LoadPolicy(path string) (Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Policy{}, fmt.Errorf("reading policy file: %w", err)
+	}
+
+	policy := Policy{}
+	if err = yaml.UnmarshalStrict(data, &policy); err != nil {
+		return Policy{}, fmt.Errorf("parsing policy file: %w", err)
+	}
+	if err = policy.Validate(); err != nil {
+		return Policy{}, fmt.Errorf("validating policy: %w", err)
+	}
+	return policy, nil
+}
+
+// Validate checks that every rule allows some usernames to some ServiceAccounts in some clusters.
+func (p Policy) Validate() error {
+	for i, rule := range p.Rules {
+		if len(rule.ServiceAccounts) == 0 {
+			return fmt.Errorf("rule %d: no serviceAccounts", i)
+		}
+		for _, sa := range rule.ServiceAccounts {
+			if sa.Namespace == "" {
+				return fmt.Errorf("rule %d: serviceAccount namespace is empty", i)
+			}
+		}
+		if len(rule.Clusters) == 0 {
+			return fmt.Errorf("rule %d: no clusters", i)
+		}
+		for _, cluster := range rule.Clusters {
+			if cluster.Namespace == "" || cluster.Name == "" {
+				return fmt.Errorf("rule %d: cluster namespace and name must be set", i)
+			}
+		}
+		if len(rule.Usernames) == 0 {
+			return fmt.Errorf("rule %d: no usernames", i)
+		}
+		if slices.Contains(rule.Usernames, "") {
+			return fmt.Errorf("rule %d: username is empty", i)
+		}
+	}
+	return nil
+}
+
+// AllowedUsernames returns the Slurm usernames the ServiceAccount can get JWTs for in the cluster, in the order of the
+// rules.
+func (p Policy) AllowedUsernames(serviceAccount, cluster types.NamespacedName) []string {
+	var res []string
+	for _, rule := range p.Rules {
+		if !rule.matchesServiceAccount(serviceAccount) || !rule.matchesCluster(cluster) {
+			continue
+		}
+		for _, username := range rule.Usernames {
+			if !slices.Contains(res, username) {
+				res = append(res, username)
+			}
+		}
+	}
+	return res
+}
+
+func (r PolicyRule) matchesServiceAccount(serviceAccount types.NamespacedName) bool {
+	return slices.ContainsFunc(r.ServiceAccounts, func(ref ServiceAccountRef) bool {
+		return ref.Namespace == serviceAccount.Namespace && (ref.Name == "" || ref.Name == serviceAccount.Name)
+	})
+}
+
+func (r PolicyRule) matchesCluster(cluster types.NamespacedName) bool {
+	return slices.Contains(r.Clusters, ClusterRef{Namespace: cluster.Namespace, Name: cluster.Name})
+}
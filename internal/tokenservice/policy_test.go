@@ -0,0 +1,77 @@
+package tokenservice
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestLoadPolicy(t *testing.T) {
+	writePolicy := func(t *testing.T, content string) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "policy.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	policy, err := LoadPolicy(writePolicy(t, `
+rules:
+  - serviceAccounts:
+      - namespace: ci
+        name: runner
+    clusters:
+      - namespace: soperator
+        name: slurm
+    usernames: [ci]
+`))
+	require.NoError(t, err)
+	assert.Equal(t, Policy{Rules: []PolicyRule{{
+		ServiceAccounts: []ServiceAccountRef{{Namespace: "ci", Name: "runner"}},
+		Clusters:        []ClusterRef{{Namespace: "soperator", Name: "slurm"}},
+		Usernames:       []string{"ci"},
+	}}}, policy)
+
+	for name, content := range map[string]string{
+		"unknown field":  `rules: [{serviceAccount: ci}]`,
+		"no usernames":   `rules: [{serviceAccounts: [{namespace: ci}], clusters: [{namespace: soperator, name: slurm}]}]`,
+		"no clusters":    `rules: [{serviceAccounts: [{namespace: ci}], usernames: [ci]}]`,
+		"empty username": `rules: [{serviceAccounts: [{namespace: ci}], clusters: [{namespace: soperator, name: slurm}], usernames: [""]}]`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := LoadPolicy(writePolicy(t, content))
+			assert.Error(t, err)
+		})
+	}
+
+	_, err = LoadPolicy(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestPolicy_AllowedUsernames(t *testing.T) {
+	slurm := types.NamespacedName{Namespace: "soperator", Name: "slurm"}
+	policy := Policy{Rules: []PolicyRule{
+		{
+			ServiceAccounts: []ServiceAccountRef{{Namespace: "ci", Name: "runner"}},
+			Clusters:        []ClusterRef{{Namespace: "soperator", Name: "slurm"}},
+			Usernames:       []string{"ci", "ci-nightly"},
+		},
+		{
+			ServiceAccounts: []ServiceAccountRef{{Namespace: "ci"}},
+			Clusters:        []ClusterRef{{Namespace: "soperator", Name: "slurm"}},
+			Usernames:       []string{"ci-shared", "ci"},
+		},
+	}}
+
+	assert.Equal(t, []string{"ci", "ci-nightly", "ci-shared"},
+		policy.AllowedUsernames(types.NamespacedName{Namespace: "ci", Name: "runner"}, slurm))
+	assert.Equal(t, []string{"ci-shared", "ci"},
+		policy.AllowedUsernames(types.NamespacedName{Namespace: "ci", Name: "other"}, slurm),
+		"rule without ServiceAccount name matches the whole namespace")
+	assert.Empty(t, policy.AllowedUsernames(types.NamespacedName{Namespace: "dev", Name: "runner"}, slurm))
+	assert.Empty(t, policy.AllowedUsernames(types.NamespacedName{Namespace: "ci", Name: "runner"},
+		types.NamespacedName{Namespace: "soperator", Name: "other"}))
+}
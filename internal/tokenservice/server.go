@@ -0,0 +1,327 @@
+package tokenservice
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	jwtImpl "github.com/golang-jwt/jwt/v5"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slurmv1 "nebius.ai/slurm-operator/api/v1"
+	"nebius.ai/slurm-operator/internal/jwt"
+)
+
+const (
+	// ServerName is the name of the token service in logs.
+	ServerName = "token-service"
+
+	// TokenPath is the path the JWTs are requested at.
+	TokenPath = "/token"
+
+	// DefaultTokenLifetime is the lifetime of the issued JWTs, unless configured otherwise.
+	DefaultTokenLifetime = 15 * time.Minute
+	// DefaultAudience is the audience the ServiceAccount tokens of the callers must be issued for, unless configured
+	// otherwise. A dedicated audience keeps the token service from accepting the tokens for the Kubernetes API server,
+	// which it could replay.
+	DefaultAudience = "soperator-token-service"
+
+	// serviceAccountUsernamePrefix prefixes the usernames of the authenticated ServiceAccounts in the TokenReviews.
+	serviceAccountUsernamePrefix = "system:serviceaccount:"
+	// maxRequestSize limits the size of the token request body.
+	maxRequestSize = 1 << 12
+	// maxCachedTokens limits the number of the issued JWTs cached for reuse.
+	maxCachedTokens = 1024
+	// shutdownTimeout is how long the in-flight requests are waited for on shutdown.
+	shutdownTimeout = 10 * time.Second
+)
+
+// errUnauthenticated is returned if the caller can't be authenticated as a ServiceAccount.
+var errUnauthenticated = errors.New("unauthenticated")
+
+// Options configure the token service.
+type Options struct {
+	// BindAddress is the address the token service listens on.
+	BindAddress string
+	// CertDir is the directory with the tls.crt and tls.key serving certificate. It's required unless Insecure is set.
+	CertDir string
+	// Insecure allows serving the token service over plain HTTP, which exposes the ServiceAccount tokens of the callers.
+	Insecure bool
+	// TLSOpts configure the TLS of the token service.
+	TLSOpts []func(*tls.Config)
+	// TokenLifetime is the lifetime of the issued JWTs.
+	TokenLifetime time.Duration
+	// Audiences are the audiences the ServiceAccount tokens of the callers must be issued for. [DefaultAudience] is
+	// required if it's empty.
+	Audiences []string
+}
+
+// TokenRequest is the body of a request for a JWT.
+type TokenRequest struct {
+	// Cluster is the SlurmCluster the JWT is issued for.
+	Cluster ClusterRef `json:"cluster"`
+	// Username is the Slurm username the JWT is issued for. The first username allowed by the policy is used if it's
+	// empty.
+	Username string `json:"username,omitempty"`
+}
+
+// TokenResponse is the body of a response with the issued JWT.
+type TokenResponse struct {
+	Token     string    `json:"token"`
+	Username  string    `json:"username"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Server issues short-lived Slurm JWTs to the ServiceAccounts the policy allows, authenticating them by their tokens
+// through the Kubernetes TokenReview API.
+type Server struct {
+	client   client.Client
+	policy   Policy
+	options  Options
+	registry *jwt.TokenRegistry
+	logger   logr.Logger
+}
+
+// NewServer creates a new token service.
+func NewServer(client client.Client, policy Policy, options Options) *Server {
+	if options.TokenLifetime <= 0 {
+		options.TokenLifetime = DefaultTokenLifetime
+	}
+	if len(options.Audiences) == 0 {
+		options.Audiences = []string{DefaultAudience}
+	}
+
+	return &Server{
+		client:  client,
+		policy:  policy,
+		options: options,
+		// Cached JWTs are reissued after half of their lifetime, so that callers always get JWTs valid long enough
+		registry: jwt.NewTokenRegistry().
+			WithMaxEntries(maxCachedTokens).
+			WithRenewBefore(options.TokenLifetime / 2).
+			Build(),
+		logger: log.Log.WithName(ServerName),
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable interface.
+// JWTs are issued by all operator replicas.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements manager.Runnable interface.
+// It serves the token service until the context is done.
+func (s *Server) Start(ctx context.Context) error {
+	if s.options.CertDir == "" && !s.options.Insecure {
+		return errors.New("serving certificate is required to serve token service, unless insecure serving is allowed")
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+TokenPath, s.handleToken)
+
+	listener, err := net.Listen("tcp", s.options.BindAddress)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", s.options.BindAddress, err)
+	}
+
+	if s.options.CertDir != "" {
+		watcher, err := certwatcher.New(
+			filepath.Join(s.options.CertDir, "tls.crt"),
+			filepath.Join(s.options.CertDir, "tls.key"),
+		)
+		if err != nil {
+			return fmt.Errorf("loading serving certificate: %w", err)
+		}
+		go func() {
+			if err := watcher.Start(ctx); err != nil {
+				s.logger.Error(err, "Failed to watch serving certificate")
+			}
+		}()
+
+		tlsConfig := &tls.Config{
+			GetCertificate: watcher.GetCertificate,
+			MinVersion:     tls.VersionTLS12,
+		}
+		for _, opt := range s.options.TLSOpts {
+			opt(tlsConfig)
+		}
+		listener = tls.NewListener(listener, tlsConfig)
+	}
+
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			s.logger.Error(err, "Failed to shut down token service")
+		}
+	}()
+
+	if s.options.CertDir == "" {
+		s.logger.Info("Token service is served over plain HTTP, exposing the ServiceAccount tokens of the callers")
+	}
+	s.logger.Info("Starting token service", "addr", s.options.BindAddress, "tls", s.options.CertDir != "")
+	if err = server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serving token service: %w", err)
+	}
+	return nil
+}
+
+// handleToken issues a JWT for the Slurm username in the cluster, if the policy allows it to the caller.
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	bearerToken, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !found || bearerToken == "" {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "bearer token is required", http.StatusUnauthorized)
+		return
+	}
+
+	serviceAccount, err := s.authenticate(ctx, bearerToken)
+	if err != nil {
+		if errors.Is(err, errUnauthenticated) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		s.logger.Error(err, "Failed to authenticate caller")
+		http.Error(w, "failed to authenticate caller", http.StatusInternalServerError)
+		return
+	}
+	logger := s.logger.WithValues("serviceAccount", serviceAccount.String())
+
+	req := TokenRequest{}
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+		return
+	}
+	if req.Cluster.Namespace == "" || req.Cluster.Name == "" {
+		http.Error(w, "invalid request: cluster namespace and name are required", http.StatusBadRequest)
+		return
+	}
+	cluster := types.NamespacedName{Namespace: req.Cluster.Namespace, Name: req.Cluster.Name}
+	logger = logger.WithValues("cluster", cluster.String())
+
+	username, allowed := s.authorize(serviceAccount, cluster, req.Username)
+	if !allowed {
+		logger.Info("Denied JWT", "username", req.Username)
+		http.Error(w, "the policy doesn't allow the username in the cluster to the ServiceAccount", http.StatusForbidden)
+		return
+	}
+	logger = logger.WithValues("username", username)
+
+	if err = s.client.Get(ctx, cluster, &slurmv1.SlurmCluster{}); err != nil {
+		if apierrors.IsNotFound(err) {
+			http.Error(w, fmt.Sprintf("cluster %s not found", cluster), http.StatusNotFound)
+			return
+		}
+		logger.Error(err, "Failed to get SlurmCluster")
+		http.Error(w, "failed to get cluster", http.StatusInternalServerError)
+		return
+	}
+
+	token, err := jwt.NewToken(s.client).
+		For(cluster, username).
+		WithLifetime(s.options.TokenLifetime).
+		WithRegistry(s.registry).
+		Issue(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to issue JWT")
+		http.Error(w, "failed to issue token", http.StatusInternalServerError)
+		return
+	}
+
+	expiresAt, err := tokenExpirationTime(token)
+	if err != nil {
+		logger.Error(err, "Failed to get expiration time of JWT")
+		http.Error(w, "failed to issue token", http.StatusInternalServerError)
+		return
+	}
+
+	logger.Info("Issued JWT", "expiresAt", expiresAt)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err = json.NewEncoder(w).Encode(TokenResponse{Token: token, Username: username, ExpiresAt: expiresAt}); err != nil {
+		logger.Error(err, "Failed to write response")
+	}
+}
+
+// authenticate returns the ServiceAccount the bearer token belongs to, reviewed by the Kubernetes API server.
+func (s *Server) authenticate(ctx context.Context, bearerToken string) (types.NamespacedName, error) {
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{
+			Token:     bearerToken,
+			Audiences: s.options.Audiences,
+		},
+	}
+	if err := s.client.Create(ctx, review); err != nil {
+		return types.NamespacedName{}, fmt.Errorf("creating TokenReview: %w", err)
+	}
+
+	if !review.Status.Authenticated {
+		if review.Status.Error != "" {
+			return types.NamespacedName{}, fmt.Errorf("%w: %s", errUnauthenticated, review.Status.Error)
+		}
+		return types.NamespacedName{}, errUnauthenticated
+	}
+	if !slices.ContainsFunc(review.Status.Audiences, func(audience string) bool {
+		return slices.Contains(s.options.Audiences, audience)
+	}) {
+		return types.NamespacedName{}, fmt.Errorf("%w: token isn't issued for the token service", errUnauthenticated)
+	}
+
+	serviceAccount, isServiceAccount := strings.CutPrefix(review.Status.User.Username, serviceAccountUsernamePrefix)
+	namespace, name, found := strings.Cut(serviceAccount, ":")
+	if !isServiceAccount || !found {
+		return types.NamespacedName{}, fmt.Errorf("%w: %s isn't a ServiceAccount", errUnauthenticated,
+			review.Status.User.Username)
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}, nil
+}
+
+// authorize returns the Slurm username the JWT is issued for, and whether the policy allows it to the ServiceAccount
+// in the cluster.
+func (s *Server) authorize(serviceAccount, cluster types.NamespacedName, username string) (string, bool) {
+	allowedUsernames := s.policy.AllowedUsernames(serviceAccount, cluster)
+	if len(allowedUsernames) == 0 {
+		return "", false
+	}
+	if username == "" {
+		return allowedUsernames[0], true
+	}
+	return username, slices.Contains(allowedUsernames, username)
+}
+
+// tokenExpirationTime returns the expiration time of the JWT issued by the operator.
+func tokenExpirationTime(token string) (time.Time, error) {
+	claims := jwt.TokenClaims{}
+	if _, _, err := jwtImpl.NewParser().ParseUnverified(token, &claims); err != nil {
+		return time.Time{}, fmt.Errorf("parsing JWT: %w", err)
+	}
+	if claims.ExpiresAt == nil {
+		return time.Time{}, errors.New("JWT has no expiration time")
+	}
+	return claims.ExpiresAt.Time, nil
+}
@@ -0,0 +1,179 @@
+package tokenservice
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	jwtImpl "github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	slurmv1 "nebius.ai/slurm-operator/api/v1"
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/naming"
+)
+
+var testSigningKey = []byte("test-signing-key")
+
+// newTestServer creates a token service whose client authenticates the bearer tokens by the usernames they map to.
+// The tokens are issued for the DefaultAudience only.
+func newTestServer(t *testing.T, tokenUsernames map[string]string) *Server {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, slurmv1.AddToScheme(scheme))
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&slurmv1.SlurmCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "soperator", Name: "slurm"}},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "soperator",
+					Name:      naming.BuildSecretSlurmRESTSecretName("slurm"),
+				},
+				Data: map[string][]byte{consts.SecretRESTJWTKeyFileName: testSigningKey},
+			},
+		).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				review, ok := obj.(*authenticationv1.TokenReview)
+				if !ok {
+					return c.Create(ctx, obj, opts...)
+				}
+				username, found := tokenUsernames[review.Spec.Token]
+				if found && slices.Contains(review.Spec.Audiences, DefaultAudience) {
+					review.Status.Authenticated = true
+					review.Status.User.Username = username
+					review.Status.Audiences = []string{DefaultAudience}
+				}
+				return nil
+			},
+		}).
+		Build()
+
+	policy := Policy{Rules: []PolicyRule{{
+		ServiceAccounts: []ServiceAccountRef{{Namespace: "ci", Name: "runner"}},
+		Clusters:        []ClusterRef{{Namespace: "soperator", Name: "slurm"}, {Namespace: "soperator", Name: "missing"}},
+		Usernames:       []string{"ci", "ci-nightly"},
+	}}}
+	return NewServer(c, policy, Options{TokenLifetime: time.Hour})
+}
+
+func requestToken(s *Server, bearerToken, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, TokenPath, strings.NewReader(body))
+	if bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+bearerToken)
+	}
+	rec := httptest.NewRecorder()
+	s.handleToken(rec, req)
+	return rec
+}
+
+func TestServer_HandleToken(t *testing.T) {
+	s := newTestServer(t, map[string]string{
+		"runner-token": "system:serviceaccount:ci:runner",
+		"other-token":  "system:serviceaccount:ci:other",
+		"user-token":   "jane",
+	})
+	const clusterBody = `{"cluster":{"namespace":"soperator","name":"slurm"}`
+
+	t.Run("issues JWT for the first allowed username", func(t *testing.T) {
+		rec := requestToken(s, "runner-token", clusterBody+`}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		resp := TokenResponse{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "ci", resp.Username)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), resp.ExpiresAt, time.Minute)
+
+		claims := jwtImpl.MapClaims{}
+		_, err := jwtImpl.ParseWithClaims(resp.Token, claims, func(*jwtImpl.Token) (any, error) { return testSigningKey, nil })
+		require.NoError(t, err)
+		assert.Equal(t, "ci", claims["sun"])
+
+		cachedRec := requestToken(s, "runner-token", clusterBody+`}`)
+		require.Equal(t, http.StatusOK, cachedRec.Code)
+		assert.Equal(t, rec.Body.String(), cachedRec.Body.String(), "cached JWT is reused")
+	})
+
+	t.Run("issues JWT for the requested username", func(t *testing.T) {
+		rec := requestToken(s, "runner-token", clusterBody+`,"username":"ci-nightly"}`)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+		resp := TokenResponse{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "ci-nightly", resp.Username)
+	})
+
+	for _, tc := range []struct {
+		name        string
+		bearerToken string
+		body        string
+		wantCode    int
+	}{
+		{name: "no bearer token", body: clusterBody + `}`, wantCode: http.StatusUnauthorized},
+		{name: "invalid bearer token", bearerToken: "forged", body: clusterBody + `}`, wantCode: http.StatusUnauthorized},
+		{name: "not a ServiceAccount", bearerToken: "user-token", body: clusterBody + `}`, wantCode: http.StatusUnauthorized},
+		{name: "malformed request", bearerToken: "runner-token", body: `{"cluster":"slurm"}`, wantCode: http.StatusBadRequest},
+		{name: "no cluster", bearerToken: "runner-token", body: `{}`, wantCode: http.StatusBadRequest},
+		{name: "ServiceAccount not allowed", bearerToken: "other-token", body: clusterBody + `}`, wantCode: http.StatusForbidden},
+		{name: "username not allowed", bearerToken: "runner-token", body: clusterBody + `,"username":"root"}`, wantCode: http.StatusForbidden},
+		{
+			name:        "cluster not allowed",
+			bearerToken: "runner-token",
+			body:        `{"cluster":{"namespace":"soperator","name":"other"}}`,
+			wantCode:    http.StatusForbidden,
+		},
+		{
+			name:        "cluster not found",
+			bearerToken: "runner-token",
+			body:        `{"cluster":{"namespace":"soperator","name":"missing"}}`,
+			wantCode:    http.StatusNotFound,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := requestToken(s, tc.bearerToken, tc.body)
+			assert.Equal(t, tc.wantCode, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func TestServer_Authenticate_Audiences(t *testing.T) {
+	s := newTestServer(t, map[string]string{"runner-token": "system:serviceaccount:ci:runner"})
+
+	serviceAccount, err := s.authenticate(context.Background(), "runner-token")
+	require.NoError(t, err, "token for the default audience is accepted")
+	assert.Equal(t, types.NamespacedName{Namespace: "ci", Name: "runner"}, serviceAccount)
+
+	s.options.Audiences = []string{"other-audience"}
+	_, err = s.authenticate(context.Background(), "runner-token")
+	assert.ErrorIs(t, err, errUnauthenticated, "token for another audience is rejected")
+}
+
+func TestServer_Start_RequiresTLS(t *testing.T) {
+	s := newTestServer(t, nil)
+	s.options.BindAddress = "127.0.0.1:0"
+
+	assert.Error(t, s.Start(context.Background()), "plain HTTP isn't served unless allowed")
+
+	s.options.Insecure = true
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.NoError(t, s.Start(ctx), "plain HTTP is served if allowed")
+}
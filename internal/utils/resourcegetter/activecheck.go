@@ -0,0 +1,42 @@
+package resourcegetter
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/utils/sliceutils"
+)
+
+// ListActiveChecksByClusterRef returns a list of [slurmv1alpha1.ActiveCheck] with spec.SlurmClusterRefName matching
+// clusterRef.Name.
+func ListActiveChecksByClusterRef(
+	ctx context.Context,
+	r client.Reader,
+	clusterRef types.NamespacedName,
+) ([]slurmv1alpha1.ActiveCheck, error) {
+	logger := log.FromContext(ctx)
+
+	activeCheckList := slurmv1alpha1.ActiveCheckList{}
+	if err := r.List(ctx, &activeCheckList,
+		client.InNamespace(clusterRef.Namespace),
+	); err != nil {
+		logger.Error(err, fmt.Sprintf("Failed to list %s in namespace %q", slurmv1alpha1.KindActiveCheck, clusterRef.Namespace))
+		return nil, fmt.Errorf("listing %s: %w", slurmv1alpha1.KindActiveCheck, err)
+	}
+
+	return slices.SortedFunc(
+		sliceutils.FilterSliceSeq(activeCheckList.Items, func(activeCheck slurmv1alpha1.ActiveCheck) bool {
+			return activeCheck.Spec.SlurmClusterRefName == clusterRef.Name
+		}),
+		func(a, b slurmv1alpha1.ActiveCheck) int {
+			return strings.Compare(a.Name, b.Name)
+		},
+	), nil
+}
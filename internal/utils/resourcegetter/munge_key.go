@@ -0,0 +1,41 @@
+package resourcegetter
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/naming"
+)
+
+// GetMungeKeyDependency returns the dependency on the active munge key of the cluster, for the workloads whose munge
+// containers don't switch to the rotated key on their own.
+// Its version is the hash of the active key rather than the resourceVersion of the munge key Secret, so that the
+// workloads are restarted once the key is rotated, but not while the next key is only staged.
+func GetMungeKeyDependency(ctx context.Context, r client.Reader, clusterRef types.NamespacedName) (metav1.Object, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(
+		ctx,
+		types.NamespacedName{
+			Namespace: clusterRef.Namespace,
+			Name:      naming.BuildSecretMungeKeyName(clusterRef.Name),
+		},
+		secret,
+	); err != nil {
+		return nil, fmt.Errorf("getting munge key Secret: %w", err)
+	}
+
+	sum := sha256.Sum256(secret.Data[consts.SecretMungeKeyFileName])
+	return &metav1.ObjectMeta{
+		Namespace:       secret.Namespace,
+		Name:            secret.Name,
+		ResourceVersion: hex.EncodeToString(sum[:8]),
+	}, nil
+}
@@ -0,0 +1,46 @@
+package resourcegetter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/naming"
+)
+
+func TestGetMungeKeyDependency(t *testing.T) {
+	ctx := context.Background()
+	clusterRef := types.NamespacedName{Namespace: "soperator", Name: "slurm"}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "soperator", Name: naming.BuildSecretMungeKeyName("slurm")},
+		Data:       map[string][]byte{consts.SecretMungeKeyFileName: []byte("current")},
+	}
+	c := fake.NewClientBuilder().WithObjects(secret).Build()
+
+	dep, err := GetMungeKeyDependency(ctx, c, clusterRef)
+	require.NoError(t, err)
+	assert.Equal(t, secret.Name, dep.GetName())
+	version := dep.GetResourceVersion()
+
+	secret.Data[consts.SecretMungeKeyNextFileName] = []byte("next")
+	require.NoError(t, c.Update(ctx, secret))
+	dep, err = GetMungeKeyDependency(ctx, c, clusterRef)
+	require.NoError(t, err)
+	assert.Equal(t, version, dep.GetResourceVersion(), "staged key doesn't change the version")
+
+	secret.Data = map[string][]byte{consts.SecretMungeKeyFileName: []byte("next")}
+	require.NoError(t, c.Update(ctx, secret))
+	dep, err = GetMungeKeyDependency(ctx, c, clusterRef)
+	require.NoError(t, err)
+	assert.NotEqual(t, version, dep.GetResourceVersion(), "rotated key changes the version")
+
+	_, err = GetMungeKeyDependency(ctx, c, types.NamespacedName{Namespace: "soperator", Name: "missing"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,88 @@
+package timewindow
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	// Windows are in time zones loaded by name, and the images don't ship the time zone database.
+	_ "time/tzdata"
+)
+
+// Window is a recurring time window.
+type Window struct {
+	// Days are the days of the week the window starts on, e.g. "Mon". All days if empty.
+	Days []string
+	// Start is the time of day the window starts at, in the "HH:MM" format.
+	Start string
+	// End is the time of day the window ends at, in the "HH:MM" format.
+	// An end before the start ends the window on the next day.
+	End string
+	// TimeZone is the IANA time zone Start and End are in. UTC if empty.
+	TimeZone string
+}
+
+// Evaluate reports whether the window is active at the given moment, and when the window next starts or ends.
+func (w Window) Evaluate(now time.Time) (bool, time.Time, error) {
+	timeZone := w.TimeZone
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("loading time zone: %w", err)
+	}
+	startHour, startMinute, err := parseTimeOfDay(w.Start)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("parsing start: %w", err)
+	}
+	endHour, endMinute, err := parseTimeOfDay(w.End)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("parsing end: %w", err)
+	}
+
+	localNow := now.In(location)
+	active := false
+	var next time.Time
+	// A window which started yesterday may still be active, and the next window starts within a week.
+	for dayOffset := -1; dayOffset <= 7; dayOffset++ {
+		day := localNow.AddDate(0, 0, dayOffset)
+		if len(w.Days) > 0 && !slices.Contains(w.Days, day.Weekday().String()[:3]) {
+			continue
+		}
+
+		start := time.Date(day.Year(), day.Month(), day.Day(), startHour, startMinute, 0, 0, location)
+		end := time.Date(day.Year(), day.Month(), day.Day(), endHour, endMinute, 0, 0, location)
+		if !end.After(start) {
+			end = end.AddDate(0, 0, 1)
+		}
+
+		if !now.Before(start) && now.Before(end) {
+			active = true
+		}
+		for _, boundary := range []time.Time{start, end} {
+			if boundary.After(now) && (next.IsZero() || boundary.Before(next)) {
+				next = boundary
+			}
+		}
+	}
+
+	return active, next, nil
+}
+
+func parseTimeOfDay(s string) (int, int, error) {
+	hours, minutes, found := strings.Cut(s, ":")
+	if !found {
+		return 0, 0, fmt.Errorf("invalid time of day %q", s)
+	}
+	hour, err := strconv.Atoi(hours)
+	if err != nil || hour < 0 || hour > 23 {
+		return 0, 0, fmt.Errorf("invalid hour in %q", s)
+	}
+	minute, err := strconv.Atoi(minutes)
+	if err != nil || minute < 0 || minute > 59 {
+		return 0, 0, fmt.Errorf("invalid minute in %q", s)
+	}
+	return hour, minute, nil
+}
@@ -0,0 +1,72 @@
+package timewindow_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"nebius.ai/slurm-operator/internal/utils/timewindow"
+)
+
+func TestWindowEvaluate(t *testing.T) {
+	// 2026-03-04 is a Wednesday.
+	wednesdayNoon := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		window     timewindow.Window
+		now        time.Time
+		wantActive bool
+		wantNext   time.Time
+	}{
+		{
+			name:       "active on any day",
+			window:     timewindow.Window{Start: "10:00", End: "14:00"},
+			now:        wednesdayNoon,
+			wantActive: true,
+			wantNext:   time.Date(2026, 3, 4, 14, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "inactive on other days",
+			window:   timewindow.Window{Days: []string{"Sat", "Sun"}, Start: "10:00", End: "14:00"},
+			now:      wednesdayNoon,
+			wantNext: time.Date(2026, 3, 7, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "overnight window started the day before",
+			window:     timewindow.Window{Days: []string{"Tue"}, Start: "22:00", End: "02:00"},
+			now:        time.Date(2026, 3, 4, 1, 0, 0, 0, time.UTC),
+			wantActive: true,
+			wantNext:   time.Date(2026, 3, 4, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "time zone",
+			window:     timewindow.Window{Start: "13:00", End: "14:00", TimeZone: "Europe/Amsterdam"},
+			now:        wednesdayNoon,
+			wantActive: true,
+			wantNext:   time.Date(2026, 3, 4, 13, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			active, next, err := tt.window.Evaluate(tt.now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantActive, active)
+			assert.True(t, tt.wantNext.Equal(next), "next: want %s, got %s", tt.wantNext, next)
+		})
+	}
+}
+
+func TestWindowEvaluateInvalid(t *testing.T) {
+	for _, window := range []timewindow.Window{
+		{Start: "25:00", End: "02:00"},
+		{Start: "10:00", End: "1000"},
+		{Start: "10:00", End: "11:00", TimeZone: "Mars/Olympus"},
+	} {
+		_, _, err := window.Evaluate(time.Now())
+		assert.Error(t, err, window)
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	slurmv1 "nebius.ai/slurm-operator/api/v1"
 	slurmav1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/consts"
 )
 
 type SlurmCluster struct {
@@ -38,8 +39,42 @@ type SlurmCluster struct {
 	PlugStackConfig    slurmv1.PlugStackConfig
 	SConfigController  SConfigController
 	NodeSets           []slurmav1alpha1.NodeSet
+	// StoreJobComments is set if the Slurm job comments are to be stored in accounting, as an ActiveCheck of the
+	// cluster reads the results of its Slurm jobs from them.
+	StoreJobComments bool
 
 	UseDefaultAppArmorProfile bool
+
+	JWTAuth JWTAuth
+}
+
+// JWTAuth is the configuration of the auth/jwt plugin of slurmctld and slurmdbd.
+type JWTAuth struct {
+	// JWKS is the published set of public keys of the JWT signing keys, merged with the external ones.
+	// It's empty until the first signing key is generated.
+	JWKS string
+	// UsernameClaim is the JWT claim holding the Slurm username. Slurm's default is used if it's empty.
+	UsernameClaim string
+}
+
+func buildJWTAuthFrom(externalJWT *slurmv1.ExternalJWT) JWTAuth {
+	if externalJWT == nil {
+		return JWTAuth{}
+	}
+	return JWTAuth{UsernameClaim: externalJWT.UsernameClaim}
+}
+
+// JWTSigningKeysRequired checks whether the JWT signing keys Secret has to be created, so that their JWKS is published.
+// It's required once the rotation of the JWT signing key is requested or scheduled, or to hold a custom username
+// claim. Otherwise, the JWTs are signed by the REST JWT key.
+func JWTSigningKeysRequired(cluster *slurmv1.SlurmCluster, usernameClaim string) bool {
+	if cluster.Annotations[consts.AnnotationRotateJWTSigningKey] != "" {
+		return true
+	}
+	if interval := cluster.Spec.KeyRotation.JWTSigningKeyInterval; interval != nil && interval.Duration > 0 {
+		return true
+	}
+	return usernameClaim != "" && usernameClaim != consts.JWTDefaultUsernameClaim
 }
 
 // BuildSlurmClusterFrom creates a new instance of SlurmCluster given a SlurmCluster CRD.
@@ -84,6 +119,7 @@ func BuildSlurmClusterFrom(ctx context.Context, cluster *slurmv1.SlurmCluster, n
 			namePrefix,
 		),
 		UseDefaultAppArmorProfile: cluster.Spec.UseDefaultAppArmorProfile,
+		JWTAuth:                   buildJWTAuthFrom(cluster.Spec.SlurmNodes.Rest.ExternalJWT),
 	}
 
 	if err := res.Validate(ctx); err != nil {
@@ -94,6 +130,18 @@ func BuildSlurmClusterFrom(ctx context.Context, cluster *slurmv1.SlurmCluster, n
 	return res, nil
 }
 
+// BuildStoreJobCommentsFromActiveChecks checks whether any of the ActiveChecks reads the results its Slurm jobs publish
+// in their comments.
+func BuildStoreJobCommentsFromActiveChecks(activeChecks []slurmav1alpha1.ActiveCheck) bool {
+	for _, activeCheck := range activeChecks {
+		if activeCheck.Spec.SlurmJobSpec.Results != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
 func BuildClusterWithGPUFromNodeSets(nodeSets []slurmav1alpha1.NodeSet) bool {
 	for _, nodeSet := range nodeSets {
 		if nodeSet.Spec.GPU.Enabled {
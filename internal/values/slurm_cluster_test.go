@@ -0,0 +1,27 @@
+package values
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	slurmv1 "nebius.ai/slurm-operator/api/v1"
+	"nebius.ai/slurm-operator/internal/consts"
+)
+
+func TestJWTSigningKeysRequired(t *testing.T) {
+	newCluster := func(annotations map[string]string, interval *metav1.Duration) *slurmv1.SlurmCluster {
+		return &slurmv1.SlurmCluster{
+			ObjectMeta: metav1.ObjectMeta{Annotations: annotations},
+			Spec:       slurmv1.SlurmClusterSpec{KeyRotation: slurmv1.KeyRotation{JWTSigningKeyInterval: interval}},
+		}
+	}
+
+	assert.False(t, JWTSigningKeysRequired(newCluster(nil, nil), ""), "REST JWT key is kept by default")
+	assert.False(t, JWTSigningKeysRequired(newCluster(nil, nil), consts.JWTDefaultUsernameClaim))
+	assert.True(t, JWTSigningKeysRequired(newCluster(map[string]string{consts.AnnotationRotateJWTSigningKey: "1"}, nil), ""))
+	assert.True(t, JWTSigningKeysRequired(newCluster(nil, &metav1.Duration{Duration: 24 * time.Hour}), ""))
+	assert.True(t, JWTSigningKeysRequired(newCluster(nil, nil), "preferred_username"), "custom username claim is kept in the Secret")
+}
@@ -1,6 +1,8 @@
 package values
 
 import (
+	"fmt"
+
 	corev1 "k8s.io/api/core/v1"
 
 	slurmv1 "nebius.ai/slurm-operator/api/v1"
@@ -22,6 +24,12 @@ type SlurmController struct {
 	StatefulSet StatefulSet
 	DaemonSet   DaemonSet
 
+	// HighAvailability is whether a backup controller runs next to the primary one.
+	HighAvailability bool
+	// ReplicaServices select the controller pods one by one, in the order of their ordinals.
+	// They're only used with HighAvailability.
+	ReplicaServices []Service
+
 	VolumeSpool             slurmv1.NodeVolume
 	VolumeJail              slurmv1.NodeVolume
 	CustomVolumeMounts      []slurmv1.NodeVolumeMount
@@ -37,10 +45,14 @@ type SlurmController struct {
 }
 
 func buildSlurmControllerFrom(clusterName, namePrefix string, maintenance *consts.MaintenanceMode, controller *slurmv1.SlurmNodeController) SlurmController {
-	// Controller always has 1 replica
+	// Controller has 1 replica, or the primary and the backup ones with high availability
+	replicas := consts.SingleReplicas
+	if controller.HighAvailability.Enabled {
+		replicas = consts.ControllerHighAvailabilityReplicas
+	}
 	statefulSet := buildStatefulSetWithMaxUnavailableFrom(
 		naming.BuildStatefulSetName(consts.ComponentTypeController, namePrefix),
-		consts.SingleReplicas,
+		replicas,
 		nil,
 	)
 
@@ -78,6 +90,13 @@ func buildSlurmControllerFrom(clusterName, namePrefix string, maintenance *const
 		PriorityClass:           controller.PriorityClass,
 		ServiceAccountName:      controller.ServiceAccountName,
 		OpenMetrics:             controller.OpenMetrics,
+		HighAvailability:        controller.HighAvailability.Enabled,
+	}
+
+	if res.HighAvailability {
+		for ordinal := range replicas {
+			res.ReplicaServices = append(res.ReplicaServices, buildServiceFrom(naming.BuildControllerReplicaServiceName(clusterName, ordinal)))
+		}
 	}
 
 	if controller.Sssd != nil {
@@ -96,3 +115,29 @@ func buildSlurmControllerFrom(clusterName, namePrefix string, maintenance *const
 
 	return res
 }
+
+// SlurmctldHost is a controller listed as SlurmctldHost in slurm.conf.
+type SlurmctldHost struct {
+	// Hostname is the hostname of the controller pod.
+	Hostname string
+	// Address is the name of the Service the controller is reachable at.
+	Address string
+}
+
+// SlurmctldHosts returns the controllers in the order Slurm takes control, the primary one first.
+func (c *SlurmController) SlurmctldHosts() []SlurmctldHost {
+	if !c.HighAvailability {
+		return []SlurmctldHost{{
+			Hostname: c.StatefulSet.Name + "-0",
+			Address:  c.Service.Name,
+		}}
+	}
+	res := make([]SlurmctldHost, 0, len(c.ReplicaServices))
+	for ordinal, service := range c.ReplicaServices {
+		res = append(res, SlurmctldHost{
+			Hostname: fmt.Sprintf("%s-%d", c.StatefulSet.Name, ordinal),
+			Address:  service.Name,
+		})
+	}
+	return res
+}
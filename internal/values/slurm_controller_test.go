@@ -63,3 +63,36 @@ func TestBuildSlurmControllerFrom_SSSD(t *testing.T) {
 	})
 
 }
+
+func TestBuildSlurmControllerFrom_HighAvailability(t *testing.T) {
+	newController := func(highAvailability bool) *slurmv1.SlurmNodeController {
+		return &slurmv1.SlurmNodeController{
+			Slurmctld:        slurmv1.NodeContainer{Image: "slurmctld-image"},
+			Munge:            slurmv1.NodeContainer{Image: "munge-image"},
+			Volumes:          slurmv1.SlurmNodeControllerVolumes{Spool: slurmv1.NodeVolume{}, Jail: slurmv1.NodeVolume{}},
+			HighAvailability: slurmv1.SlurmctldHighAvailability{Enabled: highAvailability},
+		}
+	}
+
+	t.Run("single controller", func(t *testing.T) {
+		result := buildSlurmControllerFrom("test-cluster", "", nil, newController(false))
+
+		assert.False(t, result.HighAvailability)
+		assert.Equal(t, consts.SingleReplicas, result.StatefulSet.Replicas)
+		assert.Empty(t, result.ReplicaServices)
+		assert.Equal(t, []SlurmctldHost{
+			{Hostname: "controller-0", Address: "test-cluster-controller-svc"},
+		}, result.SlurmctldHosts())
+	})
+
+	t.Run("primary and backup controllers", func(t *testing.T) {
+		result := buildSlurmControllerFrom("test-cluster", "", nil, newController(true))
+
+		assert.True(t, result.HighAvailability)
+		assert.Equal(t, consts.ControllerHighAvailabilityReplicas, result.StatefulSet.Replicas)
+		assert.Equal(t, []SlurmctldHost{
+			{Hostname: "controller-0", Address: "test-cluster-controller-0-svc"},
+			{Hostname: "controller-1", Address: "test-cluster-controller-1-svc"},
+		}, result.SlurmctldHosts())
+	})
+}
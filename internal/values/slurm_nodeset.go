@@ -47,10 +47,12 @@ type SlurmNodeSet struct {
 	// docker CLI in jail) are enabled for the NodeSet workers.
 	DockerEnabled bool
 
-	StatefulSet     StatefulSet
-	UpdateStrategy  consts.UpdateStrategy
-	Service         Service
-	ServiceUmbrella Service
+	StatefulSet        StatefulSet
+	UpdateStrategy     consts.UpdateStrategy
+	UpdateVerification *slurmv1alpha1.UpdateVerificationSpec
+	Rollout            *slurmv1alpha1.RolloutSpec
+	Service            Service
+	ServiceUmbrella    Service
 
 	VolumeSpool                          corev1.VolumeSource
 	VolumeJail                           corev1.VolumeSource
@@ -135,9 +137,11 @@ func BuildSlurmNodeSetFrom(
 			nsSpec.Replicas,
 			nsSpec.MaxUnavailable,
 		),
-		UpdateStrategy:  nsSpec.UpdateStrategy,
-		Service:         buildServiceFrom(naming.BuildNodeSetServiceName(clusterName, nodeSet.Name)),
-		ServiceUmbrella: buildServiceFrom(naming.BuildServiceName(consts.ComponentTypeNodeSet, clusterName)),
+		UpdateStrategy:     nsSpec.UpdateStrategy,
+		UpdateVerification: nsSpec.UpdateVerification.DeepCopy(),
+		Rollout:            nsSpec.Rollout.DeepCopy(),
+		Service:            buildServiceFrom(naming.BuildNodeSetServiceName(clusterName, nodeSet.Name)),
+		ServiceUmbrella:    buildServiceFrom(naming.BuildServiceName(consts.ComponentTypeNodeSet, clusterName)),
 		//
 		VolumeSpool:      *nsSpec.Slurmd.Volumes.Spool.DeepCopy(),
 		VolumeJail:       *nsSpec.Slurmd.Volumes.Jail.DeepCopy(),
@@ -16,6 +16,7 @@ type SlurmREST struct {
 	Enabled              bool
 	ThreadCount          *int32
 	MaxConnections       *int32
+	ExternalJWT          *slurmv1.ExternalJWT
 	ContainerREST        Container
 	CustomInitContainers []corev1.Container
 	Service              Service
@@ -38,6 +39,7 @@ func buildRestFrom(clusterName, namePrefix string, maintenance *consts.Maintenan
 		Enabled:              rest.Enabled,
 		ThreadCount:          rest.ThreadCount,
 		MaxConnections:       rest.MaxConnections,
+		ExternalJWT:          rest.ExternalJWT.DeepCopy(),
 		ContainerREST:        containerREST,
 		CustomInitContainers: rest.CustomInitContainers,
 		Service:              buildServiceFrom(naming.BuildServiceName(consts.ComponentTypeREST, clusterName)),
@@ -18,17 +18,44 @@ package v1alpha1
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	slurmv1 "nebius.ai/slurm-operator/api/v1"
 	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	"nebius.ai/slurm-operator/internal/consts"
+	"nebius.ai/slurm-operator/internal/utils/resourcegetter"
+)
+
+// nolint:unused
+// nodeSetLog is for logging in this package.
+var nodeSetLog = logf.Log.WithName("nodeset-resource")
+
+const (
+	// resourceNvidiaGPU is the resource the slurmd container requests GPUs with.
+	resourceNvidiaGPU corev1.ResourceName = "nvidia.com/gpu"
+	// resourceGPU is the vendor-less GPU resource rendered by the nodesets chart.
+	resourceGPU corev1.ResourceName = "gpu"
+
+	// volumeTypeClaimTemplate is the volume type of mounts backed by a PersistentVolumeClaim template.
+	volumeTypeClaimTemplate = "volumeClaimTemplateSpec"
 )
 
 // SetupNodeSetWebhookWithManager registers the webhook for NodeSet in the manager.
 func SetupNodeSetWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr, &slurmv1alpha1.NodeSet{}).
-		WithValidator(&NodeSetCustomValidator{}).
+		WithValidator(&NodeSetCustomValidator{Client: mgr.GetClient()}).
 		Complete()
 }
 
@@ -36,21 +63,266 @@ func SetupNodeSetWebhookWithManager(mgr ctrl.Manager) error {
 
 // NodeSetCustomValidator struct is responsible for validating the NodeSet resource
 // when it is created, updated, or deleted.
-type NodeSetCustomValidator struct{}
+type NodeSetCustomValidator struct {
+	Client client.Client
+}
 
 var _ admission.Validator[*slurmv1alpha1.NodeSet] = &NodeSetCustomValidator{}
 
 // ValidateCreate implements admission.Validator so a webhook will be registered for the type NodeSet.
-func (v *NodeSetCustomValidator) ValidateCreate(_ context.Context, _ *slurmv1alpha1.NodeSet) (admission.Warnings, error) {
-	return nil, nil
+func (v *NodeSetCustomValidator) ValidateCreate(ctx context.Context, nodeSet *slurmv1alpha1.NodeSet) (admission.Warnings, error) {
+	nodeSetLog.Info("Validation for NodeSet upon creation", "name", nodeSet.GetName())
+
+	clusterName := nodeSetClusterName(nodeSet)
+	if clusterName == "" {
+		return nil, errors.New("spec.clusterName must be set")
+	}
+
+	cluster := &slurmv1.SlurmCluster{}
+	if err := v.Client.Get(ctx, types.NamespacedName{Namespace: nodeSet.Namespace, Name: clusterName}, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("spec.clusterName: SlurmCluster %q not found in namespace %q", clusterName, nodeSet.Namespace)
+		}
+		return nil, fmt.Errorf("getting SlurmCluster %q: %w", clusterName, err)
+	}
+
+	return v.validate(ctx, nodeSet)
 }
 
 // ValidateUpdate implements admission.Validator so a webhook will be registered for the type NodeSet.
-func (v *NodeSetCustomValidator) ValidateUpdate(_ context.Context, _, _ *slurmv1alpha1.NodeSet) (admission.Warnings, error) {
-	return nil, nil
+func (v *NodeSetCustomValidator) ValidateUpdate(ctx context.Context, oldNodeSet, newNodeSet *slurmv1alpha1.NodeSet) (admission.Warnings, error) {
+	nodeSetLog.Info("Validation for NodeSet upon update", "name", newNodeSet.GetName())
+
+	// Don't block removing finalizers and other updates of a NodeSet which is going away.
+	if newNodeSet.GetDeletionTimestamp() != nil {
+		return nil, nil
+	}
+
+	if err := validateImmutableFields(oldNodeSet, newNodeSet); err != nil {
+		return nil, err
+	}
+
+	return v.validate(ctx, newNodeSet)
 }
 
 // ValidateDelete implements admission.Validator so a webhook will be registered for the type NodeSet.
 func (v *NodeSetCustomValidator) ValidateDelete(_ context.Context, _ *slurmv1alpha1.NodeSet) (admission.Warnings, error) {
 	return nil, nil
 }
+
+// validate runs the checks shared by creation and update.
+func (v *NodeSetCustomValidator) validate(ctx context.Context, nodeSet *slurmv1alpha1.NodeSet) (admission.Warnings, error) {
+	if err := validateEphemeralNodes(nodeSet); err != nil {
+		return nil, err
+	}
+
+	warnings, err := validateGRESConfig(nodeSet)
+	if err != nil {
+		return warnings, err
+	}
+
+	if err := v.validateSlurmNodeNames(ctx, nodeSet); err != nil {
+		return warnings, err
+	}
+
+	return warnings, nil
+}
+
+// nodeSetClusterName returns the name of the SlurmCluster the NodeSet belongs to,
+// falling back to the legacy annotation for NodeSets not yet migrated by the nodesetcontroller.
+func nodeSetClusterName(nodeSet *slurmv1alpha1.NodeSet) string {
+	if nodeSet.Spec.ClusterName != "" {
+		return nodeSet.Spec.ClusterName
+	}
+	return nodeSet.GetAnnotations()[consts.AnnotationParentalClusterRefName]
+}
+
+// validateEphemeralNodes checks that the ephemeral nodes settings are coherent.
+func validateEphemeralNodes(nodeSet *slurmv1alpha1.NodeSet) error {
+	if nodeSet.Spec.EphemeralNodes == nil || !*nodeSet.Spec.EphemeralNodes {
+		return nil
+	}
+
+	if nodeSet.Spec.InitialNumberEphemeralNodes > nodeSet.Spec.Replicas {
+		return fmt.Errorf(
+			"spec.initialNumberEphemeralNodes (%d) must not exceed spec.replicas (%d)",
+			nodeSet.Spec.InitialNumberEphemeralNodes, nodeSet.Spec.Replicas,
+		)
+	}
+	return nil
+}
+
+// validateGRESConfig checks that the gres.conf lines are consistent with the GPU settings and resources.
+func validateGRESConfig(nodeSet *slurmv1alpha1.NodeSet) (admission.Warnings, error) {
+	var warnings admission.Warnings
+
+	gpuCount, hasGPUResource := slurmdGPUCount(nodeSet.Spec.Slurmd.Resources)
+	if nodeSet.Spec.GPU.Enabled && !hasGPUResource {
+		return nil, fmt.Errorf("spec.slurmd.resources must request %s as spec.gpu.enabled is true", resourceNvidiaGPU)
+	}
+	if !nodeSet.Spec.GPU.Enabled && hasGPUResource {
+		warnings = append(warnings, fmt.Sprintf(
+			"spec.slurmd.resources requests %d GPUs, but spec.gpu.enabled is false, so Slurm won't schedule them", gpuCount,
+		))
+	}
+
+	var configuredGPUs int64
+	for i, line := range nodeSet.Spec.NodeConfig.GRESConfig {
+		params := parseGRESLine(line)
+
+		_, autoDetect := params["autodetect"]
+		isGPU := strings.EqualFold(params["name"], "gpu")
+		if (isGPU || autoDetect) && !nodeSet.Spec.GPU.Enabled {
+			return warnings, fmt.Errorf("spec.nodeConfig.gresConfig[%d] %q configures GPUs, but spec.gpu.enabled is false", i, line)
+		}
+		if !isGPU {
+			continue
+		}
+
+		count, found := params["count"]
+		if !found {
+			continue
+		}
+		n, err := strconv.ParseInt(count, 10, 64)
+		if err != nil {
+			return warnings, fmt.Errorf("spec.nodeConfig.gresConfig[%d] %q: invalid Count %q", i, line, count)
+		}
+		configuredGPUs += n
+	}
+
+	if configuredGPUs > gpuCount {
+		return warnings, fmt.Errorf(
+			"spec.nodeConfig.gresConfig configures %d GPUs, but spec.slurmd.resources requests only %d",
+			configuredGPUs, gpuCount,
+		)
+	}
+
+	return warnings, nil
+}
+
+// slurmdGPUCount returns the number of GPUs requested by the slurmd container.
+func slurmdGPUCount(resources corev1.ResourceList) (int64, bool) {
+	for _, name := range []corev1.ResourceName{resourceNvidiaGPU, resourceGPU} {
+		if quantity, found := resources[name]; found && quantity.Sign() > 0 {
+			return quantity.Value(), true
+		}
+	}
+	return 0, false
+}
+
+// parseGRESLine parses a gres.conf line such as "Name=gpu Type=h200 File=/dev/nvidia[0-7]" into lowercase keys and
+// their values. Keys without a value, like "AutoDetect" in "AutoDetect=nvml", are present as well.
+func parseGRESLine(line string) map[string]string {
+	res := map[string]string{}
+	for _, field := range strings.Fields(line) {
+		key, value, _ := strings.Cut(field, "=")
+		res[strings.ToLower(key)] = value
+	}
+	return res
+}
+
+// validateSlurmNodeNames checks that the name of the NodeSet doesn't collide with the Slurm node names of other
+// NodeSets of the same cluster, and vice versa.
+// Slurm node names are "<nodeset>-<ordinal>", and Slurm resolves NodeSet names in the same namespace as node names,
+// so a NodeSet "worker-1" would be ambiguous with the node 1 of the NodeSet "worker".
+func (v *NodeSetCustomValidator) validateSlurmNodeNames(ctx context.Context, nodeSet *slurmv1alpha1.NodeSet) error {
+	clusterName := nodeSetClusterName(nodeSet)
+	if clusterName == "" {
+		return nil
+	}
+
+	nodeSets, err := resourcegetter.ListNodeSetsByClusterRef(ctx, v.Client, types.NamespacedName{
+		Namespace: nodeSet.Namespace,
+		Name:      clusterName,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, other := range nodeSets {
+		if other.Name == nodeSet.Name {
+			continue
+		}
+		if isSlurmNodeNameOf(nodeSet.Name, other.Name) {
+			return fmt.Errorf("name %q collides with a Slurm node of NodeSet %q", nodeSet.Name, other.Name)
+		}
+		if isSlurmNodeNameOf(other.Name, nodeSet.Name) {
+			return fmt.Errorf("Slurm nodes of NodeSet %q collide with the name of NodeSet %q", nodeSet.Name, other.Name)
+		}
+	}
+	return nil
+}
+
+// isSlurmNodeNameOf reports whether name has the form of a Slurm node name of the given NodeSet.
+func isSlurmNodeNameOf(name, nodeSetName string) bool {
+	ordinal, found := strings.CutPrefix(name, nodeSetName+"-")
+	if !found || ordinal == "" {
+		return false
+	}
+	_, err := strconv.ParseUint(ordinal, 10, 32)
+	return err == nil
+}
+
+// validateImmutableFields checks that the fields which can't be changed after the NodeSet is created stay the same.
+func validateImmutableFields(oldNodeSet, newNodeSet *slurmv1alpha1.NodeSet) error {
+	oldClusterName, newClusterName := nodeSetClusterName(oldNodeSet), nodeSetClusterName(newNodeSet)
+	if oldClusterName != "" && oldClusterName != newClusterName {
+		return fmt.Errorf("spec.clusterName is immutable: %q can't be changed to %q", oldClusterName, newClusterName)
+	}
+
+	oldVolumes, newVolumes := oldNodeSet.Spec.Slurmd.Volumes, newNodeSet.Spec.Slurmd.Volumes
+	if oldType, newType := volumeSourceType(&oldVolumes.Spool), volumeSourceType(&newVolumes.Spool); oldType != newType {
+		return fmt.Errorf("spec.slurmd.volumes.spool: volume type is immutable: %q can't be changed to %q", oldType, newType)
+	}
+	if oldType, newType := volumeSourceType(&oldVolumes.Jail), volumeSourceType(&newVolumes.Jail); oldType != newType {
+		return fmt.Errorf("spec.slurmd.volumes.jail: volume type is immutable: %q can't be changed to %q", oldType, newType)
+	}
+
+	for _, mounts := range []struct {
+		path     string
+		old, new []slurmv1alpha1.NodeVolumeMount
+	}{
+		{path: "spec.slurmd.volumes.jailSubMounts", old: oldVolumes.JailSubMounts, new: newVolumes.JailSubMounts},
+		{path: "spec.slurmd.volumes.customVolumeMounts", old: oldVolumes.CustomVolumeMounts, new: newVolumes.CustomVolumeMounts},
+	} {
+		oldTypes := make(map[string]string, len(mounts.old))
+		for _, mount := range mounts.old {
+			oldTypes[mount.Name] = volumeMountType(mount)
+		}
+		for _, mount := range mounts.new {
+			oldType, found := oldTypes[mount.Name]
+			if !found {
+				continue
+			}
+			if newType := volumeMountType(mount); oldType != newType {
+				return fmt.Errorf("%s[%s]: volume type is immutable: %q can't be changed to %q", mounts.path, mount.Name, oldType, newType)
+			}
+		}
+	}
+
+	return nil
+}
+
+// volumeMountType returns the type of the volume backing the mount.
+func volumeMountType(mount slurmv1alpha1.NodeVolumeMount) string {
+	if mount.VolumeClaimTemplateSpec != nil {
+		return volumeTypeClaimTemplate
+	}
+	if mount.VolumeSource != nil {
+		return volumeSourceType(mount.VolumeSource)
+	}
+	return ""
+}
+
+// volumeSourceType returns the JSON name of the volume source which is set, e.g. "persistentVolumeClaim".
+func volumeSourceType(source *corev1.VolumeSource) string {
+	value := reflect.ValueOf(source).Elem()
+	for i := range value.NumField() {
+		if value.Field(i).IsNil() {
+			continue
+		}
+		name, _, _ := strings.Cut(value.Type().Field(i).Tag.Get("json"), ",")
+		return name
+	}
+	return ""
+}
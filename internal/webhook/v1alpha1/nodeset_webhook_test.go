@@ -0,0 +1,243 @@
+package v1alpha1_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	slurmv1 "nebius.ai/slurm-operator/api/v1"
+	slurmv1alpha1 "nebius.ai/slurm-operator/api/v1alpha1"
+	. "nebius.ai/slurm-operator/internal/webhook/v1alpha1"
+)
+
+const (
+	testNamespace   = "soperator"
+	testClusterName = "soperator"
+)
+
+func newValidator(objects ...client.Object) *NodeSetCustomValidator {
+	scheme := runtime.NewScheme()
+	_ = slurmv1.AddToScheme(scheme)
+	_ = slurmv1alpha1.AddToScheme(scheme)
+
+	cluster := &slurmv1.SlurmCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: testClusterName, Namespace: testNamespace},
+	}
+
+	return &NodeSetCustomValidator{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(append(objects, cluster)...).Build(),
+	}
+}
+
+func newNodeSet(name string) *slurmv1alpha1.NodeSet {
+	return &slurmv1alpha1.NodeSet{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+		Spec: slurmv1alpha1.NodeSetSpec{
+			ClusterName: testClusterName,
+			Replicas:    4,
+			Slurmd: slurmv1alpha1.ContainerSlurmdSpec{
+				Resources: corev1.ResourceList{
+					"nvidia.com/gpu": resource.MustParse("8"),
+				},
+				Volumes: slurmv1alpha1.WorkerVolumesSpec{
+					Spool: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+					Jail: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: "jail",
+					}},
+				},
+			},
+			NodeConfig: slurmv1alpha1.NodeConfig{
+				GRESConfig: []string{"AutoDetect=nvidia"},
+			},
+			GPU: slurmv1alpha1.GPUSpec{Enabled: true},
+		},
+	}
+}
+
+func TestNodeSetValidateCreate(t *testing.T) {
+	tests := []struct {
+		name         string
+		modify       func(*slurmv1alpha1.NodeSet)
+		existing     []client.Object
+		wantErr      bool
+		wantWarnings bool
+	}{
+		{
+			name:   "valid",
+			modify: func(*slurmv1alpha1.NodeSet) {},
+		},
+		{
+			name:    "empty cluster name",
+			modify:  func(ns *slurmv1alpha1.NodeSet) { ns.Spec.ClusterName = "" },
+			wantErr: true,
+		},
+		{
+			name:    "unknown cluster",
+			modify:  func(ns *slurmv1alpha1.NodeSet) { ns.Spec.ClusterName = "unknown" },
+			wantErr: true,
+		},
+		{
+			name: "too many initial ephemeral nodes",
+			modify: func(ns *slurmv1alpha1.NodeSet) {
+				ns.Spec.EphemeralNodes = ptr.To(true)
+				ns.Spec.InitialNumberEphemeralNodes = 5
+			},
+			wantErr: true,
+		},
+		{
+			name: "initial ephemeral nodes are ignored for non-ephemeral NodeSets",
+			modify: func(ns *slurmv1alpha1.NodeSet) {
+				ns.Spec.InitialNumberEphemeralNodes = 5
+			},
+		},
+		{
+			name: "GPU GRES without GPU enabled",
+			modify: func(ns *slurmv1alpha1.NodeSet) {
+				ns.Spec.GPU.Enabled = false
+				ns.Spec.Slurmd.Resources = nil
+			},
+			wantErr: true,
+		},
+		{
+			name: "GPU enabled without GPU resources",
+			modify: func(ns *slurmv1alpha1.NodeSet) {
+				ns.Spec.Slurmd.Resources = nil
+			},
+			wantErr: true,
+		},
+		{
+			name: "GRES count exceeds GPU resources",
+			modify: func(ns *slurmv1alpha1.NodeSet) {
+				ns.Spec.NodeConfig.GRESConfig = []string{
+					"Name=gpu Type=h200 File=/dev/nvidia[0-7] Count=8",
+					"Name=gpu Type=h200 File=/dev/nvidia8 Count=1",
+				}
+			},
+			wantErr: true,
+		},
+		{
+			name: "GPU resources without GPU enabled",
+			modify: func(ns *slurmv1alpha1.NodeSet) {
+				ns.Spec.GPU.Enabled = false
+				ns.Spec.NodeConfig.GRESConfig = nil
+			},
+			wantWarnings: true,
+		},
+		{
+			name:     "name is a Slurm node of another NodeSet",
+			modify:   func(ns *slurmv1alpha1.NodeSet) { ns.Name = "worker-1" },
+			existing: []client.Object{newNodeSet("worker")},
+			wantErr:  true,
+		},
+		{
+			name:     "Slurm nodes collide with another NodeSet",
+			modify:   func(*slurmv1alpha1.NodeSet) {},
+			existing: []client.Object{newNodeSet("worker-12")},
+			wantErr:  true,
+		},
+		{
+			name:     "similar names don't collide",
+			modify:   func(*slurmv1alpha1.NodeSet) {},
+			existing: []client.Object{newNodeSet("worker-h200")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodeSet := newNodeSet("worker")
+			tt.modify(nodeSet)
+
+			warnings, err := newValidator(tt.existing...).ValidateCreate(context.Background(), nodeSet)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantWarnings, len(warnings) > 0)
+		})
+	}
+}
+
+func TestNodeSetValidateUpdate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*slurmv1alpha1.NodeSet)
+		wantErr bool
+	}{
+		{
+			name:   "replicas change",
+			modify: func(ns *slurmv1alpha1.NodeSet) { ns.Spec.Replicas = 8 },
+		},
+		{
+			name:    "cluster name change",
+			modify:  func(ns *slurmv1alpha1.NodeSet) { ns.Spec.ClusterName = "other" },
+			wantErr: true,
+		},
+		{
+			name: "spool volume type change",
+			modify: func(ns *slurmv1alpha1.NodeSet) {
+				ns.Spec.Slurmd.Volumes.Spool = corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/spool"}}
+			},
+			wantErr: true,
+		},
+		{
+			name: "jail claim name change",
+			modify: func(ns *slurmv1alpha1.NodeSet) {
+				ns.Spec.Slurmd.Volumes.Jail.PersistentVolumeClaim.ClaimName = "jail-2"
+			},
+		},
+		{
+			name: "custom mount type change",
+			modify: func(ns *slurmv1alpha1.NodeSet) {
+				ns.Spec.Slurmd.Volumes.CustomVolumeMounts[0].VolumeSource = nil
+				ns.Spec.Slurmd.Volumes.CustomVolumeMounts[0].VolumeClaimTemplateSpec = &corev1.PersistentVolumeClaimSpec{}
+			},
+			wantErr: true,
+		},
+		{
+			name: "new custom mount",
+			modify: func(ns *slurmv1alpha1.NodeSet) {
+				ns.Spec.Slurmd.Volumes.CustomVolumeMounts = append(ns.Spec.Slurmd.Volumes.CustomVolumeMounts, slurmv1alpha1.NodeVolumeMount{
+					Name:                    "scratch",
+					MountPath:               "/scratch",
+					VolumeClaimTemplateSpec: &corev1.PersistentVolumeClaimSpec{},
+				})
+			},
+		},
+		{
+			name: "deleted NodeSet",
+			modify: func(ns *slurmv1alpha1.NodeSet) {
+				ns.DeletionTimestamp = ptr.To(metav1.Now())
+				ns.Spec.ClusterName = "other"
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldNodeSet := newNodeSet("worker")
+			oldNodeSet.Spec.Slurmd.Volumes.CustomVolumeMounts = []slurmv1alpha1.NodeVolumeMount{{
+				Name:         "data",
+				MountPath:    "/data",
+				VolumeSource: &corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			}}
+			newNodeSet := oldNodeSet.DeepCopy()
+			tt.modify(newNodeSet)
+
+			_, err := newValidator().ValidateUpdate(context.Background(), oldNodeSet, newNodeSet)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}